@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// runCheck validates built NEF/manifest pairs before deployment and exits
+// non-zero if any contract has compatibility problems.
+func runCheck(buildDir, contractName, compilerPrefix string) {
+	if contractName == "" {
+		log.Fatal("--contract flag is required (use contract name or 'all')")
+	}
+
+	var toCheck []string
+	if contractName == "all" {
+		toCheck = platformContracts
+	} else {
+		toCheck = []string{contractName}
+	}
+
+	log.Println("=== Contract Compatibility Check ===")
+	log.Printf("Build dir: %s", buildDir)
+
+	failed := 0
+	for _, name := range toCheck {
+		nefPath := filepath.Join(buildDir, name+".nef")
+		manifestPath := filepath.Join(buildDir, name+".manifest.json")
+
+		problems, err := checkContractArtifacts(nefPath, manifestPath, compilerPrefix)
+		if err != nil {
+			log.Printf("❌ %s: %v", name, err)
+			failed++
+			continue
+		}
+		if len(problems) > 0 {
+			log.Printf("❌ %s:", name)
+			for _, p := range problems {
+				log.Printf("   - %s", p)
+			}
+			failed++
+			continue
+		}
+		log.Printf("✅ %s", name)
+	}
+
+	if failed > 0 {
+		log.Fatalf("%d contract(s) failed compatibility checks", failed)
+	}
+}
+
+// checkContractArtifacts parses a NEF file and its manifest and returns every
+// compatibility problem found: compiler mismatches, invalid or unpermitted
+// method-token call flags, and declared standards (NEP-17/NEP-11, ...) that
+// the ABI does not implement. A non-nil error means the artifacts could not
+// be read or parsed at all.
+func checkContractArtifacts(nefPath, manifestPath, compilerPrefix string) ([]string, error) {
+	nefData, err := os.ReadFile(nefPath)
+	if err != nil {
+		return nil, fmt.Errorf("read nef: %w", err)
+	}
+	nefFile, err := nef.FileFromBytes(nefData)
+	if err != nil {
+		return nil, fmt.Errorf("parse nef: %w", err)
+	}
+
+	manifestData, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	var m manifest.Manifest
+	if err := json.Unmarshal(manifestData, &m); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+
+	var problems []string
+
+	compiler := strings.TrimSpace(nefFile.Compiler)
+	switch {
+	case compiler == "":
+		problems = append(problems, "nef does not declare a compiler")
+	case compilerPrefix != "" && !strings.HasPrefix(compiler, compilerPrefix):
+		problems = append(problems, fmt.Sprintf("compiler %q does not match expected %q", compiler, compilerPrefix))
+	}
+
+	if err := m.IsValid(util.Uint160{}, true); err != nil {
+		problems = append(problems, fmt.Sprintf("manifest is invalid: %v", err))
+	}
+
+	for _, token := range nefFile.Tokens {
+		if token.CallFlag&^callflag.All != 0 {
+			problems = append(problems, fmt.Sprintf("method token %s.%s has invalid call flags 0x%02x",
+				token.Hash.StringLE(), token.Method, byte(token.CallFlag)))
+			continue
+		}
+		if !manifestPermitsCall(&m, token.Hash, token.Method) {
+			problems = append(problems, fmt.Sprintf("method token %s.%s is not permitted by manifest permissions",
+				token.Hash.StringLE(), token.Method))
+		}
+	}
+
+	for _, std := range m.SupportedStandards {
+		if err := standard.Check(&m, std); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	return problems, nil
+}
+
+// manifestPermitsCall reports whether any manifest permission allows calling
+// method on hash. Group permissions cannot be resolved without the callee's
+// manifest, so only their method list is checked.
+func manifestPermitsCall(m *manifest.Manifest, hash util.Uint160, method string) bool {
+	for i := range m.Permissions {
+		p := &m.Permissions[i]
+		if p.Contract.Type == manifest.PermissionHash && !p.Contract.Hash().Equals(hash) {
+			continue
+		}
+		if p.Methods.IsWildcard() || p.Methods.Contains(method) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+var testTokenHash = util.Uint160{1, 2, 3}
+
+// writeArtifacts writes a minimal valid NEF/manifest pair after applying the
+// given mutations and returns their paths.
+func writeArtifacts(t *testing.T, mutateNEF func(*nef.File), mutateManifest func(*manifest.Manifest)) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+
+	f, err := nef.NewFile([]byte{0x40}) // RET
+	if err != nil {
+		t.Fatalf("new nef: %v", err)
+	}
+	f.Compiler = "neo-go-0.114.0"
+	if mutateNEF != nil {
+		mutateNEF(f)
+	}
+	f.Checksum = f.CalculateChecksum()
+	nefData, err := f.Bytes()
+	if err != nil {
+		t.Fatalf("encode nef: %v", err)
+	}
+
+	m := manifest.DefaultManifest("Sample")
+	m.ABI.Methods = []manifest.Method{{Name: "main", ReturnType: smartcontract.VoidType, Safe: true}}
+	if mutateManifest != nil {
+		mutateManifest(m)
+	}
+	manifestData, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("encode manifest: %v", err)
+	}
+
+	nefPath := filepath.Join(dir, "Sample.nef")
+	manifestPath := filepath.Join(dir, "Sample.manifest.json")
+	if err := os.WriteFile(nefPath, nefData, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manifestPath, manifestData, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return nefPath, manifestPath
+}
+
+func withToken(flags callflag.CallFlag) func(*nef.File) {
+	return func(f *nef.File) {
+		f.Tokens = []nef.MethodToken{{Hash: testTokenHash, Method: "transfer", ParamCount: 4, HasReturn: true, CallFlag: flags}}
+	}
+}
+
+func TestCheckContractArtifacts(t *testing.T) {
+	tests := []struct {
+		name           string
+		mutateNEF      func(*nef.File)
+		mutateManifest func(*manifest.Manifest)
+		compilerPrefix string
+		wantProblem    string // empty means no problems
+	}{
+		{name: "valid", compilerPrefix: "neo-go"},
+		{name: "valid without prefix"},
+		{
+			name:        "missing compiler",
+			mutateNEF:   func(f *nef.File) { f.Compiler = "" },
+			wantProblem: "does not declare a compiler",
+		},
+		{
+			name:           "compiler mismatch",
+			compilerPrefix: "neon",
+			wantProblem:    `does not match expected "neon"`,
+		},
+		{
+			name:           "invalid manifest",
+			mutateManifest: func(m *manifest.Manifest) { m.ABI.Methods = nil },
+			wantProblem:    "manifest is invalid",
+		},
+		{
+			name:        "permitted token",
+			mutateNEF:   withToken(callflag.All),
+			wantProblem: "",
+		},
+		{
+			name:      "unpermitted token",
+			mutateNEF: withToken(callflag.All),
+			mutateManifest: func(m *manifest.Manifest) {
+				p := manifest.NewPermission(manifest.PermissionWildcard)
+				p.Methods.Add("balanceOf")
+				m.Permissions = []manifest.Permission{*p}
+			},
+			wantProblem: "not permitted by manifest permissions",
+		},
+		{
+			name:           "declared standard not implemented",
+			mutateManifest: func(m *manifest.Manifest) { m.SupportedStandards = []string{manifest.NEP17StandardName} },
+			wantProblem:    "NEP-17",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nefPath, manifestPath := writeArtifacts(t, tt.mutateNEF, tt.mutateManifest)
+
+			problems, err := checkContractArtifacts(nefPath, manifestPath, tt.compilerPrefix)
+			if err != nil {
+				t.Fatalf("checkContractArtifacts: %v", err)
+			}
+			if tt.wantProblem == "" {
+				if len(problems) != 0 {
+					t.Fatalf("problems = %v, want none", problems)
+				}
+				return
+			}
+			for _, p := range problems {
+				if strings.Contains(p, tt.wantProblem) {
+					return
+				}
+			}
+			t.Fatalf("problems = %v, want one containing %q", problems, tt.wantProblem)
+		})
+	}
+}
+
+func TestCheckContractArtifactsUnreadable(t *testing.T) {
+	nefPath, manifestPath := writeArtifacts(t, nil, nil)
+	badFlagsNEF, _ := writeArtifacts(t, withToken(callflag.CallFlag(0x80)), nil)
+	garbage := filepath.Join(t.TempDir(), "garbage")
+	if err := os.WriteFile(garbage, []byte("not an artifact"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name         string
+		nefPath      string
+		manifestPath string
+		wantErr      string
+	}{
+		{"missing nef", missing, manifestPath, "read nef"},
+		{"corrupt nef", garbage, manifestPath, "parse nef"},
+		{"invalid token call flags", badFlagsNEF, manifestPath, "parse nef"},
+		{"missing manifest", nefPath, missing, "read manifest"},
+		{"corrupt manifest", nefPath, garbage, "parse manifest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkContractArtifacts(tt.nefPath, tt.manifestPath, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManifestPermitsCall(t *testing.T) {
+	other := util.Uint160{9}
+
+	byHash := manifest.NewPermission(manifest.PermissionHash, testTokenHash)
+	byHash.Methods.Add("transfer")
+	byGroup := manifest.Permission{Contract: manifest.PermissionDesc{Type: manifest.PermissionGroup}}
+	byGroup.Methods.Restrict()
+	byGroup.Methods.Add("symbol")
+
+	tests := []struct {
+		name        string
+		permissions []manifest.Permission
+		hash        util.Uint160
+		method      string
+		want        bool
+	}{
+		{"wildcard", []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}, other, "anything", true},
+		{"hash and method", []manifest.Permission{*byHash}, testTokenHash, "transfer", true},
+		{"hash, other method", []manifest.Permission{*byHash}, testTokenHash, "mint", false},
+		{"other hash", []manifest.Permission{*byHash}, other, "transfer", false},
+		{"group checks methods only", []manifest.Permission{byGroup}, other, "symbol", true},
+		{"no permissions", nil, testTokenHash, "transfer", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := manifest.NewManifest("Sample")
+			m.Permissions = tt.permissions
+			if got := manifestPermitsCall(m, tt.hash, tt.method); got != tt.want {
+				t.Fatalf("manifestPermitsCall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
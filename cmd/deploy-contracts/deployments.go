@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
+)
+
+// deploymentsFileFor returns the deployment tracker file next to the registry
+// config file, e.g. deploy/config/testnet_contracts.json -> deploy/config/deployments.json.
+// It records pending and failed attempts for every network; confirmed
+// deployments come from the per-network <network>_contracts.json registries.
+func deploymentsFileFor(configFile string) string {
+	return filepath.Join(filepath.Dir(configFile), "deployments.json")
+}
+
+// trackDeployAttempts records deploy attempts in the tracker file.
+func trackDeployAttempts(configFile string, attempts []chain.Deployment) error {
+	filename := deploymentsFileFor(configFile)
+	tracker := chain.NewDeploymentTracker()
+	if err := tracker.LoadFromFile(filename); err != nil {
+		return err
+	}
+	for _, d := range attempts {
+		if err := tracker.Record(d); err != nil {
+			return err
+		}
+	}
+	return tracker.SaveToFile(filename)
+}
+
+// loadDeploymentTracker builds the cross-network view: platform contracts
+// target every network (unless the tracker file says otherwise), tracked
+// attempts are loaded, and each network's registry marks its contracts
+// confirmed.
+func loadDeploymentTracker(configDir string, networks []string) (*chain.DeploymentTracker, error) {
+	tracker := chain.NewDeploymentTracker()
+	for _, name := range platformContracts {
+		tracker.SetTargets(name, networks)
+	}
+	if err := tracker.LoadFromFile(filepath.Join(configDir, "deployments.json")); err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		registry := chain.NewContractRegistry(network, configDir)
+		if err := registry.LoadFromFile(filepath.Join(configDir, network+"_contracts.json")); err != nil {
+			return nil, err
+		}
+		if err := tracker.LoadRegistry(registry); err != nil {
+			return nil, err
+		}
+	}
+	return tracker, nil
+}
+
+// runServe serves deployment status over HTTP:
+//
+//	GET /deployments             aggregate status of every tracked contract
+//	GET /deployments/{contract}  per-network address, status and tx hash
+//
+// Files are re-read on every request so the view follows deploys and rollbacks.
+func runServe(addr, configDir, networkList string) {
+	var networks []string
+	for _, n := range strings.Split(networkList, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			networks = append(networks, n)
+		}
+	}
+	if len(networks) == 0 {
+		log.Fatal("--networks must list at least one network")
+	}
+
+	log.Printf("Serving deployment status on %s (networks: %s, config: %s)", addr, strings.Join(networks, ", "), configDir)
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           newDeploymentsHandler(configDir, networks),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatal(server.ListenAndServe())
+}
+
+// newDeploymentsHandler returns the routes served by runServe.
+func newDeploymentsHandler(configDir string, networks []string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /deployments", func(w http.ResponseWriter, r *http.Request) {
+		tracker, err := loadDeploymentTracker(configDir, networks)
+		if err != nil {
+			httputil.InternalError(w, err.Error())
+			return
+		}
+		statuses := make([]*chain.ContractDeploymentStatus, 0)
+		for _, id := range tracker.Contracts() {
+			status, err := tracker.ContractStatus(id)
+			if err != nil {
+				httputil.InternalError(w, err.Error())
+				return
+			}
+			statuses = append(statuses, status)
+		}
+		httputil.WriteJSON(w, http.StatusOK, map[string]any{"contracts": statuses})
+	})
+	mux.HandleFunc("GET /deployments/{contract}", func(w http.ResponseWriter, r *http.Request) {
+		tracker, err := loadDeploymentTracker(configDir, networks)
+		if err != nil {
+			httputil.InternalError(w, err.Error())
+			return
+		}
+		status, err := tracker.ContractStatus(r.PathValue("contract"))
+		if errors.Is(err, chain.ErrContractNotTracked) {
+			httputil.NotFound(w, err.Error())
+			return
+		}
+		if err != nil {
+			httputil.InternalError(w, err.Error())
+			return
+		}
+		httputil.WriteJSON(w, http.StatusOK, status)
+	})
+	return mux
+}
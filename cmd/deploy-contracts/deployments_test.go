@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+)
+
+// writeDeploymentConfig writes a testnet registry with PaymentHub confirmed
+// and a tracker file with a failed mainnet PaymentHub attempt.
+func writeDeploymentConfig(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	registry := chain.NewContractRegistry("testnet", dir)
+	registry.SetHash("PaymentHub", "0x01")
+	if err := registry.SaveToFile(filepath.Join(dir, "testnet_contracts.json")); err != nil {
+		t.Fatalf("save registry: %v", err)
+	}
+	if err := trackDeployAttempts(filepath.Join(dir, "testnet_contracts.json"), []chain.Deployment{
+		{Template: "PaymentHub", Network: "mainnet", Status: chain.DeploymentFailed},
+	}); err != nil {
+		t.Fatalf("track attempts: %v", err)
+	}
+	return dir
+}
+
+func serveDeployments(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	return rr
+}
+
+func TestDeploymentsHandlerList(t *testing.T) {
+	handler := newDeploymentsHandler(writeDeploymentConfig(t), []string{"testnet", "mainnet"})
+
+	rr := serveDeployments(t, handler, "/deployments")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Contracts []chain.ContractDeploymentStatus `json:"contracts"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Contracts) != len(platformContracts) {
+		t.Fatalf("contracts = %d, want %d", len(resp.Contracts), len(platformContracts))
+	}
+	for _, status := range resp.Contracts {
+		want := chain.ContractNotDeployed
+		if status.Contract == "PaymentHub" {
+			want = chain.ContractPartiallyDeployed
+		}
+		if status.Status != want {
+			t.Errorf("%s status = %q, want %q", status.Contract, status.Status, want)
+		}
+	}
+}
+
+func TestDeploymentsHandlerContract(t *testing.T) {
+	handler := newDeploymentsHandler(writeDeploymentConfig(t), []string{"testnet", "mainnet"})
+
+	rr := serveDeployments(t, handler, "/deployments/PaymentHub")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rr.Code, rr.Body.String())
+	}
+	var status chain.ContractDeploymentStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(status.Confirmed, []string{"testnet"}) || !reflect.DeepEqual(status.Failed, []string{"mainnet"}) {
+		t.Fatalf("confirmed = %v, failed = %v", status.Confirmed, status.Failed)
+	}
+	if d := status.Networks["testnet"]; d == nil || d.Address != "0x01" {
+		t.Fatalf("testnet deployment = %+v, want address 0x01", d)
+	}
+
+	if rr := serveDeployments(t, handler, "/deployments/Unknown"); rr.Code != http.StatusNotFound {
+		t.Fatalf("unknown contract status = %d, want 404", rr.Code)
+	}
+}
+
+func TestDeploymentsHandlerCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "deployments.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	handler := newDeploymentsHandler(dir, []string{"testnet"})
+
+	for _, path := range []string{"/deployments", "/deployments/PaymentHub"} {
+		if rr := serveDeployments(t, handler, path); rr.Code != http.StatusInternalServerError {
+			t.Errorf("GET %s status = %d, want 500", path, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+)
+
+// DeploymentRecord is one timestamped contract set in the deployment history.
+type DeploymentRecord struct {
+	ID        int               `json:"id"`
+	Timestamp string            `json:"timestamp"`
+	Deployer  string            `json:"deployer,omitempty"`
+	Active    bool              `json:"active"`
+	Contracts map[string]string `json:"contracts"`
+}
+
+// DeploymentHistory holds every recorded deployment for a network, oldest first.
+type DeploymentHistory struct {
+	Network     string             `json:"network"`
+	Deployments []DeploymentRecord `json:"deployments"`
+}
+
+// historyFileFor derives the history file path from the registry config file,
+// e.g. deploy/config/testnet_contracts.json -> deploy/config/testnet_contracts_history.json.
+func historyFileFor(configFile string) string {
+	ext := filepath.Ext(configFile)
+	return strings.TrimSuffix(configFile, ext) + "_history" + ext
+}
+
+func loadDeploymentHistory(filename, network string) (*DeploymentHistory, error) {
+	history := &DeploymentHistory{Network: network}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("unmarshal history: %w", err)
+	}
+	return history, nil
+}
+
+func (h *DeploymentHistory) save(filename string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// appendDeployment adds a new record and makes it the active deployment.
+func (h *DeploymentHistory) appendDeployment(deployer string, contracts map[string]string) DeploymentRecord {
+	nextID := 1
+	for i := range h.Deployments {
+		h.Deployments[i].Active = false
+		if h.Deployments[i].ID >= nextID {
+			nextID = h.Deployments[i].ID + 1
+		}
+	}
+
+	record := DeploymentRecord{
+		ID:        nextID,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Deployer:  deployer,
+		Active:    true,
+		Contracts: contracts,
+	}
+	h.Deployments = append(h.Deployments, record)
+	return record
+}
+
+// activate marks the record with the given ID as the only active deployment.
+func (h *DeploymentHistory) activate(id int) (*DeploymentRecord, error) {
+	var target *DeploymentRecord
+	for i := range h.Deployments {
+		if h.Deployments[i].ID == id {
+			target = &h.Deployments[i]
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("deployment %d not found", id)
+	}
+
+	for i := range h.Deployments {
+		h.Deployments[i].Active = h.Deployments[i].ID == id
+	}
+	return target, nil
+}
+
+// recordDeployment appends the given contract hashes to the history file.
+// Contracts not part of this run keep the hash currently in the registry so
+// every record describes a complete contract set.
+func recordDeployment(configFile string, registry *chain.ContractRegistry, deployer string, results map[string]*chain.DeployedContract) error {
+	contracts := make(map[string]string)
+	for _, info := range registry.List() {
+		if info != nil && info.Hash != "" {
+			contracts[info.Name] = info.Hash
+		}
+	}
+	for name, deployed := range results {
+		contracts[name] = deployed.Hash
+	}
+
+	historyFile := historyFileFor(configFile)
+	history, err := loadDeploymentHistory(historyFile, "testnet")
+	if err != nil {
+		return err
+	}
+	record := history.appendDeployment(deployer, contracts)
+	if err := history.save(historyFile); err != nil {
+		return err
+	}
+
+	log.Printf("Recorded deployment #%d in %s", record.ID, historyFile)
+	return nil
+}
+
+func runHistory(configFile string) {
+	historyFile := historyFileFor(configFile)
+	history, err := loadDeploymentHistory(historyFile, "testnet")
+	if err != nil {
+		log.Fatalf("Failed to load deployment history: %v", err)
+	}
+
+	if len(history.Deployments) == 0 {
+		log.Printf("No deployments recorded in %s", historyFile)
+		return
+	}
+
+	log.Printf("=== Deployment History (%s) ===", history.Network)
+	for _, record := range history.Deployments {
+		marker := " "
+		if record.Active {
+			marker = "*"
+		}
+		fmt.Printf("%s #%-4d %s  %s\n", marker, record.ID, record.Timestamp, record.Deployer)
+
+		names := make([]string, 0, len(record.Contracts))
+		for name := range record.Contracts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("      %-20s %s\n", name, record.Contracts[name])
+		}
+	}
+	fmt.Println("\n(* = active deployment)")
+}
+
+// applyRollback sets the registry to exactly the contract set in record.
+// Contracts registered now but absent from record did not exist at that
+// deployment, so their hashes are cleared; their names are returned sorted.
+func applyRollback(registry *chain.ContractRegistry, record *DeploymentRecord) []string {
+	var cleared []string
+	for _, info := range registry.List() {
+		if info == nil || info.Hash == "" {
+			continue
+		}
+		if _, ok := record.Contracts[info.Name]; !ok {
+			registry.SetHash(info.Name, "")
+			cleared = append(cleared, info.Name)
+		}
+	}
+	for name, hash := range record.Contracts {
+		registry.SetHash(name, hash)
+	}
+	sort.Strings(cleared)
+	return cleared
+}
+
+// runRollback repoints the registry config to the hashes of a prior deployment
+// and marks that deployment active.
+func runRollback(configFile string, id int) {
+	if id <= 0 {
+		log.Fatal("--id flag is required (see 'history' for deployment IDs)")
+	}
+
+	historyFile := historyFileFor(configFile)
+	history, err := loadDeploymentHistory(historyFile, "testnet")
+	if err != nil {
+		log.Fatalf("Failed to load deployment history: %v", err)
+	}
+
+	record, err := history.activate(id)
+	if err != nil {
+		log.Fatalf("Rollback failed: %v", err)
+	}
+
+	registry := chain.NewContractRegistry(history.Network, filepath.Dir(configFile))
+	if err := registry.LoadFromFile(configFile); err != nil {
+		log.Fatalf("Failed to load config file: %v", err)
+	}
+	for _, name := range applyRollback(registry, record) {
+		log.Printf("⚠️  %s was not part of deployment #%d; cleared its hash", name, record.ID)
+	}
+
+	if err := registry.SaveToFile(configFile); err != nil {
+		log.Fatalf("Failed to write config file: %v", err)
+	}
+	if err := history.save(historyFile); err != nil {
+		log.Fatalf("Failed to write deployment history: %v", err)
+	}
+
+	log.Printf("✅ Rolled back to deployment #%d (%s)", record.ID, record.Timestamp)
+	log.Printf("Config updated: %s", configFile)
+	log.Println("Re-export addresses with 'deploy-contracts export' and restart dependent services")
+}
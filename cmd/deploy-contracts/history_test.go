@@ -0,0 +1,96 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+)
+
+func TestAppendDeploymentActivatesNewRecord(t *testing.T) {
+	h := &DeploymentHistory{Network: "testnet"}
+
+	first := h.appendDeployment("NDeployer", map[string]string{"PaymentHub": "0x01"})
+	second := h.appendDeployment("NDeployer", map[string]string{"PaymentHub": "0x02"})
+
+	if first.ID != 1 || second.ID != 2 {
+		t.Fatalf("ids = %d, %d, want 1, 2", first.ID, second.ID)
+	}
+	if h.Deployments[0].Active || !h.Deployments[1].Active {
+		t.Fatalf("only the newest record should be active: %+v", h.Deployments)
+	}
+
+	// IDs keep increasing past the highest existing one, even out of order.
+	h.Deployments = append(h.Deployments, DeploymentRecord{ID: 7})
+	if third := h.appendDeployment("", nil); third.ID != 8 {
+		t.Fatalf("id = %d, want 8", third.ID)
+	}
+}
+
+func TestActivate(t *testing.T) {
+	h := &DeploymentHistory{}
+	h.appendDeployment("", map[string]string{"PaymentHub": "0x01"})
+	h.appendDeployment("", map[string]string{"PaymentHub": "0x02"})
+
+	record, err := h.activate(1)
+	if err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if record.Contracts["PaymentHub"] != "0x01" {
+		t.Fatalf("activated record = %+v", record)
+	}
+	if !h.Deployments[0].Active || h.Deployments[1].Active {
+		t.Fatalf("only #1 should be active: %+v", h.Deployments)
+	}
+
+	if _, err := h.activate(99); err == nil {
+		t.Fatal("expected error for unknown deployment")
+	}
+	if !h.Deployments[0].Active {
+		t.Fatal("failed activate must not change the active record")
+	}
+}
+
+func TestDeploymentHistoryRoundTrip(t *testing.T) {
+	filename := historyFileFor(filepath.Join(t.TempDir(), "testnet_contracts.json"))
+	if filepath.Base(filename) != "testnet_contracts_history.json" {
+		t.Fatalf("history file = %s", filename)
+	}
+
+	h, err := loadDeploymentHistory(filename, "testnet")
+	if err != nil {
+		t.Fatalf("load missing file: %v", err)
+	}
+	h.appendDeployment("NDeployer", map[string]string{"PaymentHub": "0x01"})
+	if err := h.save(filename); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := loadDeploymentHistory(filename, "testnet")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, h) {
+		t.Fatalf("loaded = %+v, want %+v", loaded, h)
+	}
+}
+
+func TestApplyRollbackClearsContractsMissingFromRecord(t *testing.T) {
+	registry := chain.NewContractRegistry("testnet", t.TempDir())
+	registry.SetHash("PaymentHub", "0x02")
+	registry.SetHash("Governance", "0x03")
+
+	record := &DeploymentRecord{ID: 1, Contracts: map[string]string{"PaymentHub": "0x01"}}
+	cleared := applyRollback(registry, record)
+
+	if !reflect.DeepEqual(cleared, []string{"Governance"}) {
+		t.Fatalf("cleared = %v, want [Governance]", cleared)
+	}
+	if got := registry.GetHash("PaymentHub"); got != "0x01" {
+		t.Fatalf("PaymentHub = %q, want 0x01", got)
+	}
+	if got := registry.GetHash("Governance"); got != "" {
+		t.Fatalf("Governance = %q, want cleared", got)
+	}
+}
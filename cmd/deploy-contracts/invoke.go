@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/R3E-Network/service_layer/deploy/testnet"
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+)
+
+// runInvoke test-invokes an arbitrary contract method and prints the VM state
+// and result stack. Nothing is signed or broadcast.
+func runInvoke(rpcURL, contractHash, method string, rawArgs []string) {
+	if contractHash == "" || method == "" {
+		log.Fatal("--hash and --method flags are required")
+	}
+
+	params, err := parseInvokeArgs(rawArgs)
+	if err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	deployer, err := testnet.NewDeployer(rpcURL)
+	if err != nil {
+		log.Fatalf("Failed to create deployer: %v", err)
+	}
+
+	args := make([]interface{}, len(params))
+	for i, p := range params {
+		args[i] = p
+	}
+
+	log.Println("=== Contract Invoke (test) ===")
+	log.Printf("RPC: %s", rpcURL)
+	log.Printf("Contract: %s", contractHash)
+	log.Printf("Method: %s", method)
+
+	result, err := deployer.InvokeFunction(contractHash, method, args)
+	if err != nil {
+		log.Fatalf("Invoke failed: %v", err)
+	}
+
+	fmt.Printf("VMState: %s\n", result.State)
+	fmt.Printf("GasConsumed: %.8f\n", parseGas(result.GasConsumed))
+	if result.Exception != "" {
+		fmt.Printf("Exception: %s\n", result.Exception)
+	}
+	for i, item := range result.Stack {
+		fmt.Printf("Stack[%d]: %s %s\n", i, item.Type, string(item.Value))
+	}
+}
+
+// parseInvokeArgs converts typed CLI arguments into contract parameters.
+// Each argument has the form <type>:<value>, for example:
+//
+//	str:hello  int:5  bool:true  hash160:0x...  hash256:0x...  bytes:deadbeef  pubkey:02...  any:
+func parseInvokeArgs(rawArgs []string) ([]chain.ContractParam, error) {
+	params := make([]chain.ContractParam, 0, len(rawArgs))
+	for i, raw := range rawArgs {
+		param, err := parseInvokeArg(raw)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d (%q): %w", i, raw, err)
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+func parseInvokeArg(raw string) (chain.ContractParam, error) {
+	prefix, value, ok := strings.Cut(raw, ":")
+	if !ok {
+		return chain.ContractParam{}, fmt.Errorf("missing type prefix (expected <type>:<value>)")
+	}
+
+	switch strings.ToLower(prefix) {
+	case "str", "string":
+		return chain.NewStringParam(value), nil
+	case "int", "integer":
+		n, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return chain.ContractParam{}, fmt.Errorf("invalid integer %q", value)
+		}
+		return chain.NewIntegerParam(n), nil
+	case "bool", "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return chain.ContractParam{}, fmt.Errorf("invalid boolean %q", value)
+		}
+		return chain.NewBoolParam(b), nil
+	case "hash160":
+		h, err := parseHexArg(value, 20)
+		if err != nil {
+			return chain.ContractParam{}, err
+		}
+		return chain.NewHash160Param("0x" + h), nil
+	case "hash256":
+		h, err := parseHexArg(value, 32)
+		if err != nil {
+			return chain.ContractParam{}, err
+		}
+		return chain.NewHash256Param("0x" + h), nil
+	case "bytes", "bytearray":
+		b, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+		if err != nil {
+			return chain.ContractParam{}, fmt.Errorf("invalid hex bytes %q", value)
+		}
+		return chain.NewByteArrayParam(b), nil
+	case "pubkey", "publickey":
+		h, err := parseHexArg(value, 33)
+		if err != nil {
+			return chain.ContractParam{}, err
+		}
+		return chain.NewPublicKeyParam(h), nil
+	case "any":
+		return chain.NewAnyParam(), nil
+	default:
+		return chain.ContractParam{}, fmt.Errorf("unknown type prefix %q (use str, int, bool, hash160, hash256, bytes, pubkey, any)", prefix)
+	}
+}
+
+func parseHexArg(value string, size int) (string, error) {
+	h := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	b, err := hex.DecodeString(h)
+	if err != nil || len(b) != size {
+		return "", fmt.Errorf("expected %d-byte hex value, got %q", size, value)
+	}
+	return h, nil
+}
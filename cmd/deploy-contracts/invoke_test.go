@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseInvokeArg(t *testing.T) {
+	hash160 := "0x" + strings.Repeat("ab", 20)
+	pubkey := "02" + strings.Repeat("11", 32)
+
+	tests := []struct {
+		raw      string
+		wantType string
+		wantVal  any
+	}{
+		{"str:hello", "String", "hello"},
+		{"string:a:b", "String", "a:b"},
+		{"int:42", "Integer", "42"},
+		{"INT:-7", "Integer", "-7"},
+		{"bool:true", "Boolean", true},
+		{"hash160:" + hash160, "Hash160", hash160},
+		{"hash160:" + strings.ToUpper(hash160[2:]), "Hash160", hash160},
+		{"hash256:" + strings.Repeat("cd", 32), "Hash256", "0x" + strings.Repeat("cd", 32)},
+		{"bytes:0x0102", "ByteArray", "AQI="},
+		{"pubkey:" + pubkey, "PublicKey", pubkey},
+		{"any:", "Any", nil},
+	}
+	for _, tt := range tests {
+		param, err := parseInvokeArg(tt.raw)
+		if err != nil {
+			t.Errorf("parseInvokeArg(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if param.Type != tt.wantType {
+			t.Errorf("parseInvokeArg(%q) type = %s, want %s", tt.raw, param.Type, tt.wantType)
+		}
+		if tt.wantVal != nil && param.Value != tt.wantVal {
+			t.Errorf("parseInvokeArg(%q) value = %v, want %v", tt.raw, param.Value, tt.wantVal)
+		}
+	}
+}
+
+func TestParseInvokeArgRejectsInvalid(t *testing.T) {
+	for _, raw := range []string{
+		"hello",                // no type prefix
+		"float:1.5",            // unknown prefix
+		"int:1.5",              // not an integer
+		"bool:maybe",           // not a boolean
+		"hash160:0x1234",       // wrong length
+		"hash256:zz",           // not hex
+		"bytes:0xzz",           // not hex
+		"pubkey:" + "02abcdef", // wrong length
+	} {
+		if _, err := parseInvokeArg(raw); err == nil {
+			t.Errorf("parseInvokeArg(%q) expected error", raw)
+		}
+	}
+}
+
+func TestParseInvokeArgsReportsPosition(t *testing.T) {
+	_, err := parseInvokeArgs([]string{"int:1", "bool:nope"})
+	if err == nil || !strings.Contains(err.Error(), "arg 1") {
+		t.Fatalf("err = %v, want error naming arg 1", err)
+	}
+}
@@ -34,6 +34,11 @@ func main() {
 	updateCmd := flag.NewFlagSet("update", flag.ExitOnError)
 	verifyCmd := flag.NewFlagSet("verify", flag.ExitOnError)
 	exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+	checkCmd := flag.NewFlagSet("check", flag.ExitOnError)
+	invokeCmd := flag.NewFlagSet("invoke", flag.ExitOnError)
+	historyCmd := flag.NewFlagSet("history", flag.ExitOnError)
+	rollbackCmd := flag.NewFlagSet("rollback", flag.ExitOnError)
+	serveCmd := flag.NewFlagSet("serve", flag.ExitOnError)
 
 	// Common flags
 	rpcURL := "https://testnet1.neo.coz.io:443"
@@ -65,6 +70,28 @@ func main() {
 	exportConfig := exportCmd.String("config", configFile, "Contract config file")
 	exportFormat := exportCmd.String("format", "env", "Export format: env, json, or dotenv")
 
+	// Check flags
+	checkBuild := checkCmd.String("build", buildDir, "Contract build directory")
+	checkContract := checkCmd.String("contract", "all", "Specific contract to check (or 'all')")
+	checkCompiler := checkCmd.String("compiler", "", "Required compiler prefix recorded in the NEF (e.g. 'neon-')")
+
+	// Invoke flags
+	invokeRPC := invokeCmd.String("rpc", rpcURL, "Neo N3 RPC URL")
+	invokeHash := invokeCmd.String("hash", "", "Contract script hash (0x...)")
+	invokeMethod := invokeCmd.String("method", "", "Contract method to invoke")
+
+	// History flags
+	historyConfig := historyCmd.String("config", configFile, "Contract config file")
+
+	// Rollback flags
+	rollbackConfig := rollbackCmd.String("config", configFile, "Contract config file")
+	rollbackID := rollbackCmd.Int("id", 0, "Deployment ID to roll back to")
+
+	// Serve flags
+	serveAddr := serveCmd.String("addr", "127.0.0.1:8090", "HTTP listen address")
+	serveConfigDir := serveCmd.String("config-dir", filepath.Dir(configFile), "Directory with <network>_contracts.json and deployments.json")
+	serveNetworks := serveCmd.String("networks", "testnet,mainnet", "Comma-separated target networks")
+
 	if len(os.Args) < 2 {
 		printUsage()
 		os.Exit(1)
@@ -86,6 +113,21 @@ func main() {
 	case "export":
 		exportCmd.Parse(os.Args[2:])
 		runExport(*exportConfig, *exportFormat)
+	case "check":
+		checkCmd.Parse(os.Args[2:])
+		runCheck(*checkBuild, *checkContract, *checkCompiler)
+	case "invoke":
+		invokeCmd.Parse(os.Args[2:])
+		runInvoke(*invokeRPC, *invokeHash, *invokeMethod, invokeCmd.Args())
+	case "history":
+		historyCmd.Parse(os.Args[2:])
+		runHistory(*historyConfig)
+	case "rollback":
+		rollbackCmd.Parse(os.Args[2:])
+		runRollback(*rollbackConfig, *rollbackID)
+	case "serve":
+		serveCmd.Parse(os.Args[2:])
+		runServe(*serveAddr, *serveConfigDir, *serveNetworks)
 	case "help", "-h", "--help":
 		printUsage()
 	default:
@@ -107,6 +149,11 @@ Commands:
   update    Update an existing contract
   verify    Verify contracts are callable
   export    Export contract addresses
+  check     Check built NEF/manifest compatibility before deploy
+  invoke    Test-invoke a contract method with typed arguments
+  history   List recorded deployments (active one marked with *)
+  rollback  Repoint the contract config to a prior deployment
+  serve     Serve per-network deployment status over HTTP
 
 Examples:
   # Check contract status
@@ -122,7 +169,20 @@ Examples:
   deploy-contracts export --format=env
 
   # Verify contracts are callable
-  deploy-contracts verify`)
+  deploy-contracts verify
+
+  # Check built artifacts (compiler, call flags, declared standards)
+  deploy-contracts check --contract=all --compiler=neon-
+
+  # Test-invoke a method (args: str:, int:, bool:, hash160:, hash256:, bytes:, pubkey:, any:)
+  deploy-contracts invoke --hash=0x... --method=balanceOf hash160:0x...
+
+  # Show deployment history and roll back to deployment #2
+  deploy-contracts history
+  deploy-contracts rollback --id=2
+
+  # Serve deployment status across networks (GET /deployments/{contract})
+  deploy-contracts serve --networks=testnet,mainnet`)
 }
 
 func runStatus(rpcURL, configFile string) {
@@ -221,7 +281,8 @@ func runDeploy(rpcURL, configFile, buildDir, contractName string, dryRun bool) {
 	}
 
 	var totalGas float64
-	results := make(map[string]*chain.DeployedContract)
+	confirmed := make(map[string]*chain.DeployedContract)
+	var attempts []chain.Deployment
 
 	for _, name := range toDeploy {
 		nefPath := filepath.Join(buildDir, name+".nef")
@@ -244,10 +305,32 @@ func runDeploy(rpcURL, configFile, buildDir, contractName string, dryRun bool) {
 			}
 		}
 
+		// A contract already live at its expected hash was deployed since the
+		// last run (e.g. with neo-go); confirm it instead of simulating again.
+		if expected, hashErr := deployer.ExpectedContractHash(nefPath, manifestPath); hashErr == nil {
+			if _, stateErr := deployer.GetContractState(expected); stateErr == nil {
+				log.Printf("✅ Confirmed on-chain at %s", expected)
+				confirmed[name] = &chain.DeployedContract{Name: name, Hash: expected}
+				attempts = append(attempts, chain.Deployment{
+					Template: name,
+					Network:  "testnet",
+					Address:  expected,
+					Status:   chain.DeploymentConfirmed,
+				})
+				continue
+			}
+		}
+
 		// Simulate deployment
 		deployed, err := deployer.DeployContract(nefPath, manifestPath)
 		if err != nil {
 			log.Printf("❌ Simulation failed: %v", err)
+			attempts = append(attempts, chain.Deployment{
+				Template: name,
+				Network:  "testnet",
+				Status:   chain.DeploymentFailed,
+				Error:    err.Error(),
+			})
 			continue
 		}
 
@@ -261,9 +344,16 @@ func runDeploy(rpcURL, configFile, buildDir, contractName string, dryRun bool) {
 		} else {
 			log.Printf("⚠️  Actual deployment requires neo-go CLI:")
 			log.Printf("   neo-go contract deploy -i %s -m %s -r %s -w wallet.json", nefPath, manifestPath, rpcURL)
+			log.Printf("   Then re-run deploy to confirm and register it")
 		}
 
-		results[name] = deployed
+		// Confirmed by a later run once the contract is live on-chain.
+		attempts = append(attempts, chain.Deployment{
+			Template: name,
+			Network:  "testnet",
+			Address:  deployed.Hash,
+			Status:   chain.DeploymentPending,
+		})
 	}
 
 	log.Printf("\n=== Summary ===")
@@ -274,6 +364,26 @@ func runDeploy(rpcURL, configFile, buildDir, contractName string, dryRun bool) {
 		log.Printf("⚠️  Insufficient GAS (need %.4f more)", totalGas-gasBalance)
 	}
 
+	// Only contracts confirmed on-chain are registered and recorded in the
+	// history; simulated hashes stay pending in the tracker.
+	if !dryRun && len(confirmed) > 0 {
+		for name, deployed := range confirmed {
+			registry.RegisterDeployment(name, deployed.Hash, "", "", deployer.GetAddress())
+		}
+		if err := registry.SaveToFile(configFile); err != nil {
+			log.Fatalf("Failed to write config file: %v", err)
+		}
+		log.Printf("Registered %d confirmed contract(s) in %s", len(confirmed), configFile)
+		if err := recordDeployment(configFile, registry, deployer.GetAddress(), confirmed); err != nil {
+			log.Printf("Warning: Failed to record deployment history: %v", err)
+		}
+	}
+	if !dryRun && len(attempts) > 0 {
+		if err := trackDeployAttempts(configFile, attempts); err != nil {
+			log.Printf("Warning: Failed to record deployment status: %v", err)
+		}
+	}
+
 	if dryRun {
 		log.Println("\nTo deploy for real, run with --dry-run=false")
 		log.Println("Note: Actual deployment requires manual signing with neo-go CLI")
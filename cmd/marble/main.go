@@ -30,6 +30,7 @@ import (
 	"github.com/R3E-Network/service_layer/infrastructure/runtime"
 	"github.com/R3E-Network/service_layer/infrastructure/secrets"
 	secretssupabase "github.com/R3E-Network/service_layer/infrastructure/secrets/supabase"
+	commonservice "github.com/R3E-Network/service_layer/infrastructure/service"
 	txproxyclient "github.com/R3E-Network/service_layer/infrastructure/txproxy/client"
 	txproxytypes "github.com/R3E-Network/service_layer/infrastructure/txproxy/types"
 
@@ -54,6 +55,7 @@ import (
 
 // ServiceRunner interface for all Neo services
 type ServiceRunner interface {
+	SelfTest(ctx context.Context) error
 	Start(ctx context.Context) error
 	Stop() error
 	Router() *mux.Router
@@ -128,20 +130,40 @@ func main() {
 		supabaseServiceKey = strings.TrimSpace(string(secret))
 	}
 
-	dbClient, err := database.NewClient(database.Config{
-		URL:        supabaseURL,
-		ServiceKey: supabaseServiceKey,
+	store, err := database.OpenStore(database.StoreConfig{
+		Backend: os.Getenv("STORAGE_BACKEND"),
+		Postgres: database.Config{
+			URL:        supabaseURL,
+			ServiceKey: supabaseServiceKey,
+		},
 	})
 	if err != nil {
-		log.Fatalf("Failed to create database client: %v", err)
+		log.Fatalf("Failed to open storage backend: %v", err)
 	}
-	db := database.NewRepository(dbClient)
+	if store.Backend != database.BackendPostgres {
+		if runtime.StrictIdentityMode() || m.IsEnclave() {
+			log.Fatalf("CRITICAL: STORAGE_BACKEND=%s is not allowed in production/SGX mode", store.Backend)
+		}
+		log.Printf("Warning: using %s storage backend; data is not persisted (development/testing only)", store.Backend)
+	}
+	if err := checkStorageBackend(serviceType, store); err != nil {
+		log.Fatalf("Unsupported storage backend: %v", err)
+	}
+	db := store.Repository
 
-	// Initialize repositories
-	globalSignerRepo := globalsignersupabase.NewRepository(db)
-	neoaccountsRepo := neoaccountssupabase.NewRepository(db)
-	neoflowRepo := neoflowsupabase.NewRepository(db)
-	neorequestsRepo := neorequestsupabase.NewRepository(db)
+	// Initialize repositories (service-specific stores require postgres)
+	var (
+		globalSignerRepo globalsignersupabase.Repository
+		neoaccountsRepo  neoaccountssupabase.RepositoryInterface
+		neoflowRepo      neoflowsupabase.RepositoryInterface
+		neorequestsRepo  neorequestsupabase.RepositoryInterface
+	)
+	if pg := store.Postgres; pg != nil {
+		globalSignerRepo = globalsignersupabase.NewRepository(pg)
+		neoaccountsRepo = neoaccountssupabase.NewRepository(pg)
+		neoflowRepo = neoflowsupabase.NewRepository(pg)
+		neorequestsRepo = neorequestsupabase.NewRepository(pg)
+	}
 
 	// Chain configuration
 	neoRPCURLs := chain.ParseEndpoints(strings.TrimSpace(os.Getenv("NEO_RPC_URLS")))
@@ -467,7 +489,7 @@ func main() {
 		svc, err = neocompute.New(neocompute.Config{
 			Marble:         m,
 			DB:             db,
-			SecretProvider: newServiceSecretsProvider(m, db, neocompute.ServiceID),
+			SecretProvider: newServiceSecretsProvider(m, store.Postgres, neocompute.ServiceID),
 		})
 	case "neofeeds":
 		var feedsSvc *neofeeds.Service
@@ -475,6 +497,7 @@ func main() {
 			Marble:          m,
 			DB:              db,
 			ArbitrumRPC:     arbitrumRPC,
+			Aggregation:     os.Getenv("NEOFEEDS_AGGREGATION"),
 			ChainClient:     chainClient,
 			PriceFeedHash:   priceFeedHash,
 			TxProxy:         txProxyInvoker,
@@ -498,8 +521,15 @@ func main() {
 		})
 		svc = flowSvc
 	case "neooracle":
-		oracleAllowlistRaw := strings.TrimSpace(os.Getenv("ORACLE_HTTP_ALLOWLIST"))
-		oracleAllowlist := neooracle.URLAllowlist{Prefixes: splitAndTrimCSV(oracleAllowlistRaw)}
+		var oracleExtra map[string]any
+		if settings := servicesCfg.GetSettings(serviceType); settings != nil {
+			oracleExtra = settings.Extra
+		}
+		oraclePrefixes, allowlistErr := oracleURLAllowlist(oracleExtra)
+		if allowlistErr != nil {
+			log.Fatalf("Invalid NeoOracle url_allowlist: %v", allowlistErr)
+		}
+		oracleAllowlist := neooracle.URLAllowlist{Prefixes: oraclePrefixes}
 		if len(oracleAllowlist.Prefixes) == 0 {
 			if runtime.StrictIdentityMode() || m.IsEnclave() {
 				log.Fatalf("CRITICAL: ORACLE_HTTP_ALLOWLIST is required for NeoOracle in strict identity/SGX mode")
@@ -525,12 +555,22 @@ func main() {
 			}
 		}
 
+		oracleMaxConcurrency := 0
+		if raw := strings.TrimSpace(os.Getenv("ORACLE_MAX_CONCURRENCY")); raw != "" {
+			if parsed, parseErr := strconv.Atoi(raw); parseErr != nil || parsed <= 0 {
+				log.Printf("Warning: invalid ORACLE_MAX_CONCURRENCY %q; using default %d", raw, neooracle.DefaultMaxConcurrency)
+			} else {
+				oracleMaxConcurrency = parsed
+			}
+		}
+
 		svc, err = neooracle.New(neooracle.Config{
 			Marble:         m,
-			SecretProvider: newServiceSecretsProvider(m, db, neooracle.ServiceID),
+			SecretProvider: newServiceSecretsProvider(m, store.Postgres, neooracle.ServiceID),
 			Timeout:        oracleTimeout,
 			MaxBodyBytes:   oracleMaxBodyBytes,
 			URLAllowlist:   oracleAllowlist,
+			MaxConcurrency: oracleMaxConcurrency,
 		})
 	case "neorequests":
 		svc, err = neorequests.New(neorequests.Config{
@@ -601,12 +641,27 @@ func main() {
 	// Cap request bodies to reduce memory/CPU DoS risk. Services are typically
 	// accessed via the gateway, but this also protects internal mesh calls.
 	svc.Router().Use(slmiddleware.NewBodyLimitMiddleware(0).Handler)
+	// Honor the caller's deadline on mesh calls so work stops once the caller
+	// has given up; the Marble HTTP client forwards it downstream.
+	svc.Router().Use(slmiddleware.DeadlineMiddleware)
+
+	// Verify critical dependencies before starting so misconfiguration fails
+	// loudly instead of running degraded.
+	if err := svc.SelfTest(ctx); err != nil {
+		log.Fatalf("Startup self-test failed: %v", err)
+	}
 
 	// Start service
 	if err := svc.Start(ctx); err != nil {
 		log.Fatalf("Failed to start service: %v", err)
 	}
 
+	// Apply the runtime settings from config (read-only mode, feature flags,
+	// service-specific settings); SIGHUP re-applies them the same way.
+	for _, change := range applyRuntimeSettings(svc, servicesCfg.GetSettings(serviceType)) {
+		log.Printf("Config: %s", change)
+	}
+
 	// Get port from config or environment
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -629,7 +684,9 @@ func main() {
 		MaxHeaderBytes:    1 << 20, // 1MB
 	}
 
-	// Start server
+	// Start server. A serve error stops the service like a signal does, but
+	// is recorded as a ShutdownError and makes the process exit non-zero.
+	serverErr := make(chan error, 1)
 	go func() {
 		log.Printf("%s service listening on port %s", serviceType, port)
 		var err error
@@ -639,27 +696,37 @@ func main() {
 			err = server.ListenAndServe()
 		}
 		if err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
+			serverErr <- err
 		}
 	}()
 
-	// Wait for shutdown signal
+	// Wait for shutdown signal; SIGHUP reloads runtime configuration in place.
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	<-sigCh
-
-	log.Println("Shutting down...")
-	shutdownCtx, shutdownCancel := context.WithTimeout(ctx, 30*time.Second)
-	defer shutdownCancel()
-
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Printf("Shutdown error: %v", err)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	currentSettings := servicesCfg.GetSettings(serviceType)
+	var reason commonservice.ShutdownReason
+wait:
+	for {
+		select {
+		case sig := <-sigCh:
+			if sig != syscall.SIGHUP {
+				reason = commonservice.ShutdownReason{Code: commonservice.ShutdownSignal, Detail: sig.String()}
+				break wait
+			}
+			log.Printf("Received SIGHUP, reloading configuration...")
+			currentSettings = reloadRuntimeConfig(serviceType, svc, currentSettings)
+		case err := <-serverErr:
+			log.Printf("Server error: %v", err)
+			reason = commonservice.ShutdownReason{Code: commonservice.ShutdownError, Detail: err.Error()}
+			break wait
+		}
 	}
 
-	if err := svc.Stop(); err != nil {
-		log.Printf("Service stop error: %v", err)
-	}
+	shutdown(ctx, server, svc, reason, shutdownGracePeriod)
 
+	if reason.Code == commonservice.ShutdownError {
+		log.Fatalf("Service stopped after error: %s", reason.Detail)
+	}
 	log.Println("Service stopped")
 }
 
@@ -790,5 +857,9 @@ func newServiceSecretsProvider(m *marble.Marble, db *database.Repository, servic
 	if err != nil {
 		log.Fatalf("CRITICAL: initialize secrets manager for %s: %v", serviceID, err)
 	}
-	return secrets.ServiceProvider{Manager: manager, ServiceID: serviceID}
+	return allowlistedSecretsProvider{
+		next:      secrets.ServiceProvider{Manager: manager, ServiceID: serviceID},
+		access:    repo,
+		serviceID: serviceID,
+	}
 }
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/R3E-Network/service_layer/infrastructure/config"
+	commonservice "github.com/R3E-Network/service_layer/infrastructure/service"
+	neooracle "github.com/R3E-Network/service_layer/services/conforacle/marble"
+	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+)
+
+// reloadRuntimeConfig re-reads config/services.yaml on SIGHUP and re-applies
+// the runtime settings of applyRuntimeSettings, the same code path used at
+// startup. Enabled/port changes are only reported; they need a restart.
+// Settings sealed into the enclave by the MarbleRun Coordinator (manifest
+// secrets and environment, e.g. ORACLE_HTTP_ALLOWLIST) are fixed when the
+// marble is activated and are not re-read; change them with a manifest
+// update and restart. Failures are logged and leave the current settings in
+// place. It returns the settings to compare against on the next reload.
+func reloadRuntimeConfig(serviceType string, svc ServiceRunner, current *config.ServiceSettings) (next *config.ServiceSettings) {
+	next = current
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Warning: config reload panicked: %v", r)
+			next = current
+		}
+	}()
+
+	cfg, err := config.LoadServicesConfig()
+	if err != nil {
+		log.Printf("Warning: config reload failed: %v", err)
+		return current
+	}
+	settings := cfg.GetSettings(serviceType)
+	if settings == nil {
+		log.Printf("Warning: config reload: no settings for %s; keeping current configuration", serviceType)
+		return current
+	}
+
+	if current != nil {
+		if settings.Enabled != current.Enabled {
+			log.Printf("Config reload: enabled %v -> %v (takes effect on restart)", current.Enabled, settings.Enabled)
+		}
+		if settings.Port != current.Port {
+			log.Printf("Config reload: port %d -> %d (takes effect on restart)", current.Port, settings.Port)
+		}
+	}
+
+	changes := applyRuntimeSettings(svc, settings)
+	if len(changes) == 0 {
+		log.Printf("Config reload: no runtime changes for %s", serviceType)
+	}
+	for _, change := range changes {
+		log.Printf("Config reload: %s", change)
+	}
+	return settings
+}
+
+// applyRuntimeSettings applies the settings a service can change in place,
+// taken from its services.yaml entry:
+//
+//	neooracle: extra.url_allowlist (list or comma-separated string; falls
+//	           back to ORACLE_HTTP_ALLOWLIST when unset)
+//	neofeeds:  extra.publish_policy (threshold_bps, hysteresis_bps,
+//	           min_interval, max_per_minute, heartbeat), merged over the
+//	           current policy
+//	all:       extra.read_only (bool), extra.read_only_reason (string) and
+//	           the top-level flags block
+//
+// It runs once at startup and again on every reload, so both read the same
+// source. It returns a description of each setting that changed.
+func applyRuntimeSettings(svc ServiceRunner, settings *config.ServiceSettings) []string {
+	if settings == nil {
+		return nil
+	}
+
+	var changes []string
+	if change, ok := applyReadOnly(svc, settings.Extra); ok {
+		changes = append(changes, change)
+	}
+	if change, ok := applyFlags(svc, settings.Flags); ok {
+		changes = append(changes, change)
+	}
+	switch s := svc.(type) {
+	case *neooracle.Service:
+		if change, ok := applyOracleAllowlist(s, settings.Extra); ok {
+			changes = append(changes, change)
+		}
+	case *neofeeds.Service:
+		if change, ok := applyPublishPolicy(s, settings.Extra); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// oracleURLAllowlist resolves NeoOracle's outbound URL allowlist: the
+// url_allowlist extra setting when present, otherwise ORACLE_HTTP_ALLOWLIST.
+func oracleURLAllowlist(extra map[string]any) ([]string, error) {
+	if raw, ok := extra["url_allowlist"]; ok {
+		return stringList(raw)
+	}
+	return splitAndTrimCSV(strings.TrimSpace(os.Getenv("ORACLE_HTTP_ALLOWLIST"))), nil
+}
+
+// applyOracleAllowlist replaces the oracle's URL allowlist when the resolved
+// allowlist differs from the current one.
+func applyOracleAllowlist(s *neooracle.Service, extra map[string]any) (string, bool) {
+	prefixes, err := oracleURLAllowlist(extra)
+	if err != nil {
+		log.Printf("Warning: config: url_allowlist: %v", err)
+		return "", false
+	}
+	old := s.URLAllowlist().Prefixes
+	if strings.Join(old, ",") == strings.Join(prefixes, ",") {
+		return "", false
+	}
+	if err := s.SetURLAllowlist(neooracle.URLAllowlist{Prefixes: prefixes}); err != nil {
+		log.Printf("Warning: config: url_allowlist: %v", err)
+		return "", false
+	}
+	return fmt.Sprintf("url_allowlist %v -> %v", old, prefixes), true
+}
+
+// applyPublishPolicy merges the publish_policy extra setting over the feed
+// service's current policy (which starts from the feeds config). Omitted
+// fields keep their current value.
+func applyPublishPolicy(s *neofeeds.Service, extra map[string]any) (string, bool) {
+	raw, ok := extra["publish_policy"]
+	if !ok {
+		return "", false
+	}
+	var policy neofeeds.PublishPolicyConfig
+	data, err := yaml.Marshal(raw)
+	if err == nil {
+		err = yaml.Unmarshal(data, &policy)
+	}
+	if err != nil {
+		log.Printf("Warning: config: publish_policy: %v", err)
+		return "", false
+	}
+	old := s.PublishPolicy()
+	s.SetPublishPolicy(policy)
+	updated := s.PublishPolicy()
+	if updated == old {
+		return "", false
+	}
+	return fmt.Sprintf("publish_policy %+v -> %+v", old, updated), true
+}
+
+// applyReadOnly applies the read_only / read_only_reason extra settings to
+// services that support read-only mode. Omitting read_only leaves the current
+// mode (which may have been set via READ_ONLY_MODE) unchanged.
+func applyReadOnly(svc ServiceRunner, extra map[string]any) (string, bool) {
+	toggler, ok := svc.(commonservice.ReadOnlyToggler)
+	if !ok {
+		return "", false
+	}
+	raw, ok := extra["read_only"]
+	if !ok {
+		return "", false
+	}
+	enabled, ok := raw.(bool)
+	if !ok {
+		log.Printf("Warning: config reload: read_only: expected bool, got %T", raw)
+		return "", false
+	}
+	reason, _ := extra["read_only_reason"].(string)
+	if reason == "" && enabled {
+		reason = "enabled via config"
+	}
+
+	wasEnabled, oldReason := toggler.ReadOnly()
+	toggler.SetReadOnly(enabled, reason)
+	if _, newReason := toggler.ReadOnly(); wasEnabled == enabled && oldReason == newReason {
+		return "", false
+	}
+	return fmt.Sprintf("read_only %v -> %v", wasEnabled, enabled), true
+}
+
+// stringList accepts a YAML list of strings or a comma-separated string.
+func stringList(raw any) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		return splitAndTrimCSV(v), nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string entries, got %T", item)
+			}
+			if str = strings.TrimSpace(str); str != "" {
+				out = append(out, str)
+			}
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected list or string, got %T", raw)
+	}
+}
+
+// applyFlags replaces the feature flags of services that evaluate them. An
+// invalid flag set is logged and leaves the current flags in place.
+func applyFlags(svc ServiceRunner, flags map[string]config.FeatureFlag) (string, bool) {
+	setter, ok := svc.(interface {
+		commonservice.FlagSetter
+		Flags() *config.FlagStore
+	})
+	if !ok {
+		return "", false
+	}
+	if flags == nil {
+		flags = map[string]config.FeatureFlag{}
+	}
+
+	store := setter.Flags()
+	if reflect.DeepEqual(store.Flags(), flags) {
+		return "", false
+	}
+	oldNames := store.Names()
+	if err := setter.SetFlags(flags); err != nil {
+		log.Printf("Warning: config reload: flags: %v", err)
+		return "", false
+	}
+	return fmt.Sprintf("flags %v -> %v", oldNames, store.Names()), true
+}
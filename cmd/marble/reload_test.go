@@ -0,0 +1,203 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/config"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+	neooracle "github.com/R3E-Network/service_layer/services/conforacle/marble"
+	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+)
+
+func TestStringList(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     any
+		want    []string
+		wantErr bool
+	}{
+		{"csv", " https://a.example , https://b.example,", []string{"https://a.example", "https://b.example"}, false},
+		{"empty string", "", nil, false},
+		{"list", []any{"https://a.example", " ", " https://b.example "}, []string{"https://a.example", "https://b.example"}, false},
+		{"non-string entry", []any{"https://a.example", 42}, nil, true},
+		{"wrong type", 42, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stringList(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("stringList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("stringList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestFeeds(t *testing.T) *neofeeds.Service {
+	t.Helper()
+	m, _ := marble.New(marble.Config{MarbleType: neofeeds.ServiceID})
+	svc, err := neofeeds.New(neofeeds.Config{Marble: m})
+	if err != nil {
+		t.Fatalf("neofeeds.New() error = %v", err)
+	}
+	return svc
+}
+
+func TestApplyReadOnly(t *testing.T) {
+	svc := newTestFeeds(t)
+
+	if _, changed := applyReadOnly(svc, map[string]any{}); changed {
+		t.Error("omitting read_only should leave the mode unchanged")
+	}
+	if _, changed := applyReadOnly(svc, map[string]any{"read_only": "yes"}); changed {
+		t.Error("a non-bool read_only should be ignored")
+	}
+
+	change, changed := applyReadOnly(svc, map[string]any{"read_only": true})
+	if enabled, reason := svc.ReadOnly(); !changed || !enabled || reason != "enabled via config" {
+		t.Fatalf("applyReadOnly() = %q, %v; mode = %v %q", change, changed, enabled, reason)
+	}
+	if _, changed := applyReadOnly(svc, map[string]any{"read_only": true}); changed {
+		t.Error("re-applying the same mode should report no change")
+	}
+	if _, changed := applyReadOnly(svc, map[string]any{"read_only": true, "read_only_reason": "failover"}); !changed {
+		t.Error("a new reason should be reported")
+	}
+	if _, changed := applyReadOnly(svc, map[string]any{"read_only": false}); !changed {
+		t.Error("leaving read-only mode should be reported")
+	}
+	if enabled, _ := svc.ReadOnly(); enabled {
+		t.Error("service should have left read-only mode")
+	}
+}
+
+func TestApplyFlags(t *testing.T) {
+	svc := newTestFeeds(t)
+
+	flags := map[string]config.FeatureFlag{"new_aggregation": {Enabled: true}}
+	if _, changed := applyFlags(svc, flags); !changed {
+		t.Fatal("new flags should be reported")
+	}
+	if _, changed := applyFlags(svc, flags); changed {
+		t.Error("re-applying the same flags should report no change")
+	}
+	bad := 150
+	if _, changed := applyFlags(svc, map[string]config.FeatureFlag{"x": {Enabled: true, Percentage: &bad}}); changed {
+		t.Error("an invalid flag set should be rejected")
+	}
+	if got := svc.Flags().Flags(); !reflect.DeepEqual(got, flags) {
+		t.Errorf("flags = %v, want the previous set kept", got)
+	}
+	if _, changed := applyFlags(svc, nil); !changed || len(svc.Flags().Flags()) != 0 {
+		t.Error("removing the flags block should clear the flags")
+	}
+}
+
+func TestApplyRuntimeSettingsMergesPublishPolicy(t *testing.T) {
+	svc := newTestFeeds(t)
+
+	settings := &config.ServiceSettings{Extra: map[string]any{
+		"publish_policy": map[string]any{"heartbeat": "1h"},
+	}}
+	if changes := applyRuntimeSettings(svc, settings); len(changes) != 1 {
+		t.Fatalf("changes = %v, want the publish policy", changes)
+	}
+	settings.Extra["publish_policy"] = map[string]any{"threshold_bps": 25}
+	applyRuntimeSettings(svc, settings)
+
+	policy := svc.PublishPolicy()
+	if policy.ThresholdBps != 25 || policy.Heartbeat != time.Hour {
+		t.Errorf("policy = %+v, want threshold 25 with the heartbeat kept", policy)
+	}
+}
+
+func TestOracleAllowlistSameSourceAtStartupAndReload(t *testing.T) {
+	t.Setenv("ORACLE_HTTP_ALLOWLIST", "https://env.example")
+
+	prefixes, err := oracleURLAllowlist(nil)
+	if err != nil || strings.Join(prefixes, ",") != "https://env.example" {
+		t.Fatalf("oracleURLAllowlist(nil) = %v, %v; want the env allowlist", prefixes, err)
+	}
+
+	m, _ := marble.New(marble.Config{MarbleType: neooracle.ServiceID})
+	svc, err := neooracle.New(neooracle.Config{Marble: m, URLAllowlist: neooracle.URLAllowlist{Prefixes: prefixes}})
+	if err != nil {
+		t.Fatalf("neooracle.New() error = %v", err)
+	}
+
+	// No url_allowlist in config: reload keeps the env allowlist it started with.
+	if changes := applyRuntimeSettings(svc, &config.ServiceSettings{}); len(changes) != 0 {
+		t.Errorf("changes = %v, want none without url_allowlist", changes)
+	}
+
+	extra := map[string]any{"url_allowlist": []any{"https://cfg.example"}}
+	if changes := applyRuntimeSettings(svc, &config.ServiceSettings{Extra: extra}); len(changes) != 1 {
+		t.Fatalf("changes = %v, want the allowlist", changes)
+	}
+	if got := svc.URLAllowlist().Prefixes; strings.Join(got, ",") != "https://cfg.example" {
+		t.Errorf("allowlist = %v, want the config override", got)
+	}
+
+	// Dropping the override falls back to the env, as at startup.
+	applyRuntimeSettings(svc, &config.ServiceSettings{})
+	if got := svc.URLAllowlist().Prefixes; strings.Join(got, ",") != "https://env.example" {
+		t.Errorf("allowlist = %v, want the env allowlist again", got)
+	}
+}
+
+func TestReloadRuntimeConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	logs := captureLog(t)
+
+	svc := newTestFeeds(t)
+	current := &config.ServiceSettings{Enabled: true, Port: 8083}
+
+	// A missing file keeps the current settings.
+	if next := reloadRuntimeConfig(neofeeds.ServiceID, svc, current); next != current {
+		t.Fatal("a failed reload should keep the current settings")
+	}
+
+	yaml := `services:
+  neofeeds:
+    enabled: true
+    port: 9083
+    extra:
+      read_only: true
+      read_only_reason: maintenance
+      publish_policy:
+        min_interval: 30s
+`
+	if err := os.WriteFile(filepath.Join(dir, "config", "services.yaml"), []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	next := reloadRuntimeConfig(neofeeds.ServiceID, svc, current)
+	if next == current || next.Port != 9083 {
+		t.Fatalf("reloadRuntimeConfig() = %+v, want the new settings", next)
+	}
+	if enabled, reason := svc.ReadOnly(); !enabled || reason != "maintenance" {
+		t.Errorf("read-only = %v %q, want enabled for maintenance", enabled, reason)
+	}
+	if got := svc.PublishPolicy().MinInterval; got != 30*time.Second {
+		t.Errorf("MinInterval = %v, want 30s", got)
+	}
+	out := logs.String()
+	if !strings.Contains(out, "port 8083 -> 9083 (takes effect on restart)") || !strings.Contains(out, "publish_policy") {
+		t.Errorf("log = %q, want the port and policy changes reported", out)
+	}
+
+	// A service missing from the file keeps the current settings.
+	if again := reloadRuntimeConfig("neooracle", svc, next); again != next {
+		t.Error("a reload without settings for the service should keep the current settings")
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/R3E-Network/service_layer/infrastructure/secrets"
+)
+
+// errSecretNotAllowed is returned when a service resolves a user secret
+// whose allowlist does not include it.
+var errSecretNotAllowed = errors.New("service not allowed to use secret")
+
+// secretAccessChecker reports whether a service may use a user's secret
+// (implemented by the secrets supabase repository).
+type secretAccessChecker interface {
+	IsServiceAllowed(ctx context.Context, userID, secretName, serviceID string) (bool, error)
+}
+
+// allowlistedSecretsProvider enforces the per-secret service allowlist
+// before a service resolves a user secret. A failed lookup denies access.
+type allowlistedSecretsProvider struct {
+	next      secrets.Provider
+	access    secretAccessChecker
+	serviceID string
+}
+
+func (p allowlistedSecretsProvider) GetSecret(ctx context.Context, userID, name string) (string, error) {
+	allowed, err := p.access.IsServiceAllowed(ctx, userID, name, p.serviceID)
+	if err != nil {
+		return "", fmt.Errorf("check %s access to secret %s: %w", p.serviceID, name, err)
+	}
+	if !allowed {
+		return "", fmt.Errorf("%w: %s may not use secret %s", errSecretNotAllowed, p.serviceID, name)
+	}
+	return p.next.GetSecret(ctx, userID, name)
+}
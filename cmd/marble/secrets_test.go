@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeSecretAccess struct {
+	allowed map[string]bool // "user/name/service"
+	err     error
+}
+
+func (f fakeSecretAccess) IsServiceAllowed(_ context.Context, userID, secretName, serviceID string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.allowed[userID+"/"+secretName+"/"+serviceID], nil
+}
+
+type fakeSecretStore struct {
+	reads int
+}
+
+func (f *fakeSecretStore) GetSecret(_ context.Context, userID, name string) (string, error) {
+	f.reads++
+	return "value-of-" + name, nil
+}
+
+func TestAllowlistedSecretsProvider(t *testing.T) {
+	access := fakeSecretAccess{allowed: map[string]bool{"user-1/api_key/neooracle": true}}
+
+	tests := []struct {
+		name      string
+		access    fakeSecretAccess
+		userID    string
+		secret    string
+		wantValue string
+		wantErr   error
+	}{
+		{"allowed", access, "user-1", "api_key", "value-of-api_key", nil},
+		{"not on allowlist", access, "user-1", "db_password", "", errSecretNotAllowed},
+		{"other user's secret", access, "user-2", "api_key", "", errSecretNotAllowed},
+		{"lookup error", fakeSecretAccess{err: errors.New("db down")}, "user-1", "api_key", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeSecretStore{}
+			p := allowlistedSecretsProvider{next: store, access: tt.access, serviceID: "neooracle"}
+
+			got, err := p.GetSecret(context.Background(), tt.userID, tt.secret)
+			if tt.wantValue != "" {
+				if err != nil || got != tt.wantValue {
+					t.Fatalf("GetSecret() = %q, %v; want %q", got, err, tt.wantValue)
+				}
+				return
+			}
+			if err == nil || got != "" {
+				t.Fatalf("GetSecret() = %q, %v; want denial", got, err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSecret() error = %v, want %v", err, tt.wantErr)
+			}
+			if store.reads != 0 {
+				t.Errorf("denied request read the secret store %d times", store.reads)
+			}
+		})
+	}
+}
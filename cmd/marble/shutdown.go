@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	commonservice "github.com/R3E-Network/service_layer/infrastructure/service"
+)
+
+// shutdownGracePeriod bounds how long shutdown waits for in-flight HTTP
+// requests and worker runs before abandoning them.
+const shutdownGracePeriod = 30 * time.Second
+
+// shutdownProgressInterval is how often shutdown reports operations it is
+// still waiting for.
+const shutdownProgressInterval = 5 * time.Second
+
+// shutdown records why the service is stopping, drains the HTTP server,
+// stops the service and waits for in-flight operations until the grace
+// period ends. In-flight work is reported before and during the drain, and
+// operations still running when the grace period ends are logged as
+// abandoned, so operators can tell whether a deploy interrupted work.
+func shutdown(ctx context.Context, server *http.Server, svc ServiceRunner, reason commonservice.ShutdownReason, grace time.Duration) {
+	reporter, tracked := svc.(commonservice.ShutdownReporter)
+	if tracked {
+		reporter.SetShutdownReason(reason)
+		kinds, total := commonservice.InFlightSummary(reporter.InFlight())
+		log.Printf("Shutting down (%s %s): %d operations in flight %v", reason.Code, reason.Detail, total, kinds)
+	} else {
+		log.Printf("Shutting down (%s %s)...", reason.Code, reason.Detail)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, grace)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Shutdown error: %v", err)
+	}
+
+	if err := svc.Stop(); err != nil {
+		log.Printf("Service stop error: %v", err)
+	}
+
+	if tracked {
+		waitInFlight(shutdownCtx, reporter, grace)
+	}
+}
+
+// waitInFlight waits for the service's in-flight operations to finish or ctx
+// to end, reporting progress, and warns about operations left running.
+func waitInFlight(ctx context.Context, reporter commonservice.ShutdownReporter, grace time.Duration) {
+	poll := time.NewTicker(100 * time.Millisecond)
+	defer poll.Stop()
+	lastReport := time.Now()
+
+	for {
+		kinds, total := commonservice.InFlightSummary(reporter.InFlight())
+		if total == 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			log.Printf("Warning: shutdown grace period (%s) elapsed; abandoning %d in-flight operations: %v", grace, total, reporter.InFlight())
+			return
+		case <-poll.C:
+			if time.Since(lastReport) >= shutdownProgressInterval {
+				log.Printf("Shutdown waiting for %d in-flight operations %v", total, kinds)
+				lastReport = time.Now()
+			}
+		}
+	}
+}
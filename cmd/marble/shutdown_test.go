@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	commonservice "github.com/R3E-Network/service_layer/infrastructure/service"
+)
+
+// fakeReporter is a ShutdownReporter with a fixed set of in-flight operations.
+type fakeReporter struct {
+	mu       sync.Mutex
+	inflight map[string]int
+}
+
+func (f *fakeReporter) SetShutdownReason(commonservice.ShutdownReason) {}
+
+func (f *fakeReporter) InFlight() map[string]int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[string]int, len(f.inflight))
+	for k, v := range f.inflight {
+		out[k] = v
+	}
+	return out
+}
+
+func (f *fakeReporter) finish() {
+	f.mu.Lock()
+	f.inflight = nil
+	f.mu.Unlock()
+}
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prevOut, prevFlags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(prevOut)
+		log.SetFlags(prevFlags)
+	})
+	return &buf
+}
+
+func TestWaitInFlightWarnsAboutAbandonedOperations(t *testing.T) {
+	logs := captureLog(t)
+	reporter := &fakeReporter{inflight: map[string]int{"http": 2, "worker:sync": 1}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	waitInFlight(ctx, reporter, 150*time.Millisecond)
+
+	out := logs.String()
+	if !strings.Contains(out, "shutdown grace period (150ms) elapsed; abandoning 3 in-flight operations") {
+		t.Fatalf("log = %q, want the abandon warning", out)
+	}
+	if !strings.Contains(out, "http:2") || !strings.Contains(out, "worker:sync:1") {
+		t.Errorf("log = %q, want the abandoned operations by kind", out)
+	}
+}
+
+func TestWaitInFlightReturnsWhenDrained(t *testing.T) {
+	logs := captureLog(t)
+	reporter := &fakeReporter{inflight: map[string]int{"http": 1}}
+	time.AfterFunc(50*time.Millisecond, reporter.finish)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	waitInFlight(ctx, reporter, 5*time.Second)
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("waitInFlight returned after %s, want soon after operations finished", elapsed)
+	}
+	if strings.Contains(logs.String(), "abandoning") {
+		t.Errorf("log = %q, want no abandon warning", logs.String())
+	}
+}
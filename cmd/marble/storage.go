@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+// postgresOnlyServices lists services whose storage is only implemented
+// over Supabase: they use service-specific supabase repositories or the
+// concrete *database.Repository, which the memory backend does not provide.
+var postgresOnlyServices = map[string]bool{
+	"globalsigner":  true, // key versions and replay records
+	"neoaccounts":   true, // pool accounts
+	"neoflow":       true, // triggers and executions
+	"neorequests":   true, // service requests, chain txs, processed events
+	"neosimulation": true, // simulation tx records via generic create
+}
+
+// checkStorageBackend refuses backends the service cannot run on, so a
+// misconfigured STORAGE_BACKEND fails at startup instead of on first use.
+func checkStorageBackend(serviceType string, store *database.Store) error {
+	if store == nil {
+		return fmt.Errorf("storage backend not opened")
+	}
+	if store.Postgres == nil && postgresOnlyServices[serviceType] {
+		return fmt.Errorf("%s requires the %s storage backend (STORAGE_BACKEND=%s is not supported)",
+			serviceType, database.BackendPostgres, store.Backend)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+	neocompute "github.com/R3E-Network/service_layer/services/confcompute/marble"
+	neooracle "github.com/R3E-Network/service_layer/services/conforacle/marble"
+	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+	neogasbank "github.com/R3E-Network/service_layer/services/gasbank/marble"
+	txproxy "github.com/R3E-Network/service_layer/services/txproxy/marble"
+	neovrf "github.com/R3E-Network/service_layer/services/vrf/marble"
+)
+
+// newMemoryBackendService builds a service the way main does when no chain,
+// TxProxy or secrets are configured, on the given shared repository.
+// TxProxy cannot run without a chain client and signer, so it gets an
+// unconnected client and a local test key.
+func newMemoryBackendService(t *testing.T, serviceType string, db database.RepositoryInterface) ServiceRunner {
+	t.Helper()
+
+	m, err := marble.New(marble.Config{MarbleType: serviceType})
+	if err != nil {
+		t.Fatalf("marble.New: %v", err)
+	}
+
+	var svc ServiceRunner
+	switch serviceType {
+	case "neocompute":
+		svc, err = neocompute.New(neocompute.Config{Marble: m, DB: db})
+	case "neofeeds":
+		svc, err = neofeeds.New(neofeeds.Config{Marble: m, DB: db})
+	case "neogasbank":
+		svc, err = neogasbank.New(neogasbank.Config{Marble: m, DB: db})
+	case "neooracle":
+		svc, err = neooracle.New(neooracle.Config{Marble: m})
+	case "neovrf":
+		svc, err = neovrf.New(neovrf.Config{Marble: m, DB: db})
+	case "txproxy":
+		client, clientErr := chain.NewClient(chain.Config{RPCURL: "http://127.0.0.1:1", NetworkID: 894710606})
+		if clientErr != nil {
+			t.Fatalf("chain.NewClient: %v", clientErr)
+		}
+		signer, signerErr := chain.NewLocalTEESignerFromPrivateKeyHex("0000000000000000000000000000000000000000000000000000000000000001")
+		if signerErr != nil {
+			t.Fatalf("NewLocalTEESignerFromPrivateKeyHex: %v", signerErr)
+		}
+		svc, err = txproxy.New(txproxy.Config{Marble: m, DB: db, ChainClient: client, Signer: signer})
+	default:
+		t.Fatalf("no memory backend constructor for %s", serviceType)
+	}
+	if err != nil {
+		t.Fatalf("New(%s): %v", serviceType, err)
+	}
+	return svc
+}
+
+func TestStartupOnMemoryBackend(t *testing.T) {
+	t.Setenv("MARBLE_ENV", "development")
+
+	for _, serviceType := range availableServices {
+		t.Run(serviceType, func(t *testing.T) {
+			store, err := database.OpenStore(database.StoreConfig{Backend: database.BackendMemory})
+			if err != nil {
+				t.Fatalf("OpenStore: %v", err)
+			}
+
+			err = checkStorageBackend(serviceType, store)
+			if postgresOnlyServices[serviceType] {
+				if err == nil {
+					t.Fatalf("%s accepted the memory backend", serviceType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkStorageBackend: %v", err)
+			}
+
+			ctx := context.Background()
+			svc := newMemoryBackendService(t, serviceType, store.Repository)
+			if err := svc.SelfTest(ctx); err != nil {
+				t.Fatalf("SelfTest: %v", err)
+			}
+			if err := svc.Start(ctx); err != nil {
+				t.Fatalf("Start: %v", err)
+			}
+			if err := svc.Stop(); err != nil {
+				t.Fatalf("Stop: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckStorageBackendAllowsPostgres(t *testing.T) {
+	store, err := database.OpenStore(database.StoreConfig{
+		Postgres: database.Config{URL: "https://example.supabase.co", ServiceKey: "test-key"},
+	})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	for _, serviceType := range availableServices {
+		if err := checkStorageBackend(serviceType, store); err != nil {
+			t.Errorf("checkStorageBackend(%s) = %v", serviceType, err)
+		}
+	}
+}
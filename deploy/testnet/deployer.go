@@ -232,23 +232,45 @@ func (d *Deployer) DeployContract(nefPath, manifestPath string) (*chain.Deployed
 		return nil, fmt.Errorf("simulation failed: %s (exception: %s)", invokeResult.State, invokeResult.Exception)
 	}
 
-	// Calculate expected contract hash
-	nefFile, err := nef.FileFromBytes(nefData)
+	contractHash, err := d.contractHash(nefData, manifestData)
 	if err != nil {
-		return nil, fmt.Errorf("parse nef: %w", err)
-	}
-	var m manifest.Manifest
-	if err := json.Unmarshal(manifestData, &m); err != nil {
-		return nil, fmt.Errorf("parse manifest: %w", err)
+		return nil, err
 	}
-	contractHash := state.CreateContractHash(d.GetAccountHash(), nefFile.Checksum, m.Name)
 
 	return &chain.DeployedContract{
-		Hash:        "0x" + contractHash.StringLE(),
+		Hash:        contractHash,
 		GasConsumed: invokeResult.GasConsumed,
 	}, nil
 }
 
+// ExpectedContractHash returns the hash the contract gets when this deployer
+// deploys it, so a deployment made outside this tool can be looked up.
+func (d *Deployer) ExpectedContractHash(nefPath, manifestPath string) (string, error) {
+	nefData, err := os.ReadFile(nefPath)
+	if err != nil {
+		return "", fmt.Errorf("read nef: %w", err)
+	}
+	manifestData, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return "", fmt.Errorf("read manifest: %w", err)
+	}
+	return d.contractHash(nefData, manifestData)
+}
+
+// contractHash derives the contract hash from the sender, NEF checksum and
+// manifest name, as ContractManagement does.
+func (d *Deployer) contractHash(nefData, manifestData []byte) (string, error) {
+	nefFile, err := nef.FileFromBytes(nefData)
+	if err != nil {
+		return "", fmt.Errorf("parse nef: %w", err)
+	}
+	var m manifest.Manifest
+	if err := json.Unmarshal(manifestData, &m); err != nil {
+		return "", fmt.Errorf("parse manifest: %w", err)
+	}
+	return "0x" + state.CreateContractHash(d.GetAccountHash(), nefFile.Checksum, m.Name).StringLE(), nil
+}
+
 // InvokeFunction invokes a contract function (read-only).
 func (d *Deployer) InvokeFunction(contractHash, method string, args []interface{}) (*chain.InvokeResult, error) {
 	if args == nil {
@@ -178,6 +178,18 @@ func (c *Client) ReleaseAccounts(ctx context.Context, accountIDs []string) (*Rel
 	return &out, nil
 }
 
+// RenewLocks resets the lock TTL for accounts held by this client's service.
+func (c *Client) RenewLocks(ctx context.Context, accountIDs []string) (*RenewLocksResponse, error) {
+	var out RenewLocksResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/renew", RenewLocksInput{
+		ServiceID:  c.serviceID,
+		AccountIDs: accountIDs,
+	}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // UpdateBalance updates an account's balance.
 func (c *Client) UpdateBalance(ctx context.Context, accountID, token string, delta int64, absolute *int64) (*UpdateBalanceResponse, error) {
 	var out UpdateBalanceResponse
@@ -258,6 +270,19 @@ func (c *Client) Transfer(ctx context.Context, accountID, toAddress string, amou
 	return &out, nil
 }
 
+// BatchTransfer transfers tokens from multiple pool accounts in one request.
+// Per-transfer failures are reported in the response results.
+func (c *Client) BatchTransfer(ctx context.Context, transfers []TransferRequest) (*BatchTransferResponse, error) {
+	var out BatchTransferResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/batch-transfer", BatchTransferInput{
+		ServiceID: c.serviceID,
+		Transfers: transfers,
+	}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // TransferWithData transfers GAS from a pool account to an external address with optional data.
 // The data parameter is passed to the OnNEP17Payment callback of the receiving contract.
 // This is used for payments to contracts like PaymentHub that need to identify the payment source.
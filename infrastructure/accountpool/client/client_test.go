@@ -155,6 +155,66 @@ func TestReleaseAccounts(t *testing.T) {
 	}
 }
 
+func TestRenewLocks(t *testing.T) {
+	server := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/renew" {
+			t.Errorf("Path = %s, want /renew", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Method = %s, want POST", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(RenewLocksResponse{RenewedCount: 1})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	resp, err := client.RenewLocks(context.Background(), []string{"acc-1"})
+	if err != nil {
+		t.Fatalf("RenewLocks() error = %v", err)
+	}
+	if resp.RenewedCount != 1 {
+		t.Errorf("RenewedCount = %d, want 1", resp.RenewedCount)
+	}
+}
+
+func TestBatchTransfer(t *testing.T) {
+	server := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/batch-transfer" {
+			t.Errorf("Path = %s, want /batch-transfer", r.URL.Path)
+		}
+		var input BatchTransferInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(input.Transfers) != 2 {
+			t.Errorf("len(Transfers) = %d, want 2", len(input.Transfers))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(BatchTransferResponse{
+			Results: []TransferResult{
+				{AccountID: "acc-1", TxHash: "0xabc"},
+				{AccountID: "acc-2", Error: "account not locked by service test"},
+			},
+			SuccessCount: 1,
+			FailureCount: 1,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	resp, err := client.BatchTransfer(context.Background(), []TransferRequest{
+		{AccountID: "acc-1", ToAddress: "NAddr1", Amount: 100},
+		{AccountID: "acc-2", ToAddress: "NAddr2", Amount: 200},
+	})
+	if err != nil {
+		t.Fatalf("BatchTransfer() error = %v", err)
+	}
+	if resp.SuccessCount != 1 || resp.FailureCount != 1 {
+		t.Errorf("counts = %d/%d, want 1/1", resp.SuccessCount, resp.FailureCount)
+	}
+}
+
 func TestUpdateBalance(t *testing.T) {
 	server := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/balance" {
@@ -20,6 +20,8 @@ type (
 	RequestAccountsResponse = neoaccountstypes.RequestAccountsResponse
 	ReleaseAccountsInput    = neoaccountstypes.ReleaseAccountsInput
 	ReleaseAccountsResponse = neoaccountstypes.ReleaseAccountsResponse
+	RenewLocksInput         = neoaccountstypes.RenewLocksInput
+	RenewLocksResponse      = neoaccountstypes.RenewLocksResponse
 	SignTransactionInput    = neoaccountstypes.SignTransactionInput
 	SignTransactionResponse = neoaccountstypes.SignTransactionResponse
 	BatchSignInput          = neoaccountstypes.BatchSignInput
@@ -31,6 +33,10 @@ type (
 	ListAccountsResponse    = neoaccountstypes.ListAccountsResponse
 	TransferInput           = neoaccountstypes.TransferInput
 	TransferResponse        = neoaccountstypes.TransferResponse
+	TransferRequest         = neoaccountstypes.TransferRequest
+	BatchTransferInput      = neoaccountstypes.BatchTransferInput
+	TransferResult          = neoaccountstypes.TransferResult
+	BatchTransferResponse   = neoaccountstypes.BatchTransferResponse
 	TransferWithDataInput   = neoaccountstypes.TransferWithDataInput
 	TransferWithDataResponse = neoaccountstypes.TransferWithDataResponse
 	MasterKeyAttestation    = neoaccountstypes.MasterKeyAttestation
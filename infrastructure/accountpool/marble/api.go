@@ -16,10 +16,12 @@ func (s *Service) registerRoutes() {
 	router.HandleFunc("/accounts/low-balance", s.handleListLowBalanceAccounts).Methods("GET")
 	router.HandleFunc("/request", s.handleRequestAccounts).Methods("POST")
 	router.HandleFunc("/release", s.handleReleaseAccounts).Methods("POST")
+	router.HandleFunc("/renew", s.handleRenewLocks).Methods("POST")
 	router.HandleFunc("/sign", s.handleSignTransaction).Methods("POST")
 	router.HandleFunc("/batch-sign", s.handleBatchSign).Methods("POST")
 	router.HandleFunc("/balance", s.handleUpdateBalance).Methods("POST")
 	router.HandleFunc("/transfer", s.handleTransfer).Methods("POST")
+	router.HandleFunc("/batch-transfer", s.handleBatchTransfer).Methods("POST")
 	router.HandleFunc("/transfer-with-data", s.handleTransferWithData).Methods("POST")
 
 	// Fund pool accounts from master wallet (TEE_PRIVATE_KEY)
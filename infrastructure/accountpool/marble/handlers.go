@@ -115,6 +115,33 @@ func (s *Service) handleReleaseAccounts(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// handleRenewLocks resets the lock TTL for accounts held by the caller.
+func (s *Service) handleRenewLocks(w http.ResponseWriter, r *http.Request) {
+	var input RenewLocksInput
+	if !httputil.DecodeJSON(w, r, &input) {
+		return
+	}
+
+	serviceID, ok := resolveServiceID(w, r, input.ServiceID)
+	if !ok {
+		return
+	}
+	if len(input.AccountIDs) == 0 {
+		httputil.BadRequest(w, "account_ids required")
+		return
+	}
+
+	renewed, err := s.RenewLocks(r.Context(), serviceID, input.AccountIDs)
+	if err != nil {
+		httputil.InternalError(w, err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, RenewLocksResponse{
+		RenewedCount: renewed,
+	})
+}
+
 // handleSignTransaction signs a transaction hash with an account's private key.
 func (s *Service) handleSignTransaction(w http.ResponseWriter, r *http.Request) {
 	var input SignTransactionInput
@@ -237,6 +264,45 @@ func (s *Service) handleTransfer(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleBatchTransfer executes multiple transfers from pool accounts.
+// Individual failures are reported per transfer and do not fail the request.
+func (s *Service) handleBatchTransfer(w http.ResponseWriter, r *http.Request) {
+	var input BatchTransferInput
+	if !httputil.DecodeJSON(w, r, &input) {
+		return
+	}
+
+	serviceID, ok := resolveServiceID(w, r, input.ServiceID)
+	if !ok {
+		return
+	}
+	input.ServiceID = serviceID
+
+	if len(input.Transfers) == 0 || len(input.Transfers) > 100 {
+		httputil.BadRequest(w, "transfers must contain 1-100 entries")
+		return
+	}
+	for _, t := range input.Transfers {
+		if t.AccountID == "" || t.ToAddress == "" || t.Amount <= 0 {
+			httputil.BadRequest(w, "each transfer requires account_id, to_address, and positive amount")
+			return
+		}
+	}
+
+	results, errs := s.BatchTransfer(r.Context(), input.ServiceID, input.Transfers)
+
+	resp := BatchTransferResponse{Results: results}
+	for _, err := range errs {
+		if err != nil {
+			resp.FailureCount++
+		} else {
+			resp.SuccessCount++
+		}
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
 // handleTransferWithData transfers GAS from a pool account to a target address with optional data.
 // The data parameter is passed to the OnNEP17Payment callback of the receiving contract.
 // This is used for payments to contracts like PaymentHub that need to identify the payment source.
@@ -398,32 +398,97 @@ func (s *Service) rotateAccounts(ctx context.Context) {
 	}
 }
 
-// cleanupStaleLocks releases accounts that have been locked too long.
-func (s *Service) cleanupStaleLocks(ctx context.Context) {
+// RenewLocks resets the lock TTL for accounts held by serviceID.
+// Accounts not locked by serviceID are skipped.
+func (s *Service) RenewLocks(ctx context.Context, serviceID string, accountIDs []string) (int, error) {
 	if s.repo == nil {
-		return
+		return 0, fmt.Errorf("repository not configured")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	renewed := 0
+	now := time.Now()
+	for _, accID := range accountIDs {
+		acc, err := s.repo.GetByID(ctx, accID)
+		if err != nil {
+			continue
+		}
+
+		// Only renew if locked by this service
+		if acc.LockedBy != serviceID {
+			continue
+		}
+
+		acc.LockedAt = now
+		if err := s.repo.Update(ctx, acc); err != nil {
+			s.Logger().WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
+				"account_id": accID,
+				"service_id": serviceID,
+			}).Warn("failed to renew account lock")
+			continue
+		}
+		renewed++
+	}
+
+	return renewed, nil
+}
+
+// ReleaseExpiredLocks releases accounts whose lock is older than the lock TTL
+// as of now, so a crashed consumer cannot strand pool accounts. It returns the
+// IDs of the accounts that were freed.
+func (s *Service) ReleaseExpiredLocks(ctx context.Context, now time.Time) ([]string, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("repository not configured")
 	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	accounts, err := s.repo.List(ctx)
 	if err != nil {
-		return
+		return nil, fmt.Errorf("list accounts: %w", err)
 	}
 
-	now := time.Now()
+	var released []string
 	for i := range accounts {
 		acc := &accounts[i]
-		if acc.LockedBy != "" && !acc.LockedAt.IsZero() {
-			if now.Sub(acc.LockedAt) > LockTimeout {
-				// Force release stale lock
-				acc.LockedBy = ""
-				acc.LockedAt = time.Time{}
-				if err := s.repo.Update(ctx, acc); err != nil {
-					s.Logger().WithContext(ctx).WithError(err).WithField("account_id", acc.ID).Warn("failed to release stale lock")
-				}
-			}
+		if acc.LockedBy == "" || acc.LockedAt.IsZero() {
+			continue
+		}
+		if now.Sub(acc.LockedAt) <= s.lockTTL {
+			continue
+		}
+
+		// Force release expired lock
+		lockedBy := acc.LockedBy
+		acc.LockedBy = ""
+		acc.LockedAt = time.Time{}
+		if err := s.repo.Update(ctx, acc); err != nil {
+			s.Logger().WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
+				"account_id": acc.ID,
+				"service_id": lockedBy,
+			}).Warn("failed to release expired lock")
+			continue
 		}
+		released = append(released, acc.ID)
+	}
+
+	return released, nil
+}
+
+// cleanupStaleLocks releases accounts that have been locked too long.
+func (s *Service) cleanupStaleLocks(ctx context.Context) {
+	if s.repo == nil {
+		return
+	}
+
+	released, err := s.ReleaseExpiredLocks(ctx, time.Now())
+	if err != nil {
+		s.Logger().WithContext(ctx).WithError(err).Warn("failed to release expired locks")
+		return
+	}
+	if len(released) > 0 {
+		s.Logger().WithContext(ctx).WithField("count", len(released)).Info("released expired account locks")
 	}
 }
 
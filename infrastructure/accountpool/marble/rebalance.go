@@ -0,0 +1,97 @@
+// Package neoaccounts provides pool rebalancing for the neoaccounts service.
+package neoaccounts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// RebalanceDustThreshold is the smallest GAS amount (8 decimals) worth moving.
+// Accounts holding less than this are left out of rebalancing, and surpluses or
+// deficits smaller than this are ignored.
+const RebalanceDustThreshold = int64(100000) // 0.001 GAS
+
+// TransferPlan is a single GAS transfer proposed by RebalancePool.
+type TransferPlan struct {
+	FromAccountID string `json:"from_account_id"`
+	ToAccountID   string `json:"to_account_id"`
+	TokenType     string `json:"token_type"`
+	Amount        int64  `json:"amount"`
+}
+
+type balanceGap struct {
+	accountID string
+	amount    int64
+}
+
+// RebalancePool computes GAS transfers that move each account's balance toward
+// target. Accounts are matched largest surplus to largest deficit, which keeps
+// the number of transfers low. Retiring accounts and accounts below
+// RebalanceDustThreshold are skipped. Nothing is executed; callers submit the
+// plan with Transfer after locking the source accounts.
+func (s *Service) RebalancePool(ctx context.Context, accounts []AccountInfo, target int64) ([]TransferPlan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if target < 0 {
+		return nil, fmt.Errorf("target must be non-negative")
+	}
+
+	var surpluses, deficits []balanceGap
+	for i := range accounts {
+		acc := &accounts[i]
+		if acc.IsRetiring {
+			continue
+		}
+		balance := acc.Balances[TokenTypeGAS].Amount
+		if balance < RebalanceDustThreshold {
+			continue
+		}
+
+		switch diff := balance - target; {
+		case diff >= RebalanceDustThreshold:
+			surpluses = append(surpluses, balanceGap{accountID: acc.ID, amount: diff})
+		case -diff >= RebalanceDustThreshold:
+			deficits = append(deficits, balanceGap{accountID: acc.ID, amount: -diff})
+		}
+	}
+
+	sortGaps(surpluses)
+	sortGaps(deficits)
+
+	var plans []TransferPlan
+	i, j := 0, 0
+	for i < len(surpluses) && j < len(deficits) {
+		from, to := &surpluses[i], &deficits[j]
+		amount := min(from.amount, to.amount)
+
+		plans = append(plans, TransferPlan{
+			FromAccountID: from.accountID,
+			ToAccountID:   to.accountID,
+			TokenType:     TokenTypeGAS,
+			Amount:        amount,
+		})
+
+		from.amount -= amount
+		to.amount -= amount
+		if from.amount < RebalanceDustThreshold {
+			i++
+		}
+		if to.amount < RebalanceDustThreshold {
+			j++
+		}
+	}
+
+	return plans, nil
+}
+
+// sortGaps orders gaps by amount descending, then by account ID for stable plans.
+func sortGaps(gaps []balanceGap) {
+	sort.Slice(gaps, func(a, b int) bool {
+		if gaps[a].amount != gaps[b].amount {
+			return gaps[a].amount > gaps[b].amount
+		}
+		return gaps[a].accountID < gaps[b].accountID
+	})
+}
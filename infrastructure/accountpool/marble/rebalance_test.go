@@ -0,0 +1,69 @@
+package neoaccounts
+
+import (
+	"context"
+	"testing"
+)
+
+func gasAccount(id string, amount int64) AccountInfo {
+	return AccountInfo{
+		ID:       id,
+		Balances: map[string]TokenBalance{TokenTypeGAS: {TokenType: TokenTypeGAS, Amount: amount}},
+	}
+}
+
+func TestRebalancePoolEqualizes(t *testing.T) {
+	svc, _ := newTestServiceWithMock(t)
+
+	accounts := []AccountInfo{
+		gasAccount("rich", 10_000_000),
+		gasAccount("a", 1_000_000),
+		gasAccount("b", 1_000_000),
+		gasAccount("dust", 10), // below dust threshold, skipped
+	}
+
+	plans, err := svc.RebalancePool(context.Background(), accounts, 4_000_000)
+	if err != nil {
+		t.Fatalf("RebalancePool() error = %v", err)
+	}
+	if len(plans) != 2 {
+		t.Fatalf("len(plans) = %d, want 2: %+v", len(plans), plans)
+	}
+
+	received := map[string]int64{}
+	for _, p := range plans {
+		if p.FromAccountID != "rich" {
+			t.Errorf("plan from %s, want rich", p.FromAccountID)
+		}
+		if p.ToAccountID == "dust" {
+			t.Error("dust account should be skipped")
+		}
+		received[p.ToAccountID] += p.Amount
+	}
+	if received["a"] != 3_000_000 || received["b"] != 3_000_000 {
+		t.Errorf("received = %v, want 3000000 each for a and b", received)
+	}
+}
+
+func TestRebalancePoolSkipsRetiringAndBalanced(t *testing.T) {
+	svc, _ := newTestServiceWithMock(t)
+
+	retiring := gasAccount("retiring", 50_000_000)
+	retiring.IsRetiring = true
+	accounts := []AccountInfo{retiring, gasAccount("ok", 4_000_000)}
+
+	plans, err := svc.RebalancePool(context.Background(), accounts, 4_000_000)
+	if err != nil {
+		t.Fatalf("RebalancePool() error = %v", err)
+	}
+	if len(plans) != 0 {
+		t.Errorf("plans = %+v, want none", plans)
+	}
+}
+
+func TestRebalancePoolNegativeTarget(t *testing.T) {
+	svc, _ := newTestServiceWithMock(t)
+	if _, err := svc.RebalancePool(context.Background(), nil, -1); err == nil {
+		t.Error("RebalancePool() expected error for negative target")
+	}
+}
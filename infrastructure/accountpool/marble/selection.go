@@ -0,0 +1,50 @@
+// Package neoaccounts provides balance-aware account selection for the neoaccounts service.
+package neoaccounts
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SelectAccountsForAmount returns the IDs of the fewest accounts whose
+// combined tokenType balance covers amount. Accounts are taken largest
+// balance first, with the least recently used winning ties so load spreads
+// across the pool. Retiring accounts and accounts without a positive balance
+// are ignored; callers are expected to pass accounts they may lock.
+func SelectAccountsForAmount(accounts []AccountInfo, tokenType string, amount int64) ([]string, error) {
+	if tokenType == "" {
+		return nil, fmt.Errorf("token type required")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	candidates := make([]AccountInfo, 0, len(accounts))
+	for i := range accounts {
+		if accounts[i].IsRetiring || accounts[i].Balances[tokenType].Amount <= 0 {
+			continue
+		}
+		candidates = append(candidates, accounts[i])
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		bi := candidates[i].Balances[tokenType].Amount
+		bj := candidates[j].Balances[tokenType].Amount
+		if bi != bj {
+			return bi > bj
+		}
+		return candidates[i].LastUsedAt.Before(candidates[j].LastUsedAt)
+	})
+
+	var (
+		ids   []string
+		total int64
+	)
+	for i := range candidates {
+		ids = append(ids, candidates[i].ID)
+		total += candidates[i].Balances[tokenType].Amount
+		if total >= amount {
+			return ids, nil
+		}
+	}
+	return nil, fmt.Errorf("insufficient %s balance: pool has %d, need %d", tokenType, total, amount)
+}
@@ -0,0 +1,56 @@
+package neoaccounts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSelectAccountsForAmount(t *testing.T) {
+	now := time.Now()
+	older := gasAccount("older", 3_000_000)
+	older.LastUsedAt = now.Add(-time.Hour)
+	newer := gasAccount("newer", 3_000_000)
+	newer.LastUsedAt = now
+	retiring := gasAccount("retiring", 50_000_000)
+	retiring.IsRetiring = true
+
+	accounts := []AccountInfo{
+		gasAccount("small", 1_000_000),
+		newer,
+		older,
+		retiring,
+		gasAccount("big", 5_000_000),
+	}
+
+	tests := []struct {
+		name   string
+		amount int64
+		want   []string
+	}{
+		{"single account covers", 4_000_000, []string{"big"}},
+		{"least recently used wins ties", 8_000_000, []string{"big", "older"}},
+		{"all accounts", 12_000_000, []string{"big", "older", "newer", "small"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectAccountsForAmount(accounts, TokenTypeGAS, tt.amount)
+			if err != nil {
+				t.Fatalf("SelectAccountsForAmount() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectAccountsForAmount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SelectAccountsForAmount(accounts, TokenTypeGAS, 12_000_001); err == nil {
+		t.Error("SelectAccountsForAmount() should fail on insufficient balance")
+	}
+	if _, err := SelectAccountsForAmount(accounts, TokenTypeNEO, 1); err == nil {
+		t.Error("SelectAccountsForAmount() should fail for a token with no balance")
+	}
+	if _, err := SelectAccountsForAmount(accounts, TokenTypeGAS, 0); err == nil {
+		t.Error("SelectAccountsForAmount() should reject non-positive amounts")
+	}
+}
@@ -41,7 +41,7 @@ const (
 	RotationRate    = 0.1  // 10% of accounts rotated per day
 	RotationMinAge  = 24   // Minimum age in hours before rotation
 
-	// Lock timeout - accounts locked longer than this can be force-released
+	// Lock timeout - default TTL after which a lock that was not renewed can be force-released
 	LockTimeout = 24 * time.Hour
 )
 
@@ -62,6 +62,9 @@ type Service struct {
 
 	// Chain interaction (for signing)
 	chainClient *chain.Client
+
+	// Lock TTL - locks not renewed within this window are auto-released
+	lockTTL time.Duration
 }
 
 // Config holds NeoAccounts service configuration.
@@ -70,6 +73,8 @@ type Config struct {
 	DB              database.RepositoryInterface
 	NeoAccountsRepo neoaccountssupabase.RepositoryInterface
 	ChainClient     *chain.Client
+	// LockTTL overrides LockTimeout when > 0.
+	LockTTL time.Duration
 }
 
 // New creates a new NeoAccounts service.
@@ -92,6 +97,10 @@ func New(cfg Config) (*Service, error) {
 		BaseService: base,
 		repo:        cfg.NeoAccountsRepo,
 		chainClient: cfg.ChainClient,
+		lockTTL:     LockTimeout,
+	}
+	if cfg.LockTTL > 0 {
+		s.lockTTL = cfg.LockTTL
 	}
 
 	// Load and validate master key material.
@@ -133,11 +142,24 @@ func New(cfg Config) (*Service, error) {
 		return nil
 	}, commonservice.WithTickerWorkerName("lock-cleanup"))
 
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 	return s, nil
 }
 
+// selfTest requires the pool repository, and the chain client that
+// transfers and contract invocations are signed and sent through.
+func (s *Service) selfTest(ctx context.Context) error {
+	if s.repo == nil {
+		return fmt.Errorf("neoaccounts: pool repository is required")
+	}
+	return s.CheckDependencies(ctx, commonservice.Dependency{
+		Name:    "chain client (NEO_RPC_URL)",
+		Missing: s.chainClient == nil,
+	})
+}
+
 func allowEphemeralMasterKey() bool {
 	raw := strings.TrimSpace(os.Getenv("NEOACCOUNTS_ALLOW_EPHEMERAL_MASTER_KEY"))
 	switch strings.ToLower(raw) {
@@ -2016,6 +2016,159 @@ func TestCleanupStaleLocks(t *testing.T) {
 	}
 }
 
+func TestReleaseExpiredLocks(t *testing.T) {
+	svc, mockRepo := newTestServiceWithMock(t)
+	svc.lockTTL = time.Hour
+
+	now := time.Now()
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:       "acc-expired",
+		Address:  "NAddr1",
+		LockedBy: "crashed-service",
+		LockedAt: now.Add(-2 * time.Hour),
+	})
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:       "acc-held",
+		Address:  "NAddr2",
+		LockedBy: "active-service",
+		LockedAt: now.Add(-30 * time.Minute),
+	})
+
+	released, err := svc.ReleaseExpiredLocks(context.Background(), now)
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLocks() error = %v", err)
+	}
+	if len(released) != 1 || released[0] != "acc-expired" {
+		t.Errorf("released = %v, want [acc-expired]", released)
+	}
+
+	held, _ := mockRepo.GetByID(context.Background(), "acc-held")
+	if held == nil || held.LockedBy != "active-service" {
+		t.Error("Lock within TTL should not be released")
+	}
+}
+
+func TestRenewLocksResetsTTL(t *testing.T) {
+	svc, mockRepo := newTestServiceWithMock(t)
+	svc.lockTTL = time.Hour
+
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:       "acc-renewed",
+		Address:  "NAddr1",
+		LockedBy: "svc-a",
+		LockedAt: time.Now().Add(-50 * time.Minute),
+	})
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:       "acc-other",
+		Address:  "NAddr2",
+		LockedBy: "svc-b",
+		LockedAt: time.Now().Add(-50 * time.Minute),
+	})
+
+	renewed, err := svc.RenewLocks(context.Background(), "svc-a", []string{"acc-renewed", "acc-other"})
+	if err != nil {
+		t.Fatalf("RenewLocks() error = %v", err)
+	}
+	if renewed != 1 {
+		t.Errorf("renewed = %d, want 1", renewed)
+	}
+
+	// 20 minutes later the renewed lock is still within its TTL; the other is not.
+	released, err := svc.ReleaseExpiredLocks(context.Background(), time.Now().Add(20*time.Minute))
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLocks() error = %v", err)
+	}
+	if len(released) != 1 || released[0] != "acc-other" {
+		t.Errorf("released = %v, want [acc-other]", released)
+	}
+}
+
+func TestBatchTransferUnlockedAccountFailsAlone(t *testing.T) {
+	svc, mockRepo := newTestServiceWithMock(t)
+
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:       "acc-locked",
+		Address:  "NAddr1",
+		LockedBy: "svc-a",
+		LockedAt: time.Now(),
+	})
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:      "acc-unlocked",
+		Address: "NAddr2",
+	})
+
+	results, errs := svc.BatchTransfer(context.Background(), "svc-a", []TransferRequest{
+		{AccountID: "acc-unlocked", ToAddress: "NTarget", Amount: 100},
+		{AccountID: "acc-locked", ToAddress: "NTarget", Amount: 100},
+	})
+	if len(results) != 2 || len(errs) != 2 {
+		t.Fatalf("len(results)=%d len(errs)=%d, want 2/2", len(results), len(errs))
+	}
+
+	if errs[0] == nil || !strings.Contains(errs[0].Error(), "not locked") {
+		t.Errorf("errs[0] = %v, want lock error", errs[0])
+	}
+	if results[0].Error == "" {
+		t.Error("results[0].Error should be set")
+	}
+
+	// The locked transfer is still attempted; without a chain client it fails
+	// on execution rather than on lock verification.
+	if errs[1] == nil || strings.Contains(errs[1].Error(), "not locked") {
+		t.Errorf("errs[1] = %v, want execution error", errs[1])
+	}
+}
+
+func TestTransferRejectsNonGASToken(t *testing.T) {
+	svc, mockRepo := newTestServiceWithMock(t)
+	mockRepo.Create(context.Background(), &neoaccountssupabase.Account{
+		ID:       "acc-1",
+		Address:  "NAddr1",
+		LockedBy: "svc-a",
+		LockedAt: time.Now(),
+	})
+
+	tests := []struct {
+		name      string
+		tokenHash string
+		wantErr   string
+	}{
+		// GAS selections pass the token check and fail later, without a
+		// chain client.
+		{"default", "", "chain client not configured"},
+		{"symbol", "gas", "chain client not configured"},
+		{"GAS hash", "0xd2a4cff31913016155e38e474a2c06d08be276cf", "chain client not configured"},
+		{"GAS hash upper case", "0xD2A4CFF31913016155E38E474A2C06D08BE276CF", "chain client not configured"},
+
+		{"NEO hash", "0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5", "only GAS transfers are supported"},
+		{"NEP-17 token", "0x1234567890abcdef1234567890abcdef12345678", "only GAS transfers are supported"},
+		{"malformed", "not-a-hash", "invalid token_hash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Transfer(context.Background(), "svc-a", "acc-1", "NTarget", 100, tt.tokenHash)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Transfer(token %q) error = %v, want %q", tt.tokenHash, err, tt.wantErr)
+			}
+		})
+	}
+
+	_, errs := svc.BatchTransfer(context.Background(), "svc-a", []TransferRequest{
+		{AccountID: "acc-1", ToAddress: "NTarget", Amount: 100, TokenHash: "0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5"},
+		{AccountID: "acc-1", ToAddress: "NTarget", Amount: 100},
+	})
+	if errs[0] == nil || !strings.Contains(errs[0].Error(), "only GAS") {
+		t.Errorf("BatchTransfer NEO item error = %v, want rejection", errs[0])
+	}
+	if errs[1] == nil || strings.Contains(errs[1].Error(), "token_hash") {
+		t.Errorf("BatchTransfer GAS item error = %v, want execution error", errs[1])
+	}
+
+	if _, err := svc.FundAccount(context.Background(), "NTarget", 100, "0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5"); err == nil || !strings.Contains(err.Error(), "only GAS") {
+		t.Errorf("FundAccount(NEO) error = %v, want rejection", err)
+	}
+}
+
 func TestRotateAccountsDeletesEmptyRetiringAccounts(t *testing.T) {
 	svc, mockRepo := newTestServiceWithMock(t)
 
@@ -17,6 +17,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 
@@ -110,15 +111,36 @@ func verifySignature(pub *ecdsa.PublicKey, hash, signature []byte) bool {
 	return ecdsa.Verify(pub, hash, r, s)
 }
 
-// Transfer transfers tokens from a pool account to a target address.
-// The account must be locked by the requesting service.
+// requireGASToken rejects a token_hash other than GAS. Pool transfers only
+// move GAS; an empty token_hash, "GAS" or the GAS contract hash select it.
+func requireGASToken(tokenHash string) error {
+	tokenHash = strings.TrimSpace(tokenHash)
+	if tokenHash == "" || strings.EqualFold(tokenHash, "GAS") {
+		return nil
+	}
+	h, err := chain.ParseScriptHash(strings.ToLower(tokenHash))
+	if err != nil {
+		return fmt.Errorf("invalid token_hash %q: %w", tokenHash, err)
+	}
+	if !h.Equals(gas.Hash) {
+		return fmt.Errorf("unsupported token_hash %s: only GAS transfers are supported", tokenHash)
+	}
+	return nil
+}
+
+// Transfer transfers GAS from a pool account to a target address.
+// The account must be locked by the requesting service. A tokenHash other
+// than GAS is rejected rather than silently sending GAS.
 //
-// The transfer is executed as an on-chain NEP-17 `transfer(from,to,amount,data)` invocation
+// The transfer is executed as an on-chain GAS `transfer(from,to,amount,data)` invocation
 // signed by the pool account's derived private key.
 func (s *Service) Transfer(ctx context.Context, serviceID, accountID, toAddress string, amount int64, tokenHash string) (string, error) {
 	if s.repo == nil {
 		return "", fmt.Errorf("repository not configured")
 	}
+	if err := requireGASToken(tokenHash); err != nil {
+		return "", err
+	}
 	if s.chainClient == nil {
 		return "", fmt.Errorf("chain client not configured")
 	}
@@ -132,7 +154,6 @@ func (s *Service) Transfer(ctx context.Context, serviceID, accountID, toAddress
 		return "", fmt.Errorf("amount must be positive")
 	}
 
-	tokenHash = strings.TrimSpace(tokenHash)
 	s.mu.RLock()
 	acc, err := s.repo.GetByID(ctx, accountID)
 	if err != nil {
@@ -196,6 +217,69 @@ func (s *Service) Transfer(ctx context.Context, serviceID, accountID, toAddress
 	return txHashString, nil
 }
 
+// BatchTransfer executes multiple transfers for a service. Every source account
+// is checked up front; a transfer whose account is not locked by serviceID fails
+// on its own without aborting the others. Results and errors are returned in
+// request order, with a nil error for each successful transfer.
+func (s *Service) BatchTransfer(ctx context.Context, serviceID string, transfers []TransferRequest) ([]TransferResult, []error) {
+	results := make([]TransferResult, len(transfers))
+	errs := make([]error, len(transfers))
+
+	for i, t := range transfers {
+		results[i] = TransferResult{
+			AccountID: t.AccountID,
+			ToAddress: t.ToAddress,
+			Amount:    t.Amount,
+		}
+	}
+
+	if s.repo == nil {
+		for i := range errs {
+			errs[i] = fmt.Errorf("repository not configured")
+		}
+		return results, errs
+	}
+
+	// Verify lock ownership for the whole batch before sending anything.
+	s.mu.RLock()
+	for i, t := range transfers {
+		acc, err := s.repo.GetByID(ctx, t.AccountID)
+		if err != nil {
+			errs[i] = fmt.Errorf("account not found: %w", err)
+			continue
+		}
+		if acc.LockedBy != serviceID {
+			errs[i] = fmt.Errorf("account not locked by service %s", serviceID)
+		}
+	}
+	s.mu.RUnlock()
+
+	for i, t := range transfers {
+		if errs[i] != nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		txHash, err := s.Transfer(ctx, serviceID, t.AccountID, t.ToAddress, t.Amount, t.TokenHash)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		results[i].TxHash = txHash
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			results[i].Error = err.Error()
+		}
+	}
+
+	return results, errs
+}
+
 // TransferWithData transfers GAS from a pool account to a target address with optional data.
 // The data parameter is passed to the OnNEP17Payment callback of the receiving contract.
 // This is used for payments to contracts like PaymentHub that need to identify the payment source.
@@ -838,6 +922,9 @@ func convertToChainParam(p ContractParam) chain.ContractParam {
 // Unlike Transfer(), this uses the master wallet directly, not a pool account.
 // After successful transfer, updates the database balance for the target account.
 func (s *Service) FundAccount(ctx context.Context, toAddress string, amount int64, tokenHash string) (*FundAccountResponse, error) {
+	if err := requireGASToken(tokenHash); err != nil {
+		return nil, err
+	}
 	if s.chainClient == nil {
 		return nil, fmt.Errorf("chain client not configured")
 	}
@@ -33,6 +33,12 @@ type ReleaseAccountsInput = neoaccountstypes.ReleaseAccountsInput
 // ReleaseAccountsResponse confirms release.
 type ReleaseAccountsResponse = neoaccountstypes.ReleaseAccountsResponse
 
+// RenewLocksInput for renewing locks on held accounts.
+type RenewLocksInput = neoaccountstypes.RenewLocksInput
+
+// RenewLocksResponse confirms lock renewal.
+type RenewLocksResponse = neoaccountstypes.RenewLocksResponse
+
 // SignTransactionInput for signing a transaction with an account's private key.
 type SignTransactionInput = neoaccountstypes.SignTransactionInput
 
@@ -73,6 +79,18 @@ type TransferInput = neoaccountstypes.TransferInput
 // TransferResponse returns the transfer result.
 type TransferResponse = neoaccountstypes.TransferResponse
 
+// TransferRequest is a single transfer within a batch.
+type TransferRequest = neoaccountstypes.TransferRequest
+
+// BatchTransferInput for transferring from multiple pool accounts.
+type BatchTransferInput = neoaccountstypes.BatchTransferInput
+
+// TransferResult is the outcome of a single batched transfer.
+type TransferResult = neoaccountstypes.TransferResult
+
+// BatchTransferResponse returns per-transfer results.
+type BatchTransferResponse = neoaccountstypes.BatchTransferResponse
+
 // TransferWithDataInput for transferring GAS with data to a contract.
 type TransferWithDataInput = neoaccountstypes.TransferWithDataInput
 
@@ -71,6 +71,17 @@ type ReleaseAccountsResponse struct {
 	ReleasedCount int `json:"released_count"`
 }
 
+// RenewLocksInput resets the lock TTL for accounts held by a service.
+type RenewLocksInput struct {
+	ServiceID  string   `json:"service_id"`
+	AccountIDs []string `json:"account_ids"`
+}
+
+// RenewLocksResponse confirms lock renewal.
+type RenewLocksResponse struct {
+	RenewedCount int `json:"renewed_count"`
+}
+
 // SignTransactionInput signs a transaction with an account's private key.
 type SignTransactionInput struct {
 	ServiceID string `json:"service_id"`
@@ -141,7 +152,7 @@ type TransferInput struct {
 	AccountID string `json:"account_id"`
 	ToAddress string `json:"to_address"`
 	Amount    int64  `json:"amount"`
-	TokenHash string `json:"token_hash,omitempty"` // NEP-17 script hash (defaults to GAS)
+	TokenHash string `json:"token_hash,omitempty"` // must be GAS (default); other tokens are rejected
 }
 
 // TransferResponse returns the transfer result.
@@ -152,6 +163,36 @@ type TransferResponse struct {
 	Amount    int64  `json:"amount"`
 }
 
+// TransferRequest is a single transfer within a batch.
+type TransferRequest struct {
+	AccountID string `json:"account_id"`
+	ToAddress string `json:"to_address"`
+	Amount    int64  `json:"amount"`
+	TokenHash string `json:"token_hash,omitempty"` // must be GAS (default); other tokens are rejected
+}
+
+// BatchTransferInput transfers tokens from multiple pool accounts.
+type BatchTransferInput struct {
+	ServiceID string            `json:"service_id"`
+	Transfers []TransferRequest `json:"transfers"`
+}
+
+// TransferResult is the outcome of a single transfer within a batch.
+type TransferResult struct {
+	AccountID string `json:"account_id"`
+	ToAddress string `json:"to_address"`
+	Amount    int64  `json:"amount"`
+	TxHash    string `json:"tx_hash,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BatchTransferResponse returns per-transfer results in request order.
+type BatchTransferResponse struct {
+	Results      []TransferResult `json:"results"`
+	SuccessCount int              `json:"success_count"`
+	FailureCount int              `json:"failure_count"`
+}
+
 // TransferWithDataInput transfers GAS from a pool account with optional data.
 // The data parameter is passed to the OnNEP17Payment callback of the receiving contract.
 // This is used for payments to contracts like PaymentHub that need to identify the payment source.
@@ -272,7 +313,7 @@ type SimulateContractResponse struct {
 type FundAccountInput struct {
 	ToAddress string `json:"to_address"`          // Pool account address to fund
 	Amount    int64  `json:"amount"`              // Amount in smallest units (8 decimals for GAS)
-	TokenHash string `json:"token_hash,omitempty"` // NEP-17 script hash (defaults to GAS)
+	TokenHash string `json:"token_hash,omitempty"` // must be GAS (default); other tokens are rejected
 }
 
 // FundAccountResponse returns the funding result.
@@ -0,0 +1,199 @@
+package chain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// =============================================================================
+// ABI Argument Encoding
+// =============================================================================
+
+// ContractMethod is a contract method signature as declared in the contract
+// manifest ABI ("abi.methods[]").
+type ContractMethod struct {
+	Name       string              `json:"name"`
+	Parameters []ContractMethodArg `json:"parameters"`
+}
+
+// ContractMethodArg is a declared method parameter. Type is a Neo N3
+// parameter type name (e.g. "Hash160", "Integer").
+type ContractMethodArg struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ValidateInvocationArgs checks that args supplies every declared parameter
+// of method with a value compatible with its type, and nothing else.
+func ValidateInvocationArgs(method ContractMethod, args map[string]any) error {
+	_, err := EncodeArgs(method, args)
+	return err
+}
+
+// EncodeArgs converts named args into the ordered parameter list for
+// invoking method (see Client.InvokeFunction). Accepted values per type:
+//
+//   - Hash160: util.Uint160, or a string holding a Neo address or a script
+//     hash (hex, optional 0x prefix, little-endian as in RPC)
+//   - ByteArray: []byte, or a hex string (optional 0x prefix)
+//   - Integer: Go integer types, *big.Int, json.Number, a base-10 string, or
+//     an integral float64 within ±2^53 (as produced by encoding/json)
+//   - Boolean: bool
+//   - String: string
+//
+// Missing, unknown or incompatible arguments are rejected.
+func EncodeArgs(method ContractMethod, args map[string]any) ([]ContractParam, error) {
+	declared := make(map[string]bool, len(method.Parameters))
+	for _, p := range method.Parameters {
+		declared[p.Name] = true
+	}
+	for name := range args {
+		if !declared[name] {
+			return nil, fmt.Errorf("%s: unknown argument %q", method.Name, name)
+		}
+	}
+
+	params := make([]ContractParam, 0, len(method.Parameters))
+	for _, p := range method.Parameters {
+		value, ok := args[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing argument %q", method.Name, p.Name)
+		}
+		param, err := encodeArg(p.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: argument %q: %w", method.Name, p.Name, err)
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+func encodeArg(typ string, value any) (ContractParam, error) {
+	switch typ {
+	case "Hash160":
+		h, err := hash160Arg(value)
+		if err != nil {
+			return ContractParam{}, err
+		}
+		return NewHash160Param("0x" + h.StringLE()), nil
+	case "ByteArray":
+		b, err := byteArrayArg(value)
+		if err != nil {
+			return ContractParam{}, err
+		}
+		return NewByteArrayParam(b), nil
+	case "Integer":
+		n, err := integerArg(value)
+		if err != nil {
+			return ContractParam{}, err
+		}
+		return NewIntegerParam(n), nil
+	case "Boolean":
+		b, ok := value.(bool)
+		if !ok {
+			return ContractParam{}, typeMismatch(typ, value)
+		}
+		return NewBoolParam(b), nil
+	case "String":
+		s, ok := value.(string)
+		if !ok {
+			return ContractParam{}, typeMismatch(typ, value)
+		}
+		return NewStringParam(s), nil
+	default:
+		return ContractParam{}, fmt.Errorf("unsupported parameter type %q", typ)
+	}
+}
+
+func hash160Arg(value any) (util.Uint160, error) {
+	switch v := value.(type) {
+	case util.Uint160:
+		return v, nil
+	case string:
+		h, err := resolveAccountHash(v)
+		if err != nil {
+			return util.Uint160{}, fmt.Errorf("invalid Hash160 %q: %w", v, err)
+		}
+		return h, nil
+	default:
+		return util.Uint160{}, typeMismatch("Hash160", value)
+	}
+}
+
+func byteArrayArg(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		b, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ByteArray hex: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, typeMismatch("ByteArray", value)
+	}
+}
+
+// maxExactFloat is the largest magnitude below which every integer is
+// exactly representable as a float64.
+const maxExactFloat = 1 << 53
+
+func integerArg(value any) (*big.Int, error) {
+	switch v := value.(type) {
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int8:
+		return big.NewInt(int64(v)), nil
+	case int16:
+		return big.NewInt(int64(v)), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("nil Integer")
+		}
+		return new(big.Int).Set(v), nil
+	case json.Number:
+		return parseIntegerString(v.String())
+	case string:
+		return parseIntegerString(v)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > maxExactFloat {
+			return nil, fmt.Errorf("invalid Integer %v: not an exact integer", v)
+		}
+		return big.NewInt(int64(v)), nil
+	default:
+		return nil, typeMismatch("Integer", value)
+	}
+}
+
+func parseIntegerString(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid Integer %q", s)
+	}
+	return n, nil
+}
+
+func typeMismatch(typ string, value any) error {
+	return fmt.Errorf("%T is not compatible with %s", value, typ)
+}
@@ -0,0 +1,136 @@
+package chain
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// requestMethod mixes every supported parameter type.
+var requestMethod = ContractMethod{
+	Name: "request",
+	Parameters: []ContractMethodArg{
+		{Name: "callback", Type: "Hash160"},
+		{Name: "payload", Type: "ByteArray"},
+		{Name: "gasLimit", Type: "Integer"},
+		{Name: "urgent", Type: "Boolean"},
+		{Name: "tag", Type: "String"},
+	},
+}
+
+func TestEncodeArgsMixedTypes(t *testing.T) {
+	hash := util.Uint160{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14}
+	hashParam := NewHash160Param("0x" + hash.StringLE())
+	want := []ContractParam{
+		hashParam,
+		NewByteArrayParam([]byte{0xde, 0xad, 0xbe, 0xef}),
+		NewIntegerParam(big.NewInt(1_000_000)),
+		NewBoolParam(true),
+		NewStringParam("vrf"),
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"native Go values", map[string]any{
+			"callback": hash,
+			"payload":  []byte{0xde, 0xad, 0xbe, 0xef},
+			"gasLimit": int64(1_000_000),
+			"urgent":   true,
+			"tag":      "vrf",
+		}},
+		{"string forms", map[string]any{
+			"callback": "0x" + hash.StringLE(),
+			"payload":  "0xdeadbeef",
+			"gasLimit": "1000000",
+			"urgent":   true,
+			"tag":      "vrf",
+		}},
+		{"address and big.Int", map[string]any{
+			"callback": address.Uint160ToString(hash),
+			"payload":  "deadbeef",
+			"gasLimit": big.NewInt(1_000_000),
+			"urgent":   true,
+			"tag":      "vrf",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeArgs(requestMethod, tt.args)
+			if err != nil {
+				t.Fatalf("EncodeArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("EncodeArgs() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	t.Run("decoded JSON", func(t *testing.T) {
+		var args map[string]any
+		body := `{"callback":"` + address.Uint160ToString(hash) + `","payload":"deadbeef","gasLimit":1000000,"urgent":true,"tag":"vrf"}`
+		if err := json.Unmarshal([]byte(body), &args); err != nil {
+			t.Fatal(err)
+		}
+		got, err := EncodeArgs(requestMethod, args)
+		if err != nil {
+			t.Fatalf("EncodeArgs() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("EncodeArgs() = %+v, want %+v", got, want)
+		}
+	})
+}
+
+func TestValidateInvocationArgsRejects(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{
+			"callback": util.Uint160{},
+			"payload":  []byte{},
+			"gasLimit": 1,
+			"urgent":   false,
+			"tag":      "",
+		}
+	}
+	if err := ValidateInvocationArgs(requestMethod, valid()); err != nil {
+		t.Fatalf("ValidateInvocationArgs(valid) error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(map[string]any)
+		wantErr string
+	}{
+		{"missing", func(a map[string]any) { delete(a, "urgent") }, `missing argument "urgent"`},
+		{"unknown", func(a map[string]any) { a["extra"] = 1 }, `unknown argument "extra"`},
+		{"bool as string", func(a map[string]any) { a["urgent"] = "true" }, `argument "urgent"`},
+		{"bad hash", func(a map[string]any) { a["callback"] = "0x1234" }, `argument "callback"`},
+		{"hash wrong type", func(a map[string]any) { a["callback"] = 42 }, `argument "callback"`},
+		{"bad hex", func(a map[string]any) { a["payload"] = "xyz" }, `argument "payload"`},
+		{"fractional integer", func(a map[string]any) { a["gasLimit"] = 1.5 }, `argument "gasLimit"`},
+		{"imprecise integer", func(a map[string]any) { a["gasLimit"] = 1e20 }, `argument "gasLimit"`},
+		{"non-numeric integer", func(a map[string]any) { a["gasLimit"] = "ten" }, `argument "gasLimit"`},
+		{"string as int", func(a map[string]any) { a["tag"] = 7 }, `argument "tag"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := valid()
+			tt.edit(args)
+			err := ValidateInvocationArgs(requestMethod, args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateInvocationArgs() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	unsupported := ContractMethod{Name: "m", Parameters: []ContractMethodArg{{Name: "x", Type: "InteropInterface"}}}
+	if err := ValidateInvocationArgs(unsupported, map[string]any{"x": nil}); err == nil {
+		t.Error("unsupported parameter type should be rejected")
+	}
+}
@@ -0,0 +1,313 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Multi-Network Deployment Tracking
+// =============================================================================
+
+// Deployment statuses (per network).
+const (
+	DeploymentPending   = "pending"
+	DeploymentConfirmed = "confirmed"
+	DeploymentFailed    = "failed"
+)
+
+// Aggregate contract statuses across its target networks.
+const (
+	ContractNotDeployed       = "not_deployed" // nothing confirmed or failed yet
+	ContractDeployed          = "deployed"     // confirmed on every target network
+	ContractPartiallyDeployed = "partial"      // confirmed on some target networks
+	ContractDeployFailed      = "failed"       // failed somewhere, confirmed nowhere
+)
+
+// ErrContractNotTracked is returned for contracts with no deployments or
+// target networks.
+var ErrContractNotTracked = errors.New("contract not tracked")
+
+// ContractDeploymentStatus summarizes a contract's deployments across its
+// target networks. Confirmed, Failed and Pending partition the targets;
+// targets without a deployment record count as pending.
+type ContractDeploymentStatus struct {
+	Contract  string                 `json:"contract"`
+	Status    string                 `json:"status"`
+	Confirmed []string               `json:"confirmed"`
+	Failed    []string               `json:"failed"`
+	Pending   []string               `json:"pending"`
+	Networks  map[string]*Deployment `json:"networks"`
+}
+
+// AggregateDeploymentStatus computes the aggregate status of deployments
+// (keyed by network) over targets. With no targets, every network with a
+// deployment is a target. A contract confirmed on one network and failed on
+// another is partially deployed, with the failure listed in Failed.
+func AggregateDeploymentStatus(contractID string, targets []string, deployments map[string]*Deployment) ContractDeploymentStatus {
+	if len(targets) == 0 {
+		for network := range deployments {
+			targets = append(targets, network)
+		}
+	}
+	targets = normalizeNetworks(targets)
+
+	status := ContractDeploymentStatus{
+		Contract:  contractID,
+		Confirmed: []string{},
+		Failed:    []string{},
+		Pending:   []string{},
+		Networks:  deployments,
+	}
+	for _, network := range targets {
+		d := deployments[network]
+		switch {
+		case d != nil && d.Status == DeploymentConfirmed:
+			status.Confirmed = append(status.Confirmed, network)
+		case d != nil && d.Status == DeploymentFailed:
+			status.Failed = append(status.Failed, network)
+		default:
+			status.Pending = append(status.Pending, network)
+		}
+	}
+
+	switch {
+	case len(status.Confirmed) > 0 && len(status.Confirmed) == len(targets):
+		status.Status = ContractDeployed
+	case len(status.Confirmed) > 0:
+		status.Status = ContractPartiallyDeployed
+	case len(status.Failed) > 0:
+		status.Status = ContractDeployFailed
+	default:
+		status.Status = ContractNotDeployed
+	}
+	return status
+}
+
+// DeploymentTracker keeps the latest deployment of each contract per network
+// and the networks each contract should be deployed to.
+type DeploymentTracker struct {
+	mu          sync.RWMutex
+	deployments map[string]map[string]*Deployment // contract -> network -> deployment
+	targets     map[string][]string
+}
+
+// NewDeploymentTracker creates an empty tracker.
+func NewDeploymentTracker() *DeploymentTracker {
+	return &DeploymentTracker{
+		deployments: make(map[string]map[string]*Deployment),
+		targets:     make(map[string][]string),
+	}
+}
+
+// SetTargets sets the networks contractID should be deployed to.
+func (t *DeploymentTracker) SetTargets(contractID string, networks []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.targets[contractID] = normalizeNetworks(networks)
+}
+
+// Record stores d as the latest deployment of d.Template on d.Network,
+// replacing any earlier one. Status defaults to pending.
+func (t *DeploymentTracker) Record(d Deployment) error {
+	d.Network = normalizeNetwork(d.Network)
+	if d.Template == "" || d.Network == "" {
+		return fmt.Errorf("deployment requires contract and network")
+	}
+	switch d.Status {
+	case "":
+		d.Status = DeploymentPending
+	case DeploymentPending, DeploymentConfirmed, DeploymentFailed:
+	default:
+		return fmt.Errorf("unknown deployment status %q", d.Status)
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = time.Now().UTC()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	byNetwork := t.deployments[d.Template]
+	if byNetwork == nil {
+		byNetwork = make(map[string]*Deployment)
+		t.deployments[d.Template] = byNetwork
+	}
+	byNetwork[d.Network] = &d
+	return nil
+}
+
+// LoadRegistry records every contract in r as confirmed on r's network. A
+// contract in the registry is live there, so this replaces a pending or
+// failed record for that network (e.g. a failed redeploy attempt).
+func (t *DeploymentTracker) LoadRegistry(r *ContractRegistry) error {
+	r.mu.RLock()
+	network := r.network
+	var deployments []Deployment
+	for name, info := range r.contracts {
+		if info == nil || info.Hash == "" {
+			continue
+		}
+		d := Deployment{
+			Template: name,
+			Network:  network,
+			Address:  info.Hash,
+			Status:   DeploymentConfirmed,
+			TxHash:   info.DeployTxHash,
+		}
+		if at, err := time.Parse(time.RFC3339, info.DeployedAt); err == nil {
+			d.UpdatedAt = at
+		}
+		deployments = append(deployments, d)
+	}
+	r.mu.RUnlock()
+
+	for _, d := range deployments {
+		if err := t.Record(d); err != nil {
+			return fmt.Errorf("%s: %w", d.Template, err)
+		}
+	}
+	return nil
+}
+
+// GetContractDeployments returns copies of contractID's deployments keyed by
+// network.
+func (t *DeploymentTracker) GetContractDeployments(contractID string) (map[string]*Deployment, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	byNetwork, ok := t.deployments[contractID]
+	if !ok && len(t.targets[contractID]) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrContractNotTracked, contractID)
+	}
+	out := make(map[string]*Deployment, len(byNetwork))
+	for network, d := range byNetwork {
+		cp := *d
+		out[network] = &cp
+	}
+	return out, nil
+}
+
+// ContractStatus aggregates contractID's deployments over its target
+// networks (see AggregateDeploymentStatus).
+func (t *DeploymentTracker) ContractStatus(contractID string) (*ContractDeploymentStatus, error) {
+	deployments, err := t.GetContractDeployments(contractID)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.RLock()
+	targets := append([]string(nil), t.targets[contractID]...)
+	t.mu.RUnlock()
+
+	status := AggregateDeploymentStatus(contractID, targets, deployments)
+	return &status, nil
+}
+
+// Contracts returns the tracked contract IDs, sorted.
+func (t *DeploymentTracker) Contracts() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	seen := make(map[string]bool, len(t.deployments)+len(t.targets))
+	for id := range t.deployments {
+		seen[id] = true
+	}
+	for id, networks := range t.targets {
+		if len(networks) > 0 {
+			seen[id] = true
+		}
+	}
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// deploymentTrackerFile is the on-disk form of a DeploymentTracker.
+type deploymentTrackerFile struct {
+	Targets     map[string][]string `json:"targets,omitempty"`
+	Deployments []Deployment        `json:"deployments"`
+}
+
+// LoadFromFile adds the deployments and targets saved in filename. A missing
+// file is not an error.
+func (t *DeploymentTracker) LoadFromFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read file: %w", err)
+	}
+	var file deploymentTrackerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	for id, networks := range file.Targets {
+		t.SetTargets(id, networks)
+	}
+	for _, d := range file.Deployments {
+		if err := t.Record(d); err != nil {
+			return fmt.Errorf("%s/%s: %w", d.Template, d.Network, err)
+		}
+	}
+	return nil
+}
+
+// SaveToFile writes the tracked deployments and targets to filename.
+func (t *DeploymentTracker) SaveToFile(filename string) error {
+	t.mu.RLock()
+	file := deploymentTrackerFile{Targets: t.targets}
+	for _, byNetwork := range t.deployments {
+		for _, d := range byNetwork {
+			file.Deployments = append(file.Deployments, *d)
+		}
+	}
+	sort.Slice(file.Deployments, func(i, j int) bool {
+		a, b := file.Deployments[i], file.Deployments[j]
+		if a.Template != b.Template {
+			return a.Template < b.Template
+		}
+		return a.Network < b.Network
+	})
+	data, err := json.MarshalIndent(file, "", "  ")
+	t.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("create dir: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	return nil
+}
+
+func normalizeNetwork(network string) string {
+	return strings.ToLower(strings.TrimSpace(network))
+}
+
+func normalizeNetworks(networks []string) []string {
+	out := make([]string, 0, len(networks))
+	seen := make(map[string]bool, len(networks))
+	for _, n := range networks {
+		n = normalizeNetwork(n)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
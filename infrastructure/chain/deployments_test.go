@@ -0,0 +1,114 @@
+package chain
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAggregateDeploymentStatus(t *testing.T) {
+	confirmed := &Deployment{Status: DeploymentConfirmed}
+	failed := &Deployment{Status: DeploymentFailed}
+	pending := &Deployment{Status: DeploymentPending}
+
+	tests := []struct {
+		name        string
+		targets     []string
+		deployments map[string]*Deployment
+		want        string
+	}{
+		{"all confirmed", []string{"testnet", "mainnet"}, map[string]*Deployment{"testnet": confirmed, "mainnet": confirmed}, ContractDeployed},
+		{"confirmed and missing", []string{"testnet", "mainnet"}, map[string]*Deployment{"testnet": confirmed}, ContractPartiallyDeployed},
+		{"confirmed and failed", []string{"testnet", "mainnet"}, map[string]*Deployment{"testnet": confirmed, "mainnet": failed}, ContractPartiallyDeployed},
+		{"failed and pending", []string{"testnet", "mainnet"}, map[string]*Deployment{"testnet": pending, "mainnet": failed}, ContractDeployFailed},
+		{"nothing yet", []string{"testnet"}, map[string]*Deployment{}, ContractNotDeployed},
+		{"targets from records", nil, map[string]*Deployment{"testnet": confirmed}, ContractDeployed},
+		{"non-target ignored", []string{"mainnet"}, map[string]*Deployment{"testnet": confirmed}, ContractNotDeployed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AggregateDeploymentStatus("PriceFeed", tt.targets, tt.deployments)
+			if got.Status != tt.want {
+				t.Errorf("Status = %s, want %s (%+v)", got.Status, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDeploymentTrackerFailedAndConfirmed(t *testing.T) {
+	tracker := NewDeploymentTracker()
+	tracker.SetTargets("PriceFeed", []string{"TestNet", "mainnet"})
+
+	if err := tracker.Record(Deployment{Template: "PriceFeed", Network: "mainnet", Status: DeploymentFailed, Error: "insufficient GAS"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	registry := NewContractRegistry("testnet", t.TempDir())
+	registry.RegisterDeployment("PriceFeed", "0xabc", "1.0.0", "0xtx", "NdeployerAddr")
+	if err := tracker.LoadRegistry(registry); err != nil {
+		t.Fatalf("LoadRegistry() error = %v", err)
+	}
+
+	deployments, err := tracker.GetContractDeployments("PriceFeed")
+	if err != nil {
+		t.Fatalf("GetContractDeployments() error = %v", err)
+	}
+	if d := deployments["testnet"]; d == nil || d.Status != DeploymentConfirmed || d.Address != "0xabc" || d.TxHash != "0xtx" {
+		t.Errorf("testnet = %+v, want confirmed at 0xabc", d)
+	}
+	if d := deployments["mainnet"]; d == nil || d.Status != DeploymentFailed || d.Error == "" {
+		t.Errorf("mainnet = %+v, want failed", d)
+	}
+
+	status, err := tracker.ContractStatus("PriceFeed")
+	if err != nil {
+		t.Fatalf("ContractStatus() error = %v", err)
+	}
+	if status.Status != ContractPartiallyDeployed ||
+		!reflect.DeepEqual(status.Confirmed, []string{"testnet"}) ||
+		!reflect.DeepEqual(status.Failed, []string{"mainnet"}) {
+		t.Errorf("ContractStatus() = %+v", status)
+	}
+
+	// Returned deployments are copies.
+	deployments["testnet"].Status = DeploymentFailed
+	if again, _ := tracker.GetContractDeployments("PriceFeed"); again["testnet"].Status != DeploymentConfirmed {
+		t.Error("mutating GetContractDeployments result changed the tracker")
+	}
+
+	if _, err := tracker.GetContractDeployments("Unknown"); !errors.Is(err, ErrContractNotTracked) {
+		t.Errorf("GetContractDeployments(Unknown) error = %v, want ErrContractNotTracked", err)
+	}
+	if err := tracker.Record(Deployment{Template: "PriceFeed", Network: "testnet", Status: "done"}); err == nil {
+		t.Error("Record() should reject unknown status")
+	}
+}
+
+func TestDeploymentTrackerFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deployments.json")
+
+	tracker := NewDeploymentTracker()
+	tracker.SetTargets("PaymentHub", []string{"testnet", "mainnet"})
+	if err := tracker.Record(Deployment{Template: "PaymentHub", Network: "testnet", Address: "0x01", Status: DeploymentPending}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile() error = %v", err)
+	}
+
+	loaded := NewDeploymentTracker()
+	if err := loaded.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+	want, _ := tracker.ContractStatus("PaymentHub")
+	got, err := loaded.ContractStatus("PaymentHub")
+	if err != nil {
+		t.Fatalf("ContractStatus() error = %v", err)
+	}
+	if got.Status != ContractNotDeployed || !reflect.DeepEqual(got.Pending, want.Pending) || got.Networks["testnet"].Address != "0x01" {
+		t.Errorf("loaded status = %+v, want %+v", got, want)
+	}
+	if err := NewDeploymentTracker().LoadFromFile(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("LoadFromFile(missing) error = %v", err)
+	}
+}
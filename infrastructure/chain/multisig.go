@@ -0,0 +1,143 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	neokeys "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// =============================================================================
+// Multisig Witnesses
+// =============================================================================
+
+// BuildMultisigWitness builds the invocation script for a multisig
+// verification script. CheckMultisig consumes exactly m signatures, ordered
+// like the public keys in the script, so signatures must hold exactly m
+// 64-byte signatures in that order.
+func BuildMultisigWitness(script []byte, signatures [][]byte) ([]byte, error) {
+	m, _, ok := vm.ParseMultiSigContract(script)
+	if !ok {
+		return nil, fmt.Errorf("not a multisig verification script")
+	}
+	if len(signatures) != m {
+		return nil, fmt.Errorf("multisig requires %d signatures, got %d", m, len(signatures))
+	}
+
+	invocation := make([]byte, 0, m*(2+neokeys.SignatureLen))
+	for i, sig := range signatures {
+		if len(sig) != neokeys.SignatureLen {
+			return nil, fmt.Errorf("signature %d: invalid length %d", i, len(sig))
+		}
+		invocation = append(invocation, byte(opcode.PUSHDATA1), neokeys.SignatureLen)
+		invocation = append(invocation, sig...)
+	}
+	return invocation, nil
+}
+
+// MultisigSigner implements TxSigner for an m-of-n multisig account where a
+// single TEE key (for pool accounts, 1-of-2) is sufficient to sign. Each
+// signature is checked against the TEE public key before the witness is
+// assembled.
+type MultisigSigner struct {
+	signer             MessageSigner
+	pubKey             *neokeys.PublicKey
+	scriptHash         util.Uint160
+	verificationScript []byte
+}
+
+// NewMultisigSigner builds the m-of-n verification script over pubKeys and
+// returns a signer that signs with signer, whose public key is pubKey.
+// pubKey must be one of pubKeys and m must be 1, since only one signature is
+// produced.
+func NewMultisigSigner(signer MessageSigner, pubKey *neokeys.PublicKey, m int, pubKeys neokeys.PublicKeys) (*MultisigSigner, error) {
+	if signer == nil || pubKey == nil {
+		return nil, fmt.Errorf("signer and public key required")
+	}
+	if m != 1 {
+		return nil, fmt.Errorf("multisig signer supports m=1 only, got %d", m)
+	}
+	if !pubKeys.Contains(pubKey) {
+		return nil, fmt.Errorf("signer public key is not part of the multisig account")
+	}
+
+	script, err := smartcontract.CreateMultiSigRedeemScript(m, pubKeys)
+	if err != nil {
+		return nil, fmt.Errorf("create multisig script: %w", err)
+	}
+
+	return &MultisigSigner{
+		signer:             signer,
+		pubKey:             pubKey,
+		scriptHash:         hash.Hash160(script),
+		verificationScript: script,
+	}, nil
+}
+
+func (s *MultisigSigner) ScriptHash() util.Uint160 {
+	if s == nil {
+		return util.Uint160{}
+	}
+	return s.scriptHash
+}
+
+func (s *MultisigSigner) GetVerificationScript() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.verificationScript
+}
+
+// SignTx signs tx with the TEE key and sets the multisig witness.
+// The transaction MUST already contain a signer entry for this signer's ScriptHash.
+func (s *MultisigSigner) SignTx(net netmode.Magic, tx *transaction.Transaction) error {
+	if s == nil || s.signer == nil {
+		return fmt.Errorf("multisig signer not configured")
+	}
+	if tx == nil {
+		return fmt.Errorf("transaction required")
+	}
+
+	pos := -1
+	for i := range tx.Signers {
+		if tx.Signers[i].Account.Equals(s.scriptHash) {
+			pos = i
+			break
+		}
+	}
+	if pos == -1 {
+		return fmt.Errorf("transaction is not signed by this account")
+	}
+	if len(tx.Scripts) < pos {
+		return fmt.Errorf("transaction is not yet signed by the previous signer")
+	}
+	if len(tx.Scripts) == pos {
+		tx.Scripts = append(tx.Scripts, transaction.Witness{
+			VerificationScript: s.verificationScript,
+		})
+	} else if len(tx.Scripts[pos].VerificationScript) == 0 {
+		tx.Scripts[pos].VerificationScript = s.verificationScript
+	}
+
+	sig, err := s.signer.Sign(context.Background(), hash.GetSignedData(uint32(net), tx))
+	if err != nil {
+		return fmt.Errorf("multisig sign tx: %w", err)
+	}
+	if !s.pubKey.VerifyHashable(sig, uint32(net), tx) {
+		return fmt.Errorf("multisig sign tx: signature does not verify against signer key")
+	}
+
+	invocation, err := BuildMultisigWitness(s.verificationScript, [][]byte{sig})
+	if err != nil {
+		return err
+	}
+	tx.Scripts[pos].InvocationScript = invocation
+	return nil
+}
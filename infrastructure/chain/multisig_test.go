@@ -0,0 +1,82 @@
+package chain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	neokeys "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+func TestBuildMultisigWitness(t *testing.T) {
+	k1, _ := neokeys.NewPrivateKey()
+	k2, _ := neokeys.NewPrivateKey()
+	script, err := smartcontract.CreateMultiSigRedeemScript(1, neokeys.PublicKeys{k1.PublicKey(), k2.PublicKey()})
+	if err != nil {
+		t.Fatalf("CreateMultiSigRedeemScript() error = %v", err)
+	}
+	sig := bytes.Repeat([]byte{0xab}, neokeys.SignatureLen)
+
+	invocation, err := BuildMultisigWitness(script, [][]byte{sig})
+	if err != nil {
+		t.Fatalf("BuildMultisigWitness() error = %v", err)
+	}
+	if len(invocation) != 2+neokeys.SignatureLen || !bytes.Equal(invocation[2:], sig) {
+		t.Errorf("invocation = %x", invocation)
+	}
+
+	if _, err := BuildMultisigWitness(script, nil); err == nil {
+		t.Error("BuildMultisigWitness() with no signatures should fail")
+	}
+	if _, err := BuildMultisigWitness(script, [][]byte{sig, sig}); err == nil {
+		t.Error("BuildMultisigWitness() with too many signatures should fail")
+	}
+	if _, err := BuildMultisigWitness(script, [][]byte{sig[:10]}); err == nil {
+		t.Error("BuildMultisigWitness() with short signature should fail")
+	}
+	if _, err := BuildMultisigWitness(k1.PublicKey().GetVerificationScript(), [][]byte{sig}); err == nil {
+		t.Error("BuildMultisigWitness() with single-sig script should fail")
+	}
+}
+
+func TestMultisigSignerSignTx(t *testing.T) {
+	const keyHex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tee, err := NewLocalTEESignerFromPrivateKeyHex(keyHex)
+	if err != nil {
+		t.Fatalf("NewLocalTEESignerFromPrivateKeyHex() error = %v", err)
+	}
+	teeKey, _ := neokeys.NewPrivateKeyFromHex(keyHex)
+	other, _ := neokeys.NewPrivateKey()
+	pubKeys := neokeys.PublicKeys{teeKey.PublicKey(), other.PublicKey()}
+
+	signer, err := NewMultisigSigner(tee, teeKey.PublicKey(), 1, pubKeys)
+	if err != nil {
+		t.Fatalf("NewMultisigSigner() error = %v", err)
+	}
+	if !vm.IsMultiSigContract(signer.GetVerificationScript()) {
+		t.Fatal("verification script is not multisig")
+	}
+
+	tx := transaction.New([]byte{0x40}, 0)
+	tx.Signers = []transaction.Signer{{Account: signer.ScriptHash(), Scopes: transaction.CalledByEntry}}
+	if err := signer.SignTx(netmode.TestNet, tx); err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+	if len(tx.Scripts) != 1 || len(tx.Scripts[0].InvocationScript) != 2+neokeys.SignatureLen {
+		t.Fatalf("unexpected witness: %+v", tx.Scripts)
+	}
+	sig := tx.Scripts[0].InvocationScript[2:]
+	if !teeKey.PublicKey().VerifyHashable(sig, uint32(netmode.TestNet), tx) {
+		t.Error("witness signature does not verify")
+	}
+
+	if _, err := NewMultisigSigner(tee, teeKey.PublicKey(), 2, pubKeys); err == nil {
+		t.Error("NewMultisigSigner() with m=2 should fail")
+	}
+	if _, err := NewMultisigSigner(tee, teeKey.PublicKey(), 1, neokeys.PublicKeys{other.PublicKey()}); err == nil {
+		t.Error("NewMultisigSigner() without signer key should fail")
+	}
+}
@@ -27,13 +27,14 @@ type GlobalSignerSigner struct {
 }
 
 // NewGlobalSignerSigner constructs a signer backed by GlobalSigner.
-// It fetches the active public key via /attestation to compute ScriptHash and verification script.
+// It fetches the active secp256r1 public key via /attestation to compute
+// ScriptHash and verification script, whatever scheme the signer defaults to.
 func NewGlobalSignerSigner(ctx context.Context, client *gsclient.Client) (*GlobalSignerSigner, error) {
 	if client == nil {
 		return nil, fmt.Errorf("globalsigner client required")
 	}
 
-	att, err := client.GetAttestation(ctx)
+	att, err := client.GetSchemeAttestation(ctx, neoSignatureScheme)
 	if err != nil {
 		return nil, fmt.Errorf("get attestation: %w", err)
 	}
@@ -106,7 +107,8 @@ func (s *GlobalSignerSigner) SignTx(net netmode.Magic, tx *transaction.Transacti
 
 	signedData := hash.GetSignedData(uint32(net), tx)
 	resp, err := s.client.SignRaw(context.Background(), &gsclient.SignRawRequest{
-		Data: hex.EncodeToString(signedData),
+		Data:   hex.EncodeToString(signedData),
+		Scheme: neoSignatureScheme,
 	})
 	if err != nil {
 		return fmt.Errorf("globalsigner sign tx: %w", err)
@@ -137,7 +139,8 @@ func (s *GlobalSignerSigner) Sign(ctx context.Context, data []byte) ([]byte, err
 	}
 
 	resp, err := s.client.SignRaw(ctx, &gsclient.SignRawRequest{
-		Data: hex.EncodeToString(data),
+		Data:   hex.EncodeToString(data),
+		Scheme: neoSignatureScheme,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("globalsigner sign: %w", err)
@@ -150,6 +153,10 @@ func (s *GlobalSignerSigner) Sign(ctx context.Context, data []byte) ([]byte, err
 	return sig, nil
 }
 
+// neoSignatureScheme is the only GlobalSigner scheme Neo N3 witnesses and
+// CryptoLib verification accept.
+const neoSignatureScheme = "secp256r1"
+
 func decodeHexMaybe0x(value string) ([]byte, error) {
 	trimmed := strings.TrimSpace(value)
 	trimmed = strings.TrimPrefix(trimmed, "0x")
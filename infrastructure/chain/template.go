@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =============================================================================
+// Contract Templates
+// =============================================================================
+
+// ContractTemplate describes a deployable contract: the networks it may be
+// deployed to and the parameters its _deploy method takes.
+type ContractTemplate struct {
+	Name     string          `json:"name"`
+	Networks []string        `json:"networks"`
+	Params   []TemplateParam `json:"params"`
+}
+
+// TemplateParam is a constructor parameter. Type is a Neo N3 parameter type
+// as accepted by EncodeArgs. A parameter that is neither required nor
+// defaulted is passed as Any (null) when no value is supplied.
+type TemplateParam struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	Default  any    `json:"default,omitempty"`
+}
+
+// Deployment is a contract (template) deployment to one network. The
+// tracking fields (Address onwards) are set as the deployment progresses;
+// see DeploymentTracker.
+type Deployment struct {
+	Template        string          `json:"template"`
+	Network         string          `json:"network"`
+	ConstructorArgs []ContractParam `json:"constructor_args,omitempty"`
+
+	Address   string    `json:"address,omitempty"` // contract script hash
+	Status    string    `json:"status,omitempty"`  // pending, confirmed, failed
+	TxHash    string    `json:"tx_hash,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// DeployData returns the constructor arguments as the single data parameter
+// of ContractManagement.deploy, which passes it on to the contract's _deploy.
+func (d *Deployment) DeployData() ContractParam {
+	return NewArrayParam(d.ConstructorArgs)
+}
+
+// InstantiateTemplate binds values to tmpl's parameters for deployment to
+// network. Supplied values, then defaults, are type-checked and encoded in
+// declaration order; missing required parameters, unknown values and
+// networks not listed in tmpl.Networks are rejected.
+func InstantiateTemplate(tmpl ContractTemplate, network string, values map[string]any) (*Deployment, error) {
+	network = normalizeNetwork(network)
+	supported := false
+	for _, n := range tmpl.Networks {
+		if normalizeNetwork(n) == network {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("template %s: network %q not supported (supported: %s)", tmpl.Name, network, strings.Join(tmpl.Networks, ", "))
+	}
+
+	declared := make(map[string]bool, len(tmpl.Params))
+	for _, p := range tmpl.Params {
+		declared[p.Name] = true
+	}
+	for name := range values {
+		if !declared[name] {
+			return nil, fmt.Errorf("template %s: unknown parameter %q", tmpl.Name, name)
+		}
+	}
+
+	args := make([]ContractParam, 0, len(tmpl.Params))
+	for _, p := range tmpl.Params {
+		value, ok := values[p.Name]
+		if !ok {
+			value, ok = p.Default, p.Default != nil
+		}
+		if !ok {
+			if p.Required {
+				return nil, fmt.Errorf("template %s: missing required parameter %q", tmpl.Name, p.Name)
+			}
+			args = append(args, NewAnyParam())
+			continue
+		}
+		arg, err := encodeArg(p.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: parameter %q: %w", tmpl.Name, p.Name, err)
+		}
+		args = append(args, arg)
+	}
+
+	return &Deployment{
+		Template:        tmpl.Name,
+		Network:         network,
+		ConstructorArgs: args,
+		Status:          DeploymentPending,
+	}, nil
+}
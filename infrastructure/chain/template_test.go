@@ -0,0 +1,123 @@
+package chain
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var tokenTemplate = ContractTemplate{
+	Name:     "nep17-token",
+	Networks: []string{"testnet", "mainnet"},
+	Params: []TemplateParam{
+		{Name: "owner", Type: "Hash160", Required: true},
+		{Name: "symbol", Type: "String", Required: true},
+		{Name: "decimals", Type: "Integer", Default: 8},
+		{Name: "mintable", Type: "Boolean", Default: false},
+		{Name: "metadata", Type: "ByteArray"},
+	},
+}
+
+const testOwner = "0x0102030405060708090a0b0c0d0e0f1011121314"
+
+func TestInstantiateTemplateAppliesDefaults(t *testing.T) {
+	d, err := InstantiateTemplate(tokenTemplate, "TestNet", map[string]any{
+		"owner":  testOwner,
+		"symbol": "TKN",
+	})
+	if err != nil {
+		t.Fatalf("InstantiateTemplate() error = %v", err)
+	}
+
+	want := []ContractParam{
+		NewHash160Param(testOwner),
+		NewStringParam("TKN"),
+		NewIntegerParam(big.NewInt(8)),
+		NewBoolParam(false),
+		NewAnyParam(),
+	}
+	if !reflect.DeepEqual(d.ConstructorArgs, want) {
+		t.Errorf("ConstructorArgs = %+v, want %+v", d.ConstructorArgs, want)
+	}
+	if d.Template != "nep17-token" || d.Network != "testnet" {
+		t.Errorf("Deployment = %+v", d)
+	}
+	if data := d.DeployData(); data.Type != "Array" {
+		t.Errorf("DeployData().Type = %s, want Array", data.Type)
+	}
+
+	// Supplied values override defaults.
+	d, err = InstantiateTemplate(tokenTemplate, "mainnet", map[string]any{
+		"owner":    testOwner,
+		"symbol":   "TKN",
+		"decimals": 0,
+		"metadata": "cafe",
+	})
+	if err != nil {
+		t.Fatalf("InstantiateTemplate() error = %v", err)
+	}
+	if got := d.ConstructorArgs[2]; !reflect.DeepEqual(got, NewIntegerParam(big.NewInt(0))) {
+		t.Errorf("decimals = %+v, want 0", got)
+	}
+	if got := d.ConstructorArgs[4]; !reflect.DeepEqual(got, NewByteArrayParam([]byte{0xca, 0xfe})) {
+		t.Errorf("metadata = %+v", got)
+	}
+}
+
+func TestInstantiateTemplateRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    ContractTemplate
+		network string
+		values  map[string]any
+		wantErr string
+	}{
+		{
+			name:    "missing required param",
+			tmpl:    tokenTemplate,
+			network: "testnet",
+			values:  map[string]any{"owner": testOwner},
+			wantErr: `missing required parameter "symbol"`,
+		},
+		{
+			name:    "network not supported",
+			tmpl:    tokenTemplate,
+			network: "privnet",
+			values:  map[string]any{"owner": testOwner, "symbol": "TKN"},
+			wantErr: `network "privnet" not supported`,
+		},
+		{
+			name:    "type mismatch",
+			tmpl:    tokenTemplate,
+			network: "testnet",
+			values:  map[string]any{"owner": testOwner, "symbol": "TKN", "mintable": "yes"},
+			wantErr: `parameter "mintable"`,
+		},
+		{
+			name:    "unknown param",
+			tmpl:    tokenTemplate,
+			network: "testnet",
+			values:  map[string]any{"owner": testOwner, "symbol": "TKN", "supply": 1},
+			wantErr: `unknown parameter "supply"`,
+		},
+		{
+			name: "invalid default",
+			tmpl: ContractTemplate{
+				Name:     "bad",
+				Networks: []string{"testnet"},
+				Params:   []TemplateParam{{Name: "limit", Type: "Integer", Default: "many"}},
+			},
+			network: "testnet",
+			wantErr: `parameter "limit"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := InstantiateTemplate(tt.tmpl, tt.network, tt.values)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("InstantiateTemplate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -64,6 +64,27 @@ func (b *TxBuilder) BuildAndSignTx(
 	invokeResult *InvokeResult,
 	account TxSigner,
 	signerScopes transaction.WitnessScope,
+) (*transaction.Transaction, error) {
+	tx, err := b.buildTx(ctx, invokeResult, account, signerScopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 8. Sign transaction
+	if err := account.SignTx(b.netMagic, tx); err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// buildTx builds an unsigned transaction from an invoke simulation, with
+// fees and ValidUntilBlock set and the signer's verification script in place.
+func (b *TxBuilder) buildTx(
+	ctx context.Context,
+	invokeResult *InvokeResult,
+	account TxSigner,
+	signerScopes transaction.WitnessScope,
 ) (*transaction.Transaction, error) {
 	// 1. Decode script from simulation result
 	script, err := base64.StdEncoding.DecodeString(invokeResult.Script)
@@ -116,11 +137,6 @@ func (b *TxBuilder) BuildAndSignTx(
 	networkFee := b.calculateNetworkFee(ctx, tx)
 	tx.NetworkFee = networkFee + b.extraFee
 
-	// 8. Sign transaction
-	if err := account.SignTx(b.netMagic, tx); err != nil {
-		return nil, fmt.Errorf("sign transaction: %w", err)
-	}
-
 	return tx, nil
 }
 
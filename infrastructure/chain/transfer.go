@@ -0,0 +1,121 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// =============================================================================
+// NEP-17 Transfer Builder
+// =============================================================================
+
+// BuildTransfer builds an unsigned NEP-17 transfer of amount from account to
+// to, ready for signing. asset is "GAS", "NEO" or a token contract hash; to is
+// a Neo address or script hash; amount is an integer in the token's smallest
+// unit (e.g. GAS fractions). Fees are computed the same way as
+// BuildAndSignTx. It returns the serialized transaction (with an empty
+// invocation script) and the hash to sign (sha256 of network magic || tx hash).
+//
+// The sender's balance is checked before building; for GAS the balance must
+// also cover the system and network fees.
+func (b *TxBuilder) BuildTransfer(ctx context.Context, account TxSigner, to, asset, amount string) (txBytes, signingHash []byte, err error) {
+	if account == nil {
+		return nil, nil, fmt.Errorf("account required")
+	}
+	assetHash, err := resolveAssetHash(asset)
+	if err != nil {
+		return nil, nil, err
+	}
+	toHash, err := resolveAccountHash(to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid recipient: %w", err)
+	}
+	value, ok := new(big.Int).SetString(strings.TrimSpace(amount), 10)
+	if !ok || value.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("invalid amount: %q", amount)
+	}
+
+	from := account.ScriptHash()
+	assetStr := "0x" + assetHash.StringLE()
+	balance, err := InvokeInt(ctx, b.client, assetStr, "balanceOf", NewHash160Param("0x"+from.StringLE()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("get balance: %w", err)
+	}
+	if balance.Cmp(value) < 0 {
+		return nil, nil, fmt.Errorf("insufficient balance: have %s, need %s", balance, value)
+	}
+
+	params := []ContractParam{
+		NewHash160Param("0x" + from.StringLE()),
+		NewHash160Param("0x" + toHash.StringLE()),
+		NewIntegerParam(value),
+		NewAnyParam(),
+	}
+	invokeResult, err := b.client.InvokeFunctionWithScope(ctx, assetStr, "transfer", params, from, ScopeCalledByEntry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("simulate transfer: %w", err)
+	}
+	if err := requireHalt("transfer", invokeResult); err != nil {
+		return nil, nil, err
+	}
+	item, err := firstStackItem("transfer", invokeResult)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok, err := ParseBoolean(item); err != nil || !ok {
+		return nil, nil, fmt.Errorf("transfer: simulation returned false")
+	}
+
+	tx, err := b.buildTx(ctx, invokeResult, account, transaction.CalledByEntry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if assetHash.Equals(gas.Hash) {
+		total := new(big.Int).Add(value, big.NewInt(tx.SystemFee+tx.NetworkFee))
+		if balance.Cmp(total) < 0 {
+			return nil, nil, fmt.Errorf("insufficient balance: have %s, need %s including fees", balance, total)
+		}
+	}
+
+	return tx.Bytes(), hash.NetSha256(uint32(b.netMagic), tx).BytesBE(), nil
+}
+
+// resolveAssetHash maps "GAS"/"NEO" to the native contract hashes and parses
+// anything else as a contract script hash.
+func resolveAssetHash(asset string) (util.Uint160, error) {
+	switch strings.ToUpper(strings.TrimSpace(asset)) {
+	case "":
+		return util.Uint160{}, fmt.Errorf("asset required")
+	case "GAS":
+		return gas.Hash, nil
+	case "NEO":
+		return neo.Hash, nil
+	}
+	h, err := ParseScriptHash(strings.TrimSpace(asset))
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("invalid asset %q: %w", asset, err)
+	}
+	return h, nil
+}
+
+// resolveAccountHash parses a Neo address or a script hash.
+func resolveAccountHash(value string) (util.Uint160, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return util.Uint160{}, fmt.Errorf("empty account")
+	}
+	if h, err := address.StringToUint160(value); err == nil {
+		return h, nil
+	}
+	return ParseScriptHash(value)
+}
@@ -0,0 +1,96 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+)
+
+func TestResolveAssetHash(t *testing.T) {
+	if h, err := resolveAssetHash("gas"); err != nil || !h.Equals(gas.Hash) {
+		t.Errorf("resolveAssetHash(gas) = %v, %v", h, err)
+	}
+	if h, err := resolveAssetHash("NEO"); err != nil || !h.Equals(neo.Hash) {
+		t.Errorf("resolveAssetHash(NEO) = %v, %v", h, err)
+	}
+	if h, err := resolveAssetHash("0x" + gas.Hash.StringLE()); err != nil || !h.Equals(gas.Hash) {
+		t.Errorf("resolveAssetHash(hash) = %v, %v", h, err)
+	}
+	if _, err := resolveAssetHash(""); err == nil {
+		t.Error("resolveAssetHash(\"\") should fail")
+	}
+	if _, err := resolveAssetHash("not-a-hash"); err == nil {
+		t.Error("resolveAssetHash(not-a-hash) should fail")
+	}
+}
+
+func TestBuildTransferValidation(t *testing.T) {
+	client, _ := NewClient(Config{RPCURL: "http://example"})
+	builder := NewTxBuilder(client, 894710606)
+	account, err := AccountFromPrivateKey("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatalf("AccountFromPrivateKey() error = %v", err)
+	}
+	to := account.Address
+
+	tests := []struct {
+		name   string
+		to     string
+		asset  string
+		amount string
+	}{
+		{"bad amount", to, "GAS", "1.5"},
+		{"zero amount", to, "GAS", "0"},
+		{"bad recipient", "nope", "GAS", "1"},
+		{"bad asset", to, "XYZ", "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := builder.BuildTransfer(context.Background(), account, tt.to, tt.asset, tt.amount); err == nil {
+				t.Error("BuildTransfer() should fail")
+			}
+		})
+	}
+}
+
+func TestBuildTransferInsufficientBalance(t *testing.T) {
+	client, _ := NewClient(Config{RPCURL: "http://example"})
+	var methods []string
+	client.httpClient.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		var req RPCRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		methods = append(methods, req.Method)
+		resp := RPCResponse{
+			JSONRPC: "2.0",
+			ID:      1,
+			Result:  json.RawMessage(`{"state":"HALT","gasconsumed":"0","stack":[{"type":"Integer","value":"5"}]}`),
+		}
+		payload, _ := json.Marshal(resp)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(bytes.NewReader(payload)),
+		}, nil
+	})
+
+	builder := NewTxBuilder(client, 894710606)
+	account, err := AccountFromPrivateKey("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatalf("AccountFromPrivateKey() error = %v", err)
+	}
+
+	_, _, err = builder.BuildTransfer(context.Background(), account, account.Address, "GAS", "10")
+	if err == nil || !strings.Contains(err.Error(), "insufficient balance") {
+		t.Fatalf("BuildTransfer() error = %v, want insufficient balance", err)
+	}
+	if len(methods) != 1 || methods[0] != "invokefunction" {
+		t.Errorf("RPC calls = %v, want only the balanceOf invocation", methods)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestFlagStoreIsEnabled(t *testing.T) {
+	store, err := NewFlagStore(map[string]FeatureFlag{
+		"on":       {Enabled: true},
+		"off":      {Enabled: false, Percentage: intPtr(100)},
+		"none":     {Enabled: true, Percentage: intPtr(0)},
+		"everyone": {Enabled: true, Percentage: intPtr(100)},
+		"half":     {Enabled: true, Percentage: intPtr(50)},
+	})
+	if err != nil {
+		t.Fatalf("NewFlagStore() error = %v", err)
+	}
+
+	ctx := WithAccountID(context.Background(), "acct-1")
+	tests := []struct {
+		flag string
+		want bool
+	}{
+		{"on", true},
+		{"off", false},
+		{"none", false},
+		{"everyone", true},
+		{"unknown", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			got, err := store.IsEnabled(ctx, tt.flag)
+			if err != nil {
+				t.Fatalf("IsEnabled() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsEnabled(%q) = %v, want %v", tt.flag, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("partial rollout without account", func(t *testing.T) {
+		if got, _ := store.IsEnabled(context.Background(), "half"); got {
+			t.Error("IsEnabled() should return false without an account ID")
+		}
+	})
+
+	t.Run("nil store", func(t *testing.T) {
+		var nilStore *FlagStore
+		if got, err := nilStore.IsEnabled(ctx, "on"); got || err != nil {
+			t.Errorf("IsEnabled() = %v, %v; want false, nil", got, err)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := store.IsEnabled(canceled, "on"); err == nil {
+			t.Error("IsEnabled() should return the context error")
+		}
+	})
+}
+
+func TestFlagStoreRolloutIsDeterministic(t *testing.T) {
+	store, err := NewFlagStore(map[string]FeatureFlag{
+		"half": {Enabled: true, Percentage: intPtr(50)},
+	})
+	if err != nil {
+		t.Fatalf("NewFlagStore() error = %v", err)
+	}
+
+	enabled := 0
+	for i := 0; i < 1000; i++ {
+		ctx := WithAccountID(context.Background(), fmt.Sprintf("acct-%d", i))
+		first, _ := store.IsEnabled(ctx, "half")
+		again, _ := store.IsEnabled(ctx, "half")
+		if first != again {
+			t.Fatalf("account acct-%d got %v then %v", i, first, again)
+		}
+		if first {
+			enabled++
+		}
+	}
+	if enabled < 400 || enabled > 600 {
+		t.Errorf("50%% rollout enabled %d of 1000 accounts", enabled)
+	}
+
+	// Raising the percentage keeps every account that was already enabled.
+	before := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		id := fmt.Sprintf("acct-%d", i)
+		before[id], _ = store.IsEnabled(WithAccountID(context.Background(), id), "half")
+	}
+	if err := store.Set(map[string]FeatureFlag{"half": {Enabled: true, Percentage: intPtr(75)}}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	for id, was := range before {
+		now, _ := store.IsEnabled(WithAccountID(context.Background(), id), "half")
+		if was && !now {
+			t.Errorf("account %s lost the flag when the rollout grew", id)
+		}
+	}
+}
+
+func TestFlagStoreSetRejectsInvalid(t *testing.T) {
+	store, err := NewFlagStore(map[string]FeatureFlag{"on": {Enabled: true}})
+	if err != nil {
+		t.Fatalf("NewFlagStore() error = %v", err)
+	}
+
+	for _, flags := range []map[string]FeatureFlag{
+		{"bad": {Enabled: true, Percentage: intPtr(101)}},
+		{"bad": {Enabled: true, Percentage: intPtr(-1)}},
+		{" ": {Enabled: true}},
+	} {
+		if err := store.Set(flags); err == nil {
+			t.Errorf("Set(%v) should fail", flags)
+		}
+	}
+	if got, _ := store.IsEnabled(context.Background(), "on"); !got {
+		t.Error("rejected Set() should keep the current flags")
+	}
+}
+
+func TestServiceSettingsFlagsYAML(t *testing.T) {
+	data := []byte(`
+enabled: true
+port: 8080
+flags:
+  new_aggregation:
+    enabled: true
+    percentage: 25
+  legacy_api:
+    enabled: false
+`)
+	var settings ServiceSettings
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	flag := settings.Flags["new_aggregation"]
+	if !flag.Enabled || flag.Percentage == nil || *flag.Percentage != 25 {
+		t.Errorf("new_aggregation = %+v, want enabled at 25%%", flag)
+	}
+	if _, err := NewFlagStore(settings.Flags); err != nil {
+		t.Errorf("NewFlagStore() error = %v", err)
+	}
+}
@@ -13,6 +13,9 @@ type ServiceSettings struct {
 
 	// Extra holds any additional service-specific configuration.
 	Extra map[string]any `yaml:"extra,omitempty" json:"extra,omitempty"`
+
+	// Flags holds the service's feature flags (see FlagStore).
+	Flags map[string]FeatureFlag `yaml:"flags,omitempty" json:"flags,omitempty"`
 }
 
 // ServicesConfig holds configuration for all services.
@@ -0,0 +1,128 @@
+// Package cron evaluates standard 5-field cron expressions
+// (minute hour day-of-month month day-of-week).
+//
+// Supported syntax: specific values (5), wildcards (*), ranges (1-5), lists
+// (1,3,5), steps (*/15) and ranges with steps (0-30/10).
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Next returns the first whole minute strictly after from that matches the
+// cron expression, searching up to a year ahead.
+func Next(cronExpr string, from time.Time) (time.Time, error) {
+	parts := strings.Fields(cronExpr)
+	if len(parts) != 5 {
+		return time.Time{}, fmt.Errorf("invalid cron expression: expected 5 fields")
+	}
+
+	// Parse each field into allowed values
+	minutes, err := parseField(parts[0], 0, 59)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid minute field: %w", err)
+	}
+	hours, err := parseField(parts[1], 0, 23)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid hour field: %w", err)
+	}
+	days, err := parseField(parts[2], 1, 31)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day field: %w", err)
+	}
+	months, err := parseField(parts[3], 1, 12)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month field: %w", err)
+	}
+	weekdays, err := parseField(parts[4], 0, 6)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid weekday field: %w", err)
+	}
+
+	// Find next matching time (search up to 1 year ahead)
+	candidate := time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), from.Minute(), 0, 0, from.Location())
+	candidate = candidate.Add(time.Minute) // Start from next minute
+
+	maxIterations := 525600 // 1 year in minutes
+	for i := 0; i < maxIterations; i++ {
+		if months[int(candidate.Month())] &&
+			days[candidate.Day()] &&
+			weekdays[int(candidate.Weekday())] &&
+			hours[candidate.Hour()] &&
+			minutes[candidate.Minute()] {
+			return candidate, nil
+		}
+		candidate = candidate.Add(time.Minute)
+	}
+
+	return time.Time{}, fmt.Errorf("no matching time found within 1 year")
+}
+
+// parseField parses a single cron field and returns a map of allowed values.
+func parseField(field string, minValue, maxValue int) (map[int]bool, error) {
+	allowed := make(map[int]bool)
+
+	// Handle wildcard
+	if field == "*" {
+		for i := minValue; i <= maxValue; i++ {
+			allowed[i] = true
+		}
+		return allowed, nil
+	}
+
+	// Handle step with wildcard (*/n)
+	if strings.HasPrefix(field, "*/") {
+		step, err := strconv.Atoi(field[2:])
+		if err != nil || step <= 0 {
+			return nil, fmt.Errorf("invalid step: %s", field)
+		}
+		for i := minValue; i <= maxValue; i += step {
+			allowed[i] = true
+		}
+		return allowed, nil
+	}
+
+	// Handle comma-separated list
+	for _, part := range strings.Split(field, ",") {
+		part = strings.TrimSpace(part)
+
+		// Handle range (n-m) or range with step (n-m/s)
+		if strings.Contains(part, "-") {
+			rangeParts := strings.Split(part, "/")
+			rangeStr := rangeParts[0]
+			step := 1
+			if len(rangeParts) == 2 {
+				var err error
+				step, err = strconv.Atoi(rangeParts[1])
+				if err != nil || step <= 0 {
+					return nil, fmt.Errorf("invalid step in range: %s", part)
+				}
+			}
+
+			bounds := strings.Split(rangeStr, "-")
+			if len(bounds) != 2 {
+				return nil, fmt.Errorf("invalid range: %s", part)
+			}
+			start, err1 := strconv.Atoi(bounds[0])
+			end, err2 := strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil || start < minValue || end > maxValue || start > end {
+				return nil, fmt.Errorf("invalid range values: %s", part)
+			}
+			for i := start; i <= end; i += step {
+				allowed[i] = true
+			}
+		} else {
+			// Single value
+			val, err := strconv.Atoi(part)
+			if err != nil || val < minValue || val > maxValue {
+				return nil, fmt.Errorf("invalid value: %s", part)
+			}
+			allowed[val] = true
+		}
+	}
+
+	return allowed, nil
+}
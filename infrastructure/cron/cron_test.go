@@ -0,0 +1,39 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	from := time.Date(2025, 1, 6, 10, 17, 30, 0, time.UTC) // Monday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 6, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 6, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2025, 1, 6, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1 2 *", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * 0,6", time.Date(2025, 1, 11, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := Next(tt.expr, from)
+		if err != nil {
+			t.Errorf("Next(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestNextInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := Next(expr, time.Now()); err == nil {
+			t.Errorf("Next(%q) should fail", expr)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Storage Backend Selection
+// =============================================================================
+
+// Storage backends selectable at startup.
+const (
+	BackendPostgres = "postgres" // Supabase PostgREST over PostgreSQL (default)
+	BackendMemory   = "memory"   // In-process store for local development; data is lost on restart
+	BackendSQLite   = "sqlite"   // Recognized but not compiled into this build
+)
+
+var (
+	// ErrUnknownBackend is returned for an unrecognized backend name.
+	ErrUnknownBackend = errors.New("unknown storage backend")
+	// ErrBackendUnavailable is returned for a recognized backend this build cannot open.
+	ErrBackendUnavailable = errors.New("storage backend not available")
+)
+
+// StoreConfig selects and configures a storage backend.
+type StoreConfig struct {
+	// Backend is postgres (default), memory or sqlite. "supabase" is accepted
+	// as an alias for postgres.
+	Backend string
+	// Postgres configures the Supabase client for the postgres backend.
+	Postgres Config
+}
+
+// Store is an opened storage backend.
+type Store struct {
+	// Backend is the normalized backend name.
+	Backend string
+	// Repository serves the shared store interfaces.
+	Repository RepositoryInterface
+	// Postgres is the concrete repository used to build service-specific
+	// supabase repositories. It is nil for non-postgres backends.
+	Postgres *Repository
+}
+
+// OpenStore opens the configured storage backend.
+func OpenStore(cfg StoreConfig) (*Store, error) {
+	backend := strings.ToLower(strings.TrimSpace(cfg.Backend))
+	switch backend {
+	case "", BackendPostgres, "supabase":
+		client, err := NewClient(cfg.Postgres)
+		if err != nil {
+			return nil, fmt.Errorf("open %s backend: %w", BackendPostgres, err)
+		}
+		repo := NewRepository(client)
+		return &Store{Backend: BackendPostgres, Repository: repo, Postgres: repo}, nil
+	case BackendMemory:
+		return &Store{Backend: BackendMemory, Repository: NewMockRepository()}, nil
+	case BackendSQLite:
+		return nil, fmt.Errorf("%w: %s (supported: %s, %s)", ErrBackendUnavailable, backend, BackendPostgres, BackendMemory)
+	default:
+		return nil, fmt.Errorf("%w: %q (supported: %s, %s)", ErrUnknownBackend, cfg.Backend, BackendPostgres, BackendMemory)
+	}
+}
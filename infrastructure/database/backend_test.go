@@ -0,0 +1,44 @@
+package database
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenStoreMemory(t *testing.T) {
+	store, err := OpenStore(StoreConfig{Backend: " Memory "})
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	if store.Backend != BackendMemory {
+		t.Errorf("Backend = %s, want %s", store.Backend, BackendMemory)
+	}
+	if _, ok := store.Repository.(*MockRepository); !ok {
+		t.Errorf("Repository = %T, want *MockRepository", store.Repository)
+	}
+	if store.Postgres != nil {
+		t.Error("Postgres should be nil for the memory backend")
+	}
+}
+
+func TestOpenStorePostgres(t *testing.T) {
+	store, err := OpenStore(StoreConfig{
+		Backend:  "supabase",
+		Postgres: Config{URL: "https://example.supabase.co", ServiceKey: "test-key"},
+	})
+	if err != nil {
+		t.Fatalf("OpenStore() error = %v", err)
+	}
+	if store.Backend != BackendPostgres || store.Postgres == nil {
+		t.Errorf("store = %+v, want postgres backend with concrete repository", store)
+	}
+}
+
+func TestOpenStoreRejectsUnknownAndUnavailable(t *testing.T) {
+	if _, err := OpenStore(StoreConfig{Backend: "mongo"}); !errors.Is(err, ErrUnknownBackend) {
+		t.Errorf("OpenStore(mongo) error = %v, want ErrUnknownBackend", err)
+	}
+	if _, err := OpenStore(StoreConfig{Backend: BackendSQLite}); !errors.Is(err, ErrBackendUnavailable) {
+		t.Errorf("OpenStore(sqlite) error = %v, want ErrBackendUnavailable", err)
+	}
+}
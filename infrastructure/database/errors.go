@@ -59,6 +59,27 @@ func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
+// VersionConflictError is returned when an optimistic-concurrency update finds
+// that the stored record no longer has the expected version.
+type VersionConflictError struct {
+	Entity          string
+	ID              string
+	ExpectedVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s with id '%s' was modified concurrently (expected version %d)", e.Entity, e.ID, e.ExpectedVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// NewVersionConflictError creates a new VersionConflictError.
+func NewVersionConflictError(entity, id string, expectedVersion int) error {
+	return &VersionConflictError{Entity: entity, ID: id, ExpectedVersion: expectedVersion}
+}
+
 // IsAlreadyExists checks if an error is an already exists error.
 func IsAlreadyExists(err error) bool {
 	return errors.Is(err, ErrAlreadyExists)
@@ -69,11 +90,45 @@ func IsUnauthorized(err error) bool {
 	return errors.Is(err, ErrUnauthorized)
 }
 
+// IsConflict checks if an error is a conflict error.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
 // IsInvalidInput checks if an error is an invalid input error.
 func IsInvalidInput(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
 
+// pgUniqueViolation is the Postgres error code for a unique constraint violation.
+const pgUniqueViolation = "23505"
+
+// APIError is returned by the Supabase client when PostgREST answers with an
+// error status. Code is the Postgres/PostgREST error code from the response
+// body, if it had one.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("supabase API error %d: %s", e.StatusCode, e.Message)
+}
+
+// IsUniqueViolation checks if an error is a unique constraint violation
+// reported by the database. A 409 without an error code is treated as one.
+func IsUniqueViolation(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code != "" {
+		return apiErr.Code == pgUniqueViolation
+	}
+	return apiErr.StatusCode == 409
+}
+
 // =============================================================================
 // Input Validation
 // =============================================================================
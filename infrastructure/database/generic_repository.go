@@ -48,6 +48,35 @@ func GenericCreate[T any](base *Repository, ctx context.Context, table string, m
 	return nil
 }
 
+// GenericBulkCreate inserts all models in a single request and returns the created rows.
+// Every model is validated before anything is written, so a validation failure
+// rejects the whole batch. The rows are sent as one PostgREST bulk insert, which
+// executes as a single statement: either all rows are created or none are.
+func GenericBulkCreate[T any](base *Repository, ctx context.Context, table string, models []T, validate func(*T) error) ([]T, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("%w: %s batch cannot be empty", ErrInvalidInput, table)
+	}
+
+	if validate != nil {
+		for i := range models {
+			if err := validate(&models[i]); err != nil {
+				return nil, fmt.Errorf("%s: item %d: %w", table, i, err)
+			}
+		}
+	}
+
+	data, err := base.Request(ctx, "POST", table, models, "")
+	if err != nil {
+		return nil, fmt.Errorf("bulk create %s: %w", table, err)
+	}
+
+	var rows []T
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", table, err)
+	}
+	return rows, nil
+}
+
 // GenericUpdate updates an existing record by a key field.
 func GenericUpdate[T any](base *Repository, ctx context.Context, table, keyField, keyValue string, model *T) error {
 	if model == nil {
@@ -65,6 +94,50 @@ func GenericUpdate[T any](base *Repository, ctx context.Context, table, keyField
 	return nil
 }
 
+// Versioned is implemented by models that support optimistic concurrency.
+// The version is stored in a "version" column and incremented on every update.
+type Versioned interface {
+	GetVersion() int
+	SetVersion(version int)
+}
+
+// GenericUpdateVersioned updates a record only if its stored version matches the
+// model's current version. On success the model's version is incremented to the
+// newly stored value. If no row matches (stale version or missing record) a
+// VersionConflictError is returned and the model is left unchanged.
+func GenericUpdateVersioned[T any, PT interface {
+	*T
+	Versioned
+}](base *Repository, ctx context.Context, table, keyField, keyValue string, model PT) error {
+	if model == nil {
+		return fmt.Errorf("%s: model cannot be nil", table)
+	}
+	if keyValue == "" {
+		return fmt.Errorf("%s: %s cannot be empty", table, keyField)
+	}
+
+	expected := model.GetVersion()
+	model.SetVersion(expected + 1)
+
+	query := fmt.Sprintf("%s=eq.%s&version=eq.%d", keyField, url.QueryEscape(keyValue), expected)
+	data, err := base.Request(ctx, "PATCH", table, model, query)
+	if err != nil {
+		model.SetVersion(expected)
+		return fmt.Errorf("update %s: %w", table, err)
+	}
+
+	var rows []T
+	if err := json.Unmarshal(data, &rows); err != nil {
+		model.SetVersion(expected)
+		return fmt.Errorf("unmarshal %s: %w", table, err)
+	}
+	if len(rows) == 0 {
+		model.SetVersion(expected)
+		return NewVersionConflictError(table, keyValue, expected)
+	}
+	return nil
+}
+
 // GenericGetByField fetches a single record by a field value.
 // Returns NotFoundError if no records match.
 func GenericGetByField[T any](base *Repository, ctx context.Context, table, field, value string) (*T, error) {
@@ -208,6 +281,12 @@ func (q *QueryBuilder) IsNull(field string) *QueryBuilder {
 	return q
 }
 
+// NotNull adds a non-null check: field=not.is.null
+func (q *QueryBuilder) NotNull(field string) *QueryBuilder {
+	q.filters = append(q.filters, fmt.Sprintf("%s=not.is.null", field))
+	return q
+}
+
 // IsFalse adds a boolean false check: field=eq.false
 func (q *QueryBuilder) IsFalse(field string) *QueryBuilder {
 	q.filters = append(q.filters, fmt.Sprintf("%s=eq.false", field))
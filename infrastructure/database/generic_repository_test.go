@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -55,6 +56,14 @@ func TestQueryBuilderIsNull(t *testing.T) {
 	}
 }
 
+func TestQueryBuilderNotNull(t *testing.T) {
+	q := NewQuery().NotNull("tx_hash")
+	result := q.Build()
+	if result != "tx_hash=not.is.null" {
+		t.Errorf("Build() = %q, want %q", result, "tx_hash=not.is.null")
+	}
+}
+
 func TestQueryBuilderIsFalse(t *testing.T) {
 	q := NewQuery().IsFalse("is_active")
 	result := q.Build()
@@ -272,6 +281,129 @@ func TestGenericCreateRequestError(t *testing.T) {
 	}
 }
 
+func TestGenericBulkCreateSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Method = %s, want POST", r.Method)
+		}
+		var body []testModel
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body) != 2 {
+			t.Errorf("batch size = %d, want 2", len(body))
+		}
+		for i := range body {
+			body[i].ID = "id-" + body[i].Name
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	})
+	defer cleanup()
+
+	models := []testModel{{Name: "a"}, {Name: "b"}}
+	rows, err := GenericBulkCreate(repo, context.Background(), "test_table", models, nil)
+	if err != nil {
+		t.Fatalf("GenericBulkCreate() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].ID != "id-a" || rows[1].ID != "id-b" {
+		t.Errorf("rows = %+v", rows)
+	}
+}
+
+func TestGenericBulkCreateValidationRejectsBatch(t *testing.T) {
+	var called bool
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	models := []testModel{{Name: "ok"}, {Name: ""}}
+	_, err := GenericBulkCreate(repo, context.Background(), "test_table", models, func(m *testModel) error {
+		if m.Name == "" {
+			return ErrInvalidInput
+		}
+		return nil
+	})
+	if !IsInvalidInput(err) {
+		t.Fatalf("GenericBulkCreate() error = %v, want invalid input", err)
+	}
+	if called {
+		t.Error("no request should be sent when validation fails")
+	}
+}
+
+func TestGenericBulkCreateEmpty(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	if _, err := GenericBulkCreate[testModel](repo, context.Background(), "test_table", nil, nil); err == nil {
+		t.Error("GenericBulkCreate() should reject an empty batch")
+	}
+}
+
+type versionedTestModel struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+func (m *versionedTestModel) GetVersion() int        { return m.Version }
+func (m *versionedTestModel) SetVersion(version int) { m.Version = version }
+
+func TestGenericUpdateVersionedSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Method = %s, want PATCH", r.Method)
+		}
+		if !strings.Contains(r.URL.RawQuery, "version=eq.3") {
+			t.Errorf("query = %q, should filter on expected version", r.URL.RawQuery)
+		}
+		var body versionedTestModel
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Version != 4 {
+			t.Errorf("body version = %d, want 4", body.Version)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]versionedTestModel{body})
+	})
+	defer cleanup()
+
+	model := &versionedTestModel{ID: "item-1", Name: "updated", Version: 3}
+	if err := GenericUpdateVersioned(repo, context.Background(), "test_table", "id", model.ID, model); err != nil {
+		t.Fatalf("GenericUpdateVersioned() error = %v", err)
+	}
+	if model.Version != 4 {
+		t.Errorf("model.Version = %d, want 4", model.Version)
+	}
+}
+
+func TestGenericUpdateVersionedConflict(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	})
+	defer cleanup()
+
+	model := &versionedTestModel{ID: "item-1", Name: "stale", Version: 2}
+	err := GenericUpdateVersioned(repo, context.Background(), "test_table", "id", model.ID, model)
+	if !IsConflict(err) {
+		t.Fatalf("GenericUpdateVersioned() error = %v, want conflict", err)
+	}
+	var conflict *VersionConflictError
+	if !errors.As(err, &conflict) || conflict.ExpectedVersion != 2 {
+		t.Errorf("error = %#v, want VersionConflictError with expected version 2", err)
+	}
+	if model.Version != 2 {
+		t.Errorf("model.Version = %d, want unchanged 2", model.Version)
+	}
+}
+
 func TestGenericUpdateNilModel(t *testing.T) {
 	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
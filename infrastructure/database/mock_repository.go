@@ -14,6 +14,7 @@ type MockRepository struct {
 	users               map[string]*User
 	serviceRequests     map[string]*ServiceRequest
 	priceFeeds          map[string]*PriceFeed
+	feedSignerSets      map[string]*FeedSignerSet
 	gasBankAccounts     map[string]*GasBankAccount
 	gasBankTransactions map[string]*GasBankTransaction
 	depositRequests     map[string]*DepositRequest
@@ -28,6 +29,7 @@ func NewMockRepository() *MockRepository {
 		users:               make(map[string]*User),
 		serviceRequests:     make(map[string]*ServiceRequest),
 		priceFeeds:          make(map[string]*PriceFeed),
+		feedSignerSets:      make(map[string]*FeedSignerSet),
 		gasBankAccounts:     make(map[string]*GasBankAccount),
 		gasBankTransactions: make(map[string]*GasBankTransaction),
 		depositRequests:     make(map[string]*DepositRequest),
@@ -51,6 +53,7 @@ func (m *MockRepository) Reset() {
 	m.users = make(map[string]*User)
 	m.serviceRequests = make(map[string]*ServiceRequest)
 	m.priceFeeds = make(map[string]*PriceFeed)
+	m.feedSignerSets = make(map[string]*FeedSignerSet)
 	m.gasBankAccounts = make(map[string]*GasBankAccount)
 	m.gasBankTransactions = make(map[string]*GasBankTransaction)
 	m.depositRequests = make(map[string]*DepositRequest)
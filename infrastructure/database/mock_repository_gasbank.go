@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +26,37 @@ func (m *MockRepository) GetGasBankAccount(ctx context.Context, userID string) (
 	return nil, NewNotFoundError("gasbank_account", userID)
 }
 
+func (m *MockRepository) GetGasBankAccountByID(ctx context.Context, accountID string) (*GasBankAccount, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if account, ok := m.gasBankAccounts[accountID]; ok {
+		return account, nil
+	}
+	return nil, NewNotFoundError("gasbank_account", accountID)
+}
+
+func (m *MockRepository) ListGasBankAccounts(ctx context.Context, afterID string, limit int) ([]GasBankAccount, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []GasBankAccount
+	for _, account := range m.gasBankAccounts {
+		if account.ID > afterID {
+			result = append(result, *account)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
 func (m *MockRepository) CreateGasBankAccount(ctx context.Context, account *GasBankAccount) error {
 	if err := m.checkError(); err != nil {
 		return err
@@ -75,6 +107,40 @@ func (m *MockRepository) UpdateGasBankBalance(ctx context.Context, userID string
 	return NewNotFoundError("gasbank_account", userID)
 }
 
+func (m *MockRepository) UpdateGasBankSpendingLimits(ctx context.Context, userID string, maxPerTx, maxPerDay int64) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, account := range m.gasBankAccounts {
+		if account.UserID == userID {
+			account.MaxPerTx = maxPerTx
+			account.MaxPerDay = maxPerDay
+			account.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return NewNotFoundError("gasbank_account", userID)
+}
+
+func (m *MockRepository) UpdateGasBankDailySpend(ctx context.Context, userID, day string, spent int64) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, account := range m.gasBankAccounts {
+		if account.UserID == userID {
+			account.DailySpent = spent
+			account.DailySpentDate = day
+			account.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return NewNotFoundError("gasbank_account", userID)
+}
+
 func (m *MockRepository) CreateGasBankTransaction(ctx context.Context, tx *GasBankTransaction) error {
 	if err := m.checkError(); err != nil {
 		return err
@@ -109,6 +175,82 @@ func (m *MockRepository) GetGasBankTransactions(ctx context.Context, accountID s
 	return result, nil
 }
 
+func (m *MockRepository) GetGasBankTransactionsAfter(ctx context.Context, accountID string, afterCreatedAt time.Time, afterID string, limit int) ([]GasBankTransaction, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []GasBankTransaction
+	for _, tx := range m.gasBankTransactions {
+		if tx.AccountID == accountID && afterCursor(tx.CreatedAt, tx.ID, afterCreatedAt, afterID) {
+			result = append(result, *tx)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return cursorLess(result[i].CreatedAt, result[i].ID, result[j].CreatedAt, result[j].ID)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// afterCursor reports whether a row sorts after the (afterCreatedAt, afterID)
+// cursor; a zero afterCreatedAt matches every row.
+func afterCursor(createdAt time.Time, id string, afterCreatedAt time.Time, afterID string) bool {
+	return afterCreatedAt.IsZero() || cursorLess(afterCreatedAt, afterID, createdAt, id)
+}
+
+func cursorLess(aCreatedAt time.Time, aID string, bCreatedAt time.Time, bID string) bool {
+	if !aCreatedAt.Equal(bCreatedAt) {
+		return aCreatedAt.Before(bCreatedAt)
+	}
+	return aID < bID
+}
+
+func (m *MockRepository) GetPendingGasBankTransactions(ctx context.Context, afterCreatedAt time.Time, afterID string, limit int) ([]GasBankTransaction, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []GasBankTransaction
+	for _, tx := range m.gasBankTransactions {
+		if tx.Status != "pending" || tx.TxHash == "" {
+			continue
+		}
+		if !afterCreatedAt.IsZero() && (tx.CreatedAt.Before(afterCreatedAt) || (tx.CreatedAt.Equal(afterCreatedAt) && tx.ID <= afterID)) {
+			continue
+		}
+		result = append(result, *tx)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].ID < result[j].ID
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockRepository) UpdateGasBankTransactionStatus(ctx context.Context, txID, status, reason string) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if tx, ok := m.gasBankTransactions[txID]; ok {
+		tx.Status = status
+		tx.Error = reason
+		return nil
+	}
+	return NewNotFoundError("gasbank_transaction", txID)
+}
+
 // =============================================================================
 // Deposit Operations (part of GasBankRepository)
 // =============================================================================
@@ -147,6 +289,27 @@ func (m *MockRepository) GetDepositRequests(ctx context.Context, userID string,
 	return result, nil
 }
 
+func (m *MockRepository) GetDepositRequestsAfter(ctx context.Context, userID string, afterCreatedAt time.Time, afterID string, limit int) ([]DepositRequest, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []DepositRequest
+	for _, deposit := range m.depositRequests {
+		if deposit.UserID == userID && afterCursor(deposit.CreatedAt, deposit.ID, afterCreatedAt, afterID) {
+			result = append(result, *deposit)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return cursorLess(result[i].CreatedAt, result[i].ID, result[j].CreatedAt, result[j].ID)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
 func (m *MockRepository) GetDepositByTxHash(ctx context.Context, txHash string) (*DepositRequest, error) {
 	if err := m.checkError(); err != nil {
 		return nil, err
@@ -241,6 +241,37 @@ func TestMockRepository_PriceFeedOperations(t *testing.T) {
 	}
 }
 
+func TestMockRepository_GetPriceHistory(t *testing.T) {
+	repo := NewMockRepository()
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		repo.CreatePriceFeed(ctx, &PriceFeed{
+			FeedID:    "BTC-USD",
+			Price:     int64(100 + i),
+			Decimals:  8,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	history, err := repo.GetPriceHistory(ctx, "BTC-USD", base, base.Add(4*time.Minute), 2)
+	if err != nil {
+		t.Fatalf("GetPriceHistory() error = %v", err)
+	}
+	if len(history) != 2 || history[0].Price != 103 || history[1].Price != 102 {
+		t.Errorf("GetPriceHistory() = %+v, want prices 103, 102", history)
+	}
+
+	empty, err := repo.GetPriceHistory(ctx, "BTC-USD", base.Add(-2*time.Hour), base.Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("GetPriceHistory() error = %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("GetPriceHistory() = %v, want empty slice", empty)
+	}
+}
+
 func TestMockRepository_ServiceRequestOperations(t *testing.T) {
 	repo := NewMockRepository()
 	ctx := context.Background()
@@ -296,3 +327,23 @@ func BenchmarkMockRepository_GetUser(b *testing.B) {
 		repo.GetUser(ctx, user.ID)
 	}
 }
+
+func TestMockRepository_SaveFeedSignerSetVersioning(t *testing.T) {
+	repo := NewMockRepository()
+	ctx := context.Background()
+
+	first := &FeedSignerSet{FeedID: "BTC-USD", Signers: []string{"02aa"}, Threshold: 1}
+	if err := repo.SaveFeedSignerSet(ctx, first); err != nil || first.Version != 1 {
+		t.Fatalf("create: err = %v, version = %d; want version 1", err, first.Version)
+	}
+	stale := *first
+
+	first.Signers = append(first.Signers, "03bb")
+	first.Threshold = 2
+	if err := repo.SaveFeedSignerSet(ctx, first); err != nil || first.Version != 2 {
+		t.Fatalf("update: err = %v, version = %d; want version 2", err, first.Version)
+	}
+	if err := repo.SaveFeedSignerSet(ctx, &stale); !IsConflict(err) {
+		t.Fatalf("stale update: err = %v, want a conflict", err)
+	}
+}
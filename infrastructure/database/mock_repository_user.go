@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -76,12 +78,46 @@ func (m *MockRepository) UpdateUserEmail(ctx context.Context, userID, email stri
 	defer m.mu.Unlock()
 	if user, ok := m.users[userID]; ok {
 		user.Email = email
+		user.EmailVerified = false
 		user.UpdatedAt = time.Now()
 		return nil
 	}
 	return NewNotFoundError("user", userID)
 }
 
+func (m *MockRepository) UpdateUserEmailVerified(ctx context.Context, userID string, verified bool) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if user, ok := m.users[userID]; ok {
+		user.EmailVerified = verified
+		user.UpdatedAt = time.Now()
+		return nil
+	}
+	return NewNotFoundError("user", userID)
+}
+
+func (m *MockRepository) UpdateUserSuspension(ctx context.Context, user *User) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("%w: user cannot be nil", ErrInvalidInput)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.users[user.ID]; ok {
+		existing.SuspendedAt = user.SuspendedAt
+		existing.SuspendedUntil = user.SuspendedUntil
+		existing.SuspensionReason = user.SuspensionReason
+		existing.UpdatedAt = time.Now()
+		return nil
+	}
+	return NewNotFoundError("user", user.ID)
+}
+
 func (m *MockRepository) UpdateUserNonce(ctx context.Context, userID, nonce string) error {
 	if err := m.checkError(); err != nil {
 		return err
@@ -166,6 +202,32 @@ func (m *MockRepository) GetLatestPrice(ctx context.Context, feedID string) (*Pr
 	return latest, nil
 }
 
+func (m *MockRepository) GetPriceHistory(ctx context.Context, feedID string, from, to time.Time, limit int) ([]PriceFeed, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidInput)
+	}
+	limit = ValidateLimit(limit, 100, 1000)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	feeds := []PriceFeed{}
+	for _, feed := range m.priceFeeds {
+		if feed.FeedID == feedID && !feed.Timestamp.Before(from) && feed.Timestamp.Before(to) {
+			feeds = append(feeds, *feed)
+		}
+	}
+	sort.Slice(feeds, func(i, j int) bool {
+		return feeds[i].Timestamp.After(feeds[j].Timestamp)
+	})
+	if len(feeds) > limit {
+		feeds = feeds[:limit]
+	}
+	return feeds, nil
+}
+
 func (m *MockRepository) CreatePriceFeed(ctx context.Context, feed *PriceFeed) error {
 	if err := m.checkError(); err != nil {
 		return err
@@ -181,3 +243,74 @@ func (m *MockRepository) CreatePriceFeed(ctx context.Context, feed *PriceFeed) e
 	m.priceFeeds[feed.ID] = feed
 	return nil
 }
+
+func (m *MockRepository) CreatePriceFeeds(ctx context.Context, feeds []PriceFeed) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	if len(feeds) == 0 {
+		return fmt.Errorf("%w: price_feeds batch cannot be empty", ErrInvalidInput)
+	}
+	for i := range feeds {
+		if feeds[i].FeedID == "" {
+			return fmt.Errorf("price_feeds: item %d: %w: feed_id cannot be empty", i, ErrInvalidInput)
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range feeds {
+		feed := feeds[i]
+		if feed.ID == "" {
+			feed.ID = uuid.New().String()
+		}
+		if feed.Timestamp.IsZero() {
+			feed.Timestamp = time.Now()
+		}
+		m.priceFeeds[feed.ID] = &feed
+	}
+	return nil
+}
+
+func (m *MockRepository) ListFeedSignerSets(ctx context.Context) ([]FeedSignerSet, error) {
+	if err := m.checkError(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sets := []FeedSignerSet{}
+	for _, set := range m.feedSignerSets {
+		copied := *set
+		copied.Signers = append([]string(nil), set.Signers...)
+		sets = append(sets, copied)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].FeedID < sets[j].FeedID
+	})
+	return sets, nil
+}
+
+func (m *MockRepository) SaveFeedSignerSet(ctx context.Context, set *FeedSignerSet) error {
+	if err := m.checkError(); err != nil {
+		return err
+	}
+	if set == nil || set.FeedID == "" {
+		return fmt.Errorf("%w: feed_id cannot be empty", ErrInvalidInput)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.feedSignerSets[set.FeedID]; ok {
+		if stored.Version != set.Version {
+			return NewVersionConflictError("price_feed_signer_sets", set.FeedID, set.Version)
+		}
+		set.Version++
+	} else if set.Version != 0 {
+		return NewVersionConflictError("price_feed_signer_sets", set.FeedID, set.Version)
+	} else {
+		set.Version = 1
+	}
+	set.UpdatedAt = time.Now()
+	copied := *set
+	copied.Signers = append([]string(nil), set.Signers...)
+	m.feedSignerSets[set.FeedID] = &copied
+	return nil
+}
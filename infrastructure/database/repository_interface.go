@@ -3,6 +3,7 @@ package database
 
 import (
 	"context"
+	"time"
 )
 
 // =============================================================================
@@ -16,6 +17,8 @@ type UserRepository interface {
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	CreateUser(ctx context.Context, user *User) error
 	UpdateUserEmail(ctx context.Context, userID, email string) error
+	UpdateUserEmailVerified(ctx context.Context, userID string, verified bool) error
+	UpdateUserSuspension(ctx context.Context, user *User) error
 	UpdateUserNonce(ctx context.Context, userID, nonce string) error
 }
 
@@ -29,19 +32,31 @@ type ServiceRequestRepository interface {
 // PriceFeedRepository defines price feed data access methods.
 type PriceFeedRepository interface {
 	GetLatestPrice(ctx context.Context, feedID string) (*PriceFeed, error)
+	GetPriceHistory(ctx context.Context, feedID string, from, to time.Time, limit int) ([]PriceFeed, error)
 	CreatePriceFeed(ctx context.Context, feed *PriceFeed) error
+	CreatePriceFeeds(ctx context.Context, feeds []PriceFeed) error
+	ListFeedSignerSets(ctx context.Context) ([]FeedSignerSet, error)
+	SaveFeedSignerSet(ctx context.Context, set *FeedSignerSet) error
 }
 
 // GasBankRepository defines gas bank data access methods.
 type GasBankRepository interface {
 	GetGasBankAccount(ctx context.Context, userID string) (*GasBankAccount, error)
+	GetGasBankAccountByID(ctx context.Context, accountID string) (*GasBankAccount, error)
 	CreateGasBankAccount(ctx context.Context, account *GasBankAccount) error
 	GetOrCreateGasBankAccount(ctx context.Context, userID string) (*GasBankAccount, error)
+	ListGasBankAccounts(ctx context.Context, afterID string, limit int) ([]GasBankAccount, error)
 	UpdateGasBankBalance(ctx context.Context, userID string, balance, reserved int64) error
+	UpdateGasBankSpendingLimits(ctx context.Context, userID string, maxPerTx, maxPerDay int64) error
+	UpdateGasBankDailySpend(ctx context.Context, userID, day string, spent int64) error
 	CreateGasBankTransaction(ctx context.Context, tx *GasBankTransaction) error
 	GetGasBankTransactions(ctx context.Context, accountID string, limit int) ([]GasBankTransaction, error)
+	GetGasBankTransactionsAfter(ctx context.Context, accountID string, afterCreatedAt time.Time, afterID string, limit int) ([]GasBankTransaction, error)
+	GetPendingGasBankTransactions(ctx context.Context, afterCreatedAt time.Time, afterID string, limit int) ([]GasBankTransaction, error)
+	UpdateGasBankTransactionStatus(ctx context.Context, txID, status, reason string) error
 	CreateDepositRequest(ctx context.Context, deposit *DepositRequest) error
 	GetDepositRequests(ctx context.Context, userID string, limit int) ([]DepositRequest, error)
+	GetDepositRequestsAfter(ctx context.Context, userID string, afterCreatedAt time.Time, afterID string, limit int) ([]DepositRequest, error)
 	GetDepositByTxHash(ctx context.Context, txHash string) (*DepositRequest, error)
 	UpdateDepositStatus(ctx context.Context, depositID, status string, confirmations int) error
 	GetPendingDeposits(ctx context.Context, limit int) ([]DepositRequest, error)
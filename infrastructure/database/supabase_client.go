@@ -185,10 +185,14 @@ func (c *Client) request(ctx context.Context, method, table string, body interfa
 			return nil, fmt.Errorf("read error response: %w", readErr)
 		}
 		msg := strings.TrimSpace(string(respBody))
+		var body struct {
+			Code string `json:"code"`
+		}
+		_ = json.Unmarshal(respBody, &body)
 		if truncated {
 			msg += "...(truncated)"
 		}
-		return nil, fmt.Errorf("supabase API error %d: %s", resp.StatusCode, msg)
+		return nil, &APIError{StatusCode: resp.StatusCode, Code: body.Code, Message: msg}
 	}
 
 	respBody, err := httputil.ReadAllStrict(resp.Body, maxSupabaseResponseBytes)
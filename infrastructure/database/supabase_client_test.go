@@ -1,6 +1,12 @@
 package database
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
 
 func TestNewClient_AllowsHTTPInNonStrictMode(t *testing.T) {
 	t.Setenv("MARBLE_ENV", "development")
@@ -49,3 +55,45 @@ func TestNewClient_StrictModeRejectsUserInfo(t *testing.T) {
 		t.Fatal("expected error for SUPABASE_URL with user info, got nil")
 	}
 }
+
+func TestRequestReturnsTypedAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantCode   string
+		wantUnique bool
+	}{
+		{"unique violation", http.StatusConflict, `{"code":"23505","message":"duplicate key value"}`, "23505", true},
+		{"foreign key violation", http.StatusConflict, `{"code":"23503","message":"violates foreign key"}`, "23503", false},
+		{"bare conflict", http.StatusConflict, ``, "", true},
+		{"server error mentioning 23505", http.StatusInternalServerError, `{"message":"row 23505 failed"}`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+			defer cleanup()
+
+			_, err := repo.Request(context.Background(), "POST", "items", map[string]string{"id": "1"}, "")
+			wrapped := fmt.Errorf("create items: %w", err)
+
+			var apiErr *APIError
+			if !errors.As(wrapped, &apiErr) {
+				t.Fatalf("Request() error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Code != tt.wantCode {
+				t.Errorf("APIError = %+v, want status %d code %q", apiErr, tt.status, tt.wantCode)
+			}
+			if got := IsUniqueViolation(wrapped); got != tt.wantUnique {
+				t.Errorf("IsUniqueViolation() = %v, want %v", got, tt.wantUnique)
+			}
+		})
+	}
+
+	if IsUniqueViolation(errors.New("supabase API error 409: 23505")) {
+		t.Error("IsUniqueViolation() matched an untyped error by its message")
+	}
+}
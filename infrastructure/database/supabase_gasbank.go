@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -32,6 +33,51 @@ func (r *Repository) GetGasBankAccount(ctx context.Context, userID string) (*Gas
 	return &accounts[0], nil
 }
 
+// GetGasBankAccountByID retrieves a gas bank account by its ID.
+func (r *Repository) GetGasBankAccountByID(ctx context.Context, accountID string) (*GasBankAccount, error) {
+	if err := ValidateID(accountID); err != nil {
+		return nil, err
+	}
+
+	data, err := r.client.request(ctx, "GET", "gasbank_accounts", nil, "id=eq."+accountID+"&limit=1")
+	if err != nil {
+		return nil, fmt.Errorf("%w: get gasbank account: %v", ErrDatabaseError, err)
+	}
+
+	var accounts []GasBankAccount
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal gasbank accounts: %v", ErrDatabaseError, err)
+	}
+	if len(accounts) == 0 {
+		return nil, NewNotFoundError("gasbank_account", accountID)
+	}
+	return &accounts[0], nil
+}
+
+// ListGasBankAccounts returns accounts ordered by ID, starting after afterID
+// (empty starts from the first).
+func (r *Repository) ListGasBankAccounts(ctx context.Context, afterID string, limit int) ([]GasBankAccount, error) {
+	limit = ValidateLimit(limit, 100, 1000)
+
+	query := fmt.Sprintf("order=id.asc&limit=%d", limit)
+	if afterID != "" {
+		if err := ValidateID(afterID); err != nil {
+			return nil, err
+		}
+		query = "id=gt." + url.QueryEscape(afterID) + "&" + query
+	}
+	data, err := r.client.request(ctx, "GET", "gasbank_accounts", nil, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: list gasbank accounts: %v", ErrDatabaseError, err)
+	}
+
+	var accounts []GasBankAccount
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal gasbank accounts: %v", ErrDatabaseError, err)
+	}
+	return accounts, nil
+}
+
 // CreateGasBankAccount creates a new gas bank account.
 func (r *Repository) CreateGasBankAccount(ctx context.Context, account *GasBankAccount) error {
 	if account == nil {
@@ -130,6 +176,53 @@ func (r *Repository) UpdateGasBankBalance(ctx context.Context, userID string, ba
 	return nil
 }
 
+// UpdateGasBankSpendingLimits sets an account's per-transaction and daily
+// spending limits. Zero disables a limit.
+func (r *Repository) UpdateGasBankSpendingLimits(ctx context.Context, userID string, maxPerTx, maxPerDay int64) error {
+	if err := ValidateUserID(userID); err != nil {
+		return err
+	}
+	if maxPerTx < 0 || maxPerDay < 0 {
+		return fmt.Errorf("%w: spending limits cannot be negative", ErrInvalidInput)
+	}
+
+	update := map[string]interface{}{
+		"max_per_tx":  maxPerTx,
+		"max_per_day": maxPerDay,
+		"updated_at":  time.Now(),
+	}
+	_, err := r.client.request(ctx, "PATCH", "gasbank_accounts", update, "user_id=eq."+userID)
+	if err != nil {
+		return fmt.Errorf("%w: update gasbank spending limits: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// UpdateGasBankDailySpend records the amount an account has spent on day
+// (UTC, YYYY-MM-DD).
+func (r *Repository) UpdateGasBankDailySpend(ctx context.Context, userID, day string, spent int64) error {
+	if err := ValidateUserID(userID); err != nil {
+		return err
+	}
+	if _, err := time.Parse(time.DateOnly, day); err != nil {
+		return fmt.Errorf("%w: invalid day %q", ErrInvalidInput, day)
+	}
+	if spent < 0 {
+		return fmt.Errorf("%w: daily spend cannot be negative", ErrInvalidInput)
+	}
+
+	update := map[string]interface{}{
+		"daily_spent":      spent,
+		"daily_spent_date": day,
+		"updated_at":       time.Now(),
+	}
+	_, err := r.client.request(ctx, "PATCH", "gasbank_accounts", update, "user_id=eq."+userID)
+	if err != nil {
+		return fmt.Errorf("%w: update gasbank daily spend: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
 // =============================================================================
 // Gas Bank Transaction Operations
 // =============================================================================
@@ -170,6 +263,82 @@ func (r *Repository) GetGasBankTransactions(ctx context.Context, accountID strin
 	return txs, nil
 }
 
+// GetGasBankTransactionsAfter retrieves an account's transactions ordered by
+// (created_at, id), starting after the (afterCreatedAt, afterID) cursor; a
+// zero afterCreatedAt starts from the oldest.
+func (r *Repository) GetGasBankTransactionsAfter(ctx context.Context, accountID string, afterCreatedAt time.Time, afterID string, limit int) ([]GasBankTransaction, error) {
+	if err := ValidateID(accountID); err != nil {
+		return nil, err
+	}
+	limit = ValidateLimit(limit, 100, 1000)
+
+	query := "account_id=eq." + accountID + cursorFilter(afterCreatedAt, afterID)
+	query += fmt.Sprintf("&order=created_at.asc,id.asc&limit=%d", limit)
+	data, err := r.client.request(ctx, "GET", "gasbank_transactions", nil, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: get gasbank transactions: %v", ErrDatabaseError, err)
+	}
+
+	var txs []GasBankTransaction
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal gasbank transactions: %v", ErrDatabaseError, err)
+	}
+	return txs, nil
+}
+
+// cursorFilter builds the PostgREST filter for rows after a (created_at, id)
+// cursor. A zero afterCreatedAt matches every row.
+func cursorFilter(afterCreatedAt time.Time, afterID string) string {
+	if afterCreatedAt.IsZero() {
+		return ""
+	}
+	ts := url.QueryEscape(afterCreatedAt.UTC().Format(time.RFC3339Nano))
+	return fmt.Sprintf("&or=(created_at.gt.%s,and(created_at.eq.%s,id.gt.%s))", ts, ts, url.QueryEscape(afterID))
+}
+
+// GetPendingGasBankTransactions retrieves pending transactions that reference an
+// on-chain transaction, ordered by (created_at, id). Only transactions after
+// the (afterCreatedAt, afterID) cursor are returned; a zero afterCreatedAt
+// starts from the oldest.
+func (r *Repository) GetPendingGasBankTransactions(ctx context.Context, afterCreatedAt time.Time, afterID string, limit int) ([]GasBankTransaction, error) {
+	limit = ValidateLimit(limit, 100, 1000)
+
+	query := "status=eq.pending&tx_hash=not.is.null" + cursorFilter(afterCreatedAt, afterID)
+	query += fmt.Sprintf("&order=created_at.asc,id.asc&limit=%d", limit)
+	data, err := r.client.request(ctx, "GET", "gasbank_transactions", nil, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: get pending gasbank transactions: %v", ErrDatabaseError, err)
+	}
+
+	var txs []GasBankTransaction
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal gasbank transactions: %v", ErrDatabaseError, err)
+	}
+	return txs, nil
+}
+
+// UpdateGasBankTransactionStatus updates a transaction's status and the reason
+// it failed (empty unless status is "failed").
+func (r *Repository) UpdateGasBankTransactionStatus(ctx context.Context, txID, status, reason string) error {
+	if err := ValidateID(txID); err != nil {
+		return err
+	}
+	validStatuses := []string{"pending", "completed", "failed"}
+	if err := ValidateStatus(status, validStatuses); err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{
+		"status": status,
+		"error":  reason,
+	}
+	_, err := r.client.request(ctx, "PATCH", "gasbank_transactions", update, "id=eq."+txID)
+	if err != nil {
+		return fmt.Errorf("%w: update gasbank transaction status: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
 // =============================================================================
 // Deposit Operations
 // =============================================================================
@@ -217,6 +386,29 @@ func (r *Repository) GetDepositRequests(ctx context.Context, userID string, limi
 	return deposits, nil
 }
 
+// GetDepositRequestsAfter retrieves a user's deposit requests ordered by
+// (created_at, id), starting after the (afterCreatedAt, afterID) cursor; a
+// zero afterCreatedAt starts from the oldest.
+func (r *Repository) GetDepositRequestsAfter(ctx context.Context, userID string, afterCreatedAt time.Time, afterID string, limit int) ([]DepositRequest, error) {
+	if err := ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+	limit = ValidateLimit(limit, 100, 1000)
+
+	query := "user_id=eq." + userID + cursorFilter(afterCreatedAt, afterID)
+	query += fmt.Sprintf("&order=created_at.asc,id.asc&limit=%d", limit)
+	data, err := r.client.request(ctx, "GET", "deposit_requests", nil, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: get deposit requests: %v", ErrDatabaseError, err)
+	}
+
+	var deposits []DepositRequest
+	if err := json.Unmarshal(data, &deposits); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal deposit requests: %v", ErrDatabaseError, err)
+	}
+	return deposits, nil
+}
+
 // GetDepositByTxHash retrieves a deposit by transaction hash.
 func (r *Repository) GetDepositByTxHash(ctx context.Context, txHash string) (*DepositRequest, error) {
 	if err := ValidateTxHash(txHash); err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -240,6 +241,67 @@ func TestUpdateGasBankBalanceRequestError(t *testing.T) {
 	}
 }
 
+func TestUpdateGasBankSpendingLimitsNegative(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	err := repo.UpdateGasBankSpendingLimits(context.Background(), "user-123", -1, 0)
+	if err == nil {
+		t.Error("UpdateGasBankSpendingLimits() should return error for negative limit")
+	}
+}
+
+func TestUpdateGasBankSpendingLimitsSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Method = %s, want PATCH", r.Method)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["max_per_tx"] != float64(100) || body["max_per_day"] != float64(1000) {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	err := repo.UpdateGasBankSpendingLimits(context.Background(), "user-123", 100, 1000)
+	if err != nil {
+		t.Fatalf("UpdateGasBankSpendingLimits() error = %v", err)
+	}
+}
+
+func TestUpdateGasBankDailySpendInvalidDay(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	err := repo.UpdateGasBankDailySpend(context.Background(), "user-123", "yesterday", 10)
+	if err == nil {
+		t.Error("UpdateGasBankDailySpend() should return error for invalid day")
+	}
+}
+
+func TestUpdateGasBankDailySpendSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["daily_spent"] != float64(10) || body["daily_spent_date"] != "2026-01-02" {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	err := repo.UpdateGasBankDailySpend(context.Background(), "user-123", "2026-01-02", 10)
+	if err != nil {
+		t.Fatalf("UpdateGasBankDailySpend() error = %v", err)
+	}
+}
+
 // =============================================================================
 // Gas Bank Transaction Tests
 // =============================================================================
@@ -357,6 +419,77 @@ func TestGetGasBankTransactionsUnmarshalError(t *testing.T) {
 	}
 }
 
+func TestGetPendingGasBankTransactionsSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.RawQuery, "status=eq.pending") || !strings.Contains(r.URL.RawQuery, "tx_hash=not.is.null") {
+			t.Errorf("Query = %s, should filter pending transactions with a tx_hash", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]GasBankTransaction{{ID: "tx-1", Status: "pending", TxHash: "0xabc"}})
+	})
+	defer cleanup()
+
+	txs, err := repo.GetPendingGasBankTransactions(context.Background(), time.Time{}, "", 100)
+	if err != nil {
+		t.Fatalf("GetPendingGasBankTransactions() error = %v", err)
+	}
+	if len(txs) != 1 {
+		t.Errorf("len(txs) = %d, want 1", len(txs))
+	}
+}
+
+func TestGetPendingGasBankTransactionsAfterCursor(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		want := "or=(created_at.gt.2026-01-01T00:00:00Z,and(created_at.eq.2026-01-01T00:00:00Z,id.gt.tx-9))"
+		if got := r.URL.Query().Get("or"); "or="+got != want {
+			t.Errorf("or = %s, want %s", got, want)
+		}
+		if got := r.URL.Query().Get("order"); got != "created_at.asc,id.asc" {
+			t.Errorf("order = %s, want created_at.asc,id.asc", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]GasBankTransaction{})
+	})
+	defer cleanup()
+
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := repo.GetPendingGasBankTransactions(context.Background(), after, "tx-9", 100); err != nil {
+		t.Fatalf("GetPendingGasBankTransactions() error = %v", err)
+	}
+}
+
+func TestUpdateGasBankTransactionStatusInvalidStatus(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	err := repo.UpdateGasBankTransactionStatus(context.Background(), "tx-123", "refunded", "")
+	if err == nil {
+		t.Error("UpdateGasBankTransactionStatus() should return error for invalid status")
+	}
+}
+
+func TestUpdateGasBankTransactionStatusSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Method = %s, want PATCH", r.Method)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["status"] != "failed" || body["error"] != "vm fault" {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	err := repo.UpdateGasBankTransactionStatus(context.Background(), "tx-123", "failed", "vm fault")
+	if err != nil {
+		t.Fatalf("UpdateGasBankTransactionStatus() error = %v", err)
+	}
+}
+
 // =============================================================================
 // Deposit Request Tests
 // =============================================================================
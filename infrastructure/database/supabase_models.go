@@ -11,12 +11,19 @@ import (
 
 // User represents a user account.
 type User struct {
-	ID        string    `json:"id"`
-	Address   string    `json:"address,omitempty"`
-	Email     string    `json:"email,omitempty"`
-	Nonce     string    `json:"nonce,omitempty"` // For signature verification
-	CreatedAt time.Time `json:"created_at,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ID            string    `json:"id"`
+	Address       string    `json:"address,omitempty"`
+	Email         string    `json:"email,omitempty"`
+	EmailVerified bool      `json:"email_verified"`
+	Nonce         string    `json:"nonce,omitempty"` // For signature verification
+	CreatedAt     time.Time `json:"created_at,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
+
+	// Suspension state; a nil SuspendedAt means the user is active and a nil
+	// SuspendedUntil means the suspension has no end.
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
 }
 
 // APIKey represents an API key.
@@ -57,8 +64,27 @@ type PriceFeed struct {
 	Timestamp time.Time `json:"timestamp"`
 	Sources   []string  `json:"sources"`
 	Signature []byte    `json:"signature"`
+	// Backfilled marks a historical value recorded after the fact rather
+	// than observed live.
+	Backfilled bool `json:"backfilled,omitempty"`
 }
 
+// FeedSignerSet is the persisted signer set of a price feed: the oracle node
+// keys allowed to sign it and the number of signatures required.
+type FeedSignerSet struct {
+	FeedID    string    `json:"feed_id"`
+	Signers   []string  `json:"signers"`
+	Threshold int       `json:"threshold"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetVersion implements Versioned.
+func (s *FeedSignerSet) GetVersion() int { return s.Version }
+
+// SetVersion implements Versioned.
+func (s *FeedSignerSet) SetVersion(version int) { s.Version = version }
+
 // GasBankAccount represents a gas bank account.
 type GasBankAccount struct {
 	ID        string    `json:"id"`
@@ -67,6 +93,13 @@ type GasBankAccount struct {
 	Reserved  int64     `json:"reserved"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Spending policy; zero means unlimited.
+	MaxPerTx  int64 `json:"max_per_tx"`
+	MaxPerDay int64 `json:"max_per_day"`
+	// DailySpent is the amount spent on DailySpentDate (UTC, YYYY-MM-DD).
+	DailySpent     int64  `json:"daily_spent"`
+	DailySpentDate string `json:"daily_spent_date,omitempty"`
 }
 
 // UserWallet represents a user's wallet binding.
@@ -111,5 +144,6 @@ type GasBankTransaction struct {
 	FromAddress  string    `json:"from_address,omitempty"`
 	ToAddress    string    `json:"to_address,omitempty"`
 	Status       string    `json:"status,omitempty"`
+	Error        string    `json:"error,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 }
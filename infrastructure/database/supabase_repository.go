@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -51,6 +52,9 @@ func (r *Repository) GetUser(ctx context.Context, id string) (*User, error) {
 	if len(users) == 0 {
 		return nil, NewNotFoundError("user", id)
 	}
+	if err := r.reactivateExpired(ctx, users); err != nil {
+		return nil, err
+	}
 	return &users[0], nil
 }
 
@@ -94,6 +98,9 @@ func (r *Repository) GetUserByAddress(ctx context.Context, address string) (*Use
 	if len(users) == 0 {
 		return nil, NewNotFoundError("user", address)
 	}
+	if err := r.reactivateExpired(ctx, users); err != nil {
+		return nil, err
+	}
 	return &users[0], nil
 }
 
@@ -118,6 +125,9 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*User, e
 	if len(users) == 0 {
 		return nil, NewNotFoundError("user", email)
 	}
+	if err := r.reactivateExpired(ctx, users); err != nil {
+		return nil, err
+	}
 	return &users[0], nil
 }
 
@@ -160,6 +170,7 @@ func (r *Repository) CreateUser(ctx context.Context, user *User) error {
 }
 
 // UpdateUserEmail updates user's email.
+// Changing the email resets its verification state.
 func (r *Repository) UpdateUserEmail(ctx context.Context, userID, email string) error {
 	if err := ValidateUserID(userID); err != nil {
 		return err
@@ -169,8 +180,9 @@ func (r *Repository) UpdateUserEmail(ctx context.Context, userID, email string)
 	}
 
 	update := map[string]interface{}{
-		"email":      email,
-		"updated_at": time.Now(),
+		"email":          email,
+		"email_verified": false,
+		"updated_at":     time.Now(),
 	}
 	_, err := r.client.request(ctx, "PATCH", "users", update, "id=eq."+url.QueryEscape(userID))
 	if err != nil {
@@ -179,6 +191,68 @@ func (r *Repository) UpdateUserEmail(ctx context.Context, userID, email string)
 	return nil
 }
 
+// UpdateUserEmailVerified updates the verification state of the user's email.
+func (r *Repository) UpdateUserEmailVerified(ctx context.Context, userID string, verified bool) error {
+	if err := ValidateUserID(userID); err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{
+		"email_verified": verified,
+		"updated_at":     time.Now(),
+	}
+	_, err := r.client.request(ctx, "PATCH", "users", update, "id=eq."+url.QueryEscape(userID))
+	if err != nil {
+		return fmt.Errorf("%w: update user email verification: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// UpdateUserSuspension persists the user's suspension state.
+// Nil suspension timestamps are written as NULL (active / indefinite).
+func (r *Repository) UpdateUserSuspension(ctx context.Context, user *User) error {
+	if user == nil {
+		return fmt.Errorf("%w: user cannot be nil", ErrInvalidInput)
+	}
+	if err := ValidateUserID(user.ID); err != nil {
+		return err
+	}
+
+	update := map[string]interface{}{
+		"suspended_at":      user.SuspendedAt,
+		"suspended_until":   user.SuspendedUntil,
+		"suspension_reason": SanitizeString(user.SuspensionReason),
+		"updated_at":        time.Now(),
+	}
+	_, err := r.client.request(ctx, "PATCH", "users", update, "id=eq."+url.QueryEscape(user.ID))
+	if err != nil {
+		return fmt.Errorf("%w: update user suspension: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// reactivateExpired lifts timed suspensions in users that have lapsed and
+// persists the change, so a loaded user never carries an ended suspension.
+func (r *Repository) reactivateExpired(ctx context.Context, users []User) error {
+	reactivated := ReactivateExpired(users, time.Now())
+	if len(reactivated) == 0 {
+		return nil
+	}
+	ids := make(map[string]bool, len(reactivated))
+	for _, id := range reactivated {
+		ids[id] = true
+	}
+	for i := range users {
+		if !ids[users[i].ID] {
+			continue
+		}
+		if err := r.UpdateUserSuspension(ctx, &users[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UpdateUserNonce updates the user's nonce for signature verification.
 func (r *Repository) UpdateUserNonce(ctx context.Context, userID, nonce string) error {
 	if err := ValidateUserID(userID); err != nil {
@@ -293,6 +367,37 @@ func (r *Repository) GetLatestPrice(ctx context.Context, feedID string) (*PriceF
 	return &feeds[0], nil
 }
 
+// GetPriceHistory retrieves prices for a feed with from <= timestamp < to,
+// newest first. Callers page backwards by passing the oldest returned
+// timestamp as the next `to`.
+func (r *Repository) GetPriceHistory(ctx context.Context, feedID string, from, to time.Time, limit int) ([]PriceFeed, error) {
+	if feedID == "" {
+		return nil, fmt.Errorf("%w: feed_id cannot be empty", ErrInvalidInput)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidInput)
+	}
+	feedID = SanitizeString(feedID)
+	limit = ValidateLimit(limit, 100, 1000)
+
+	query := fmt.Sprintf("feed_id=eq.%s&timestamp=gte.%s&timestamp=lt.%s&order=timestamp.desc&limit=%d",
+		url.QueryEscape(feedID),
+		url.QueryEscape(from.UTC().Format(time.RFC3339Nano)),
+		url.QueryEscape(to.UTC().Format(time.RFC3339Nano)),
+		limit,
+	)
+	data, err := r.client.request(ctx, "GET", "price_feeds", nil, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: get price history: %v", ErrDatabaseError, err)
+	}
+
+	feeds := []PriceFeed{}
+	if unmarshalErr := json.Unmarshal(data, &feeds); unmarshalErr != nil {
+		return nil, fmt.Errorf("%w: unmarshal price feeds: %v", ErrDatabaseError, unmarshalErr)
+	}
+	return feeds, nil
+}
+
 // CreatePriceFeed creates a new price feed entry.
 func (r *Repository) CreatePriceFeed(ctx context.Context, feed *PriceFeed) error {
 	if feed == nil {
@@ -308,3 +413,74 @@ func (r *Repository) CreatePriceFeed(ctx context.Context, feed *PriceFeed) error
 	}
 	return nil
 }
+
+// CreatePriceFeeds creates price feed entries in a single insert: either all
+// entries are created or none are.
+func (r *Repository) CreatePriceFeeds(ctx context.Context, feeds []PriceFeed) error {
+	_, err := GenericBulkCreate(r, ctx, "price_feeds", feeds, func(feed *PriceFeed) error {
+		if feed.FeedID == "" {
+			return fmt.Errorf("%w: feed_id cannot be empty", ErrInvalidInput)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidInput) {
+			return err
+		}
+		return fmt.Errorf("%w: create price feeds: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
+
+// ListFeedSignerSets retrieves every persisted feed signer set.
+func (r *Repository) ListFeedSignerSets(ctx context.Context) ([]FeedSignerSet, error) {
+	data, err := r.client.request(ctx, "GET", "price_feed_signer_sets", nil, "order=feed_id.asc")
+	if err != nil {
+		return nil, fmt.Errorf("%w: list feed signer sets: %v", ErrDatabaseError, err)
+	}
+
+	sets := []FeedSignerSet{}
+	if unmarshalErr := json.Unmarshal(data, &sets); unmarshalErr != nil {
+		return nil, fmt.Errorf("%w: unmarshal feed signer sets: %v", ErrDatabaseError, unmarshalErr)
+	}
+	return sets, nil
+}
+
+// SaveFeedSignerSet updates a feed's signer set if its stored version still
+// matches set.Version, or creates it at version 1 if set.Version is 0 and no
+// row exists. On success set.Version holds the stored version. If another
+// writer saved the set first, a VersionConflictError is returned.
+func (r *Repository) SaveFeedSignerSet(ctx context.Context, set *FeedSignerSet) error {
+	if set == nil {
+		return fmt.Errorf("%w: signer set cannot be nil", ErrInvalidInput)
+	}
+	if set.FeedID == "" {
+		return fmt.Errorf("%w: feed_id cannot be empty", ErrInvalidInput)
+	}
+	set.FeedID = SanitizeString(set.FeedID)
+	if set.Signers == nil {
+		set.Signers = []string{}
+	}
+	set.UpdatedAt = time.Now()
+
+	err := GenericUpdateVersioned(r, ctx, "price_feed_signer_sets", "feed_id", set.FeedID, set)
+	if err == nil {
+		return nil
+	}
+	if !IsConflict(err) {
+		return fmt.Errorf("%w: update feed signer set: %v", ErrDatabaseError, err)
+	}
+	if set.Version != 0 {
+		return err
+	}
+
+	set.Version = 1
+	if _, err := r.client.request(ctx, "POST", "price_feed_signer_sets", set, ""); err != nil {
+		set.Version = 0
+		if IsUniqueViolation(err) {
+			return NewVersionConflictError("price_feed_signer_sets", set.FeedID, 0)
+		}
+		return fmt.Errorf("%w: create feed signer set: %v", ErrDatabaseError, err)
+	}
+	return nil
+}
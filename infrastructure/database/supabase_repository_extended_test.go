@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
@@ -353,6 +354,172 @@ func TestCreatePriceFeedSuccess(t *testing.T) {
 	}
 }
 
+func TestCreatePriceFeedsSingleInsert(t *testing.T) {
+	var requests int
+	var created []PriceFeed
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != "POST" || !strings.Contains(r.URL.Path, "price_feeds") {
+			t.Errorf("request = %s %s, want POST price_feeds", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(created)
+	})
+	defer cleanup()
+
+	feeds := []PriceFeed{
+		{FeedID: "BTC-USD", Price: 100, Timestamp: time.Now()},
+		{FeedID: "BTC-USD", Price: 101, Timestamp: time.Now().Add(time.Hour)},
+	}
+	if err := repo.CreatePriceFeeds(context.Background(), feeds); err != nil {
+		t.Fatalf("CreatePriceFeeds() error = %v", err)
+	}
+	if requests != 1 || len(created) != 2 || created[1].Price != 101 {
+		t.Errorf("requests = %d, created = %+v; want both rows in one insert", requests, created)
+	}
+}
+
+func TestCreatePriceFeedsRejectsInvalidBatch(t *testing.T) {
+	var requests int
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer cleanup()
+
+	feeds := []PriceFeed{{FeedID: "BTC-USD", Price: 100}, {Price: 101}}
+	err := repo.CreatePriceFeeds(context.Background(), feeds)
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("CreatePriceFeeds() error = %v, want ErrInvalidInput", err)
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want none for an invalid batch", requests)
+	}
+}
+
+func TestCreatePriceFeedsServerError(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer cleanup()
+
+	err := repo.CreatePriceFeeds(context.Background(), []PriceFeed{{FeedID: "BTC-USD", Price: 100}})
+	if !errors.Is(err, ErrDatabaseError) {
+		t.Fatalf("CreatePriceFeeds() error = %v, want ErrDatabaseError", err)
+	}
+}
+
+func TestSaveFeedSignerSetUpdatesExisting(t *testing.T) {
+	var methods []string
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if !strings.Contains(r.URL.Path, "price_feed_signer_sets") || !strings.Contains(r.URL.RawQuery, "feed_id=eq.BTC-USD") {
+			t.Errorf("request = %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]FeedSignerSet{{FeedID: "BTC-USD"}})
+	})
+	defer cleanup()
+
+	set := &FeedSignerSet{FeedID: "BTC-USD", Signers: []string{"02aa"}, Threshold: 1}
+	if err := repo.SaveFeedSignerSet(context.Background(), set); err != nil {
+		t.Fatalf("SaveFeedSignerSet() error = %v", err)
+	}
+	if len(methods) != 1 || methods[0] != "PATCH" {
+		t.Errorf("methods = %v, want [PATCH]", methods)
+	}
+}
+
+func TestSaveFeedSignerSetCreatesMissing(t *testing.T) {
+	var methods []string
+	var created FeedSignerSet
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == "POST" {
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	})
+	defer cleanup()
+
+	set := &FeedSignerSet{FeedID: "BTC-USD", Signers: []string{"02aa", "03bb"}, Threshold: 2}
+	if err := repo.SaveFeedSignerSet(context.Background(), set); err != nil {
+		t.Fatalf("SaveFeedSignerSet() error = %v", err)
+	}
+	if len(methods) != 2 || methods[0] != "PATCH" || methods[1] != "POST" {
+		t.Errorf("methods = %v, want [PATCH POST]", methods)
+	}
+	if created.FeedID != "BTC-USD" || len(created.Signers) != 2 || created.Threshold != 2 || created.Version != 1 {
+		t.Errorf("created = %+v", created)
+	}
+}
+
+func TestSaveFeedSignerSetStaleVersionConflicts(t *testing.T) {
+	var methods []string
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if !strings.Contains(r.URL.RawQuery, "version=eq.2") {
+			t.Errorf("query = %s, want the expected version", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	})
+	defer cleanup()
+
+	set := &FeedSignerSet{FeedID: "BTC-USD", Signers: []string{"02aa"}, Threshold: 1, Version: 2}
+	err := repo.SaveFeedSignerSet(context.Background(), set)
+	var conflict *VersionConflictError
+	if !errors.As(err, &conflict) || conflict.ExpectedVersion != 2 {
+		t.Fatalf("SaveFeedSignerSet() error = %v, want VersionConflictError", err)
+	}
+	if len(methods) != 1 || set.Version != 2 {
+		t.Errorf("methods = %v, version = %d; want one PATCH and the version unchanged", methods, set.Version)
+	}
+}
+
+func TestSaveFeedSignerSetConcurrentCreateConflicts(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"code":"23505","message":"duplicate key"}`))
+			return
+		}
+		w.Write([]byte("[]"))
+	})
+	defer cleanup()
+
+	err := repo.SaveFeedSignerSet(context.Background(), &FeedSignerSet{FeedID: "BTC-USD", Signers: []string{"02aa"}, Threshold: 1})
+	if !IsConflict(err) {
+		t.Fatalf("SaveFeedSignerSet() error = %v, want a conflict", err)
+	}
+}
+
+func TestListFeedSignerSets(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Method = %s, want GET", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]FeedSignerSet{{FeedID: "BTC-USD", Signers: []string{"02aa"}, Threshold: 1}})
+	})
+	defer cleanup()
+
+	sets, err := repo.ListFeedSignerSets(context.Background())
+	if err != nil {
+		t.Fatalf("ListFeedSignerSets() error = %v", err)
+	}
+	if len(sets) != 1 || sets[0].FeedID != "BTC-USD" || sets[0].Threshold != 1 {
+		t.Errorf("sets = %+v", sets)
+	}
+}
+
 func TestCreatePriceFeedRequestError(t *testing.T) {
 	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/crypto"
+)
+
+// DefaultEmailVerificationTTL is the validity window of email verification tokens.
+const DefaultEmailVerificationTTL = 24 * time.Hour
+
+var (
+	// ErrInvalidVerificationToken is returned when a token is malformed, has a bad
+	// signature, or was issued for a different user or email address.
+	ErrInvalidVerificationToken = errors.New("invalid email verification token")
+
+	// ErrVerificationTokenExpired is returned when a token is past its expiry.
+	ErrVerificationTokenExpired = errors.New("email verification token expired")
+)
+
+// GenerateEmailVerificationToken creates an HMAC-signed token binding the user ID,
+// the current email address, and an expiry time. Changing the email address
+// invalidates any previously issued token.
+func GenerateEmailVerificationToken(key []byte, user User, expiresAt time.Time) (string, error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("%w: verification key cannot be empty", ErrInvalidInput)
+	}
+	if err := ValidateUserID(user.ID); err != nil {
+		return "", err
+	}
+	email := strings.TrimSpace(user.Email)
+	if email == "" {
+		return "", fmt.Errorf("%w: user has no email address", ErrInvalidInput)
+	}
+	if err := ValidateEmail(email); err != nil {
+		return "", err
+	}
+	if expiresAt.IsZero() {
+		return "", fmt.Errorf("%w: expiry cannot be zero", ErrInvalidInput)
+	}
+
+	payload := emailVerificationPayload(user.ID, email, expiresAt.Unix())
+	sig := crypto.HMACSign(key, payload)
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(sig), nil
+}
+
+// VerifyEmail validates token against the user and marks the email as verified.
+// The user is left unchanged when verification fails.
+func VerifyEmail(key []byte, user *User, token string, now time.Time) error {
+	if user == nil {
+		return fmt.Errorf("%w: user cannot be nil", ErrInvalidInput)
+	}
+	if len(key) == 0 {
+		return fmt.Errorf("%w: verification key cannot be empty", ErrInvalidInput)
+	}
+
+	encPayload, encSig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return ErrInvalidVerificationToken
+	}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(encPayload)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+	sig, err := enc.DecodeString(encSig)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+	if !crypto.HMACVerify(key, payload, sig) {
+		return ErrInvalidVerificationToken
+	}
+
+	parts := strings.Split(string(payload), "\n")
+	if len(parts) != 3 {
+		return ErrInvalidVerificationToken
+	}
+	if parts[0] != user.ID || !strings.EqualFold(parts[1], strings.TrimSpace(user.Email)) {
+		return ErrInvalidVerificationToken
+	}
+	expiresUnix, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return ErrInvalidVerificationToken
+	}
+	if !now.Before(time.Unix(expiresUnix, 0)) {
+		return ErrVerificationTokenExpired
+	}
+
+	user.EmailVerified = true
+	user.UpdatedAt = now
+	return nil
+}
+
+func emailVerificationPayload(userID, email string, expiresUnix int64) []byte {
+	return []byte(userID + "\n" + strings.ToLower(email) + "\n" + strconv.FormatInt(expiresUnix, 10))
+}
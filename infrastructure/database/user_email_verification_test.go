@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEmailVerificationRoundTrip(t *testing.T) {
+	key := []byte("email-verification-test-key")
+	now := time.Unix(1_700_000_000, 0)
+	user := User{ID: "user-123", Email: "alice@example.com"}
+
+	token, err := GenerateEmailVerificationToken(key, user, now.Add(DefaultEmailVerificationTTL))
+	if err != nil {
+		t.Fatalf("GenerateEmailVerificationToken() error = %v", err)
+	}
+
+	if err := VerifyEmail(key, &user, token, now.Add(time.Hour)); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !user.EmailVerified {
+		t.Error("VerifyEmail() should set EmailVerified")
+	}
+}
+
+func TestVerifyEmailExpired(t *testing.T) {
+	key := []byte("email-verification-test-key")
+	now := time.Unix(1_700_000_000, 0)
+	user := User{ID: "user-123", Email: "alice@example.com"}
+
+	token, err := GenerateEmailVerificationToken(key, user, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("GenerateEmailVerificationToken() error = %v", err)
+	}
+
+	err = VerifyEmail(key, &user, token, now.Add(2*time.Minute))
+	if !errors.Is(err, ErrVerificationTokenExpired) {
+		t.Fatalf("VerifyEmail() error = %v, want ErrVerificationTokenExpired", err)
+	}
+	if user.EmailVerified {
+		t.Error("EmailVerified should remain false for expired token")
+	}
+}
+
+func TestVerifyEmailMismatch(t *testing.T) {
+	key := []byte("email-verification-test-key")
+	now := time.Unix(1_700_000_000, 0)
+	user := User{ID: "user-123", Email: "alice@example.com"}
+
+	token, err := GenerateEmailVerificationToken(key, user, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateEmailVerificationToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		key   []byte
+		user  User
+		token string
+	}{
+		{"other user", key, User{ID: "user-456", Email: "alice@example.com"}, token},
+		{"changed email", key, User{ID: "user-123", Email: "bob@example.com"}, token},
+		{"wrong key", []byte("other-key"), user, token},
+		{"tampered", key, user, token + "x"},
+		{"malformed", key, user, "not-a-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.user
+			err := VerifyEmail(tt.key, &u, tt.token, now)
+			if !errors.Is(err, ErrInvalidVerificationToken) {
+				t.Fatalf("VerifyEmail() error = %v, want ErrInvalidVerificationToken", err)
+			}
+			if u.EmailVerified {
+				t.Error("EmailVerified should remain false")
+			}
+		})
+	}
+}
+
+func TestGenerateEmailVerificationTokenRequiresEmail(t *testing.T) {
+	_, err := GenerateEmailVerificationToken([]byte("key"), User{ID: "user-123"}, time.Now().Add(time.Hour))
+	if !IsInvalidInput(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestUpdateUserEmailVerifiedSuccess(t *testing.T) {
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Method = %s, want PATCH", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer cleanup()
+
+	if err := repo.UpdateUserEmailVerified(context.Background(), "user-123", true); err != nil {
+		t.Fatalf("UpdateUserEmailVerified() error = %v", err)
+	}
+}
+
+func TestMockUpdateUserEmailResetsVerification(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	user := &User{ID: "user-123", Email: "alice@example.com"}
+	if err := repo.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	if err := repo.UpdateUserEmailVerified(ctx, user.ID, true); err != nil {
+		t.Fatalf("UpdateUserEmailVerified() error = %v", err)
+	}
+	if err := repo.UpdateUserEmail(ctx, user.ID, "bob@example.com"); err != nil {
+		t.Fatalf("UpdateUserEmail() error = %v", err)
+	}
+
+	got, err := repo.GetUser(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if got.EmailVerified {
+		t.Error("changing email should reset EmailVerified")
+	}
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// IsSuspended reports whether the user is suspended at the given time.
+// A suspension without SuspendedUntil lasts until it is lifted explicitly.
+func (u *User) IsSuspended(now time.Time) bool {
+	if u == nil || u.SuspendedAt == nil {
+		return false
+	}
+	return u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil)
+}
+
+// Suspend marks the user as suspended for reason until the given time.
+// Pass a zero until for an indefinite suspension.
+func Suspend(user *User, reason string, until time.Time) error {
+	if user == nil {
+		return fmt.Errorf("%w: user cannot be nil", ErrInvalidInput)
+	}
+	reason = SanitizeString(reason)
+	if reason == "" {
+		return fmt.Errorf("%w: suspension reason cannot be empty", ErrInvalidInput)
+	}
+
+	now := time.Now()
+	if !until.IsZero() && !until.After(now) {
+		return fmt.Errorf("%w: suspension end must be in the future", ErrInvalidInput)
+	}
+
+	user.SuspendedAt = &now
+	user.SuspendedUntil = nil
+	if !until.IsZero() {
+		user.SuspendedUntil = &until
+	}
+	user.SuspensionReason = reason
+	user.UpdatedAt = now
+	return nil
+}
+
+// Reactivate lifts any suspension on the user.
+func Reactivate(user *User, now time.Time) {
+	if user == nil {
+		return
+	}
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspensionReason = ""
+	user.UpdatedAt = now
+}
+
+// ReactivateExpired lifts suspensions whose window has passed and returns the
+// IDs of the reactivated users. Indefinite suspensions are left untouched.
+func ReactivateExpired(users []User, now time.Time) []string {
+	var reactivated []string
+	for i := range users {
+		user := &users[i]
+		if user.SuspendedAt == nil || user.SuspendedUntil == nil {
+			continue
+		}
+		if now.Before(*user.SuspendedUntil) {
+			continue
+		}
+		Reactivate(user, now)
+		reactivated = append(reactivated, user.ID)
+	}
+	return reactivated
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuspendAndIsSuspended(t *testing.T) {
+	user := &User{ID: "user-123"}
+	until := time.Now().Add(time.Hour)
+
+	if err := Suspend(user, "chargeback investigation", until); err != nil {
+		t.Fatalf("Suspend() error = %v", err)
+	}
+	if !user.IsSuspended(time.Now()) {
+		t.Error("user should be suspended")
+	}
+	if user.IsSuspended(until.Add(time.Second)) {
+		t.Error("user should not be suspended after window")
+	}
+	if user.SuspensionReason != "chargeback investigation" {
+		t.Errorf("SuspensionReason = %q", user.SuspensionReason)
+	}
+}
+
+func TestSuspendValidation(t *testing.T) {
+	if err := Suspend(nil, "reason", time.Time{}); !IsInvalidInput(err) {
+		t.Errorf("nil user: got %v", err)
+	}
+	if err := Suspend(&User{ID: "user-123"}, "  ", time.Time{}); !IsInvalidInput(err) {
+		t.Errorf("empty reason: got %v", err)
+	}
+	if err := Suspend(&User{ID: "user-123"}, "reason", time.Now().Add(-time.Minute)); !IsInvalidInput(err) {
+		t.Errorf("past until: got %v", err)
+	}
+}
+
+func TestReactivateExpired(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	users := []User{
+		{ID: "expired", SuspendedAt: at(-2 * time.Hour), SuspendedUntil: at(-time.Hour), SuspensionReason: "spam"},
+		{ID: "active-suspension", SuspendedAt: at(-time.Hour), SuspendedUntil: at(time.Hour), SuspensionReason: "spam"},
+		{ID: "indefinite", SuspendedAt: at(-time.Hour), SuspensionReason: "fraud"},
+		{ID: "never-suspended"},
+	}
+
+	ids := ReactivateExpired(users, now)
+	if len(ids) != 1 || ids[0] != "expired" {
+		t.Fatalf("ReactivateExpired() = %v, want [expired]", ids)
+	}
+	if users[0].IsSuspended(now) || users[0].SuspensionReason != "" {
+		t.Error("expired suspension should be cleared")
+	}
+	if !users[1].IsSuspended(now) {
+		t.Error("active suspension should remain")
+	}
+	if !users[2].IsSuspended(now) {
+		t.Error("indefinite suspension should remain")
+	}
+}
+
+func TestGetUserReactivatesExpiredSuspension(t *testing.T) {
+	suspendedAt := time.Now().Add(-2 * time.Hour)
+	until := time.Now().Add(-time.Hour)
+
+	var patch map[string]any
+	repo, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode([]User{{ID: "user-1", SuspendedAt: &suspendedAt, SuspendedUntil: &until, SuspensionReason: "spam"}})
+		case http.MethodPatch:
+			if !strings.Contains(r.URL.RawQuery, "id=eq.user-1") {
+				t.Errorf("PATCH query = %s, want user-1 filter", r.URL.RawQuery)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				t.Errorf("decode PATCH body: %v", err)
+			}
+			w.Write([]byte("[]"))
+		}
+	})
+	defer cleanup()
+
+	user, err := repo.GetUser(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if user.SuspendedAt != nil || user.SuspensionReason != "" {
+		t.Errorf("expired suspension not lifted: %+v", user)
+	}
+	if patch == nil {
+		t.Fatal("expired suspension was not persisted")
+	}
+	if patch["suspended_at"] != nil || patch["suspended_until"] != nil {
+		t.Errorf("PATCH body = %v, want cleared suspension", patch)
+	}
+}
+
+func TestMockUpdateUserSuspension(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	user := &User{ID: "user-123"}
+	if err := repo.CreateUser(ctx, user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	suspended := User{ID: user.ID}
+	if err := Suspend(&suspended, "abuse", time.Time{}); err != nil {
+		t.Fatalf("Suspend() error = %v", err)
+	}
+	if err := repo.UpdateUserSuspension(ctx, &suspended); err != nil {
+		t.Fatalf("UpdateUserSuspension() error = %v", err)
+	}
+
+	got, err := repo.GetUser(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+	if !got.IsSuspended(time.Now()) {
+		t.Error("stored user should be suspended")
+	}
+}
+
+func TestSuspensionJSONOmitsUnsetTimes(t *testing.T) {
+	data, err := json.Marshal(User{ID: "user-123"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "suspended_") {
+		t.Errorf("active user JSON has suspension fields: %s", data)
+	}
+
+	user := &User{ID: "user-123"}
+	if err := Suspend(user, "abuse", time.Time{}); err != nil {
+		t.Fatalf("Suspend() error = %v", err)
+	}
+	data, err = json.Marshal(user)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"suspended_at"`) || strings.Contains(string(data), "suspended_until") {
+		t.Errorf("indefinite suspension JSON = %s", data)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -80,12 +81,25 @@ type SignRequest struct {
 	Domain     string `json:"domain"`
 	Data       string `json:"data"` // hex-encoded
 	KeyVersion string `json:"key_version,omitempty"`
+	Scheme     string `json:"scheme,omitempty"`     // expected signature scheme; default: the signer's configured scheme
+	RequestID  string `json:"request_id,omitempty"` // idempotency key; repeats return the original signature
+}
+
+// SignBatchRequest is a request for signing several payloads at once.
+type SignBatchRequest struct {
+	Requests []SignRequest `json:"requests"`
+}
+
+// SignBatchResponse is the response from batch signing, in request order.
+type SignBatchResponse struct {
+	Signatures []SignResponse `json:"signatures"`
 }
 
 // SignRawRequest is a request for raw signing without domain separation.
 type SignRawRequest struct {
 	Data       string `json:"data"` // hex-encoded
 	KeyVersion string `json:"key_version,omitempty"`
+	Scheme     string `json:"scheme,omitempty"` // expected signature scheme
 }
 
 // SignResponse is the response from signing.
@@ -93,6 +107,8 @@ type SignResponse struct {
 	Signature  string `json:"signature"` // hex-encoded
 	KeyVersion string `json:"key_version"`
 	PubKeyHex  string `json:"pubkey_hex"`
+	Scheme     string `json:"scheme"`             // "secp256r1" or "ed25519"
+	Replayed   bool   `json:"replayed,omitempty"` // returned from an earlier request with the same RequestID
 }
 
 // DeriveRequest is a request for key derivation.
@@ -126,6 +142,7 @@ type AttestationResponse struct {
 type KeyVersion struct {
 	Version       string     `json:"version"`
 	Status        string     `json:"status"`
+	Scheme        string     `json:"scheme,omitempty"`
 	PubKeyHex     string     `json:"pubkey_hex"`
 	PubKeyHash    string     `json:"pubkey_hash"`
 	CreatedAt     time.Time  `json:"created_at"`
@@ -195,6 +212,65 @@ func (c *Client) Sign(ctx context.Context, req *SignRequest) (*SignResponse, err
 	return &result, nil
 }
 
+// SignBatch performs domain-separated signing of several requests in one
+// call. Signatures are returned in request order.
+func (c *Client) SignBatch(ctx context.Context, req *SignBatchRequest) (*SignBatchResponse, error) {
+	if c == nil {
+		return nil, fmt.Errorf("globalsigner: client is nil")
+	}
+	if c.httpClient == nil {
+		return nil, fmt.Errorf("globalsigner: http client not configured")
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/sign-batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.serviceID != "" {
+		httpReq.Header.Set(serviceauth.ServiceIDHeader, c.serviceID)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, truncated, readErr := slhttputil.ReadAllWithLimit(resp.Body, 32<<10)
+		if readErr != nil {
+			return nil, fmt.Errorf("request failed: %s (failed to read body: %v)", resp.Status, readErr)
+		}
+		msg := strings.TrimSpace(string(body))
+		if truncated {
+			msg += "...(truncated)"
+		}
+		if msg != "" {
+			return nil, fmt.Errorf("request failed: %s - %s", resp.Status, msg)
+		}
+		return nil, fmt.Errorf("request failed: %s", resp.Status)
+	}
+
+	respBody, err := slhttputil.ReadAllStrict(resp.Body, c.maxBodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	var result SignBatchResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // SignRaw performs raw signing without domain separation.
 func (c *Client) SignRaw(ctx context.Context, req *SignRawRequest) (*SignResponse, error) {
 	if c == nil {
@@ -310,6 +386,12 @@ func (c *Client) Derive(ctx context.Context, req *DeriveRequest) (*DeriveRespons
 
 // GetAttestation gets the attestation for the active key.
 func (c *Client) GetAttestation(ctx context.Context) (*AttestationResponse, error) {
+	return c.GetSchemeAttestation(ctx, "")
+}
+
+// GetSchemeAttestation gets the attestation for scheme's active key. An empty
+// scheme means the signer's configured scheme.
+func (c *Client) GetSchemeAttestation(ctx context.Context, scheme string) (*AttestationResponse, error) {
 	if c == nil {
 		return nil, fmt.Errorf("globalsigner: client is nil")
 	}
@@ -317,7 +399,11 @@ func (c *Client) GetAttestation(ctx context.Context) (*AttestationResponse, erro
 		return nil, fmt.Errorf("globalsigner: http client not configured")
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/attestation", http.NoBody)
+	endpoint := c.baseURL + "/attestation"
+	if scheme != "" {
+		endpoint += "?scheme=" + url.QueryEscape(scheme)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
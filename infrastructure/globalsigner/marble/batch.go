@@ -0,0 +1,71 @@
+package globalsigner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/R3E-Network/service_layer/infrastructure/serviceauth"
+)
+
+// MaxSignBatchItems bounds the number of requests signed per batch.
+const MaxSignBatchItems = 100
+
+// BatchRequestError reports the request of a batch that failed, by index.
+type BatchRequestError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchRequestError) Error() string {
+	return fmt.Sprintf("request %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchRequestError) Unwrap() error { return e.Err }
+
+// SignBatch signs several domain-separated requests, returning responses in
+// request order. Every request is validated (fields, key version, scheme)
+// before any is signed, and the first invalid one fails the batch with a
+// *BatchRequestError carrying its index. Requests are then signed like Sign,
+// including RequestID replay protection; if one fails at that stage the batch
+// stops with its index and the earlier signatures are discarded.
+func (s *Service) SignBatch(ctx context.Context, reqs []SignRequest) ([]SignResponse, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("requests required")
+	}
+	if len(reqs) > MaxSignBatchItems {
+		return nil, fmt.Errorf("too many requests: %d (max %d)", len(reqs), MaxSignBatchItems)
+	}
+
+	domains := make([]string, len(reqs))
+	for i := range reqs {
+		_, scheme, err := s.parseSignRequest(&reqs[i])
+		if err == nil {
+			_, _, err = s.signingEntry(reqs[i].KeyVersion, scheme)
+		}
+		if err != nil {
+			return nil, &BatchRequestError{Index: i, Err: err}
+		}
+		domains[i] = reqs[i].Domain
+	}
+
+	s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+		"audit":      true,
+		"action":     "sign_batch",
+		"service_id": serviceauth.GetServiceID(ctx),
+		"batch_size": len(reqs),
+		"domains":    domains,
+	}).Info("signing batch")
+
+	resps := make([]SignResponse, len(reqs))
+	for i := range reqs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := s.Sign(ctx, &reqs[i])
+		if err != nil {
+			return nil, &BatchRequestError{Index: i, Err: err}
+		}
+		resps[i] = *resp
+	}
+	return resps, nil
+}
@@ -0,0 +1,132 @@
+package globalsigner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignBatchPreservesOrder(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+
+	reqs := []SignRequest{
+		{Domain: "neofeeds", Data: "01"},
+		{Domain: "neooracle", Data: "02"},
+		{Domain: "neofeeds", Data: "03"},
+		{Domain: "neovrf", Data: "01"},
+	}
+	resps, err := svc.SignBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("SignBatch() error = %v", err)
+	}
+	if len(resps) != len(reqs) {
+		t.Fatalf("len(resps) = %d, want %d", len(resps), len(reqs))
+	}
+
+	// Each signature must verify against its own request and no other.
+	for i, resp := range resps {
+		for j, req := range reqs {
+			valid, err := svc.BatchVerify(context.Background(), []VerifyItem{{
+				Domain:    req.Domain,
+				Data:      req.Data,
+				Signature: resp.Signature,
+				PubKeyHex: resp.PubKeyHex,
+			}})
+			if err != nil {
+				t.Fatalf("BatchVerify() error = %v", err)
+			}
+			if valid[0] != (i == j) {
+				t.Errorf("signature %d verifies against request %d = %v", i, j, valid[0])
+			}
+		}
+	}
+}
+
+func TestSignBatchFailsFastOnInvalidRequest(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+
+	tests := []struct {
+		name  string
+		reqs  []SignRequest
+		index int
+	}{
+		{"missing domain", []SignRequest{{Domain: "a", Data: "01"}, {Data: "01"}, {Domain: "c"}}, 1},
+		{"bad hex", []SignRequest{{Domain: "a", Data: "zz"}, {Domain: "b", Data: "01"}}, 0},
+		{"unknown key version", []SignRequest{{Domain: "a", Data: "01"}, {Domain: "b", Data: "01"}, {Domain: "c", Data: "01", KeyVersion: "v1999-01"}}, 2},
+		{"scheme mismatch", []SignRequest{{Domain: "a", Data: "01"}, {Domain: "b", Data: "01", Scheme: SchemeEd25519}}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := svc.signaturesIssued
+			_, err := svc.SignBatch(context.Background(), tt.reqs)
+			var batchErr *BatchRequestError
+			if !errors.As(err, &batchErr) {
+				t.Fatalf("SignBatch() error = %v, want *BatchRequestError", err)
+			}
+			if batchErr.Index != tt.index {
+				t.Errorf("Index = %d, want %d", batchErr.Index, tt.index)
+			}
+			if svc.signaturesIssued != before {
+				t.Errorf("signed %d requests before rejecting the batch", svc.signaturesIssued-before)
+			}
+		})
+	}
+
+	if _, err := svc.SignBatch(context.Background(), make([]SignRequest, MaxSignBatchItems+1)); err == nil {
+		t.Error("SignBatch() should reject oversized batches")
+	}
+}
+
+func TestHandleSignBatchReportsIndex(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+
+	body := `{"requests":[{"domain":"a","data":"01"},{"domain":"b","data":"nothex"}]}`
+	rec := httptest.NewRecorder()
+	svc.handleSignBatch(rec, httptest.NewRequest(http.MethodPost, "/sign-batch", strings.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Details struct {
+			Index int `json:"index"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Details.Index != 1 {
+		t.Errorf("details.index = %d, want 1", resp.Details.Index)
+	}
+
+	body = `{"requests":[{"domain":"a","data":"01"},{"domain":"b","data":"02"}]}`
+	rec = httptest.NewRecorder()
+	svc.handleSignBatch(rec, httptest.NewRequest(http.MethodPost, "/sign-batch", strings.NewReader(body)))
+	var ok SignBatchResponse
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &ok) != nil || len(ok.Signatures) != 2 {
+		t.Errorf("valid batch: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReadOnlyKeepsBatchVerify(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+	svc.SetReadOnly(true, "maintenance")
+
+	req := httptest.NewRequest(http.MethodPost, "/verify-batch", strings.NewReader(`{"items":[]}`))
+	rr := httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	if rr.Code == http.StatusServiceUnavailable {
+		t.Fatalf("/verify-batch status=%d, want it served in read-only mode", rr.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/rotate", nil)
+	rr = httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("/rotate status=%d, want 503 in read-only mode", rr.Code)
+	}
+}
@@ -0,0 +1,111 @@
+package globalsigner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/serviceauth"
+)
+
+func TestValidateDerivationPath(t *testing.T) {
+	maxSegment := strings.Repeat("a", MaxDerivationSegmentLen)
+	maxDepth := strings.TrimSuffix(strings.Repeat("x/", MaxDerivationDepth), "/")
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"single segment", "wallet", ""},
+		{"nested", "users/42/wallet-1", ""},
+		{"allowed charset", "A-z_0.9/v1.2", ""},
+		{"dots inside segment", "..hidden/a..b", ""},
+		{"max depth", maxDepth, ""},
+		{"max segment length", maxSegment, ""},
+
+		{"empty", "", "path is required"},
+		{"too deep", maxDepth + "/x", "depth 9 exceeds 8"},
+		{"segment too long", "ok/" + maxSegment + "a", "segment 1 exceeds 64 characters"},
+		{"leading slash", "/wallet", "segment 0 is empty or relative"},
+		{"trailing slash", "wallet/", "segment 1 is empty or relative"},
+		{"double slash", "a//b", "segment 1 is empty or relative"},
+		{"dot segment", "a/./b", "segment 1 is empty or relative"},
+		{"dotdot segment", "a/../b", "segment 1 is empty or relative"},
+		{"colon", "a:b", `segment 0 contains ':'`},
+		{"colon in later segment", "users/1:2", `segment 1 contains ':'`},
+		{"space", "a b", `segment 0 contains ' '`},
+		{"backslash", `a\b`, `segment 0 contains '\\'`},
+		{"non-ascii", "wallét", `segment 0 contains 'é'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDerivationPath(tt.path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateDerivationPath(%q) error = %v", tt.path, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateDerivationPath(%q) error = %v, want %q", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeriveIsDeterministicPerDomainAndPath(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+	ctx := context.Background()
+
+	derive := func(domain, path string) string {
+		t.Helper()
+		resp, err := svc.Derive(ctx, &DeriveRequest{Domain: domain, Path: path})
+		if err != nil {
+			t.Fatalf("Derive(%q, %q) error = %v", domain, path, err)
+		}
+		return resp.PubKeyHex
+	}
+
+	a := derive("neovrf", "users/1")
+	if again := derive("neovrf", "users/1"); again != a {
+		t.Errorf("Derive is not deterministic: %s != %s", a, again)
+	}
+	if other := derive("neovrf", "users/2"); other == a {
+		t.Error("different paths derived the same key")
+	}
+	if other := derive("neovrf:users", "1"); other == a {
+		t.Error("domain:path split ambiguity derived the same key")
+	}
+
+	if _, err := svc.Derive(ctx, &DeriveRequest{Domain: "neovrf", Path: "a:b"}); err == nil {
+		t.Error("Derive accepted a path containing ':'")
+	}
+}
+
+func TestHandleDeriveRequiresCallerScopedDomain(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+
+	derive := func(domain string) *httptest.ResponseRecorder {
+		body := `{"domain":"` + domain + `","path":"users/1"}`
+		req := httptest.NewRequest(http.MethodPost, "/derive", strings.NewReader(body))
+		req.Header.Set(serviceauth.ServiceIDHeader, "neovrf")
+		rec := httptest.NewRecorder()
+		svc.handleDerive(rec, req)
+		return rec
+	}
+
+	for _, domain := range []string{"neovrf", "neovrf:wallets"} {
+		if rec := derive(domain); rec.Code != http.StatusOK {
+			t.Errorf("domain %q: status = %d, want 200: %s", domain, rec.Code, rec.Body.String())
+		}
+	}
+	for _, domain := range []string{"neooracle", "neovrfx", "neooracle:neovrf", ""} {
+		if rec := derive(domain); rec.Code != http.StatusForbidden {
+			t.Errorf("domain %q: status = %d, want 403: %s", domain, rec.Code, rec.Body.String())
+		}
+	}
+}
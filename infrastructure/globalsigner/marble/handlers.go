@@ -1,7 +1,9 @@
 package globalsigner
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/R3E-Network/service_layer/infrastructure/httputil"
@@ -21,13 +23,16 @@ func (s *Service) RegisterRoutes(mux *http.ServeMux) {
 	// These endpoints can sign data, rotate keys, or derive keys - must be protected
 	mux.Handle("/rotate", middleware.RequireServiceAuth(http.HandlerFunc(s.handleRotate)))
 	mux.Handle("/sign", middleware.RequireServiceAuth(http.HandlerFunc(s.handleSign)))
+	mux.Handle("/sign-batch", middleware.RequireServiceAuth(http.HandlerFunc(s.handleSignBatch)))
 	mux.Handle("/sign-raw", middleware.RequireServiceAuth(http.HandlerFunc(s.handleSignRaw)))
 	mux.Handle("/derive", middleware.RequireServiceAuth(http.HandlerFunc(s.handleDerive)))
 
 	// Public endpoints (read-only, safe to expose)
 	mux.HandleFunc("/attestation", s.handleAttestation)
 	mux.HandleFunc("/keys", s.handleListKeys)
+	mux.HandleFunc("/verify-batch", s.handleBatchVerify)
 	mux.HandleFunc("/status", s.handleStatus)
+	s.AllowInReadOnly("/verify-batch")
 }
 
 // handleRotate handles POST /rotate - trigger key rotation.
@@ -44,7 +49,13 @@ func (s *Service) handleRotate(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	resp, err := s.Rotate(r.Context(), req.Force)
+	scheme, err := s.requestScheme(req.Scheme)
+	if err != nil {
+		httputil.BadRequest(w, err.Error())
+		return
+	}
+
+	resp, err := s.RotateScheme(r.Context(), scheme, req.Force)
 	if err != nil {
 		httputil.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -67,13 +78,54 @@ func (s *Service) handleSign(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := s.Sign(r.Context(), &req)
 	if err != nil {
-		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		switch {
+		case errors.Is(err, ErrRequestIDReused):
+			httputil.WriteError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, errReplayUnavailable):
+			httputil.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		default:
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		}
 		return
 	}
 
 	httputil.WriteJSON(w, http.StatusOK, resp)
 }
 
+// handleSignBatch handles POST /sign-batch - domain-separated signing of
+// several requests at once.
+func (s *Service) handleSignBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httputil.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req SignBatchRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+
+	resps, err := s.SignBatch(r.Context(), req.Requests)
+	if err != nil {
+		var batchErr *BatchRequestError
+		if !errors.As(err, &batchErr) {
+			httputil.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, ErrRequestIDReused):
+			status = http.StatusConflict
+		case errors.Is(err, errReplayUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+		httputil.WriteErrorResponse(w, r, status, "", err.Error(), map[string]any{"index": batchErr.Index})
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, SignBatchResponse{Signatures: resps})
+}
+
 // handleSignRaw handles POST /sign-raw - raw signing without domain separation.
 func (s *Service) handleSignRaw(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -107,6 +159,16 @@ func (s *Service) handleDerive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Derived keys are scoped to the caller: a service may only derive under
+	// its own ID (or an "<id>:" sub-domain) so services cannot collide with
+	// or reproduce each other's keys.
+	if caller := httputil.GetServiceID(r); caller != "" {
+		if req.Domain != caller && !strings.HasPrefix(req.Domain, caller+":") {
+			httputil.WriteError(w, http.StatusForbidden, "domain must be scoped to the calling service")
+			return
+		}
+	}
+
 	resp, err := s.Derive(r.Context(), &req)
 	if err != nil {
 		httputil.WriteError(w, http.StatusBadRequest, err.Error())
@@ -116,6 +178,37 @@ func (s *Service) handleDerive(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, resp)
 }
 
+// handleBatchVerify handles POST /verify-batch - domain-separated signature verification.
+func (s *Service) handleBatchVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httputil.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req BatchVerifyRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+	if len(req.Items) == 0 {
+		httputil.WriteError(w, http.StatusBadRequest, "items required")
+		return
+	}
+
+	results, err := s.verifyBatch(r.Context(), req.Items)
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp := BatchVerifyResponse{Results: results}
+	for i := range results {
+		if results[i].Valid {
+			resp.ValidCount++
+		}
+	}
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
 // handleAttestation handles GET /attestation - get current key attestation.
 func (s *Service) handleAttestation(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -123,7 +216,13 @@ func (s *Service) handleAttestation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	att, err := s.GetAttestation(r.Context())
+	scheme, err := s.requestScheme(SignatureScheme(r.URL.Query().Get("scheme")))
+	if err != nil {
+		httputil.BadRequest(w, err.Error())
+		return
+	}
+
+	att, err := s.GetSchemeAttestation(r.Context(), scheme)
 	if err != nil {
 		httputil.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
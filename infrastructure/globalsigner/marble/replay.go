@@ -0,0 +1,177 @@
+package globalsigner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/globalsigner/supabase"
+)
+
+// ErrRequestIDReused is returned when a RequestID already signed within the
+// retention window is submitted again with different data.
+var ErrRequestIDReused = errors.New("request_id already used with different data")
+
+// errReplayUnavailable wraps repository failures during a replay check.
+var errReplayUnavailable = errors.New("replay check unavailable")
+
+// replayKey identifies a signing request by purpose (domain), calling service
+// and RequestID.
+func replayKey(domain, serviceID, requestID string) string {
+	sum := sha256.Sum256([]byte(domain + "\x00" + serviceID + "\x00" + requestID))
+	return hex.EncodeToString(sum[:])
+}
+
+// replayStore remembers signatures issued for a RequestID until they expire.
+// Records are cached in memory and, when a repository is configured,
+// persisted so that duplicates are still detected after a restart.
+type replayStore struct {
+	retention time.Duration
+	repo      supabase.Repository
+	now       func() time.Time
+
+	mu       sync.Mutex
+	entries  map[string]*SignedRequest
+	inflight map[string]chan struct{}
+}
+
+func newReplayStore(retention time.Duration, repo supabase.Repository) *replayStore {
+	if retention <= 0 {
+		retention = DefaultReplayRetention
+	}
+	return &replayStore{
+		retention: retention,
+		repo:      repo,
+		now:       time.Now,
+		entries:   make(map[string]*SignedRequest),
+		inflight:  make(map[string]chan struct{}),
+	}
+}
+
+// lock serializes requests for the same key so concurrent duplicates cannot
+// both sign. The returned function releases the key.
+func (r *replayStore) lock(key string) func() {
+	for {
+		r.mu.Lock()
+		wait, busy := r.inflight[key]
+		if !busy {
+			done := make(chan struct{})
+			r.inflight[key] = done
+			r.mu.Unlock()
+			return func() {
+				r.mu.Lock()
+				delete(r.inflight, key)
+				r.mu.Unlock()
+				close(done)
+			}
+		}
+		r.mu.Unlock()
+		<-wait
+	}
+}
+
+// lookup returns the unexpired record for key, or nil if there is none. A
+// repository error is returned rather than treated as a miss, so an outage
+// cannot cause a second signature.
+func (r *replayStore) lookup(ctx context.Context, key string) (*SignedRequest, error) {
+	now := r.now()
+
+	r.mu.Lock()
+	rec, ok := r.entries[key]
+	if ok && !now.Before(rec.ExpiresAt) {
+		delete(r.entries, key)
+		ok = false
+	}
+	r.mu.Unlock()
+	if ok {
+		return rec, nil
+	}
+
+	if r.repo == nil {
+		return nil, nil
+	}
+	rec, err := r.repo.GetSignedRequest(ctx, key)
+	if err != nil {
+		if database.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", errReplayUnavailable, err)
+	}
+	if !now.Before(rec.ExpiresAt) {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	r.entries[key] = rec
+	r.mu.Unlock()
+	return rec, nil
+}
+
+// remember records a signature for key until the retention window ends.
+func (r *replayStore) remember(ctx context.Context, key string, data []byte, resp *SignResponse) error {
+	now := r.now()
+	sum := sha256.Sum256(data)
+	rec := &SignedRequest{
+		Key:        key,
+		DataHash:   hex.EncodeToString(sum[:]),
+		Signature:  resp.Signature,
+		KeyVersion: resp.KeyVersion,
+		PubKeyHex:  resp.PubKeyHex,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(r.retention),
+	}
+
+	r.mu.Lock()
+	r.entries[key] = rec
+	r.mu.Unlock()
+
+	if r.repo == nil {
+		return nil
+	}
+	return r.repo.StoreSignedRequest(ctx, rec)
+}
+
+// evictExpired drops expired records from memory and the repository.
+func (r *replayStore) evictExpired(ctx context.Context) error {
+	now := r.now()
+
+	r.mu.Lock()
+	for key, rec := range r.entries {
+		if !now.Before(rec.ExpiresAt) {
+			delete(r.entries, key)
+		}
+	}
+	r.mu.Unlock()
+
+	if r.repo == nil {
+		return nil
+	}
+	return r.repo.DeleteExpiredSignedRequests(ctx, now)
+}
+
+// size returns the number of records cached in memory.
+func (r *replayStore) size() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// replayed checks a signing request against an earlier signature for the
+// same key and returns that signature if the data matches.
+func replayed(rec *SignedRequest, data []byte) (*SignResponse, error) {
+	sum := sha256.Sum256(data)
+	if rec.DataHash != hex.EncodeToString(sum[:]) {
+		return nil, ErrRequestIDReused
+	}
+	return &SignResponse{
+		Signature:  rec.Signature,
+		KeyVersion: rec.KeyVersion,
+		PubKeyHex:  rec.PubKeyHex,
+		Replayed:   true,
+	}, nil
+}
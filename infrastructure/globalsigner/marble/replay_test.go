@@ -0,0 +1,114 @@
+package globalsigner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/globalsigner/supabase"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+	"github.com/R3E-Network/service_layer/infrastructure/serviceauth"
+)
+
+func newTestSigner(t *testing.T, repo supabase.Repository, retention time.Duration) *Service {
+	t.Helper()
+
+	m, err := marble.New(marble.Config{MarbleType: ServiceID})
+	if err != nil {
+		t.Fatalf("marble.New() error = %v", err)
+	}
+	svc, err := New(Config{Marble: m, Repository: repo, ReplayRetention: retention})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := svc.hydrate(context.Background()); err != nil {
+		t.Fatalf("hydrate() error = %v", err)
+	}
+	return svc
+}
+
+func TestSignDuplicateRequestIDReturnsOriginal(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+	ctx := serviceauth.WithServiceID(context.Background(), "neovrf")
+
+	first, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", RequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	second, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", RequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("Sign() duplicate error = %v", err)
+	}
+	if !second.Replayed || second.Signature != first.Signature {
+		t.Errorf("duplicate = %+v, want replay of %s", second, first.Signature)
+	}
+	if svc.signaturesIssued != 1 || svc.signaturesReplayed != 1 {
+		t.Errorf("issued = %d, replayed = %d; want 1, 1", svc.signaturesIssued, svc.signaturesReplayed)
+	}
+
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "02", RequestID: "req-1"}); !errors.Is(err, ErrRequestIDReused) {
+		t.Errorf("Sign() with different data error = %v, want ErrRequestIDReused", err)
+	}
+
+	// The same RequestID from another service or domain is a different request.
+	other := serviceauth.WithServiceID(context.Background(), "neoflow")
+	if resp, err := svc.Sign(other, &SignRequest{Domain: "neovrf", Data: "02", RequestID: "req-1"}); err != nil || resp.Replayed {
+		t.Errorf("Sign() from other service = %+v, %v; want fresh signature", resp, err)
+	}
+	if resp, err := svc.Sign(ctx, &SignRequest{Domain: "neocompute", Data: "02", RequestID: "req-1"}); err != nil || resp.Replayed {
+		t.Errorf("Sign() in other domain = %+v, %v; want fresh signature", resp, err)
+	}
+}
+
+func TestSignDuplicateDetectedAfterRestart(t *testing.T) {
+	repo := supabase.NewMockRepository()
+	ctx := serviceauth.WithServiceID(context.Background(), "neovrf")
+
+	first, err := newTestSigner(t, repo, time.Hour).Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", RequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	restarted := newTestSigner(t, repo, time.Hour)
+	second, err := restarted.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", RequestID: "req-1"})
+	if err != nil {
+		t.Fatalf("Sign() after restart error = %v", err)
+	}
+	if !second.Replayed || second.Signature != first.Signature {
+		t.Errorf("after restart = %+v, want replay of %s", second, first.Signature)
+	}
+}
+
+func TestReplayStoreExpiryEviction(t *testing.T) {
+	repo := supabase.NewMockRepository()
+	store := newReplayStore(time.Minute, repo)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	resp := &SignResponse{Signature: "aa", KeyVersion: "v1", PubKeyHex: "02"}
+	if err := store.remember(ctx, "k1", []byte{1}, resp); err != nil {
+		t.Fatalf("remember() error = %v", err)
+	}
+
+	now = now.Add(59 * time.Second)
+	if rec, err := store.lookup(ctx, "k1"); err != nil || rec == nil {
+		t.Fatalf("lookup() inside window = %v, %v; want record", rec, err)
+	}
+
+	now = now.Add(time.Second)
+	if rec, err := store.lookup(ctx, "k1"); err != nil || rec != nil {
+		t.Fatalf("lookup() at expiry = %v, %v; want nil", rec, err)
+	}
+
+	if err := store.evictExpired(ctx); err != nil {
+		t.Fatalf("evictExpired() error = %v", err)
+	}
+	if store.size() != 0 {
+		t.Errorf("size() = %d, want 0", store.size())
+	}
+	if _, err := repo.GetSignedRequest(ctx, "k1"); err == nil {
+		t.Error("expired record still persisted after eviction")
+	}
+}
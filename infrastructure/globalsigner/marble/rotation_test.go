@@ -0,0 +1,114 @@
+package globalsigner
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/globalsigner/supabase"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+func TestSignatureValidDuringOverlapAndInvalidAfterSunset(t *testing.T) {
+	repo := supabase.NewMockRepository()
+	svc := newTestSigner(t, repo, time.Hour)
+	ctx := context.Background()
+
+	oldVersion := svc.ActiveVersion()
+	sig, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "0102"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	now := time.Now().AddDate(0, 1, 0)
+	svc.now = func() time.Time { return now }
+
+	rotated, err := svc.Rotate(ctx, false)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if !rotated.Rotated || rotated.OldVersion != oldVersion || rotated.NewVersion == oldVersion {
+		t.Fatalf("Rotate() = %+v, want rotation away from %s", rotated, oldVersion)
+	}
+	wantSunset := now.UTC().Add(DefaultOverlapPeriod)
+	if rotated.OverlapEndsAt == nil || !rotated.OverlapEndsAt.Equal(wantSunset) {
+		t.Fatalf("OverlapEndsAt = %v, want %v", rotated.OverlapEndsAt, wantSunset)
+	}
+
+	item := VerifyItem{Domain: "neovrf", Data: "0102", Signature: sig.Signature, KeyVersion: oldVersion}
+	verify := func() VerifyResult {
+		t.Helper()
+		results, err := svc.verifyBatch(ctx, []VerifyItem{item})
+		if err != nil {
+			t.Fatalf("verifyBatch() error = %v", err)
+		}
+		return results[0]
+	}
+
+	// Just before sunset the old signature verifies and the old key can
+	// still sign.
+	now = wantSunset.Add(-time.Second)
+	if res := verify(); !res.Valid {
+		t.Errorf("during overlap: %+v, want valid", res)
+	}
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "03", KeyVersion: oldVersion}); err != nil {
+		t.Errorf("Sign(old version) during overlap error = %v", err)
+	}
+
+	// At sunset it no longer does.
+	now = wantSunset
+	if res := verify(); res.Valid || !strings.Contains(res.Error, "overlap expired") {
+		t.Errorf("after sunset: %+v, want invalid with overlap expired", res)
+	}
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "03", KeyVersion: oldVersion}); err == nil {
+		t.Error("Sign(old version) after sunset should fail")
+	}
+
+	if err := svc.retireSunsetKeys(ctx); err != nil {
+		t.Fatalf("retireSunsetKeys() error = %v", err)
+	}
+	if v, _ := repo.GetKeyVersion(ctx, oldVersion); v == nil || v.Status != KeyStatusRevoked {
+		t.Errorf("old key after retire = %+v, want revoked", v)
+	}
+	if v, _ := svc.GetKeyVersion(rotated.NewVersion); v.Status != KeyStatusActive {
+		t.Errorf("new key status = %s, want active", v.Status)
+	}
+}
+
+func TestOverlapVerifyOnly(t *testing.T) {
+	m, err := marble.New(marble.Config{MarbleType: ServiceID})
+	if err != nil {
+		t.Fatalf("marble.New() error = %v", err)
+	}
+	cfg := DefaultRotationConfig()
+	cfg.OverlapVerifyOnly = true
+	svc, err := New(Config{Marble: m, RotationConfig: cfg})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := svc.hydrate(ctx); err != nil {
+		t.Fatalf("hydrate() error = %v", err)
+	}
+
+	oldVersion := svc.ActiveVersion()
+	sig, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	now := time.Now().AddDate(0, 1, 0)
+	svc.now = func() time.Time { return now }
+	if _, err := svc.Rotate(ctx, false); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", KeyVersion: oldVersion}); err == nil {
+		t.Error("Sign(old version) should fail when the overlap is verify-only")
+	}
+	valid, err := svc.BatchVerify(ctx, []VerifyItem{{Domain: "neovrf", Data: "01", Signature: sig.Signature, KeyVersion: oldVersion}})
+	if err != nil || !valid[0] {
+		t.Errorf("BatchVerify(old version) = %v, %v; want valid", valid, err)
+	}
+}
@@ -0,0 +1,121 @@
+package globalsigner
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/R3E-Network/service_layer/infrastructure/crypto"
+)
+
+// ErrSchemeMismatch is returned when a signing request's key version was
+// created under a different signature scheme than the request expects.
+var ErrSchemeMismatch = errors.New("key version signature scheme mismatch")
+
+// signingKey is a key version's signing backend.
+type signingKey interface {
+	scheme() SignatureScheme
+	// sign signs msg. Both schemes take the un-hashed message: secp256r1
+	// hashes it with SHA-256, ed25519 with SHA-512 internally.
+	sign(msg []byte) ([]byte, error)
+	publicKey() []byte
+}
+
+// secp256r1Key signs with ECDSA over P-256 (Neo N3).
+type secp256r1Key struct {
+	priv *ecdsa.PrivateKey
+}
+
+func (k *secp256r1Key) scheme() SignatureScheme { return SchemeSecp256r1 }
+
+func (k *secp256r1Key) sign(msg []byte) ([]byte, error) {
+	return crypto.Sign(k.priv, msg)
+}
+
+func (k *secp256r1Key) publicKey() []byte {
+	return elliptic.MarshalCompressed(k.priv.Curve, k.priv.PublicKey.X, k.priv.PublicKey.Y)
+}
+
+// ed25519Key signs with Ed25519.
+type ed25519Key struct {
+	priv ed25519.PrivateKey
+}
+
+func (k *ed25519Key) scheme() SignatureScheme { return SchemeEd25519 }
+
+func (k *ed25519Key) sign(msg []byte) ([]byte, error) {
+	return ed25519.Sign(k.priv, msg), nil
+}
+
+func (k *ed25519Key) publicKey() []byte {
+	return k.priv.Public().(ed25519.PublicKey)
+}
+
+// keyInfo is the HKDF info a key version is derived with. secp256r1 keeps the
+// original info so existing key versions derive the same keys; other schemes
+// are namespaced so no key material is shared across curves.
+func keyInfo(version string, scheme SignatureScheme) string {
+	if scheme == SchemeSecp256r1 {
+		return "globalsigner:" + version
+	}
+	return "globalsigner:" + string(scheme) + ":" + version
+}
+
+// newSigningKey builds a scheme's key from 32 bytes of derived key material.
+func newSigningKey(scheme SignatureScheme, material []byte) (signingKey, error) {
+	switch scheme {
+	case SchemeSecp256r1:
+		curve := elliptic.P256()
+		priv := new(ecdsa.PrivateKey)
+		priv.PublicKey.Curve = curve
+		d := new(big.Int).SetBytes(material)
+		nMinus1 := new(big.Int).Sub(curve.Params().N, big.NewInt(1))
+		d.Mod(d, nMinus1)
+		d.Add(d, big.NewInt(1)) // ensure non-zero
+		priv.D = d
+		priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
+		return &secp256r1Key{priv: priv}, nil
+	case SchemeEd25519:
+		if len(material) != ed25519.SeedSize {
+			return nil, fmt.Errorf("ed25519 seed must be %d bytes, got %d", ed25519.SeedSize, len(material))
+		}
+		return &ed25519Key{priv: ed25519.NewKeyFromSeed(material)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature scheme %q", scheme)
+	}
+}
+
+// verifySignature checks sig over msg with a scheme's public key. An error
+// means the public key or signature is malformed.
+func verifySignature(scheme SignatureScheme, pubBytes, msg, sig []byte) (bool, error) {
+	if len(sig) != 64 {
+		return false, fmt.Errorf("invalid signature length: %d (want 64)", len(sig))
+	}
+	switch scheme {
+	case SchemeSecp256r1:
+		pub, err := crypto.PublicKeyFromBytes(pubBytes)
+		if err != nil {
+			return false, fmt.Errorf("invalid pubkey: %w", err)
+		}
+		return crypto.Verify(pub, msg, sig), nil
+	case SchemeEd25519:
+		if len(pubBytes) != ed25519.PublicKeySize {
+			return false, fmt.Errorf("invalid pubkey: ed25519 public key must be %d bytes, got %d", ed25519.PublicKeySize, len(pubBytes))
+		}
+		return ed25519.Verify(ed25519.PublicKey(pubBytes), msg, sig), nil
+	default:
+		return false, fmt.Errorf("unsupported signature scheme %q", scheme)
+	}
+}
+
+// keyScheme returns a key version's scheme, treating versions recorded before
+// schemes existed as DefaultSignatureScheme.
+func keyScheme(v *KeyVersion) SignatureScheme {
+	if v == nil || v.Scheme == "" {
+		return DefaultSignatureScheme
+	}
+	return v.Scheme
+}
@@ -0,0 +1,261 @@
+package globalsigner
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	neokeys "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	gsclient "github.com/R3E-Network/service_layer/infrastructure/globalsigner/client"
+	"github.com/R3E-Network/service_layer/infrastructure/globalsigner/supabase"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q) error = %v", s, err)
+	}
+	return b
+}
+
+func TestSigningKeyVectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		scheme   SignatureScheme
+		material string
+		msg      []byte
+		pub      string
+		sig      string
+	}{
+		{
+			// RFC 8032 section 7.1, TEST 1.
+			name:     "ed25519 RFC 8032",
+			scheme:   SchemeEd25519,
+			material: "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			msg:      nil,
+			pub:      "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			sig:      "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			// RFC 6979 A.2.5, P-256 with SHA-256, message "sample". Key
+			// material is x-1 because derivation maps material m to m+1, and s
+			// is the low-s form (n - s) that crypto.Sign produces.
+			name:     "secp256r1 RFC 6979",
+			scheme:   SchemeSecp256r1,
+			material: "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6720",
+			msg:      []byte("sample"),
+			pub:      "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+			sig:      "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716" + "0834e36ad29a83bf2bc9385e491d6099c8fdf9d1ed67aa7ea5f51f93782857a9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := newSigningKey(tt.scheme, mustHex(t, tt.material))
+			if err != nil {
+				t.Fatalf("newSigningKey() error = %v", err)
+			}
+			if got := hex.EncodeToString(key.publicKey()); got != tt.pub {
+				t.Errorf("publicKey() = %s, want %s", got, tt.pub)
+			}
+			sig, err := key.sign(tt.msg)
+			if err != nil {
+				t.Fatalf("sign() error = %v", err)
+			}
+			if got := hex.EncodeToString(sig); got != tt.sig {
+				t.Errorf("sign() = %s, want %s", got, tt.sig)
+			}
+			if ok, err := verifySignature(tt.scheme, key.publicKey(), tt.msg, sig); !ok || err != nil {
+				t.Errorf("verifySignature() = %v, %v; want true", ok, err)
+			}
+		})
+	}
+}
+
+func newSchemeSigner(t *testing.T, repo supabase.Repository, scheme SignatureScheme) *Service {
+	t.Helper()
+
+	m, err := marble.New(marble.Config{MarbleType: ServiceID})
+	if err != nil {
+		t.Fatalf("marble.New() error = %v", err)
+	}
+	m.SetTestSecret("GLOBALSIGNER_MASTER_SEED", make([]byte, 32))
+	svc, err := New(Config{Marble: m, Repository: repo, Scheme: scheme})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := svc.hydrate(context.Background()); err != nil {
+		t.Fatalf("hydrate() error = %v", err)
+	}
+	return svc
+}
+
+func TestSignSchemes(t *testing.T) {
+	for _, scheme := range []SignatureScheme{SchemeSecp256r1, SchemeEd25519} {
+		t.Run(string(scheme), func(t *testing.T) {
+			svc := newSchemeSigner(t, nil, scheme)
+
+			resp, err := svc.Sign(context.Background(), &SignRequest{Domain: "neovrf", Data: "0102"})
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			if resp.Scheme != scheme {
+				t.Errorf("Scheme = %q, want %q", resp.Scheme, scheme)
+			}
+
+			// The same domain-separated message verifies under either scheme.
+			valid, err := svc.BatchVerify(context.Background(), []VerifyItem{
+				{Domain: "neovrf", Data: "0102", Signature: resp.Signature, PubKeyHex: resp.PubKeyHex, Scheme: scheme},
+				{Domain: "neoflow", Data: "0102", Signature: resp.Signature, PubKeyHex: resp.PubKeyHex, Scheme: scheme},
+			})
+			if err != nil {
+				t.Fatalf("BatchVerify() error = %v", err)
+			}
+			if !valid[0] || valid[1] {
+				t.Errorf("BatchVerify() = %v, want [true false]", valid)
+			}
+		})
+	}
+}
+
+func TestSignRejectsSchemeMismatch(t *testing.T) {
+	svc := newSchemeSigner(t, nil, SchemeEd25519)
+	ctx := context.Background()
+
+	// A key version pinned under another scheme is rejected.
+	edVersion := svc.ActiveVersion()
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", KeyVersion: edVersion, Scheme: SchemeSecp256r1}); !errors.Is(err, ErrSchemeMismatch) {
+		t.Errorf("Sign() error = %v, want ErrSchemeMismatch", err)
+	}
+	if _, err := svc.SignRaw(ctx, &SignRawRequest{Data: "01", KeyVersion: edVersion, Scheme: SchemeSecp256r1}); !errors.Is(err, ErrSchemeMismatch) {
+		t.Errorf("SignRaw() error = %v, want ErrSchemeMismatch", err)
+	}
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", Scheme: "rsa"}); err == nil {
+		t.Error("Sign() with unknown scheme should fail")
+	}
+}
+
+func TestSignPicksActiveKeyOfRequestedScheme(t *testing.T) {
+	svc := newSchemeSigner(t, nil, SchemeEd25519)
+	ctx := context.Background()
+
+	ed, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01"})
+	if err != nil || ed.Scheme != SchemeEd25519 {
+		t.Fatalf("Sign() default = %+v, %v; want ed25519", ed, err)
+	}
+	ec, err := svc.SignRaw(ctx, &SignRawRequest{Data: "01", Scheme: SchemeSecp256r1})
+	if err != nil {
+		t.Fatalf("SignRaw(secp256r1) error = %v", err)
+	}
+	if ec.Scheme != SchemeSecp256r1 || ec.KeyVersion == ed.KeyVersion || len(mustHex(t, ec.PubKeyHex)) != 33 {
+		t.Errorf("SignRaw(secp256r1) = %+v, want the active secp256r1 key", ec)
+	}
+
+	att, err := svc.GetSchemeAttestation(ctx, SchemeSecp256r1)
+	if err != nil || att.KeyVersion != ec.KeyVersion {
+		t.Errorf("GetSchemeAttestation(secp256r1) = %+v, %v; want %s", att, err, ec.KeyVersion)
+	}
+}
+
+func TestChainSigningUnderEd25519Scheme(t *testing.T) {
+	svc := newSchemeSigner(t, nil, SchemeEd25519)
+	server := httptest.NewServer(svc.Router())
+	t.Cleanup(server.Close)
+
+	client, err := gsclient.New(gsclient.Config{BaseURL: server.URL, ServiceID: "neoaccounts"})
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	signer, err := chain.NewGlobalSignerSigner(context.Background(), client)
+	if err != nil {
+		t.Fatalf("NewGlobalSignerSigner() error = %v", err)
+	}
+	pub, err := neokeys.NewPublicKeyFromBytes(signer.GetVerificationScript()[2:35], elliptic.P256())
+	if err != nil {
+		t.Fatalf("verification script pubkey: %v", err)
+	}
+
+	tx := transaction.New([]byte{byte(opcode.RET)}, 0)
+	tx.ValidUntilBlock = 100
+	tx.Signers = []transaction.Signer{{Account: signer.ScriptHash(), Scopes: transaction.CalledByEntry}}
+	if err := signer.SignTx(netmode.TestNet, tx); err != nil {
+		t.Fatalf("SignTx() error = %v", err)
+	}
+	sig := tx.Scripts[0].InvocationScript[2:]
+	if !pub.VerifyHashable(sig, uint32(netmode.TestNet), tx) {
+		t.Error("SignTx() witness does not verify against the secp256r1 account key")
+	}
+
+	msg := []byte("payload")
+	raw, err := signer.Sign(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	digest := sha256.Sum256(msg)
+	if !pub.Verify(raw, digest[:]) {
+		t.Error("Sign() signature does not verify against the secp256r1 account key")
+	}
+}
+
+func TestSchemeChangeKeepsActiveKeyPerScheme(t *testing.T) {
+	repo := supabase.NewMockRepository()
+	ctx := context.Background()
+
+	old := newSchemeSigner(t, repo, SchemeSecp256r1)
+	ecVersion := old.ActiveVersion()
+
+	svc := newSchemeSigner(t, repo, SchemeEd25519)
+	active, err := svc.GetKeyVersion(svc.ActiveVersion())
+	if err != nil {
+		t.Fatalf("GetKeyVersion() error = %v", err)
+	}
+	if active.Version == ecVersion || active.Scheme != SchemeEd25519 {
+		t.Fatalf("active key = %s (%s), want a new ed25519 key", active.Version, active.Scheme)
+	}
+
+	// The secp256r1 key stays active for its scheme: it is not rotated away
+	// and unpinned secp256r1 requests still get it.
+	if v, _ := svc.GetKeyVersion(ecVersion); v.Status != KeyStatusActive {
+		t.Errorf("secp256r1 key status = %s, want active", v.Status)
+	}
+	if _, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", KeyVersion: ecVersion}); !errors.Is(err, ErrSchemeMismatch) {
+		t.Errorf("Sign(secp256r1 version) error = %v, want ErrSchemeMismatch", err)
+	}
+	resp, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01", Scheme: SchemeSecp256r1})
+	if err != nil || resp.KeyVersion != ecVersion {
+		t.Errorf("Sign(secp256r1) = %+v, %v; want %s", resp, err, ecVersion)
+	}
+
+	// Restarting under the same scheme keeps both active keys.
+	again := newSchemeSigner(t, repo, SchemeEd25519)
+	if again.ActiveVersion() != active.Version || again.activeVersionFor(SchemeSecp256r1) != ecVersion {
+		t.Errorf("active after restart = %s/%s, want %s/%s", again.ActiveVersion(), again.activeVersionFor(SchemeSecp256r1), active.Version, ecVersion)
+	}
+}
+
+func TestRotationWorkerRotatesEachScheme(t *testing.T) {
+	svc := newSchemeSigner(t, nil, SchemeEd25519)
+	ed, ec := svc.ActiveVersion(), svc.activeVersionFor(SchemeSecp256r1)
+
+	now := time.Now().AddDate(0, 2, 0)
+	svc.now = func() time.Time { return now }
+	if err := svc.rotationWorkerWithError(context.Background()); err != nil {
+		t.Fatalf("rotationWorkerWithError() error = %v", err)
+	}
+	if svc.ActiveVersion() == ed || svc.activeVersionFor(SchemeSecp256r1) == ec {
+		t.Errorf("active versions after rotation = %s/%s, want both rotated from %s/%s",
+			svc.ActiveVersion(), svc.activeVersionFor(SchemeSecp256r1), ed, ec)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/R3E-Network/service_layer/infrastructure/marble"
 	"github.com/R3E-Network/service_layer/infrastructure/runtime"
 	commonservice "github.com/R3E-Network/service_layer/infrastructure/service"
+	"github.com/R3E-Network/service_layer/infrastructure/serviceauth"
 )
 
 // =============================================================================
@@ -34,27 +36,35 @@ type Service struct {
 
 	// Configuration
 	rotationConfig *RotationConfig
+	scheme         SignatureScheme
 
 	// Master seed (injected via MarbleRun)
 	masterSeed []byte
 
-	// Key management
-	activeVersion string
-	keys          map[string]*keyEntry
+	// Key management: one active version per scheme.
+	activeVersions map[SignatureScheme]string
+	keys           map[string]*keyEntry
 
 	// Repository
 	repo supabase.Repository
 
+	// Replay protection for signing requests carrying a RequestID
+	replay *replayStore
+
 	// Metrics
-	signaturesIssued int64
-	rotationsCount   int64
-	startTime        time.Time
+	signaturesIssued   int64
+	signaturesReplayed int64
+	rotationsCount     int64
+	startTime          time.Time
+
+	// now is the clock used for rotation and overlap checks.
+	now func() time.Time
 }
 
-// keyEntry holds a key version's private key and metadata.
+// keyEntry holds a key version's signing key and metadata.
 type keyEntry struct {
-	privateKey *ecdsa.PrivateKey
-	version    *KeyVersion
+	key     signingKey
+	version *KeyVersion
 }
 
 // Config holds GlobalSigner service configuration.
@@ -63,6 +73,16 @@ type Config struct {
 	DB             database.RepositoryInterface
 	Repository     supabase.Repository
 	RotationConfig *RotationConfig
+
+	// Scheme is the signature scheme requests get when they do not name one
+	// (default: GLOBALSIGNER_SCHEME, else DefaultSignatureScheme). Each
+	// scheme keeps its own active key; secp256r1 is always kept because
+	// chain signing requires it.
+	Scheme SignatureScheme
+
+	// ReplayRetention is how long signed RequestIDs are remembered (default:
+	// GLOBALSIGNER_REPLAY_RETENTION, else DefaultReplayRetention).
+	ReplayRetention time.Duration
 }
 
 // =============================================================================
@@ -74,6 +94,22 @@ func New(cfg Config) (*Service, error) {
 	if cfg.RotationConfig == nil {
 		cfg.RotationConfig = DefaultRotationConfig()
 	}
+	if cfg.Scheme == "" {
+		cfg.Scheme = SignatureScheme(os.Getenv("GLOBALSIGNER_SCHEME"))
+	}
+	scheme, err := ParseSignatureScheme(string(cfg.Scheme))
+	if err != nil {
+		return nil, fmt.Errorf("globalsigner: %w", err)
+	}
+	if cfg.ReplayRetention <= 0 {
+		if raw := strings.TrimSpace(os.Getenv("GLOBALSIGNER_REPLAY_RETENTION")); raw != "" {
+			retention, err := time.ParseDuration(raw)
+			if err != nil || retention <= 0 {
+				return nil, fmt.Errorf("globalsigner: invalid GLOBALSIGNER_REPLAY_RETENTION %q", raw)
+			}
+			cfg.ReplayRetention = retention
+		}
+	}
 
 	base := commonservice.NewBase(&commonservice.BaseConfig{
 		ID:      ServiceID,
@@ -89,9 +125,13 @@ func New(cfg Config) (*Service, error) {
 	s := &Service{
 		BaseService:    base,
 		rotationConfig: cfg.RotationConfig,
+		scheme:         scheme,
+		activeVersions: make(map[SignatureScheme]string),
 		keys:           make(map[string]*keyEntry),
 		repo:           cfg.Repository,
+		replay:         newReplayStore(cfg.ReplayRetention, cfg.Repository),
 		startTime:      time.Now(),
+		now:            time.Now,
 	}
 
 	// Set up hydration to load keys on startup
@@ -105,10 +145,20 @@ func New(cfg Config) (*Service, error) {
 		s.AddTickerWorker(24*time.Hour, s.rotationWorkerWithError)
 	}
 
-	// Attach ServeMux routes to the marble router.
+	// Revoke deprecated keys whose overlap period has ended (runs hourly)
+	s.AddTickerWorker(time.Hour, s.retireSunsetKeys, commonservice.WithTickerWorkerName("key-sunset"))
+
+	// Drop expired replay records (runs hourly)
+	s.AddTickerWorker(time.Hour, s.replay.evictExpired, commonservice.WithTickerWorkerName("replay-evict"))
+
+	// Require persistence for key versions and replay records
+	s.AddSelfTest(s.selfTest)
+
+	// Attach ServeMux routes to the marble router. Router middleware does not
+	// run for the NotFoundHandler, so read-only mode is applied explicitly.
 	mux := http.NewServeMux()
 	s.RegisterRoutes(mux)
-	s.Router().NotFoundHandler = mux
+	s.Router().NotFoundHandler = s.WithReadOnly(mux)
 
 	return s, nil
 }
@@ -117,6 +167,15 @@ func New(cfg Config) (*Service, error) {
 // Lifecycle
 // =============================================================================
 
+// selfTest requires the repository: without it key versions and replay
+// records live only in memory and are lost on restart.
+func (s *Service) selfTest(ctx context.Context) error {
+	return s.CheckDependencies(ctx, commonservice.Dependency{
+		Name:    "key version repository (SUPABASE_URL)",
+		Missing: s.repo == nil,
+	})
+}
+
 // hydrate loads master seed and existing keys from storage.
 func (s *Service) hydrate(ctx context.Context) error {
 	s.Logger().Info(ctx, "Hydrating GlobalSigner state...", nil)
@@ -166,21 +225,33 @@ func (s *Service) hydrate(ctx context.Context) error {
 		}
 	}
 
-	// Bootstrap if no active key exists
-	if s.activeVersion == "" {
-		s.Logger().Info(ctx, "No active key found, bootstrapping initial key...", nil)
-		if _, err := s.rotate(ctx, true); err != nil {
-			return fmt.Errorf("failed to bootstrap initial key: %w", err)
+	// Bootstrap every scheme that has no active key yet.
+	for _, scheme := range s.keptSchemes() {
+		if s.activeVersionFor(scheme) != "" {
+			continue
+		}
+		s.Logger().Info(ctx, "No active key found, bootstrapping initial key...", map[string]interface{}{"scheme": scheme})
+		if _, err := s.rotate(ctx, scheme, true); err != nil {
+			return fmt.Errorf("failed to bootstrap initial %s key: %w", scheme, err)
 		}
 	}
 
-	s.Logger().Info(ctx, "GlobalSigner hydrated", map[string]interface{}{"active_version": s.activeVersion, "key_count": len(s.keys)})
+	s.Logger().Info(ctx, "GlobalSigner hydrated", map[string]interface{}{"active_version": s.ActiveVersion(), "key_count": len(s.keys)})
 	return nil
 }
 
+// keptSchemes returns the schemes that always have an active key: the
+// configured scheme, plus secp256r1 for chain signing.
+func (s *Service) keptSchemes() []SignatureScheme {
+	if s.scheme == SchemeSecp256r1 {
+		return []SignatureScheme{SchemeSecp256r1}
+	}
+	return []SignatureScheme{s.scheme, SchemeSecp256r1}
+}
+
 // loadKeyVersion derives and loads a key version into memory.
 func (s *Service) loadKeyVersion(v *KeyVersion) error {
-	priv, err := s.deriveKeyForVersion(v.Version)
+	key, err := s.deriveKeyForVersion(v.Version, keyScheme(v))
 	if err != nil {
 		return err
 	}
@@ -189,38 +260,25 @@ func (s *Service) loadKeyVersion(v *KeyVersion) error {
 	defer s.mu.Unlock()
 
 	s.keys[v.Version] = &keyEntry{
-		privateKey: priv,
-		version:    v,
+		key:     key,
+		version: v,
 	}
 
 	if v.Status == KeyStatusActive {
-		s.activeVersion = v.Version
+		s.activeVersions[keyScheme(v)] = v.Version
 	}
 
 	return nil
 }
 
-// deriveKeyForVersion derives a P-256 private key for a given version.
-func (s *Service) deriveKeyForVersion(version string) (*ecdsa.PrivateKey, error) {
+// deriveKeyForVersion derives the signing key of a version under a scheme.
+func (s *Service) deriveKeyForVersion(version string, scheme SignatureScheme) (signingKey, error) {
 	// Use HKDF to derive key material
-	info := "globalsigner:" + version
-	keyMaterial, err := crypto.DeriveKey(s.masterSeed, nil, info, 32)
+	keyMaterial, err := crypto.DeriveKey(s.masterSeed, nil, keyInfo(version, scheme), 32)
 	if err != nil {
 		return nil, fmt.Errorf("key derivation failed: %w", err)
 	}
-
-	// Convert to P-256 private key using standard library
-	curve := elliptic.P256()
-	priv := new(ecdsa.PrivateKey)
-	priv.PublicKey.Curve = curve
-	d := new(big.Int).SetBytes(keyMaterial)
-	nMinus1 := new(big.Int).Sub(curve.Params().N, big.NewInt(1))
-	d.Mod(d, nMinus1)
-	d.Add(d, big.NewInt(1)) // ensure non-zero
-	priv.D = d
-	priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
-
-	return priv, nil
+	return newSigningKey(scheme, keyMaterial)
 }
 
 // statistics returns service statistics for the /info endpoint.
@@ -233,13 +291,23 @@ func (s *Service) statistics() map[string]any {
 		keyVersions = append(keyVersions, v)
 	}
 
+	activeVersions := make(map[string]string, len(s.activeVersions))
+	for scheme, version := range s.activeVersions {
+		activeVersions[string(scheme)] = version
+	}
+
 	return map[string]any{
-		"active_version":    s.activeVersion,
-		"key_versions":      keyVersions,
-		"signatures_issued": s.signaturesIssued,
-		"rotations_count":   s.rotationsCount,
-		"uptime":            time.Since(s.startTime).String(),
-		"is_enclave":        s.Marble().IsEnclave(),
+		"active_version":      s.activeVersions[s.scheme],
+		"active_versions":     activeVersions,
+		"scheme":              s.scheme,
+		"key_versions":        keyVersions,
+		"signatures_issued":   s.signaturesIssued,
+		"signatures_replayed": s.signaturesReplayed,
+		"replay_retention":    s.replay.retention.String(),
+		"replay_cached":       s.replay.size(),
+		"rotations_count":     s.rotationsCount,
+		"uptime":              time.Since(s.startTime).String(),
+		"is_enclave":          s.Marble().IsEnclave(),
 	}
 }
 
@@ -247,46 +315,51 @@ func (s *Service) statistics() map[string]any {
 // Key Rotation
 // =============================================================================
 
-// rotationWorkerWithError checks if rotation is needed and performs it.
+// rotationWorkerWithError rotates every scheme's active key whose rotation
+// period has elapsed.
 func (s *Service) rotationWorkerWithError(ctx context.Context) error {
 	s.mu.RLock()
-	activeEntry := s.keys[s.activeVersion]
-	s.mu.RUnlock()
-
-	if activeEntry == nil || activeEntry.version == nil {
-		return nil
-	}
-
-	// Check if rotation is due
-	activatedAt := activeEntry.version.ActivatedAt
-	if activatedAt == nil {
-		return nil
-	}
-
-	nextRotation := activatedAt.Add(s.rotationConfig.RotationPeriod)
-	if time.Now().Before(nextRotation) {
-		return nil
+	due := make([]SignatureScheme, 0, len(s.activeVersions))
+	for scheme, version := range s.activeVersions {
+		entry := s.keys[version]
+		if entry == nil || entry.version == nil || entry.version.ActivatedAt == nil {
+			continue
+		}
+		if !s.now().Before(entry.version.ActivatedAt.Add(s.rotationConfig.RotationPeriod)) {
+			due = append(due, scheme)
+		}
 	}
+	s.mu.RUnlock()
 
-	s.Logger().Info(ctx, "Rotation period reached, initiating key rotation...", nil)
-	if _, err := s.rotate(ctx, false); err != nil {
-		s.Logger().Error(ctx, "Automatic key rotation failed", err, nil)
-		return err
+	var firstErr error
+	for _, scheme := range due {
+		s.Logger().Info(ctx, "Rotation period reached, initiating key rotation...", map[string]interface{}{"scheme": scheme})
+		if _, err := s.rotate(ctx, scheme, false); err != nil {
+			s.Logger().Error(ctx, "Automatic key rotation failed", err, map[string]interface{}{"scheme": scheme})
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
 	}
-	return nil
+	return firstErr
 }
 
-// Rotate performs a key rotation.
+// Rotate rotates the configured scheme's active key.
 func (s *Service) Rotate(ctx context.Context, force bool) (*RotateResponse, error) {
-	return s.rotate(ctx, force)
+	return s.rotate(ctx, s.scheme, force)
+}
+
+// RotateScheme rotates the active key of scheme.
+func (s *Service) RotateScheme(ctx context.Context, scheme SignatureScheme, force bool) (*RotateResponse, error) {
+	return s.rotate(ctx, scheme, force)
 }
 
-func (s *Service) rotate(ctx context.Context, force bool) (*RotateResponse, error) {
-	now := time.Now().UTC()
-	newVersion := keyVersionFromTime(now)
+func (s *Service) rotate(ctx context.Context, scheme SignatureScheme, force bool) (*RotateResponse, error) {
+	now := s.now().UTC()
+	newVersion := keyVersionFor(now, scheme)
 
 	s.mu.Lock()
-	oldVersion := s.activeVersion
+	oldVersion := s.activeVersions[scheme]
 
 	// Idempotency check
 	if oldVersion == newVersion && !force {
@@ -301,13 +374,13 @@ func (s *Service) rotate(ctx context.Context, force bool) (*RotateResponse, erro
 	s.mu.Unlock()
 
 	// Derive new key
-	priv, err := s.deriveKeyForVersion(newVersion)
+	key, err := s.deriveKeyForVersion(newVersion, scheme)
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive new key: %w", err)
 	}
 
 	// Compute public key hash
-	pubKeyBytes := elliptic.MarshalCompressed(priv.Curve, priv.PublicKey.X, priv.PublicKey.Y)
+	pubKeyBytes := key.publicKey()
 	pubKeyHex := hex.EncodeToString(pubKeyBytes)
 	pubKeyHash := sha256.Sum256(pubKeyBytes)
 	pubKeyHashHex := hex.EncodeToString(pubKeyHash[:])
@@ -316,6 +389,7 @@ func (s *Service) rotate(ctx context.Context, force bool) (*RotateResponse, erro
 	newKeyVersion := &KeyVersion{
 		Version:     newVersion,
 		Status:      KeyStatusActive,
+		Scheme:      scheme,
 		PubKeyHex:   pubKeyHex,
 		PubKeyHash:  pubKeyHashHex,
 		CreatedAt:   now,
@@ -340,10 +414,10 @@ func (s *Service) rotate(ctx context.Context, force bool) (*RotateResponse, erro
 
 	// Add new key
 	s.keys[newVersion] = &keyEntry{
-		privateKey: priv,
-		version:    newKeyVersion,
+		key:     key,
+		version: newKeyVersion,
 	}
-	s.activeVersion = newVersion
+	s.activeVersions[scheme] = newVersion
 	s.rotationsCount++
 	s.mu.Unlock()
 
@@ -374,6 +448,7 @@ func (s *Service) rotate(ctx context.Context, force bool) (*RotateResponse, erro
 	}
 
 	s.Logger().Info(ctx, "Key rotation completed", map[string]interface{}{
+		"scheme":          scheme,
 		"old_version":     oldVersion,
 		"new_version":     newVersion,
 		"overlap_ends_at": overlapEndsAt,
@@ -388,76 +463,147 @@ func (s *Service) rotate(ctx context.Context, force bool) (*RotateResponse, erro
 	}, nil
 }
 
+// checkKeyUsable reports why a key version can no longer be used, if it
+// cannot: it was revoked, or it is deprecated and its overlap period (sunset)
+// has passed.
+func checkKeyUsable(v *KeyVersion, now time.Time) error {
+	switch v.Status {
+	case KeyStatusRevoked:
+		return fmt.Errorf("key version revoked: %s", v.Version)
+	case KeyStatusOverlapping:
+		if v.OverlapEndsAt != nil && !now.Before(*v.OverlapEndsAt) {
+			return fmt.Errorf("key version overlap expired: %s (sunset %s)", v.Version, v.OverlapEndsAt.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// retireSunsetKeys revokes deprecated key versions whose overlap period has
+// ended.
+func (s *Service) retireSunsetKeys(ctx context.Context) error {
+	now := s.now()
+
+	var retired []string
+	s.mu.Lock()
+	for version, entry := range s.keys {
+		if entry.version.Status != KeyStatusOverlapping || checkKeyUsable(entry.version, now) == nil {
+			continue
+		}
+		revokedAt := now
+		entry.version.Status = KeyStatusRevoked
+		entry.version.RevokedAt = &revokedAt
+		retired = append(retired, version)
+	}
+	s.mu.Unlock()
+
+	var firstErr error
+	for _, version := range retired {
+		s.Logger().Info(ctx, "Deprecated key version reached sunset, revoked", map[string]interface{}{"version": version})
+		if s.repo == nil {
+			continue
+		}
+		if err := s.repo.UpdateKeyStatus(ctx, version, KeyStatusRevoked, nil); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("revoke %s: %w", version, err)
+		}
+	}
+	return firstErr
+}
+
 // keyVersionFromTime generates a version string from a timestamp.
 func keyVersionFromTime(t time.Time) string {
 	return fmt.Sprintf("v%d-%02d", t.Year(), t.Month())
 }
 
+// keyVersionFor generates a version string for a key of the given scheme.
+// Non-default schemes get a suffix so switching schemes within a rotation
+// period creates a distinct version rather than replacing the active one.
+func keyVersionFor(t time.Time, scheme SignatureScheme) string {
+	if scheme == DefaultSignatureScheme {
+		return keyVersionFromTime(t)
+	}
+	return keyVersionFromTime(t) + "-" + string(scheme)
+}
+
 // =============================================================================
 // Signing Operations
 // =============================================================================
 
-// Sign performs domain-separated signing.
+// Sign performs domain-separated signing. If req.RequestID is set, a repeat
+// of the same RequestID (for the same domain and calling service) within the
+// replay retention window returns the original signature, or
+// ErrRequestIDReused if the data differs.
 func (s *Service) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
-	if req.Domain == "" {
-		return nil, fmt.Errorf("domain is required")
-	}
-	if req.Data == "" {
-		return nil, fmt.Errorf("data is required")
-	}
-
-	data, err := decodeHexString(req.Data)
+	data, scheme, err := s.parseSignRequest(req)
 	if err != nil {
-		return nil, fmt.Errorf("invalid data hex: %w", err)
+		return nil, err
 	}
 
-	// Get signing key
-	version := req.KeyVersion
-	if version == "" {
-		version = s.ActiveVersion()
+	if req.RequestID == "" {
+		return s.sign(req.Domain, req.KeyVersion, scheme, data)
 	}
 
-	s.mu.RLock()
-	entry, ok := s.keys[version]
-	s.mu.RUnlock()
+	key := replayKey(req.Domain, serviceauth.GetServiceID(ctx), req.RequestID)
+	unlock := s.replay.lock(key)
+	defer unlock()
 
-	if !ok {
-		return nil, fmt.Errorf("key version not found: %s", version)
+	rec, err := s.replay.lookup(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		resp, err := replayed(rec, data)
+		if err != nil {
+			return nil, err
+		}
+		resp.Scheme = s.versionScheme(resp.KeyVersion)
+		s.mu.Lock()
+		s.signaturesReplayed++
+		s.mu.Unlock()
+		return resp, nil
 	}
 
-	// Validate key status
-	if entry.version.Status == KeyStatusRevoked {
-		return nil, fmt.Errorf("key version revoked: %s", version)
+	resp, err := s.sign(req.Domain, req.KeyVersion, scheme, data)
+	if err != nil {
+		return nil, err
 	}
-	if entry.version.Status == KeyStatusOverlapping {
-		if entry.version.OverlapEndsAt != nil && time.Now().After(*entry.version.OverlapEndsAt) {
-			return nil, fmt.Errorf("key version overlap expired: %s", version)
-		}
+	if err := s.replay.remember(ctx, key, data, resp); err != nil {
+		s.Logger().Warn(ctx, "Failed to persist signed request; duplicate detection limited to this instance", map[string]interface{}{
+			"domain": req.Domain,
+			"error":  err.Error(),
+		})
 	}
+	return resp, nil
+}
 
-	// Domain-separated signing: sign over sha256(domain || 0x00 || data).
-	// crypto.Sign hashes its input with sha256 before producing the Neo-style
-	// 64-byte (r||s) signature, so we pass the un-hashed message here to avoid
-	// accidentally double hashing.
-	signingMessage := make([]byte, 0, len(req.Domain)+1+len(data))
-	signingMessage = append(signingMessage, []byte(req.Domain)...)
-	signingMessage = append(signingMessage, 0x00) // separator
-	signingMessage = append(signingMessage, data...)
+// parseSignRequest validates a signing request's fields and returns its
+// decoded data and expected scheme.
+func (s *Service) parseSignRequest(req *SignRequest) ([]byte, SignatureScheme, error) {
+	if req.Domain == "" {
+		return nil, "", fmt.Errorf("domain is required")
+	}
+	if req.Data == "" {
+		return nil, "", fmt.Errorf("data is required")
+	}
 
-	sig, err := crypto.Sign(entry.privateKey, signingMessage)
+	data, err := decodeHexString(req.Data)
 	if err != nil {
-		return nil, fmt.Errorf("signing failed: %w", err)
+		return nil, "", fmt.Errorf("invalid data hex: %w", err)
 	}
 
-	s.mu.Lock()
-	s.signaturesIssued++
-	s.mu.Unlock()
+	scheme, err := s.requestScheme(req.Scheme)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, scheme, nil
+}
 
-	return &SignResponse{
-		Signature:  hex.EncodeToString(sig),
-		KeyVersion: version,
-		PubKeyHex:  entry.version.PubKeyHex,
-	}, nil
+// sign produces a domain-separated signature over data.
+func (s *Service) sign(domain, version string, scheme SignatureScheme, data []byte) (*SignResponse, error) {
+	// Domain-separated signing: every scheme signs domain || 0x00 || data.
+	// The backends hash the message themselves (crypto.Sign with sha256,
+	// ed25519 with sha512), so we pass the un-hashed message here to avoid
+	// accidentally double hashing.
+	return s.signWith(version, scheme, domainMessage(domain, data))
 }
 
 // SignRaw signs data as-is without domain separation.
@@ -478,9 +624,40 @@ func (s *Service) SignRaw(ctx context.Context, req *SignRawRequest) (*SignRespon
 		return nil, fmt.Errorf("invalid data hex: %w", err)
 	}
 
-	version := req.KeyVersion
+	scheme, err := s.requestScheme(req.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return s.signWith(req.KeyVersion, scheme, data)
+}
+
+// requestScheme resolves a request's expected scheme, defaulting to the
+// service's configured scheme.
+func (s *Service) requestScheme(raw SignatureScheme) (SignatureScheme, error) {
+	if raw == "" {
+		return s.scheme, nil
+	}
+	return ParseSignatureScheme(string(raw))
+}
+
+// versionScheme returns the scheme of a loaded key version.
+func (s *Service) versionScheme(version string) SignatureScheme {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if entry, ok := s.keys[version]; ok {
+		return keyScheme(entry.version)
+	}
+	return ""
+}
+
+// signingEntry returns a key version (default: the scheme's active one) that
+// is usable for signing and was created under the expected scheme.
+func (s *Service) signingEntry(version string, scheme SignatureScheme) (string, *keyEntry, error) {
 	if version == "" {
-		version = s.ActiveVersion()
+		version = s.activeVersionFor(scheme)
+		if version == "" {
+			return "", nil, fmt.Errorf("no active %s key version", scheme)
+		}
 	}
 
 	s.mu.RLock()
@@ -488,20 +665,30 @@ func (s *Service) SignRaw(ctx context.Context, req *SignRawRequest) (*SignRespon
 	s.mu.RUnlock()
 
 	if !ok {
-		return nil, fmt.Errorf("key version not found: %s", version)
+		return "", nil, fmt.Errorf("key version not found: %s", version)
 	}
 
 	// Validate key status
-	if entry.version.Status == KeyStatusRevoked {
-		return nil, fmt.Errorf("key version revoked: %s", version)
+	if err := checkKeyUsable(entry.version, s.now()); err != nil {
+		return "", nil, err
 	}
-	if entry.version.Status == KeyStatusOverlapping {
-		if entry.version.OverlapEndsAt != nil && time.Now().After(*entry.version.OverlapEndsAt) {
-			return nil, fmt.Errorf("key version overlap expired: %s", version)
-		}
+	if entry.version.Status == KeyStatusOverlapping && s.rotationConfig.OverlapVerifyOnly {
+		return "", nil, fmt.Errorf("key version deprecated: %s is only valid for verification until its sunset", version)
+	}
+	if got := keyScheme(entry.version); got != scheme {
+		return "", nil, fmt.Errorf("%w: %s is %s, request expects %s", ErrSchemeMismatch, version, got, scheme)
+	}
+	return version, entry, nil
+}
+
+// signWith signs msg with a key version (see signingEntry).
+func (s *Service) signWith(version string, scheme SignatureScheme, msg []byte) (*SignResponse, error) {
+	version, entry, err := s.signingEntry(version, scheme)
+	if err != nil {
+		return nil, err
 	}
 
-	sig, err := crypto.Sign(entry.privateKey, data)
+	sig, err := entry.key.sign(msg)
 	if err != nil {
 		return nil, fmt.Errorf("signing failed: %w", err)
 	}
@@ -514,9 +701,105 @@ func (s *Service) SignRaw(ctx context.Context, req *SignRawRequest) (*SignRespon
 		Signature:  hex.EncodeToString(sig),
 		KeyVersion: version,
 		PubKeyHex:  entry.version.PubKeyHex,
+		Scheme:     scheme,
 	}, nil
 }
 
+// domainMessage builds the domain-separated message domain || 0x00 || data.
+func domainMessage(domain string, data []byte) []byte {
+	msg := make([]byte, 0, len(domain)+1+len(data))
+	msg = append(msg, []byte(domain)...)
+	msg = append(msg, 0x00) // separator
+	msg = append(msg, data...)
+	return msg
+}
+
+// =============================================================================
+// Verification
+// =============================================================================
+
+// MaxBatchVerifyItems bounds the number of signatures checked per batch.
+const MaxBatchVerifyItems = 100
+
+// BatchVerify checks domain-separated signatures produced by Sign. Results
+// are in item order. A malformed item (bad hex, bad public key) is reported
+// as false rather than failing the batch; the error is reserved for
+// batch-level problems such as an oversized batch or a cancelled context.
+func (s *Service) BatchVerify(ctx context.Context, items []VerifyItem) ([]bool, error) {
+	results, err := s.verifyBatch(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	valid := make([]bool, len(results))
+	for i := range results {
+		valid[i] = results[i].Valid
+	}
+	return valid, nil
+}
+
+// verifyBatch is BatchVerify with per-item error details.
+func (s *Service) verifyBatch(ctx context.Context, items []VerifyItem) ([]VerifyResult, error) {
+	if len(items) > MaxBatchVerifyItems {
+		return nil, fmt.Errorf("too many items: %d (max %d)", len(items), MaxBatchVerifyItems)
+	}
+
+	results := make([]VerifyResult, len(items))
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		valid, err := s.verifyItem(&items[i])
+		results[i].Valid = valid
+		if err != nil {
+			results[i].Error = err.Error()
+		}
+	}
+	return results, nil
+}
+
+// verifyItem checks one signature. An error means the item was malformed or
+// its key version is unknown or no longer valid.
+func (s *Service) verifyItem(item *VerifyItem) (bool, error) {
+	if item.Domain == "" {
+		return false, fmt.Errorf("domain is required")
+	}
+	scheme, err := ParseSignatureScheme(string(item.Scheme))
+	if err != nil {
+		return false, err
+	}
+	pubKeyHex := item.PubKeyHex
+	if item.KeyVersion != "" {
+		v, err := s.GetKeyVersion(item.KeyVersion)
+		if err != nil {
+			return false, err
+		}
+		if err := checkKeyUsable(v, s.now()); err != nil {
+			return false, err
+		}
+		if item.Scheme != "" && scheme != keyScheme(v) {
+			return false, fmt.Errorf("%w: %s is %s", ErrSchemeMismatch, v.Version, keyScheme(v))
+		}
+		if pubKeyHex != "" && !strings.EqualFold(strings.TrimPrefix(pubKeyHex, "0x"), v.PubKeyHex) {
+			return false, fmt.Errorf("pubkey does not match key version %s", v.Version)
+		}
+		scheme, pubKeyHex = keyScheme(v), v.PubKeyHex
+	}
+	data, err := decodeHexString(item.Data)
+	if err != nil {
+		return false, fmt.Errorf("invalid data hex: %w", err)
+	}
+	sig, err := decodeHexString(item.Signature)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature hex: %w", err)
+	}
+	pubBytes, err := decodeHexString(pubKeyHex)
+	if err != nil {
+		return false, fmt.Errorf("invalid pubkey hex: %w", err)
+	}
+
+	return verifySignature(scheme, pubBytes, domainMessage(item.Domain, data), sig)
+}
+
 func decodeHexString(raw string) ([]byte, error) {
 	trimmed := strings.TrimSpace(raw)
 	trimmed = strings.TrimPrefix(trimmed, "0x")
@@ -528,13 +811,52 @@ func decodeHexString(raw string) ([]byte, error) {
 // Key Derivation
 // =============================================================================
 
+// Derivation path limits.
+const (
+	MaxDerivationDepth      = 8
+	MaxDerivationSegmentLen = 64
+)
+
+// ValidateDerivationPath checks that path follows the hierarchical scheme
+// segment[/segment...]: at most MaxDerivationDepth non-empty segments of
+// [A-Za-z0-9_.-], each at most MaxDerivationSegmentLen long, and no "." or
+// ".." segments. Paths cannot contain ':', so the HKDF info domain:path
+// splits unambiguously at its last ':' and distinct (domain, path) pairs
+// never derive the same key.
+func ValidateDerivationPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("path is required")
+	}
+	segments := strings.Split(path, "/")
+	if len(segments) > MaxDerivationDepth {
+		return fmt.Errorf("invalid path: depth %d exceeds %d", len(segments), MaxDerivationDepth)
+	}
+	for i, seg := range segments {
+		if seg == "" || seg == "." || seg == ".." {
+			return fmt.Errorf("invalid path: segment %d is empty or relative", i)
+		}
+		if len(seg) > MaxDerivationSegmentLen {
+			return fmt.Errorf("invalid path: segment %d exceeds %d characters", i, MaxDerivationSegmentLen)
+		}
+		for _, c := range seg {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			case c == '_', c == '-', c == '.':
+			default:
+				return fmt.Errorf("invalid path: segment %d contains %q", i, c)
+			}
+		}
+	}
+	return nil
+}
+
 // Derive performs deterministic child key derivation.
 func (s *Service) Derive(ctx context.Context, req *DeriveRequest) (*DeriveResponse, error) {
 	if req.Domain == "" {
 		return nil, fmt.Errorf("domain is required")
 	}
-	if req.Path == "" {
-		return nil, fmt.Errorf("path is required")
+	if err := ValidateDerivationPath(req.Path); err != nil {
+		return nil, err
 	}
 
 	version := req.KeyVersion
@@ -628,11 +950,17 @@ func (s *Service) generateQuote(reportData string) (string, *SGXReport, error) {
 	return "", nil, fmt.Errorf("not in enclave mode")
 }
 
-// GetAttestation returns the attestation for the active key.
+// GetAttestation returns the attestation for the configured scheme's active
+// key.
 func (s *Service) GetAttestation(ctx context.Context) (*MasterKeyAttestation, error) {
-	version := s.ActiveVersion()
+	return s.GetSchemeAttestation(ctx, s.scheme)
+}
+
+// GetSchemeAttestation returns the attestation for scheme's active key.
+func (s *Service) GetSchemeAttestation(_ context.Context, scheme SignatureScheme) (*MasterKeyAttestation, error) {
+	version := s.activeVersionFor(scheme)
 	if version == "" {
-		return nil, fmt.Errorf("no active key version")
+		return nil, fmt.Errorf("no active %s key version", scheme)
 	}
 	return s.buildAttestation(version), nil
 }
@@ -641,11 +969,16 @@ func (s *Service) GetAttestation(ctx context.Context) (*MasterKeyAttestation, er
 // Accessors
 // =============================================================================
 
-// ActiveVersion returns the currently active key version.
+// ActiveVersion returns the configured scheme's active key version.
 func (s *Service) ActiveVersion() string {
+	return s.activeVersionFor(s.scheme)
+}
+
+// activeVersionFor returns scheme's active key version, or "" if none.
+func (s *Service) activeVersionFor(scheme SignatureScheme) string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.activeVersion
+	return s.activeVersions[scheme]
 }
 
 // GetKeyVersion returns information about a specific key version.
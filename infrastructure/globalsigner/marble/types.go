@@ -16,8 +16,24 @@ const (
 
 	DefaultRotationPeriod = types.DefaultRotationPeriod
 	DefaultOverlapPeriod  = types.DefaultOverlapPeriod
+
+	DefaultReplayRetention = types.DefaultReplayRetention
 )
 
+// SignatureScheme type and constants
+type SignatureScheme = types.SignatureScheme
+
+const (
+	SchemeSecp256r1        = types.SchemeSecp256r1
+	SchemeEd25519          = types.SchemeEd25519
+	DefaultSignatureScheme = types.DefaultSignatureScheme
+)
+
+// ParseSignatureScheme parses a scheme name; empty is DefaultSignatureScheme.
+func ParseSignatureScheme(raw string) (SignatureScheme, error) {
+	return types.ParseSignatureScheme(raw)
+}
+
 // KeyStatus type and constants
 type KeyStatus = types.KeyStatus
 
@@ -39,6 +55,13 @@ type (
 	SignRequest          = types.SignRequest
 	SignRawRequest       = types.SignRawRequest
 	SignResponse         = types.SignResponse
+	SignBatchRequest     = types.SignBatchRequest
+	SignBatchResponse    = types.SignBatchResponse
+	SignedRequest        = types.SignedRequest
+	VerifyItem           = types.VerifyItem
+	BatchVerifyRequest   = types.BatchVerifyRequest
+	VerifyResult         = types.VerifyResult
+	BatchVerifyResponse  = types.BatchVerifyResponse
 	DeriveRequest        = types.DeriveRequest
 	DeriveResponse       = types.DeriveResponse
 	StatusResponse       = types.StatusResponse
@@ -0,0 +1,104 @@
+package globalsigner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyBatchReportsMalformedItems(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+	ctx := context.Background()
+
+	signed, err := svc.Sign(ctx, &SignRequest{Domain: "neovrf", Data: "01"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	good := VerifyItem{Domain: "neovrf", Data: "01", Signature: signed.Signature, PubKeyHex: signed.PubKeyHex}
+
+	withItem := func(modify func(*VerifyItem)) VerifyItem {
+		item := good
+		modify(&item)
+		return item
+	}
+	items := []VerifyItem{
+		good,
+		withItem(func(it *VerifyItem) { it.Data = "zz" }),
+		withItem(func(it *VerifyItem) { it.Signature = "not-hex" }),
+		withItem(func(it *VerifyItem) { it.PubKeyHex = "05" + strings.Repeat("11", 32) }),
+		withItem(func(it *VerifyItem) { it.PubKeyHex = "0xgg" }),
+		withItem(func(it *VerifyItem) { it.Signature = signed.Signature[:len(signed.Signature)-2] }),
+		withItem(func(it *VerifyItem) { it.Domain = "neooracle" }),
+		good,
+	}
+	wantErr := []string{
+		"",
+		"invalid data hex",
+		"invalid signature hex",
+		"invalid pubkey",
+		"invalid pubkey hex",
+		"invalid signature length: 63",
+		"", // well-formed but signed for another domain
+		"",
+	}
+	wantValid := []bool{true, false, false, false, false, false, false, true}
+
+	results, err := svc.verifyBatch(ctx, items)
+	if err != nil {
+		t.Fatalf("verifyBatch() error = %v", err)
+	}
+	if len(results) != len(items) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(items))
+	}
+	for i, res := range results {
+		if res.Valid != wantValid[i] {
+			t.Errorf("item %d: Valid = %v, want %v", i, res.Valid, wantValid[i])
+		}
+		if wantErr[i] == "" && res.Error != "" {
+			t.Errorf("item %d: Error = %q, want none", i, res.Error)
+		}
+		if wantErr[i] != "" && !strings.Contains(res.Error, wantErr[i]) {
+			t.Errorf("item %d: Error = %q, want %q", i, res.Error, wantErr[i])
+		}
+	}
+
+	valid, err := svc.BatchVerify(ctx, items)
+	if err != nil {
+		t.Fatalf("BatchVerify() error = %v", err)
+	}
+	for i := range valid {
+		if valid[i] != wantValid[i] {
+			t.Errorf("BatchVerify item %d = %v, want %v", i, valid[i], wantValid[i])
+		}
+	}
+}
+
+func TestHandleBatchVerifyCountsValidItems(t *testing.T) {
+	svc := newTestSigner(t, nil, time.Hour)
+
+	signed, err := svc.Sign(context.Background(), &SignRequest{Domain: "neovrf", Data: "01"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	body, _ := json.Marshal(BatchVerifyRequest{Items: []VerifyItem{
+		{Domain: "neovrf", Data: "01", Signature: signed.Signature, PubKeyHex: signed.PubKeyHex},
+		{Domain: "neovrf", Data: "01", Signature: "abcd", PubKeyHex: signed.PubKeyHex},
+	}})
+
+	rec := httptest.NewRecorder()
+	svc.handleBatchVerify(rec, httptest.NewRequest(http.MethodPost, "/verify-batch", strings.NewReader(string(body))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var resp BatchVerifyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.ValidCount != 1 || len(resp.Results) != 2 || resp.Results[1].Error == "" {
+		t.Errorf("response = %+v, want 1 valid and an error detail for item 1", resp)
+	}
+}
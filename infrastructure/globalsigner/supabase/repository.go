@@ -18,6 +18,7 @@ import (
 const (
 	keyRotationsTable         = "signer_key_rotations"
 	attestationArtifactsTable = "attestation_artifacts"
+	signedRequestsTable       = "signer_signed_requests"
 )
 
 // =============================================================================
@@ -35,6 +36,11 @@ type Repository interface {
 	// Attestation operations
 	StoreAttestation(ctx context.Context, keyVersion string, att *types.MasterKeyAttestation) error
 	GetAttestation(ctx context.Context, keyVersion string) (*types.MasterKeyAttestation, error)
+
+	// Replay protection operations
+	GetSignedRequest(ctx context.Context, key string) (*types.SignedRequest, error)
+	StoreSignedRequest(ctx context.Context, rec *types.SignedRequest) error
+	DeleteExpiredSignedRequests(ctx context.Context, before time.Time) error
 }
 
 // =============================================================================
@@ -55,6 +61,7 @@ type keyRotationRow struct {
 	ID              int64      `json:"id"`
 	KeyID           string     `json:"key_id"`
 	PublicKey       string     `json:"public_key"`
+	Scheme          string     `json:"scheme,omitempty"`
 	AttestationHash string     `json:"attestation_hash"`
 	Status          string     `json:"status"`
 	RegistryTxHash  string     `json:"registry_tx_hash,omitempty"`
@@ -84,6 +91,7 @@ func (r *repository) CreateKeyVersion(ctx context.Context, v *types.KeyVersion)
 	payload := map[string]any{
 		"key_id":           strings.TrimSpace(v.Version),
 		"public_key":       strings.TrimSpace(v.PubKeyHex),
+		"scheme":           string(v.Scheme),
 		"attestation_hash": strings.TrimSpace(v.PubKeyHash),
 		"status":           string(v.Status),
 		"registry_tx_hash": strings.TrimSpace(v.OnChainTxHash),
@@ -153,6 +161,7 @@ func (r *repository) GetKeyVersion(ctx context.Context, version string) (*types.
 	return &types.KeyVersion{
 		Version:       row.KeyID,
 		Status:        types.KeyStatus(row.Status),
+		Scheme:        types.SignatureScheme(row.Scheme),
 		PubKeyHex:     row.PublicKey,
 		PubKeyHash:    row.AttestationHash,
 		CreatedAt:     row.CreatedAt,
@@ -193,6 +202,7 @@ func (r *repository) ListKeyVersions(ctx context.Context, statuses []types.KeySt
 		out = append(out, &types.KeyVersion{
 			Version:       row.KeyID,
 			Status:        types.KeyStatus(row.Status),
+			Scheme:        types.SignatureScheme(row.Scheme),
 			PubKeyHex:     row.PublicKey,
 			PubKeyHash:    row.AttestationHash,
 			CreatedAt:     row.CreatedAt,
@@ -295,6 +305,59 @@ func (r *repository) GetAttestation(ctx context.Context, keyVersion string) (*ty
 	return &att, nil
 }
 
+func (r *repository) GetSignedRequest(ctx context.Context, key string) (*types.SignedRequest, error) {
+	if r == nil || r.base == nil {
+		return nil, fmt.Errorf("globalsigner: database not configured")
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("request key is required")
+	}
+
+	query := "request_key=eq." + url.QueryEscape(key) + "&limit=1"
+	data, err := r.base.Request(ctx, "GET", signedRequestsTable, nil, query)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", signedRequestsTable, err)
+	}
+
+	var rows []types.SignedRequest
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", signedRequestsTable, err)
+	}
+	if len(rows) == 0 {
+		return nil, database.NewNotFoundError(signedRequestsTable, key)
+	}
+	return &rows[0], nil
+}
+
+func (r *repository) StoreSignedRequest(ctx context.Context, rec *types.SignedRequest) error {
+	if r == nil || r.base == nil {
+		return fmt.Errorf("globalsigner: database not configured")
+	}
+	if rec == nil || strings.TrimSpace(rec.Key) == "" {
+		return fmt.Errorf("request key is required")
+	}
+
+	_, err := r.base.Request(ctx, "POST", signedRequestsTable, rec, "")
+	if err != nil {
+		return fmt.Errorf("create %s: %w", signedRequestsTable, err)
+	}
+	return nil
+}
+
+func (r *repository) DeleteExpiredSignedRequests(ctx context.Context, before time.Time) error {
+	if r == nil || r.base == nil {
+		return fmt.Errorf("globalsigner: database not configured")
+	}
+
+	query := "expires_at=lte." + url.QueryEscape(before.UTC().Format(time.RFC3339Nano))
+	_, err := r.base.Request(ctx, "DELETE", signedRequestsTable, nil, query)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", signedRequestsTable, err)
+	}
+	return nil
+}
+
 // =============================================================================
 // Mock Repository for Testing
 // =============================================================================
@@ -304,6 +367,7 @@ type MockRepository struct {
 	mu           sync.RWMutex
 	keyVersions  map[string]*types.KeyVersion
 	attestations map[string]*types.MasterKeyAttestation
+	signed       map[string]*types.SignedRequest
 }
 
 // NewMockRepository creates a new mock repository.
@@ -311,6 +375,7 @@ func NewMockRepository() *MockRepository {
 	return &MockRepository{
 		keyVersions:  make(map[string]*types.KeyVersion),
 		attestations: make(map[string]*types.MasterKeyAttestation),
+		signed:       make(map[string]*types.SignedRequest),
 	}
 }
 
@@ -405,3 +470,37 @@ func (m *MockRepository) GetAttestation(ctx context.Context, keyVersion string)
 
 var _ Repository = (*repository)(nil)
 var _ Repository = (*MockRepository)(nil)
+
+// GetSignedRequest retrieves a signed request record by key.
+func (m *MockRepository) GetSignedRequest(ctx context.Context, key string) (*types.SignedRequest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rec, ok := m.signed[key]
+	if !ok {
+		return nil, database.NewNotFoundError(signedRequestsTable, key)
+	}
+	return rec, nil
+}
+
+// StoreSignedRequest stores a signed request record.
+func (m *MockRepository) StoreSignedRequest(ctx context.Context, rec *types.SignedRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.signed[rec.Key] = rec
+	return nil
+}
+
+// DeleteExpiredSignedRequests removes records that expired at or before the given time.
+func (m *MockRepository) DeleteExpiredSignedRequests(ctx context.Context, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, rec := range m.signed {
+		if !rec.ExpiresAt.After(before) {
+			delete(m.signed, key)
+		}
+	}
+	return nil
+}
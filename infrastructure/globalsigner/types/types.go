@@ -3,6 +3,8 @@
 package types
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -18,6 +20,9 @@ const (
 	// Key rotation schedule
 	DefaultRotationPeriod = 30 * 24 * time.Hour // 30 days
 	DefaultOverlapPeriod  = 7 * 24 * time.Hour  // 7 days overlap
+
+	// DefaultReplayRetention is how long a signed RequestID is remembered.
+	DefaultReplayRetention = 24 * time.Hour
 )
 
 // =============================================================================
@@ -34,6 +39,42 @@ const (
 	KeyStatusRevoked     KeyStatus = "revoked"     // No longer valid
 )
 
+// =============================================================================
+// Signature Schemes
+// =============================================================================
+
+// SignatureScheme identifies the curve and algorithm a key version signs
+// with. Every scheme signs the same domain-separated message
+// (domain || 0x00 || data), so a signature's scheme is identified by the key
+// version, not by the message.
+type SignatureScheme string
+
+const (
+	// SchemeSecp256r1 is ECDSA over P-256 with SHA-256, as used by Neo N3
+	// (64-byte r||s signatures, 33-byte compressed public keys).
+	SchemeSecp256r1 SignatureScheme = "secp256r1"
+
+	// SchemeEd25519 is Ed25519 (64-byte signatures, 32-byte public keys).
+	SchemeEd25519 SignatureScheme = "ed25519"
+
+	// DefaultSignatureScheme is used when no scheme is configured, and for
+	// key versions recorded before schemes existed.
+	DefaultSignatureScheme = SchemeSecp256r1
+)
+
+// ParseSignatureScheme parses a scheme name. An empty name is
+// DefaultSignatureScheme.
+func ParseSignatureScheme(raw string) (SignatureScheme, error) {
+	switch scheme := SignatureScheme(strings.ToLower(strings.TrimSpace(raw))); scheme {
+	case "":
+		return DefaultSignatureScheme, nil
+	case SchemeSecp256r1, SchemeEd25519:
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("unsupported signature scheme %q", raw)
+	}
+}
+
 // =============================================================================
 // Configuration
 // =============================================================================
@@ -46,6 +87,11 @@ type RotationConfig struct {
 	// OverlapPeriod is how long old keys remain valid (default 7 days).
 	OverlapPeriod time.Duration `json:"overlap_period"`
 
+	// OverlapVerifyOnly limits a deprecated key to verification during the
+	// overlap period; by default it can also still sign, so requests
+	// anchored to the old version keep working.
+	OverlapVerifyOnly bool `json:"overlap_verify_only,omitempty"`
+
 	// AutoRotate enables automatic rotation via background worker.
 	AutoRotate bool `json:"auto_rotate"`
 
@@ -75,7 +121,11 @@ type KeyVersion struct {
 	// Status is the current lifecycle state.
 	Status KeyStatus `json:"status"`
 
-	// PubKeyHex is the compressed public key in hex.
+	// Scheme is the signature scheme the key was created under. Empty means
+	// DefaultSignatureScheme.
+	Scheme SignatureScheme `json:"scheme,omitempty"`
+
+	// PubKeyHex is the public key in hex (compressed for secp256r1).
 	PubKeyHex string `json:"pubkey_hex"`
 
 	// PubKeyHash is SHA-256(pubkey) used for attestation binding.
@@ -154,17 +204,21 @@ type AttestationArtifact struct {
 type RotateRequest struct {
 	// Force bypasses the rotation schedule check.
 	Force bool `json:"force,omitempty"`
+
+	// Scheme selects whose active key rotates (default: the configured scheme).
+	Scheme SignatureScheme `json:"scheme,omitempty"`
 }
 
 // RotateResponse is the response from key rotation.
 type RotateResponse struct {
-	// OldVersion is the previous active key version.
+	// OldVersion is the previous active key version. After a rotation it is
+	// deprecated but stays valid until OverlapEndsAt.
 	OldVersion string `json:"old_version,omitempty"`
 
 	// NewVersion is the newly activated key version.
 	NewVersion string `json:"new_version"`
 
-	// OverlapEndsAt is when the old key's overlap period ends.
+	// OverlapEndsAt is when the old key's overlap period ends (its sunset).
 	OverlapEndsAt *time.Time `json:"overlap_ends_at,omitempty"`
 
 	// RotatedAt is when the rotation occurred.
@@ -187,6 +241,16 @@ type SignRequest struct {
 
 	// KeyVersion optionally specifies which key version to use.
 	KeyVersion string `json:"key_version,omitempty"`
+
+	// Scheme optionally specifies the expected signature scheme (default: the
+	// service's configured scheme). A key version created under a different
+	// scheme is rejected.
+	Scheme SignatureScheme `json:"scheme,omitempty"`
+
+	// RequestID optionally makes signing idempotent: within the replay
+	// retention window, repeating a RequestID for the same domain and calling
+	// service returns the original signature instead of signing again.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // SignRawRequest is a request for raw signing without domain separation.
@@ -198,6 +262,10 @@ type SignRawRequest struct {
 
 	// KeyVersion optionally specifies which key version to use.
 	KeyVersion string `json:"key_version,omitempty"`
+
+	// Scheme optionally specifies the expected signature scheme (see
+	// SignRequest.Scheme).
+	Scheme SignatureScheme `json:"scheme,omitempty"`
 }
 
 // SignResponse is the response from signing.
@@ -210,6 +278,87 @@ type SignResponse struct {
 
 	// PubKeyHex is the public key that can verify this signature.
 	PubKeyHex string `json:"pubkey_hex"`
+
+	// Scheme is the signature scheme of the key version used.
+	Scheme SignatureScheme `json:"scheme"`
+
+	// Replayed is true when the signature was returned from an earlier
+	// request with the same RequestID.
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// SignBatchRequest is a request to sign several domain-separated payloads
+// in one call.
+type SignBatchRequest struct {
+	Requests []SignRequest `json:"requests"`
+}
+
+// SignBatchResponse is the response from batch signing, with Signatures in
+// request order.
+type SignBatchResponse struct {
+	Signatures []SignResponse `json:"signatures"`
+}
+
+// SignedRequest records a signature issued for a RequestID, for replay
+// protection.
+type SignedRequest struct {
+	// Key identifies the request (hash of domain, service ID and RequestID).
+	Key string `json:"request_key"`
+
+	// DataHash is the sha256 of the signed data (hex), so a reused RequestID
+	// with different data can be rejected.
+	DataHash string `json:"data_hash"`
+
+	Signature  string    `json:"signature"`
+	KeyVersion string    `json:"key_version"`
+	PubKeyHex  string    `json:"public_key"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// VerifyItem is one domain-separated signature to check in a batch.
+type VerifyItem struct {
+	// Domain is the signing domain the signature was produced under.
+	Domain string `json:"domain"`
+
+	// Data is the signed data (hex-encoded).
+	Data string `json:"data"`
+
+	// Signature is the 64-byte signature (hex-encoded): r||s for secp256r1.
+	Signature string `json:"signature"`
+
+	// PubKeyHex is the public key (hex-encoded): compressed or uncompressed
+	// for secp256r1, 32 bytes for ed25519. Optional when KeyVersion is set.
+	PubKeyHex string `json:"pubkey_hex,omitempty"`
+
+	// KeyVersion optionally anchors the check to one of GlobalSigner's key
+	// versions: the signature only verifies while that version is active or
+	// within its overlap period.
+	KeyVersion string `json:"key_version,omitempty"`
+
+	// Scheme is the signature scheme (default secp256r1).
+	Scheme SignatureScheme `json:"scheme,omitempty"`
+}
+
+// BatchVerifyRequest is a request to verify several signatures at once.
+type BatchVerifyRequest struct {
+	Items []VerifyItem `json:"items"`
+}
+
+// VerifyResult is the outcome for one VerifyItem.
+type VerifyResult struct {
+	// Valid reports whether the signature verified.
+	Valid bool `json:"valid"`
+
+	// Error explains why the item could not be checked (malformed input).
+	Error string `json:"error,omitempty"`
+}
+
+// BatchVerifyResponse is the response from batch verification, with
+// Results in request order.
+type BatchVerifyResponse struct {
+	Results    []VerifyResult `json:"results"`
+	ValidCount int            `json:"valid_count"`
 }
 
 // DeriveRequest is a request for deterministic key derivation.
@@ -0,0 +1,41 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DeadlineHeader carries the caller's request deadline on service-to-service
+// calls as an RFC 3339 timestamp with nanoseconds, so the callee stops work
+// once the caller has given up.
+const DeadlineHeader = "X-Request-Deadline"
+
+// SetDeadlineHeader sets DeadlineHeader from ctx's deadline. It is a no-op
+// when ctx has no deadline or the header is already set.
+func SetDeadlineHeader(ctx context.Context, h http.Header) {
+	if h == nil || h.Get(DeadlineHeader) != "" {
+		return
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		h.Set(DeadlineHeader, deadline.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+// ParseDeadlineHeader returns the deadline carried by r, if any. Malformed
+// values are ignored.
+func ParseDeadlineHeader(r *http.Request) (time.Time, bool) {
+	if r == nil {
+		return time.Time{}, false
+	}
+	raw := strings.TrimSpace(r.Header.Get(DeadlineHeader))
+	if raw == "" {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return deadline, true
+}
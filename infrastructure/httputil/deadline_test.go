@@ -0,0 +1,47 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetDeadlineHeader_RoundTrips(t *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	SetDeadlineHeader(ctx, req.Header)
+
+	got, ok := ParseDeadlineHeader(req)
+	if !ok || !got.Equal(deadline) {
+		t.Fatalf("ParseDeadlineHeader() = %v, %v; want %v", got, ok, deadline)
+	}
+}
+
+func TestSetDeadlineHeader_KeepsExistingAndSkipsWithoutDeadline(t *testing.T) {
+	h := http.Header{}
+	SetDeadlineHeader(context.Background(), h)
+	if h.Get(DeadlineHeader) != "" {
+		t.Fatalf("header set without a context deadline")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	h.Set(DeadlineHeader, "caller-value")
+	SetDeadlineHeader(ctx, h)
+	if h.Get(DeadlineHeader) != "caller-value" {
+		t.Fatalf("existing header overwritten: %q", h.Get(DeadlineHeader))
+	}
+}
+
+func TestParseDeadlineHeader_RejectsMalformed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(DeadlineHeader, "soon")
+	if _, ok := ParseDeadlineHeader(req); ok {
+		t.Fatalf("ParseDeadlineHeader() accepted malformed value")
+	}
+}
@@ -174,11 +174,12 @@ func (m *Marble) TLSConfig() *tls.Config {
 	return m.tlsConfig
 }
 
-// HTTPClient returns an HTTP client configured for mTLS.
+// HTTPClient returns an HTTP client configured for mTLS. Outbound requests
+// carry the context's trace ID and deadline.
 func (m *Marble) HTTPClient() *http.Client {
 	if m == nil {
 		return &http.Client{
-			Transport: &traceHeaderRoundTripper{base: http.DefaultTransport},
+			Transport: &traceHeaderRoundTripper{base: http.DefaultTransport, propagateDeadline: true},
 			Timeout:   30 * time.Second,
 		}
 	}
@@ -194,7 +195,7 @@ func (m *Marble) HTTPClient() *http.Client {
 	if !useMTLS {
 		m.httpClientUsesMTLS = false
 		m.httpClient = &http.Client{
-			Transport: &traceHeaderRoundTripper{base: http.DefaultTransport},
+			Transport: &traceHeaderRoundTripper{base: http.DefaultTransport, propagateDeadline: true},
 			Timeout:   30 * time.Second,
 		}
 		return m.httpClient
@@ -218,7 +219,7 @@ func (m *Marble) HTTPClient() *http.Client {
 
 	m.httpClientUsesMTLS = true
 	m.httpClient = &http.Client{
-		Transport: &traceHeaderRoundTripper{base: transport},
+		Transport: &traceHeaderRoundTripper{base: transport, propagateDeadline: true},
 		Timeout:   30 * time.Second,
 	}
 	return m.httpClient
@@ -253,8 +254,11 @@ func (m *Marble) ExternalHTTPClient() *http.Client {
 	return m.externalHTTPClient
 }
 
+// traceHeaderRoundTripper propagates the context's trace ID and, for mesh
+// clients, its deadline (see httputil.DeadlineHeader) to outbound requests.
 type traceHeaderRoundTripper struct {
-	base http.RoundTripper
+	base              http.RoundTripper
+	propagateDeadline bool
 }
 
 func (t *traceHeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -262,13 +266,22 @@ func (t *traceHeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response,
 		t.base = http.DefaultTransport
 	}
 
-	traceID := logging.GetTraceID(req.Context())
-	if traceID == "" || req.Header.Get("X-Trace-ID") != "" {
+	ctx := req.Context()
+	traceID := logging.GetTraceID(ctx)
+	setTrace := traceID != "" && req.Header.Get("X-Trace-ID") == ""
+	_, hasDeadline := ctx.Deadline()
+	setDeadline := t.propagateDeadline && hasDeadline && req.Header.Get(slhttputil.DeadlineHeader) == ""
+	if !setTrace && !setDeadline {
 		return t.base.RoundTrip(req)
 	}
 
-	clone := req.Clone(req.Context())
-	clone.Header.Set("X-Trace-ID", traceID)
+	clone := req.Clone(ctx)
+	if setTrace {
+		clone.Header.Set("X-Trace-ID", traceID)
+	}
+	if setDeadline {
+		slhttputil.SetDeadlineHeader(ctx, clone.Header)
+	}
 	return t.base.RoundTrip(clone)
 }
 
@@ -3,8 +3,13 @@ package marble
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
+
+	slhttputil "github.com/R3E-Network/service_layer/infrastructure/httputil"
 )
 
 // =============================================================================
@@ -519,6 +524,39 @@ func TestMarbleHTTPClientCaching(t *testing.T) {
 	}
 }
 
+func TestMarbleHTTPClientPropagatesDeadline(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(slhttputil.DeadlineHeader)
+	}))
+	defer server.Close()
+
+	// Shorter than the client's own 30s timeout, which would otherwise win.
+	deadline := time.Now().Add(10 * time.Second)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	m, _ := New(Config{MarbleType: "test"})
+	for name, client := range map[string]*http.Client{"mesh": m.HTTPClient(), "external": m.ExternalHTTPClient()} {
+		got = ""
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s: Do() error = %v", name, err)
+		}
+		resp.Body.Close()
+
+		if name == "mesh" {
+			parsed, err := time.Parse(time.RFC3339Nano, got)
+			if err != nil || !parsed.Equal(deadline) {
+				t.Errorf("mesh deadline header = %q, want %v", got, deadline)
+			}
+		} else if got != "" {
+			t.Errorf("external client sent deadline header %q", got)
+		}
+	}
+}
+
 func TestServiceDB(t *testing.T) {
 	m, _ := New(Config{MarbleType: "test"})
 	svc := NewService(ServiceConfig{
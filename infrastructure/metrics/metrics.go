@@ -2,6 +2,9 @@
 package metrics
 
 import (
+	"context"
+	"errors"
+	"net"
 	"os"
 	"strings"
 	"sync"
@@ -26,6 +29,9 @@ type Metrics struct {
 	BlockchainTxTotal    *prometheus.CounterVec
 	BlockchainTxDuration *prometheus.HistogramVec
 
+	// Dispatch metrics (requests routed to another service)
+	DispatchDuration *prometheus.HistogramVec
+
 	// Database metrics
 	DatabaseQueriesTotal    *prometheus.CounterVec
 	DatabaseQueryDuration   *prometheus.HistogramVec
@@ -93,6 +99,16 @@ func NewWithRegistry(serviceName string, registerer prometheus.Registerer) *Metr
 			[]string{"service", "chain", "operation"},
 		),
 
+		// Dispatch metrics
+		DispatchDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "dispatch_duration_seconds",
+				Help:    "Duration of requests routed to downstream services in seconds",
+				Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"service", "method", "outcome"},
+		),
+
 		// Database metrics
 		DatabaseQueriesTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -141,6 +157,7 @@ func NewWithRegistry(serviceName string, registerer prometheus.Registerer) *Metr
 			m.ErrorsTotal,
 			m.BlockchainTxTotal,
 			m.BlockchainTxDuration,
+			m.DispatchDuration,
 			m.DatabaseQueriesTotal,
 			m.DatabaseQueryDuration,
 			m.DatabaseConnectionsOpen,
@@ -172,6 +189,36 @@ func (m *Metrics) RecordBlockchainTx(service, chain, operation, status string, d
 	m.BlockchainTxDuration.WithLabelValues(service, chain, operation).Observe(duration.Seconds())
 }
 
+// Dispatch outcomes.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+	OutcomeTimeout = "timeout"
+)
+
+// DispatchOutcome classifies the result of a dispatched request. Deadline
+// and network timeouts are reported as OutcomeTimeout, distinct from other
+// errors.
+func DispatchOutcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return OutcomeTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return OutcomeTimeout
+	}
+	return OutcomeError
+}
+
+// RecordDispatch records the duration and outcome of a request routed to
+// service's method.
+func (m *Metrics) RecordDispatch(service, method string, err error, duration time.Duration) {
+	m.DispatchDuration.WithLabelValues(service, method, DispatchOutcome(err)).Observe(duration.Seconds())
+}
+
 // RecordDatabaseQuery records a database query
 func (m *Metrics) RecordDatabaseQuery(service, operation, status string, duration time.Duration) {
 	m.DatabaseQueriesTotal.WithLabelValues(service, operation, status).Inc()
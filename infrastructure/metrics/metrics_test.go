@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -64,6 +67,53 @@ func TestRecordDatabaseQuery(t *testing.T) {
 	m.RecordDatabaseQuery("test-service", "insert", "failed", 5*time.Millisecond)
 }
 
+func TestDispatchOutcome(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"success", nil, OutcomeSuccess},
+		{"error", errors.New("boom"), OutcomeError},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), OutcomeTimeout},
+		{"net timeout", timeoutErr{}, OutcomeTimeout},
+	}
+	for _, tt := range tests {
+		if got := DispatchOutcome(tt.err); got != tt.want {
+			t.Errorf("%s: DispatchOutcome() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRecordDispatch(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewWithRegistry("test-service", reg)
+
+	m.RecordDispatch("neooracle", "/query", nil, 100*time.Millisecond)
+	m.RecordDispatch("neooracle", "/query", errors.New("boom"), 50*time.Millisecond)
+	m.RecordDispatch("neovrf", "/random", context.DeadlineExceeded, 10*time.Second)
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() == "dispatch_duration_seconds" {
+			if len(f.GetMetric()) != 3 {
+				t.Errorf("dispatch series = %d, want 3", len(f.GetMetric()))
+			}
+			return
+		}
+	}
+	t.Error("dispatch_duration_seconds not registered")
+}
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
 func TestSetDatabaseConnections(t *testing.T) {
 	reg := prometheus.NewRegistry()
 	m := NewWithRegistry("test-service", reg)
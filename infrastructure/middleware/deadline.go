@@ -0,0 +1,34 @@
+// Package middleware provides HTTP middleware for the service layer.
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
+)
+
+// DeadlineMiddleware derives the request context deadline from the caller's
+// X-Request-Deadline header, so downstream calls (which propagate it again
+// through the Marble HTTP client) respect the caller's remaining budget.
+// Requests whose deadline has already passed are rejected with 504 before
+// reaching the handler. The header can only shorten a request's deadline.
+func DeadlineMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := httputil.ParseDeadlineHeader(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !time.Now().Before(deadline) {
+			httputil.WriteErrorResponse(w, r, http.StatusGatewayTimeout, "DEADLINE_EXCEEDED", "request deadline exceeded", nil)
+			return
+		}
+
+		ctx, cancel := context.WithDeadline(r.Context(), deadline)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
 	"github.com/R3E-Network/service_layer/infrastructure/logging"
 	"github.com/R3E-Network/service_layer/infrastructure/metrics"
 )
@@ -257,6 +258,69 @@ func TestBodyLimitMiddleware_AllowsWhenContentLengthWithinLimit(t *testing.T) {
 	}
 }
 
+func TestDeadlineMiddleware_DerivesContextDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Minute).UTC().Truncate(time.Millisecond)
+
+	var got time.Time
+	var ok bool
+	handler := DeadlineMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = r.Context().Deadline()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(httputil.DeadlineHeader, deadline.Format(time.RFC3339Nano))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rr.Code)
+	}
+	if !ok || !got.Equal(deadline) {
+		t.Fatalf("context deadline = %v (%v), want %v", got, ok, deadline)
+	}
+}
+
+func TestDeadlineMiddleware_RejectsExpiredDeadline(t *testing.T) {
+	nextCalled := false
+	handler := DeadlineMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(httputil.DeadlineHeader, time.Now().Add(-time.Second).Format(time.RFC3339Nano))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if nextCalled {
+		t.Fatalf("expected deadline middleware to short-circuit")
+	}
+	if rr.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want 504", rr.Code)
+	}
+}
+
+func TestDeadlineMiddleware_IgnoresMissingOrMalformedHeader(t *testing.T) {
+	for _, value := range []string{"", "not-a-time"} {
+		var hasDeadline bool
+		handler := DeadlineMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, hasDeadline = r.Context().Deadline()
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if value != "" {
+			req.Header.Set(httputil.DeadlineHeader, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK || hasDeadline {
+			t.Fatalf("header %q: status = %d, deadline set = %v", value, rr.Code, hasDeadline)
+		}
+	}
+}
+
 func TestParseRSAKeysFromPEM(t *testing.T) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
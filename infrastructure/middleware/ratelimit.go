@@ -97,7 +97,10 @@ func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
 
 		limiter := rl.getLimiter(key)
 
-		if !limiter.Allow() {
+		allowed := limiter.Allow()
+		rl.setRateLimitHeaders(w, limiter)
+
+		if !allowed {
 			if rl.logger != nil {
 				rl.logger.LogSecurityEvent(r.Context(), "rate_limit_exceeded", map[string]interface{}{
 					"key":    key,
@@ -122,6 +125,30 @@ func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
 	})
 }
 
+// setRateLimitHeaders advertises the caller's current token bucket state so
+// clients can self-throttle before hitting 429 responses:
+//   - X-RateLimit-Limit: bucket capacity (burst)
+//   - X-RateLimit-Remaining: whole tokens left after this request
+//   - X-RateLimit-Reset: seconds until the bucket is full again
+func (rl *RateLimiter) setRateLimitHeaders(w http.ResponseWriter, limiter *rate.Limiter) {
+	now := time.Now()
+	tokens := limiter.TokensAt(now)
+
+	remaining := int(math.Floor(tokens))
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	reset := 0
+	if missing := float64(rl.burst) - tokens; missing > 0 && rl.rate > 0 {
+		reset = int(math.Ceil(missing / float64(rl.rate)))
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(rl.burst))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(reset))
+}
+
 // Cleanup removes old limiters (should be called periodically)
 func (rl *RateLimiter) Cleanup() {
 	rl.mu.Lock()
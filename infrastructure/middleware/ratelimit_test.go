@@ -113,6 +113,33 @@ func TestRateLimiter_Handler_BlocksExcessiveRequests(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_Handler_AdvisoryHeaders(t *testing.T) {
+	logger := logging.New("test", "info", "json")
+	rl := NewRateLimiter(1, 3, logger)
+
+	handler := rl.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	wantRemaining := []string{"2", "1", "0", "0"}
+	for i, want := range wantRemaining {
+		req := httptest.NewRequest("GET", "/api/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "3" {
+			t.Errorf("request %d: X-RateLimit-Limit = %q, want 3", i, got)
+		}
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != want {
+			t.Errorf("request %d: X-RateLimit-Remaining = %q, want %s", i, got, want)
+		}
+		if got := rec.Header().Get("X-RateLimit-Reset"); got == "" || got == "0" {
+			t.Errorf("request %d: X-RateLimit-Reset = %q, want positive seconds", i, got)
+		}
+	}
+}
+
 func TestRateLimiter_Handler_UsesUserID(t *testing.T) {
 	logger := logging.New("test", "info", "json")
 	rl := NewRateLimiter(1, 1, logger)
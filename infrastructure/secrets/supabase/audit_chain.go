@@ -0,0 +1,89 @@
+package supabase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// auditHashPayload is the canonical content covered by an audit entry hash.
+// The row ID is excluded because it is assigned by the database on insert.
+type auditHashPayload struct {
+	UserID       string `json:"user_id"`
+	SecretName   string `json:"secret_name"`
+	Action       string `json:"action"`
+	ServiceID    string `json:"service_id"`
+	IPAddress    string `json:"ip_address"`
+	UserAgent    string `json:"user_agent"`
+	Success      bool   `json:"success"`
+	ErrorMessage string `json:"error_message"`
+	CreatedAt    string `json:"created_at"`
+	PrevHash     string `json:"prev_hash"`
+}
+
+// ComputeAuditHash returns the hex SHA-256 of an entry's content and its PrevHash.
+func ComputeAuditHash(entry *AuditLog) string {
+	payload := auditHashPayload{
+		UserID:       entry.UserID,
+		SecretName:   entry.SecretName,
+		Action:       entry.Action,
+		ServiceID:    entry.ServiceID,
+		IPAddress:    entry.IPAddress,
+		UserAgent:    entry.UserAgent,
+		Success:      entry.Success,
+		ErrorMessage: entry.ErrorMessage,
+		CreatedAt:    entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:     entry.PrevHash,
+	}
+	// Marshalling a struct of plain fields cannot fail.
+	data, _ := json.Marshal(payload)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// chainAuditLog links entry to the previous entry in its user's chain and
+// fills in its hash. CreatedAt is truncated to the database's microsecond
+// precision so the hash still verifies after a round trip.
+func chainAuditLog(entry *AuditLog, prevHash string) {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC().Truncate(time.Microsecond)
+	entry.PrevHash = prevHash
+	entry.Hash = ComputeAuditHash(entry)
+}
+
+// VerifyAuditChain checks a single user's audit entries, ordered oldest first.
+// It returns true and -1 if every entry hash is intact and links to its
+// predecessor; otherwise false and the index of the first broken entry.
+// Entries without a hash were written before the hash chain existed: leading
+// ones are skipped and the chain starts at the first hashed entry, which must
+// be the genesis entry (empty PrevHash) so a chain truncated from the front is
+// detected. A hash-less entry after that is reported as broken.
+func VerifyAuditChain(entries []AuditLog) (bool, int, error) {
+	prevHash := ""
+	chained := false
+	for i := range entries {
+		entry := &entries[i]
+		if i > 0 && entry.UserID != entries[0].UserID {
+			return false, i, fmt.Errorf("audit chain mixes users %q and %q", entries[0].UserID, entry.UserID)
+		}
+		if entry.Hash == "" {
+			if chained {
+				return false, i, nil
+			}
+			continue
+		}
+		if entry.Hash != ComputeAuditHash(entry) {
+			return false, i, nil
+		}
+		if entry.PrevHash != prevHash {
+			return false, i, nil
+		}
+		prevHash = entry.Hash
+		chained = true
+	}
+	return true, -1, nil
+}
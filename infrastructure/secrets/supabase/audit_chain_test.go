@@ -0,0 +1,102 @@
+package supabase
+
+import (
+	"testing"
+	"time"
+)
+
+func buildAuditChain(t *testing.T, n int) []AuditLog {
+	t.Helper()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := make([]AuditLog, n)
+	prevHash := ""
+	for i := range entries {
+		entries[i] = AuditLog{
+			UserID:     "user-1",
+			SecretName: "api_key",
+			Action:     "read",
+			ServiceID:  "neocompute",
+			Success:    true,
+			CreatedAt:  base.Add(time.Duration(i) * time.Minute),
+		}
+		chainAuditLog(&entries[i], prevHash)
+		prevHash = entries[i].Hash
+	}
+	return entries
+}
+
+func TestVerifyAuditChainIntact(t *testing.T) {
+	entries := buildAuditChain(t, 3)
+
+	ok, idx, err := VerifyAuditChain(entries)
+	if err != nil || !ok || idx != -1 {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; want true, -1, nil", ok, idx, err)
+	}
+}
+
+func TestVerifyAuditChainDetectsTampering(t *testing.T) {
+	entries := buildAuditChain(t, 3)
+	entries[1].Action = "delete"
+
+	ok, idx, err := VerifyAuditChain(entries)
+	if err != nil || ok || idx != 1 {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; want false, 1, nil", ok, idx, err)
+	}
+}
+
+func TestVerifyAuditChainDetectsRemovedEntry(t *testing.T) {
+	entries := buildAuditChain(t, 3)
+	entries = append(entries[:1], entries[2:]...)
+
+	ok, idx, err := VerifyAuditChain(entries)
+	if err != nil || ok || idx != 1 {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; want false, 1, nil", ok, idx, err)
+	}
+}
+
+func TestVerifyAuditChainDetectsTruncatedHead(t *testing.T) {
+	entries := buildAuditChain(t, 4)[2:]
+
+	ok, idx, err := VerifyAuditChain(entries)
+	if err != nil || ok || idx != 0 {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; want false, 0, nil", ok, idx, err)
+	}
+
+	// Legacy rows in front do not exempt the first hashed entry from
+	// anchoring at genesis.
+	legacy := AuditLog{UserID: "user-1", SecretName: "api_key", Action: "create"}
+	entries = append([]AuditLog{legacy}, entries...)
+	ok, idx, err = VerifyAuditChain(entries)
+	if err != nil || ok || idx != 1 {
+		t.Fatalf("VerifyAuditChain() with legacy head = %v, %d, %v; want false, 1, nil", ok, idx, err)
+	}
+}
+
+func TestVerifyAuditChainMixedUsers(t *testing.T) {
+	entries := buildAuditChain(t, 2)
+	entries[1].UserID = "user-2"
+
+	if _, _, err := VerifyAuditChain(entries); err == nil {
+		t.Fatal("VerifyAuditChain() expected error for mixed users")
+	}
+}
+
+func TestVerifyAuditChainLegacyRowsStartChain(t *testing.T) {
+	legacy := AuditLog{UserID: "user-1", SecretName: "api_key", Action: "create"}
+	entries := append([]AuditLog{legacy, legacy}, buildAuditChain(t, 2)...)
+
+	ok, idx, err := VerifyAuditChain(entries)
+	if err != nil || !ok || idx != -1 {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; want true, -1, nil", ok, idx, err)
+	}
+}
+
+func TestVerifyAuditChainDetectsUnhashedEntryInChain(t *testing.T) {
+	entries := buildAuditChain(t, 3)
+	entries[1].Hash = ""
+
+	ok, idx, err := VerifyAuditChain(entries)
+	if err != nil || ok || idx != 1 {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; want false, 1, nil", ok, idx, err)
+	}
+}
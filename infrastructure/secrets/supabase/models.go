@@ -24,6 +24,8 @@ type Policy struct {
 }
 
 // AuditLog represents an audit log entry for secret operations.
+// Entries form a per-user hash chain: Hash covers the entry content and
+// PrevHash, which is the Hash of the user's previous entry.
 type AuditLog struct {
 	ID           string    `json:"id"`
 	UserID       string    `json:"user_id"`
@@ -35,4 +37,6 @@ type AuditLog struct {
 	Success      bool      `json:"success"`
 	ErrorMessage string    `json:"error_message,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
+	PrevHash     string    `json:"prev_hash,omitempty"`
+	Hash         string    `json:"hash,omitempty"`
 }
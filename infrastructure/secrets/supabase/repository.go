@@ -4,6 +4,7 @@ package supabase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/R3E-Network/service_layer/infrastructure/database"
 )
@@ -30,6 +31,7 @@ type RepositoryInterface interface {
 	GetPoliciesForSecret(ctx context.Context, userID, secretName string) ([]Policy, error)
 	GetAllowedServices(ctx context.Context, userID, secretName string) ([]string, error)
 	SetAllowedServices(ctx context.Context, userID, secretName string, services []string) error
+	IsServiceAllowed(ctx context.Context, userID, secretName, serviceID string) (bool, error)
 	// Audit Log Operations
 	CreateAuditLog(ctx context.Context, log *AuditLog) error
 	GetAuditLogs(ctx context.Context, userID string, limit int) ([]AuditLog, error)
@@ -208,6 +210,27 @@ func (r *Repository) GetAllowedServices(ctx context.Context, userID, secretName
 	return services, nil
 }
 
+// IsServiceAllowed reports whether serviceID may use a user's secret.
+// An empty serviceID denotes direct owner access and is always allowed. An
+// empty allowlist means "owner only": no service may use the secret until it
+// is explicitly granted.
+func (r *Repository) IsServiceAllowed(ctx context.Context, userID, secretName, serviceID string) (bool, error) {
+	if serviceID == "" {
+		return true, nil
+	}
+
+	services, err := r.GetAllowedServices(ctx, userID, secretName)
+	if err != nil {
+		return false, err
+	}
+	for _, svc := range services {
+		if svc == serviceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // SetAllowedServices replaces the allowed service list for a user's secret.
 func (r *Repository) SetAllowedServices(ctx context.Context, userID, secretName string, services []string) error {
 	if userID == "" || secretName == "" {
@@ -251,7 +274,14 @@ func (r *Repository) SetAllowedServices(ctx context.Context, userID, secretName
 // Audit Log Operations
 // =============================================================================
 
-// CreateAuditLog creates a new audit log entry.
+// auditAppendAttempts bounds how often CreateAuditLog re-reads the chain
+// head after a concurrent write for the same user took it.
+const auditAppendAttempts = 5
+
+// CreateAuditLog creates a new audit log entry, chained to the user's
+// most recent hashed entry. The unique (user_id, prev_hash) index rejects a
+// second entry linking to the same predecessor, so concurrent writers cannot
+// fork the chain; the loser re-reads the head and retries.
 func (r *Repository) CreateAuditLog(ctx context.Context, log *AuditLog) error {
 	if log == nil {
 		return fmt.Errorf("audit log cannot be nil")
@@ -262,7 +292,48 @@ func (r *Repository) CreateAuditLog(ctx context.Context, log *AuditLog) error {
 	if log.Action == "" {
 		return fmt.Errorf("action cannot be empty")
 	}
-	return database.GenericCreate(r.base, ctx, auditTable, log, nil)
+
+	var err error
+	for attempt := 0; attempt < auditAppendAttempts; attempt++ {
+		var prevHash string
+		prevHash, err = r.latestAuditHash(ctx, log.UserID)
+		if err != nil {
+			return fmt.Errorf("load previous audit log: %w", err)
+		}
+		if attempt > 0 {
+			// Stamp the retry after the entry that won the race so the chain
+			// stays in created_at order.
+			log.CreatedAt = time.Time{}
+		}
+		chainAuditLog(log, prevHash)
+
+		err = database.GenericCreate(r.base, ctx, auditTable, log, nil)
+		if err == nil || !database.IsUniqueViolation(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("append audit log: chain head kept changing: %w", err)
+}
+
+// latestAuditHash returns the hash of the user's newest hashed audit entry,
+// or "" when the user has none yet. Entries written before the hash chain
+// existed have no hash and are skipped.
+func (r *Repository) latestAuditHash(ctx context.Context, userID string) (string, error) {
+	query := database.NewQuery().
+		Eq("user_id", userID).
+		NotNull("hash").
+		OrderDesc("created_at").
+		Limit(1).
+		Build()
+
+	latest, err := database.GenericListWithQuery[AuditLog](r.base, ctx, auditTable, query)
+	if err != nil {
+		return "", err
+	}
+	if len(latest) == 0 {
+		return "", nil
+	}
+	return latest[0].Hash, nil
 }
 
 // GetAuditLogs retrieves audit logs for a user with optional limit.
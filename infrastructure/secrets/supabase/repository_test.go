@@ -0,0 +1,214 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+// auditTableServer is a minimal PostgREST stand-in for secret_audit_logs that
+// enforces the unique (user_id, prev_hash) index on hashed rows. beforeInsert
+// runs ahead of every insert, so tests can slip in a concurrent writer.
+type auditTableServer struct {
+	mu           sync.Mutex
+	rows         []AuditLog
+	beforeInsert func(s *auditTableServer)
+}
+
+func (s *auditTableServer) head(userID string) string {
+	for i := len(s.rows) - 1; i >= 0; i-- {
+		if s.rows[i].UserID == userID && s.rows[i].Hash != "" {
+			return s.rows[i].Hash
+		}
+	}
+	return ""
+}
+
+func (s *auditTableServer) insert(w http.ResponseWriter, entry AuditLog) {
+	for _, row := range s.rows {
+		if row.Hash != "" && row.UserID == entry.UserID && row.PrevHash == entry.PrevHash {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code":"23505","message":"duplicate key value violates unique constraint \"idx_secret_audit_logs_chain_link\""}`)
+			return
+		}
+	}
+	s.rows = append(s.rows, entry)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode([]AuditLog{entry})
+}
+
+func (s *auditTableServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		// The only GET issued by CreateAuditLog is the chain head lookup.
+		var rows []AuditLog
+		userID := r.URL.Query().Get("user_id")[len("eq."):]
+		for i := len(s.rows) - 1; i >= 0; i-- {
+			if s.rows[i].UserID == userID && s.rows[i].Hash != "" {
+				rows = append(rows, s.rows[i])
+				break
+			}
+		}
+		_ = json.NewEncoder(w).Encode(rows)
+	case http.MethodPost:
+		var entry AuditLog
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if s.beforeInsert != nil {
+			hook := s.beforeInsert
+			s.beforeInsert = nil
+			hook(s)
+		}
+		s.insert(w, entry)
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestRepository(t *testing.T, handler http.Handler) *Repository {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := database.NewClient(database.Config{URL: server.URL, ServiceKey: "test-key", RestPrefix: "/rest/v1"})
+	if err != nil {
+		t.Fatalf("database.NewClient() error = %v", err)
+	}
+	return NewRepository(database.NewRepository(client))
+}
+
+func TestCreateAuditLogRetriesWhenChainHeadMoves(t *testing.T) {
+	table := &auditTableServer{}
+	repo := newTestRepository(t, table)
+	ctx := context.Background()
+
+	if err := repo.CreateAuditLog(ctx, &AuditLog{UserID: "user-1", SecretName: "api_key", Action: "create"}); err != nil {
+		t.Fatalf("CreateAuditLog() error = %v", err)
+	}
+
+	// Another writer appends after our head lookup but before our insert.
+	table.beforeInsert = func(s *auditTableServer) {
+		competing := AuditLog{UserID: "user-1", SecretName: "api_key", Action: "read", CreatedAt: time.Now()}
+		chainAuditLog(&competing, s.head("user-1"))
+		s.rows = append(s.rows, competing)
+	}
+	if err := repo.CreateAuditLog(ctx, &AuditLog{UserID: "user-1", SecretName: "api_key", Action: "update"}); err != nil {
+		t.Fatalf("CreateAuditLog() with concurrent writer error = %v", err)
+	}
+
+	if len(table.rows) != 3 {
+		t.Fatalf("rows = %d, want 3", len(table.rows))
+	}
+	if ok, idx, err := VerifyAuditChain(table.rows); err != nil || !ok {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v; chain forked", ok, idx, err)
+	}
+	if table.rows[2].Action != "update" || table.rows[2].PrevHash != table.rows[1].Hash {
+		t.Fatalf("retried entry = %+v, want it linked to the concurrent entry", table.rows[2])
+	}
+}
+
+func TestCreateAuditLogStartsChainAfterLegacyRows(t *testing.T) {
+	table := &auditTableServer{rows: []AuditLog{
+		{UserID: "user-1", SecretName: "api_key", Action: "create", CreatedAt: time.Now().Add(-time.Hour)},
+	}}
+	repo := newTestRepository(t, table)
+
+	if err := repo.CreateAuditLog(context.Background(), &AuditLog{UserID: "user-1", SecretName: "api_key", Action: "read"}); err != nil {
+		t.Fatalf("CreateAuditLog() error = %v", err)
+	}
+	if table.rows[1].PrevHash != "" {
+		t.Fatalf("first hashed entry PrevHash = %q, want chain start", table.rows[1].PrevHash)
+	}
+	if ok, idx, err := VerifyAuditChain(table.rows); err != nil || !ok {
+		t.Fatalf("VerifyAuditChain() = %v, %d, %v", ok, idx, err)
+	}
+}
+
+// policyServer serves secret_policies rows, filtered by user_id and
+// secret_name, and counts the lookups.
+func policyServer(t *testing.T, policies []Policy, lookups *atomic.Int64) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/secret_policies") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		lookups.Add(1)
+		q := r.URL.Query()
+		rows := []Policy{}
+		for _, p := range policies {
+			if "eq."+p.UserID == q.Get("user_id") && "eq."+p.SecretName == q.Get("secret_name") {
+				rows = append(rows, p)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(rows)
+	})
+}
+
+func TestIsServiceAllowed(t *testing.T) {
+	policies := []Policy{
+		{UserID: "user-1", SecretName: "api_key", ServiceID: "neocompute"},
+		{UserID: "user-1", SecretName: "api_key", ServiceID: "neooracle"},
+		{UserID: "user-2", SecretName: "api_key", ServiceID: "neovrf"},
+	}
+	var lookups atomic.Int64
+	repo := newTestRepository(t, policyServer(t, policies, &lookups))
+
+	tests := []struct {
+		name       string
+		userID     string
+		secretName string
+		serviceID  string
+		want       bool
+	}{
+		{"allowed service", "user-1", "api_key", "neocompute", true},
+		{"second allowed service", "user-1", "api_key", "neooracle", true},
+		{"service not on allowlist", "user-1", "api_key", "neovrf", false},
+		{"allowlist of another user", "user-2", "api_key", "neocompute", false},
+		{"empty allowlist is owner only", "user-1", "db_password", "neocompute", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.IsServiceAllowed(context.Background(), tt.userID, tt.secretName, tt.serviceID)
+			if err != nil {
+				t.Fatalf("IsServiceAllowed() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsServiceAllowed(%s, %s, %s) = %v, want %v", tt.userID, tt.secretName, tt.serviceID, got, tt.want)
+			}
+		})
+	}
+
+	// Owner access needs no allowlist lookup, even for an empty allowlist.
+	lookups.Store(0)
+	if ok, err := repo.IsServiceAllowed(context.Background(), "user-1", "db_password", ""); err != nil || !ok {
+		t.Errorf("IsServiceAllowed() for owner = %v, %v; want true", ok, err)
+	}
+	if n := lookups.Load(); n != 0 {
+		t.Errorf("owner access made %d policy lookups, want 0", n)
+	}
+}
+
+func TestIsServiceAllowedLookupError(t *testing.T) {
+	repo := newTestRepository(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+	}))
+
+	ok, err := repo.IsServiceAllowed(context.Background(), "user-1", "api_key", "neocompute")
+	if err == nil || ok {
+		t.Fatalf("IsServiceAllowed() = %v, %v; want denied with error", ok, err)
+	}
+}
@@ -2,14 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/R3E-Network/service_layer/infrastructure/config"
 	"github.com/R3E-Network/service_layer/infrastructure/database"
 	"github.com/R3E-Network/service_layer/infrastructure/logging"
 	"github.com/R3E-Network/service_layer/infrastructure/marble"
+	"github.com/R3E-Network/service_layer/infrastructure/runtime"
 )
 
 const healthCheckTimeout = 5 * time.Second
@@ -34,6 +38,7 @@ type BaseConfig struct {
 // - Optional hydration hook for loading state on startup
 // - Background worker management
 // - Statistics provider for /info endpoint
+// - Startup self-test of critical dependencies
 type BaseService struct {
 	*marble.Service
 
@@ -42,8 +47,10 @@ type BaseService struct {
 	stopOnce sync.Once
 
 	// Extensibility hooks
-	hydrate func(context.Context) error
-	statsFn func() map[string]any
+	hydrate      func(context.Context) error
+	statsFn      func() map[string]any
+	debugStateFn func() map[string]any
+	selfTests    []func(context.Context) error
 
 	// Worker management
 	workers []func(context.Context)
@@ -56,6 +63,17 @@ type BaseService struct {
 	lastHealthCheck time.Time
 	startTime       time.Time
 
+	// Read-only mode (see readonly.go)
+	readOnlyMu sync.RWMutex
+	readOnly   readOnlyState
+
+	// Feature flags (see flags.go)
+	flags *config.FlagStore
+
+	// Shutdown reason and in-flight operations (see shutdown.go)
+	shutdownMu sync.Mutex
+	shutdown   shutdownState
+
 	logger *logging.Logger
 }
 
@@ -67,7 +85,9 @@ func NewBase(cfg *BaseConfig) *BaseService {
 	}
 
 	requiredSecrets := mergeUniqueStrings(cfgValue.RequiredSecrets)
-	if cfgValue.DB != nil {
+	// Only the Supabase-backed repository needs Supabase credentials; the
+	// in-memory store selected by STORAGE_BACKEND=memory does not.
+	if _, supabase := cfgValue.DB.(*database.Repository); supabase {
 		requiredSecrets = mergeUniqueStrings(requiredSecrets, defaultDBSecrets...)
 	}
 
@@ -80,7 +100,7 @@ func NewBase(cfg *BaseConfig) *BaseService {
 		logger = logging.NewFromEnv(serviceName)
 	}
 
-	return &BaseService{
+	b := &BaseService{
 		Service: marble.NewService(marble.ServiceConfig{
 			ID:      cfgValue.ID,
 			Name:    cfgValue.Name,
@@ -92,8 +112,15 @@ func NewBase(cfg *BaseConfig) *BaseService {
 		requiredSecrets: requiredSecrets,
 		dbHealthy:       cfgValue.DB == nil,
 		secretsLoaded:   len(requiredSecrets) == 0,
+		flags:           &config.FlagStore{},
 		logger:          logger,
 	}
+
+	b.Router().Use(b.inFlightMiddleware, b.readOnlyMiddleware)
+	if readOnlyFromEnv() {
+		b.SetReadOnly(true, ReadOnlyEnv+" set")
+	}
+	return b
 }
 
 // Logger returns the service's structured logger.
@@ -127,6 +154,79 @@ func (b *BaseService) WithStats(fn func() map[string]any) *BaseService {
 	return b
 }
 
+// AddSelfTest registers a service-specific startup check run by SelfTest.
+// Checks should fail only for missing critical dependencies; optional
+// features should log a warning and return nil.
+func (b *BaseService) AddSelfTest(fn func(context.Context) error) *BaseService {
+	b.selfTests = append(b.selfTests, fn)
+	return b
+}
+
+// SelfTest verifies critical dependencies before the service is started:
+// the database (if configured) must be reachable, required secrets must be
+// present, and every check registered with AddSelfTest must pass. All
+// failures are reported together.
+func (b *BaseService) SelfTest(ctx context.Context) error {
+	var errs []error
+
+	if repo := b.DB(); repo != nil {
+		dbCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := repo.HealthCheck(dbCtx)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("database unreachable: %w", err))
+		}
+	}
+
+	if missing := b.missingSecrets(); len(missing) > 0 {
+		errs = append(errs, fmt.Errorf("required secrets missing: %v", missing))
+	}
+
+	for _, check := range b.selfTests {
+		if err := check(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s self-test failed: %w", b.ID(), errors.Join(errs...))
+	}
+	return nil
+}
+
+// Dependency is a dependency checked by CheckDependencies.
+type Dependency struct {
+	Name    string // what is missing and how it is configured, e.g. "chain client (NEO_RPC_URL)"
+	Missing bool
+}
+
+// CheckDependencies is a helper for AddSelfTest checks of dependencies a
+// service cannot do its job without in production, but that development and
+// memory-backend runs may leave unconfigured. In strict identity mode
+// (production or SGX) any missing dependency fails the check; otherwise each
+// is logged as a warning.
+func (b *BaseService) CheckDependencies(ctx context.Context, deps ...Dependency) error {
+	var missing []string
+	for _, dep := range deps {
+		if dep.Missing {
+			missing = append(missing, dep.Name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if runtime.StrictIdentityMode() || (b.Marble() != nil && b.Marble().IsEnclave()) {
+		return fmt.Errorf("%s: missing critical dependencies: %s", b.ID(), strings.Join(missing, ", "))
+	}
+	for _, name := range missing {
+		b.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+			"dependency": name,
+		}).Warn("critical dependency not configured (development/testing only)")
+	}
+	return nil
+}
+
 // AddWorker registers a background worker started after hydrate completes.
 // Workers receive the context and should respect context cancellation.
 // Workers should also monitor StopChan() for service shutdown signals.
@@ -170,6 +270,16 @@ func (b *BaseService) AddTickerWorker(interval time.Duration, fn func(context.Co
 		opt(&cfg)
 	}
 
+	operation := "worker"
+	if cfg.name != "" {
+		operation = "worker:" + cfg.name
+	}
+	run := func(ctx context.Context) error {
+		done := b.BeginOperation(operation)
+		defer done()
+		return fn(ctx)
+	}
+
 	worker := func(ctx context.Context) {
 		logWorkerError := func(err error) {
 			if err == nil {
@@ -191,7 +301,7 @@ func (b *BaseService) AddTickerWorker(interval time.Duration, fn func(context.Co
 			default:
 			}
 
-			if err := fn(ctx); err != nil {
+			if err := run(ctx); err != nil {
 				logWorkerError(err)
 			}
 		}
@@ -206,7 +316,7 @@ func (b *BaseService) AddTickerWorker(interval time.Duration, fn func(context.Co
 			case <-b.stopCh:
 				return
 			case <-ticker.C:
-				if err := fn(ctx); err != nil {
+				if err := run(ctx); err != nil {
 					// Log error but continue - worker should handle its own errors
 					logWorkerError(err)
 				}
@@ -249,8 +359,16 @@ func (b *BaseService) Start(ctx context.Context) error {
 
 // Stop signals workers and stops the underlying marble.Service.
 // This method is idempotent - calling it multiple times is safe due to sync.Once.
+// The reason set by SetShutdownReason (if any) is logged with the operations
+// still in flight.
 func (b *BaseService) Stop() error {
 	b.stopOnce.Do(func() {
+		fields := map[string]interface{}{"in_flight": b.InFlight()}
+		if reason, ok := b.ShutdownReason(); ok {
+			fields["shutdown_code"] = reason.Code
+			fields["shutdown_detail"] = reason.Detail
+		}
+		b.Logger().WithFields(fields).Info("stopping service")
 		close(b.stopCh)
 	})
 	return b.Service.Stop()
@@ -279,34 +397,37 @@ func (b *BaseService) CheckHealth() {
 		}
 	}
 
-	secretsLoaded := true
-	if len(b.requiredSecrets) > 0 {
-		secretsLoaded = true
-		for _, name := range b.requiredSecrets {
-			if name == "" {
-				continue
-			}
+	secretsLoaded := len(b.missingSecrets()) == 0
 
-			if m := b.Marble(); m != nil {
-				if secret, ok := m.Secret(name); ok && len(secret) > 0 {
-					continue
-				}
-			}
+	b.healthMu.Lock()
+	b.dbHealthy = dbHealthy
+	b.secretsLoaded = secretsLoaded || len(b.requiredSecrets) == 0
+	b.lastHealthCheck = time.Now()
+	b.healthMu.Unlock()
+}
+
+// missingSecrets returns the required secrets available neither from the
+// Marble nor from the environment.
+func (b *BaseService) missingSecrets() []string {
+	var missing []string
+	for _, name := range b.requiredSecrets {
+		if name == "" {
+			continue
+		}
 
-			if envValue := os.Getenv(name); envValue != "" {
+		if m := b.Marble(); m != nil {
+			if secret, ok := m.Secret(name); ok && len(secret) > 0 {
 				continue
 			}
+		}
 
-			secretsLoaded = false
-			break
+		if envValue := os.Getenv(name); envValue != "" {
+			continue
 		}
-	}
 
-	b.healthMu.Lock()
-	b.dbHealthy = dbHealthy
-	b.secretsLoaded = secretsLoaded || len(b.requiredSecrets) == 0
-	b.lastHealthCheck = time.Now()
-	b.healthMu.Unlock()
+		missing = append(missing, name)
+	}
+	return missing
 }
 
 // HealthStatus returns the aggregated health status string.
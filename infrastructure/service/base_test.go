@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+func TestSelfTestAggregatesFailures(t *testing.T) {
+	t.Setenv("SELFTEST_REQUIRED_SECRET", "")
+
+	db := database.NewMockRepository()
+	db.ErrorOnNextCall = errors.New("connection refused")
+	b := NewBase(&BaseConfig{ID: "test", DB: db, RequiredSecrets: []string{"SELFTEST_REQUIRED_SECRET"}})
+
+	errChain := errors.New("chain client missing")
+	errGateway := errors.New("gateway hash missing")
+	var ran []string
+	b.AddSelfTest(func(context.Context) error { ran = append(ran, "chain"); return errChain })
+	b.AddSelfTest(func(context.Context) error { ran = append(ran, "ok"); return nil })
+	b.AddSelfTest(func(context.Context) error { ran = append(ran, "gateway"); return errGateway })
+
+	err := b.SelfTest(context.Background())
+	if err == nil {
+		t.Fatal("SelfTest() = nil, want failures")
+	}
+	if len(ran) != 3 {
+		t.Errorf("ran checks %v, want all three even after a failure", ran)
+	}
+	if !errors.Is(err, errChain) || !errors.Is(err, errGateway) {
+		t.Errorf("SelfTest() = %v, want both check errors joined", err)
+	}
+	for _, want := range []string{"test self-test failed", "database unreachable", "SELFTEST_REQUIRED_SECRET"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("SelfTest() = %q, want it to mention %q", err, want)
+		}
+	}
+
+	// A service whose dependencies are all in place passes.
+	t.Setenv("SELFTEST_REQUIRED_SECRET", "set")
+	b.selfTests = b.selfTests[1:2]
+	if err := b.SelfTest(context.Background()); err != nil {
+		t.Fatalf("SelfTest() = %v, want nil", err)
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	deps := []Dependency{
+		{Name: "chain client (NEO_RPC_URL)", Missing: true},
+		{Name: "txproxy (TXPROXY_URL)", Missing: false},
+		{Name: "gateway hash (CONTRACT_SERVICE_GATEWAY_HASH)", Missing: true},
+	}
+
+	t.Setenv("MARBLE_ENV", "development")
+	b := NewBase(&BaseConfig{ID: "test"})
+	if err := b.CheckDependencies(context.Background(), deps...); err != nil {
+		t.Fatalf("CheckDependencies() in development = %v, want warning only", err)
+	}
+
+	t.Setenv("MARBLE_ENV", "production")
+	err := b.CheckDependencies(context.Background(), deps...)
+	if err == nil {
+		t.Fatal("CheckDependencies() in production = nil, want error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "NEO_RPC_URL") || !strings.Contains(msg, "CONTRACT_SERVICE_GATEWAY_HASH") || strings.Contains(msg, "TXPROXY_URL") {
+		t.Errorf("CheckDependencies() = %q, want only the missing dependencies", msg)
+	}
+	if err := b.CheckDependencies(context.Background(), deps[1]); err != nil {
+		t.Errorf("CheckDependencies() with nothing missing = %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
+	"github.com/R3E-Network/service_layer/infrastructure/middleware"
+)
+
+// DebugStatePath is the internal endpoint that dumps in-memory service state.
+const DebugStatePath = "/internal/debug/state"
+
+// DebugStateEnv enables the internal debug state endpoint when set to a true
+// value.
+const DebugStateEnv = "DEBUG_STATE_ENABLED"
+
+// redactedValue replaces values whose key looks sensitive.
+const redactedValue = "[redacted]"
+
+// sensitiveKeyFragments mark debug state keys whose values must never be
+// returned, regardless of what a service's provider puts in them.
+var sensitiveKeyFragments = []string{
+	"secret", "private", "password", "passphrase", "token",
+	"seed", "mnemonic", "wif", "credential", "key",
+}
+
+// debugURLPattern finds URLs embedded in debug string values, such as the
+// request URL in an upstream fetch error.
+var debugURLPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// DebugStateResponse is the response for the internal debug state endpoint.
+type DebugStateResponse struct {
+	Service    string         `json:"service"`
+	Version    string         `json:"version"`
+	Timestamp  string         `json:"timestamp"`
+	Uptime     string         `json:"uptime"`
+	Workers    int            `json:"workers"`
+	Health     string         `json:"health"`
+	Statistics map[string]any `json:"statistics,omitempty"`
+	State      map[string]any `json:"state,omitempty"`
+}
+
+// DebugStateEnabled returns whether the internal debug state endpoint should
+// be registered. It is disabled unless DebugStateEnv is set to a true value.
+func DebugStateEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(DebugStateEnv))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// WithDebugState sets a provider for service-specific in-memory state (queue
+// depths, in-flight work, held locks) returned by the debug state endpoint.
+// Providers should return counts and identifiers only; keys that look
+// sensitive are redacted regardless.
+func (b *BaseService) WithDebugState(fn func() map[string]any) *BaseService {
+	b.debugStateFn = fn
+	return b
+}
+
+// DebugStateHandler returns the internal debug state handler for BaseService.
+// Standard route registration serves it behind service authentication.
+func DebugStateHandler(s *BaseService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		uptime := time.Duration(0)
+		if !s.startTime.IsZero() {
+			uptime = time.Since(s.startTime)
+		}
+
+		resp := DebugStateResponse{
+			Service:   s.Name(),
+			Version:   s.Version(),
+			Timestamp: time.Now().Format(time.RFC3339),
+			Uptime:    uptime.String(),
+			Workers:   s.WorkerCount(),
+			Health:    s.HealthStatus(),
+		}
+		if s.statsFn != nil {
+			resp.Statistics = sanitizeDebugMap(s.statsFn())
+		}
+		if s.debugStateFn != nil {
+			resp.State = sanitizeDebugMap(s.debugStateFn())
+		}
+
+		httputil.WriteJSON(w, http.StatusOK, resp)
+	}
+}
+
+// debugStateHandler wraps DebugStateHandler with service authentication, or
+// returns nil when the endpoint is disabled.
+func (b *BaseService) debugStateHandler() http.Handler {
+	if !DebugStateEnabled() {
+		return nil
+	}
+	return middleware.RequireServiceAuth(onlyGetOrHead(DebugStateHandler(b)))
+}
+
+// sanitizeDebugMap returns a copy of m with sensitive-looking keys redacted
+// and credentials stripped from URLs in string values, descending into nested
+// maps and slices.
+func sanitizeDebugMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if isSensitiveDebugKey(k) {
+			out[k] = redactedValue
+			continue
+		}
+		out[k] = sanitizeDebugValue(v)
+	}
+	return out
+}
+
+func sanitizeDebugValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return sanitizeDebugMap(val)
+	case []any:
+		out := make([]any, len(val))
+		for i := range val {
+			out[i] = sanitizeDebugValue(val[i])
+		}
+		return out
+	case []byte:
+		// Raw bytes are more likely key material than state; report the size only.
+		return map[string]any{"bytes": len(val)}
+	case string:
+		return sanitizeDebugString(val)
+	case error:
+		return sanitizeDebugString(val.Error())
+	default:
+		return v
+	}
+}
+
+// sanitizeDebugString strips the userinfo, query string and fragment from
+// URLs in s; upstream URLs often carry API keys there.
+func sanitizeDebugString(s string) string {
+	return debugURLPattern.ReplaceAllStringFunc(s, func(match string) string {
+		// Punctuation ending the match most likely ends the sentence, as in
+		// "fetch https://host/path?key=x: 429".
+		raw := strings.TrimRight(match, ".,;:!)]}")
+		suffix := match[len(raw):]
+		u, err := url.Parse(raw)
+		if err != nil {
+			// Unparseable: keep only the part before any query or userinfo.
+			if i := strings.IndexAny(raw, "?#"); i >= 0 {
+				raw = raw[:i]
+			}
+			if i := strings.LastIndex(raw, "@"); i >= 0 {
+				scheme, _, _ := strings.Cut(raw, "://")
+				raw = scheme + "://" + raw[i+1:]
+			}
+			return raw + suffix
+		}
+		u.User = nil
+		u.RawQuery = ""
+		u.ForceQuery = false
+		u.Fragment = ""
+		u.RawFragment = ""
+		return u.String() + suffix
+	})
+}
+
+func isSensitiveDebugKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
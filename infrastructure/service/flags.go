@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/R3E-Network/service_layer/infrastructure/config"
+)
+
+// FlagSetter is implemented by services that evaluate feature flags (every
+// BaseService does).
+type FlagSetter interface {
+	SetFlags(flags map[string]config.FeatureFlag) error
+}
+
+// Flags returns the service's feature flag store.
+func (b *BaseService) Flags() *config.FlagStore {
+	return b.flags
+}
+
+// SetFlags replaces the service's feature flags. Invalid definitions are
+// rejected and the current flags are kept.
+func (b *BaseService) SetFlags(flags map[string]config.FeatureFlag) error {
+	if err := b.flags.Set(flags); err != nil {
+		return err
+	}
+	b.Logger().WithFields(map[string]interface{}{
+		"flags": b.flags.Names(),
+	}).Info("feature flags updated")
+	return nil
+}
+
+// IsEnabled reports whether a feature flag is on for the account in ctx (see
+// config.WithAccountID). Unknown flags are off.
+func (b *BaseService) IsEnabled(ctx context.Context, flag string) (bool, error) {
+	return b.flags.IsEnabled(ctx, flag)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
+)
+
+// ReadOnlyEnv enables read-only mode at startup when set to a true value.
+const ReadOnlyEnv = "READ_ONLY_MODE"
+
+// readOnlyState is the current read-only mode of a service.
+type readOnlyState struct {
+	enabled bool
+	reason  string
+	since   time.Time
+	exempt  map[string]struct{}
+}
+
+// ReadOnlyToggler is implemented by services that support read-only mode
+// (every BaseService does).
+type ReadOnlyToggler interface {
+	SetReadOnly(enabled bool, reason string)
+	ReadOnly() (enabled bool, reason string)
+}
+
+// readOnlyFromEnv reports whether ReadOnlyEnv asks for read-only mode.
+func readOnlyFromEnv() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(ReadOnlyEnv))) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// SetReadOnly enters or leaves read-only mode. While enabled, write requests
+// (any method other than GET, HEAD or OPTIONS) are rejected with 503 and
+// reason, reads keep being served, and /ready reports "read_only". Transitions
+// are logged; repeated calls with the same state only update the reason.
+func (b *BaseService) SetReadOnly(enabled bool, reason string) {
+	reason = strings.TrimSpace(reason)
+
+	b.readOnlyMu.Lock()
+	changed := b.readOnly.enabled != enabled
+	b.readOnly.enabled = enabled
+	if enabled {
+		b.readOnly.reason = reason
+		if changed {
+			b.readOnly.since = time.Now()
+		}
+	} else {
+		b.readOnly.reason = ""
+		b.readOnly.since = time.Time{}
+	}
+	b.readOnlyMu.Unlock()
+
+	if !changed {
+		return
+	}
+	fields := map[string]interface{}{"service": b.ID()}
+	if reason != "" {
+		fields["reason"] = reason
+	}
+	if enabled {
+		b.Logger().WithFields(fields).Warn("entering read-only mode: write requests will be rejected")
+	} else {
+		b.Logger().WithFields(fields).Info("leaving read-only mode: write requests accepted again")
+	}
+}
+
+// ReadOnly reports whether the service is in read-only mode and why.
+func (b *BaseService) ReadOnly() (bool, string) {
+	b.readOnlyMu.RLock()
+	defer b.readOnlyMu.RUnlock()
+	return b.readOnly.enabled, b.readOnly.reason
+}
+
+// AllowInReadOnly exempts paths from read-only rejection, for endpoints that
+// use POST but do not write (verification, simulation).
+func (b *BaseService) AllowInReadOnly(paths ...string) *BaseService {
+	b.readOnlyMu.Lock()
+	defer b.readOnlyMu.Unlock()
+	if b.readOnly.exempt == nil {
+		b.readOnly.exempt = make(map[string]struct{}, len(paths))
+	}
+	for _, path := range paths {
+		b.readOnly.exempt[path] = struct{}{}
+	}
+	return b
+}
+
+// readOnlyDetails returns the read-only fields reported by /ready.
+func (b *BaseService) readOnlyDetails() map[string]any {
+	b.readOnlyMu.RLock()
+	defer b.readOnlyMu.RUnlock()
+	if !b.readOnly.enabled {
+		return nil
+	}
+	details := map[string]any{
+		"read_only":       true,
+		"read_only_since": b.readOnly.since.Format(time.RFC3339),
+	}
+	if b.readOnly.reason != "" {
+		details["read_only_reason"] = b.readOnly.reason
+	}
+	return details
+}
+
+// WithReadOnly applies read-only rejection to a handler served outside the
+// service router, such as a ServeMux set as the router's NotFoundHandler
+// (router middleware does not run for unmatched routes).
+func (b *BaseService) WithReadOnly(next http.Handler) http.Handler {
+	return b.readOnlyMiddleware(next)
+}
+
+// readOnlyMiddleware rejects write requests with 503 while read-only mode is
+// enabled.
+func (b *BaseService) readOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		b.readOnlyMu.RLock()
+		enabled, reason := b.readOnly.enabled, b.readOnly.reason
+		_, exempt := b.readOnly.exempt[r.URL.Path]
+		b.readOnlyMu.RUnlock()
+		if !enabled || exempt {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		message := "service is in read-only mode"
+		if reason != "" {
+			message += ": " + reason
+		}
+		w.Header().Set("Retry-After", "60")
+		httputil.WriteErrorResponse(w, r, http.StatusServiceUnavailable, "READ_ONLY", message, nil)
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+// newTestService returns a BaseService without a database, with the
+// standard routes and a GET and POST /items handler.
+func newTestService(t *testing.T) *BaseService {
+	t.Helper()
+	m, err := marble.New(marble.Config{MarbleType: "test"})
+	if err != nil {
+		t.Fatalf("marble.New: %v", err)
+	}
+	b := NewBase(&BaseConfig{ID: "test", Name: "Test Service", Version: "1.0.0", Marble: m})
+	b.RegisterStandardRoutes()
+	b.Router().HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodGet, http.MethodPost)
+	return b
+}
+
+func serve(b *BaseService, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	b.Router().ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func decodeHealth(t *testing.T, rec *httptest.ResponseRecorder) HealthResponse {
+	t.Helper()
+	var resp HealthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v (%s)", err, rec.Body.String())
+	}
+	return resp
+}
+
+func TestReadOnlyRejectsWrites(t *testing.T) {
+	b := newTestService(t)
+	b.SetReadOnly(true, "database migration")
+
+	rec := serve(b, http.MethodPost, "/items")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("POST status = %d, want 503", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("missing Retry-After header")
+	}
+	var errResp httputil.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if errResp.Code != "READ_ONLY" {
+		t.Errorf("code = %q, want READ_ONLY", errResp.Code)
+	}
+	if errResp.Message != "service is in read-only mode: database migration" {
+		t.Errorf("message = %q", errResp.Message)
+	}
+}
+
+func TestReadOnlyServesReads(t *testing.T) {
+	b := newTestService(t)
+	b.SetReadOnly(true, "")
+
+	if rec := serve(b, http.MethodGet, "/items"); rec.Code != http.StatusOK {
+		t.Fatalf("GET status = %d, want 200", rec.Code)
+	}
+}
+
+func TestReadOnlyExemptPaths(t *testing.T) {
+	b := newTestService(t)
+	b.Router().HandleFunc("/verify", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods(http.MethodPost)
+	b.AllowInReadOnly("/verify")
+	b.SetReadOnly(true, "")
+
+	if rec := serve(b, http.MethodPost, "/verify"); rec.Code != http.StatusOK {
+		t.Fatalf("exempt POST status = %d, want 200", rec.Code)
+	}
+	if rec := serve(b, http.MethodPost, "/items"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("non-exempt POST status = %d, want 503", rec.Code)
+	}
+}
+
+func TestReadOnlyToggle(t *testing.T) {
+	b := newTestService(t)
+
+	b.SetReadOnly(true, "maintenance")
+	if enabled, reason := b.ReadOnly(); !enabled || reason != "maintenance" {
+		t.Fatalf("ReadOnly() = %v, %q", enabled, reason)
+	}
+
+	b.SetReadOnly(false, "")
+	if enabled, reason := b.ReadOnly(); enabled || reason != "" {
+		t.Fatalf("ReadOnly() after disable = %v, %q", enabled, reason)
+	}
+	if rec := serve(b, http.MethodPost, "/items"); rec.Code != http.StatusOK {
+		t.Fatalf("POST after disable status = %d, want 200", rec.Code)
+	}
+}
+
+func TestReadinessReportsReadOnly(t *testing.T) {
+	b := newTestService(t)
+
+	rec := serve(b, http.MethodGet, "/ready")
+	if resp := decodeHealth(t, rec); rec.Code != http.StatusOK || resp.Status != "healthy" {
+		t.Fatalf("/ready = %d %q, want 200 healthy", rec.Code, resp.Status)
+	}
+
+	b.SetReadOnly(true, "database migration")
+	rec = serve(b, http.MethodGet, "/ready")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/ready status = %d, want 200 (read-only stays ready)", rec.Code)
+	}
+	resp := decodeHealth(t, rec)
+	if resp.Status != "read_only" {
+		t.Fatalf("status = %q, want read_only", resp.Status)
+	}
+	if resp.Details["read_only"] != true {
+		t.Errorf("details.read_only = %v", resp.Details["read_only"])
+	}
+	if resp.Details["read_only_reason"] != "database migration" {
+		t.Errorf("details.read_only_reason = %v", resp.Details["read_only_reason"])
+	}
+	if resp.Details["read_only_since"] == "" || resp.Details["read_only_since"] == nil {
+		t.Error("details.read_only_since missing")
+	}
+}
+
+func TestReadOnlyFromEnv(t *testing.T) {
+	t.Setenv(ReadOnlyEnv, "true")
+	b := newTestService(t)
+	if enabled, _ := b.ReadOnly(); !enabled {
+		t.Fatalf("%s=true did not enable read-only mode", ReadOnlyEnv)
+	}
+}
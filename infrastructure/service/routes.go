@@ -63,6 +63,8 @@ func HealthHandler(s *BaseService) http.HandlerFunc {
 }
 
 // ReadinessHandler returns a readiness probe handler suitable for k8s.
+// A service in read-only mode reports status "read_only" and stays ready; a
+// service that is shutting down reports "shutting_down" and is not ready.
 func ReadinessHandler(s *BaseService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		status := "healthy"
@@ -75,22 +77,43 @@ func ReadinessHandler(s *BaseService) http.HandlerFunc {
 			}
 		}
 
-		resp := HealthResponse{
+		// In read-only mode reads keep being served, so an unhealthy write
+		// path (DB) must not take the instance out of rotation.
+		if readOnly := s.readOnlyDetails(); readOnly != nil && status != "degraded" {
+			status = "read_only"
+			details = mergeDetails(details, readOnly, s)
+		}
+		if shutdown := s.shutdownDetails(); shutdown != nil {
+			status = "shutting_down"
+			details = mergeDetails(details, shutdown, s)
+		}
+
+		code := http.StatusOK
+		if status != "healthy" && status != "read_only" {
+			code = http.StatusServiceUnavailable
+		}
+
+		httputil.WriteJSON(w, code, HealthResponse{
 			Status:    status,
 			Service:   s.Name(),
 			Version:   s.Version(),
 			Enclave:   s.Marble().IsEnclave(),
 			Timestamp: time.Now().Format(time.RFC3339),
 			Details:   details,
-		}
-
-		code := http.StatusOK
-		if status != "healthy" {
-			code = http.StatusServiceUnavailable
-		}
+		})
+	}
+}
 
-		httputil.WriteJSON(w, code, resp)
+// mergeDetails adds extra to details, starting from the service's health
+// details when there are none yet.
+func mergeDetails(details, extra map[string]any, s *BaseService) map[string]any {
+	if details == nil {
+		details = s.HealthDetails()
 	}
+	for k, v := range extra {
+		details[k] = v
+	}
+	return details
 }
 
 // InfoHandler returns a standardized /info handler for BaseService.
@@ -123,7 +146,8 @@ type RouteOptions struct {
 	SkipInfo bool // Skip /info registration (for services with custom /info)
 }
 
-// RegisterStandardRoutes registers the standard /health, /ready, and /info endpoints.
+// RegisterStandardRoutes registers the standard /health, /ready, and /info endpoints,
+// plus the authenticated debug state endpoint when DebugStateEnabled.
 // This should be called by services that want consistent endpoint behavior.
 func (b *BaseService) RegisterStandardRoutes() {
 	b.RegisterStandardRoutesWithOptions(RouteOptions{})
@@ -138,4 +162,7 @@ func (b *BaseService) RegisterStandardRoutesWithOptions(opts RouteOptions) {
 	if !opts.SkipInfo {
 		router.HandleFunc("/info", InfoHandler(b)).Methods("GET")
 	}
+	if handler := b.debugStateHandler(); handler != nil {
+		router.Handle(DebugStatePath, handler).Methods("GET")
+	}
 }
@@ -34,4 +34,7 @@ func (b *BaseService) RegisterStandardRoutesOnServeMuxWithOptions(mux *http.Serv
 	if !opts.SkipInfo {
 		mux.HandleFunc("/info", onlyGetOrHead(InfoHandler(b)))
 	}
+	if handler := b.debugStateHandler(); handler != nil {
+		mux.Handle(DebugStatePath, handler)
+	}
 }
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCompensationFailed is matched (via errors.Is) by a *SagaError whose
+// rollback did not complete, so callers can tell "failed and rolled back"
+// from "failed and left partially applied".
+var ErrCompensationFailed = errors.New("saga compensation failed")
+
+// SagaStep is one step of a multi-step operation, e.g. lock accounts or
+// transfer funds. Compensate undoes Action and is run if a later step fails;
+// it may be nil for steps with nothing to undo.
+type SagaStep struct {
+	Name       string
+	Action     func(ctx context.Context) error
+	Compensate func(ctx context.Context) error
+}
+
+// CompensationError is a compensating action that failed.
+type CompensationError struct {
+	Step  string
+	Index int
+	Err   error
+}
+
+func (e CompensationError) Error() string {
+	return fmt.Sprintf("compensate %s (step %d): %v", e.Step, e.Index, e.Err)
+}
+
+// SagaError reports the step that failed and, if any compensations failed
+// during rollback, each of those failures.
+type SagaError struct {
+	Step  string
+	Index int
+	Err   error
+
+	// CompensationErrors lists failed compensations in the order they ran
+	// (reverse step order). Empty means every completed step was undone.
+	CompensationErrors []CompensationError
+}
+
+func (e *SagaError) Error() string {
+	msg := fmt.Sprintf("saga step %s (step %d) failed: %v", e.Step, e.Index, e.Err)
+	if len(e.CompensationErrors) == 0 {
+		return msg
+	}
+	failed := make([]string, len(e.CompensationErrors))
+	for i, ce := range e.CompensationErrors {
+		failed[i] = ce.Error()
+	}
+	return fmt.Sprintf("%s; %s: %s", msg, ErrCompensationFailed, strings.Join(failed, "; "))
+}
+
+// Unwrap exposes the step error and, when rollback was incomplete,
+// ErrCompensationFailed.
+func (e *SagaError) Unwrap() []error {
+	if len(e.CompensationErrors) == 0 {
+		return []error{e.Err}
+	}
+	return []error{e.Err, ErrCompensationFailed}
+}
+
+// RolledBack reports whether every completed step was compensated.
+func (e *SagaError) RolledBack() bool {
+	return len(e.CompensationErrors) == 0
+}
+
+// RunSaga runs steps in order. If a step fails (or ctx ends before a step
+// starts), the compensations of the steps that completed run in reverse
+// order and a *SagaError is returned. Every compensation is attempted even if
+// an earlier one fails, and they run with ctx's values but without its
+// cancellation, so a timed-out saga is still rolled back.
+func RunSaga(ctx context.Context, steps []SagaStep) error {
+	for i, step := range steps {
+		err := ctx.Err()
+		if err == nil {
+			if step.Action == nil {
+				err = fmt.Errorf("no action")
+			} else {
+				err = step.Action(ctx)
+			}
+		}
+		if err != nil {
+			return &SagaError{
+				Step:               sagaStepName(step, i),
+				Index:              i,
+				Err:                err,
+				CompensationErrors: compensate(context.WithoutCancel(ctx), steps[:i]),
+			}
+		}
+	}
+	return nil
+}
+
+// compensate undoes completed steps in reverse order and returns the
+// compensations that failed.
+func compensate(ctx context.Context, completed []SagaStep) []CompensationError {
+	var failed []CompensationError
+	for i := len(completed) - 1; i >= 0; i-- {
+		step := completed[i]
+		if step.Compensate == nil {
+			continue
+		}
+		if err := step.Compensate(ctx); err != nil {
+			failed = append(failed, CompensationError{Step: sagaStepName(step, i), Index: i, Err: err})
+		}
+	}
+	return failed
+}
+
+func sagaStepName(step SagaStep, index int) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return fmt.Sprintf("#%d", index)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// sagaRecorder builds steps that log their actions and compensations.
+type sagaRecorder struct {
+	log []string
+}
+
+func (r *sagaRecorder) step(name string, actionErr, compensateErr error) SagaStep {
+	return SagaStep{
+		Name: name,
+		Action: func(ctx context.Context) error {
+			r.log = append(r.log, "do "+name)
+			return actionErr
+		},
+		Compensate: func(ctx context.Context) error {
+			r.log = append(r.log, "undo "+name)
+			return compensateErr
+		},
+	}
+}
+
+func TestRunSagaSucceeds(t *testing.T) {
+	rec := &sagaRecorder{}
+	err := RunSaga(context.Background(), []SagaStep{rec.step("a", nil, nil), rec.step("b", nil, nil)})
+	if err != nil {
+		t.Fatalf("RunSaga() error = %v", err)
+	}
+	if want := []string{"do a", "do b"}; !reflect.DeepEqual(rec.log, want) {
+		t.Errorf("log = %v, want %v", rec.log, want)
+	}
+}
+
+func TestRunSagaCompensatesInReverseOrder(t *testing.T) {
+	rec := &sagaRecorder{}
+	stepErr := errors.New("transfer rejected")
+	err := RunSaga(context.Background(), []SagaStep{
+		rec.step("lock", nil, nil),
+		rec.step("reserve", nil, nil),
+		rec.step("transfer", stepErr, nil),
+		rec.step("notify", nil, nil),
+	})
+
+	want := []string{"do lock", "do reserve", "do transfer", "undo reserve", "undo lock"}
+	if !reflect.DeepEqual(rec.log, want) {
+		t.Errorf("log = %v, want %v", rec.log, want)
+	}
+
+	var sagaErr *SagaError
+	if !errors.As(err, &sagaErr) {
+		t.Fatalf("error = %v, want *SagaError", err)
+	}
+	if sagaErr.Step != "transfer" || sagaErr.Index != 2 || !sagaErr.RolledBack() {
+		t.Errorf("SagaError = %+v, want rolled back at transfer (step 2)", sagaErr)
+	}
+	if errors.Is(err, ErrCompensationFailed) {
+		t.Error("errors.Is(err, ErrCompensationFailed) = true for a complete rollback")
+	}
+}
+
+func TestRunSagaSkipsNilCompensate(t *testing.T) {
+	rec := &sagaRecorder{}
+	readOnly := rec.step("read", nil, nil)
+	readOnly.Compensate = nil
+
+	err := RunSaga(context.Background(), []SagaStep{
+		rec.step("lock", nil, nil),
+		readOnly,
+		rec.step("write", errors.New("boom"), nil),
+	})
+
+	want := []string{"do lock", "do read", "do write", "undo lock"}
+	if !reflect.DeepEqual(rec.log, want) {
+		t.Errorf("log = %v, want %v", rec.log, want)
+	}
+	var sagaErr *SagaError
+	if !errors.As(err, &sagaErr) || !sagaErr.RolledBack() {
+		t.Fatalf("error = %v, want a rolled back *SagaError", err)
+	}
+}
+
+func TestRunSagaReportsCompensationFailures(t *testing.T) {
+	rec := &sagaRecorder{}
+	undoErr := errors.New("unlock failed")
+	err := RunSaga(context.Background(), []SagaStep{
+		rec.step("lock", nil, undoErr),
+		rec.step("reserve", nil, nil),
+		rec.step("transfer", errors.New("rejected"), nil),
+	})
+
+	// A failed compensation does not stop the remaining ones.
+	want := []string{"do lock", "do reserve", "do transfer", "undo reserve", "undo lock"}
+	if !reflect.DeepEqual(rec.log, want) {
+		t.Errorf("log = %v, want %v", rec.log, want)
+	}
+
+	if !errors.Is(err, ErrCompensationFailed) {
+		t.Fatalf("errors.Is(err, ErrCompensationFailed) = false for %v", err)
+	}
+	var sagaErr *SagaError
+	if !errors.As(err, &sagaErr) {
+		t.Fatalf("error = %v, want *SagaError", err)
+	}
+	if sagaErr.RolledBack() {
+		t.Error("RolledBack() = true with a failed compensation")
+	}
+	wantComp := []CompensationError{{Step: "lock", Index: 0, Err: undoErr}}
+	if !reflect.DeepEqual(sagaErr.CompensationErrors, wantComp) {
+		t.Errorf("CompensationErrors = %+v, want %+v", sagaErr.CompensationErrors, wantComp)
+	}
+}
+
+type insufficientFundsError struct{ need int64 }
+
+func (e *insufficientFundsError) Error() string { return "insufficient funds" }
+
+func TestRunSagaUnwrapsStepError(t *testing.T) {
+	rec := &sagaRecorder{}
+	sentinel := errors.New("account locked")
+	typed := &insufficientFundsError{need: 500}
+
+	err := RunSaga(context.Background(), []SagaStep{rec.step("lock", nil, errors.New("x")), rec.step("debit", typed, nil)})
+	var got *insufficientFundsError
+	if !errors.As(err, &got) || got.need != 500 {
+		t.Errorf("errors.As(err, *insufficientFundsError) failed for %v", err)
+	}
+	if !errors.Is(err, ErrCompensationFailed) {
+		t.Errorf("errors.Is(err, ErrCompensationFailed) = false alongside the step error")
+	}
+
+	err = RunSaga(context.Background(), []SagaStep{rec.step("lock", sentinel, nil)})
+	if !errors.Is(err, sentinel) {
+		t.Errorf("errors.Is(err, sentinel) = false for %v", err)
+	}
+}
+
+func TestRunSagaCompensatesAfterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	type ctxKey struct{}
+	ctx = context.WithValue(ctx, ctxKey{}, "trace-1")
+
+	var compensateCtxErr error
+	var compensateValue any
+	steps := []SagaStep{
+		{
+			Name:   "lock",
+			Action: func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error {
+				compensateCtxErr = ctx.Err()
+				compensateValue = ctx.Value(ctxKey{})
+				return nil
+			},
+		},
+		{
+			Name: "transfer",
+			Action: func(ctx context.Context) error {
+				cancel()
+				return nil
+			},
+		},
+		{
+			Name: "notify",
+			Action: func(ctx context.Context) error {
+				t.Error("step ran after the context was cancelled")
+				return nil
+			},
+		},
+	}
+
+	err := RunSaga(ctx, steps)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	var sagaErr *SagaError
+	if !errors.As(err, &sagaErr) || sagaErr.Step != "notify" || !sagaErr.RolledBack() {
+		t.Errorf("SagaError = %+v, want rolled back before notify", sagaErr)
+	}
+	if compensateCtxErr != nil {
+		t.Errorf("compensation ctx.Err() = %v, want nil (detached from cancellation)", compensateCtxErr)
+	}
+	if compensateValue != "trace-1" {
+		t.Errorf("compensation ctx value = %v, want trace-1", compensateValue)
+	}
+}
+
+func TestRunSagaUnnamedStep(t *testing.T) {
+	err := RunSaga(context.Background(), []SagaStep{{Action: func(ctx context.Context) error { return errors.New("x") }}})
+	var sagaErr *SagaError
+	if !errors.As(err, &sagaErr) || sagaErr.Step != "#0" {
+		t.Errorf("error = %v, want step named #0", err)
+	}
+}
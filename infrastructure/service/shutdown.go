@@ -0,0 +1,147 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Shutdown reason codes.
+const (
+	// ShutdownSignal means the process received a termination signal
+	// (typically a deploy or scale-down); Detail names the signal.
+	ShutdownSignal = "signal"
+
+	// ShutdownError means the service is stopping because of a fatal error;
+	// Detail holds the error.
+	ShutdownError = "error"
+)
+
+// ShutdownReason records why a service is stopping.
+type ShutdownReason struct {
+	Code   string    `json:"code"`
+	Detail string    `json:"detail,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// ShutdownReporter is implemented by services that accept a shutdown reason
+// and report in-flight operations (every BaseService does).
+type ShutdownReporter interface {
+	SetShutdownReason(reason ShutdownReason)
+	InFlight() map[string]int
+}
+
+// shutdownState is the shutdown reason and in-flight operation counts of a
+// service.
+type shutdownState struct {
+	reason   *ShutdownReason
+	inflight map[string]int
+}
+
+// SetShutdownReason records why the service is about to stop. Call it before
+// Stop so the service's Stop (and workers watching StopChan) can read it with
+// ShutdownReason. Only the first reason is kept. Once set, /ready reports
+// "shutting_down" so the instance is taken out of rotation.
+func (b *BaseService) SetShutdownReason(reason ShutdownReason) {
+	if reason.At.IsZero() {
+		reason.At = time.Now()
+	}
+
+	b.shutdownMu.Lock()
+	if b.shutdown.reason != nil {
+		b.shutdownMu.Unlock()
+		return
+	}
+	b.shutdown.reason = &reason
+	b.shutdownMu.Unlock()
+
+	b.Logger().WithFields(map[string]interface{}{
+		"shutdown_code":   reason.Code,
+		"shutdown_detail": reason.Detail,
+		"in_flight":       b.InFlight(),
+	}).Info("shutdown requested")
+}
+
+// ShutdownReason returns the reason set by SetShutdownReason, if any.
+func (b *BaseService) ShutdownReason() (ShutdownReason, bool) {
+	b.shutdownMu.Lock()
+	defer b.shutdownMu.Unlock()
+	if b.shutdown.reason == nil {
+		return ShutdownReason{}, false
+	}
+	return *b.shutdown.reason, true
+}
+
+// BeginOperation marks an operation of the given kind as in flight until the
+// returned function is called (calling it more than once is safe). HTTP
+// requests and ticker worker runs are tracked automatically; services can
+// track other long-running work the same way.
+func (b *BaseService) BeginOperation(name string) (done func()) {
+	b.shutdownMu.Lock()
+	if b.shutdown.inflight == nil {
+		b.shutdown.inflight = make(map[string]int)
+	}
+	b.shutdown.inflight[name]++
+	b.shutdownMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.shutdownMu.Lock()
+			if b.shutdown.inflight[name]--; b.shutdown.inflight[name] <= 0 {
+				delete(b.shutdown.inflight, name)
+			}
+			b.shutdownMu.Unlock()
+		})
+	}
+}
+
+// InFlight returns the number of in-flight operations by kind.
+func (b *BaseService) InFlight() map[string]int {
+	b.shutdownMu.Lock()
+	defer b.shutdownMu.Unlock()
+	out := make(map[string]int, len(b.shutdown.inflight))
+	for name, n := range b.shutdown.inflight {
+		out[name] = n
+	}
+	return out
+}
+
+// InFlightSummary returns the kinds of in-flight operations, sorted, and the
+// total count.
+func InFlightSummary(inflight map[string]int) (kinds []string, total int) {
+	kinds = make([]string, 0, len(inflight))
+	for name, n := range inflight {
+		kinds = append(kinds, name)
+		total += n
+	}
+	sort.Strings(kinds)
+	return kinds, total
+}
+
+// shutdownDetails returns the shutdown fields reported by /ready.
+func (b *BaseService) shutdownDetails() map[string]any {
+	reason, ok := b.ShutdownReason()
+	if !ok {
+		return nil
+	}
+	details := map[string]any{
+		"shutdown_code": reason.Code,
+		"shutdown_at":   reason.At.Format(time.RFC3339),
+		"in_flight":     b.InFlight(),
+	}
+	if reason.Detail != "" {
+		details["shutdown_detail"] = reason.Detail
+	}
+	return details
+}
+
+// inFlightMiddleware counts HTTP requests as in-flight operations.
+func (b *BaseService) inFlightMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done := b.BeginOperation("http")
+		defer done()
+		next.ServeHTTP(w, r)
+	})
+}
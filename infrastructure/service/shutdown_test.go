@@ -0,0 +1,96 @@
+package service
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBeginOperationTracksInFlight(t *testing.T) {
+	b := newTestService(t)
+
+	doneA := b.BeginOperation("worker:a")
+	doneA2 := b.BeginOperation("worker:a")
+	doneB := b.BeginOperation("worker:b")
+	if got, want := b.InFlight(), map[string]int{"worker:a": 2, "worker:b": 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("InFlight() = %v, want %v", got, want)
+	}
+
+	doneA()
+	doneA() // calling done twice must not decrement twice
+	if got, want := b.InFlight(), map[string]int{"worker:a": 1, "worker:b": 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("InFlight() after one done = %v, want %v", got, want)
+	}
+
+	doneA2()
+	doneB()
+	if got := b.InFlight(); len(got) != 0 {
+		t.Fatalf("InFlight() after all done = %v, want empty", got)
+	}
+}
+
+func TestInFlightReturnsCopy(t *testing.T) {
+	b := newTestService(t)
+	done := b.BeginOperation("http")
+	defer done()
+
+	snapshot := b.InFlight()
+	snapshot["http"] = 99
+	if got := b.InFlight()["http"]; got != 1 {
+		t.Fatalf("InFlight()[http] = %d after mutating a snapshot, want 1", got)
+	}
+}
+
+func TestInFlightSummary(t *testing.T) {
+	kinds, total := InFlightSummary(map[string]int{"worker:b": 2, "http": 3})
+	if want := []string{"http", "worker:b"}; !reflect.DeepEqual(kinds, want) || total != 5 {
+		t.Fatalf("InFlightSummary() = %v, %d; want %v, 5", kinds, total, want)
+	}
+}
+
+func TestSetShutdownReasonFirstWins(t *testing.T) {
+	b := newTestService(t)
+	if _, ok := b.ShutdownReason(); ok {
+		t.Fatal("ShutdownReason() set before SetShutdownReason")
+	}
+
+	b.SetShutdownReason(ShutdownReason{Code: ShutdownSignal, Detail: "terminated"})
+	b.SetShutdownReason(ShutdownReason{Code: ShutdownError, Detail: "listen: address in use"})
+
+	reason, ok := b.ShutdownReason()
+	if !ok || reason.Code != ShutdownSignal || reason.Detail != "terminated" {
+		t.Fatalf("ShutdownReason() = %+v, %v; want the first (signal) reason", reason, ok)
+	}
+	if reason.At.IsZero() {
+		t.Error("ShutdownReason().At not defaulted")
+	}
+}
+
+func TestReadinessReportsShuttingDown(t *testing.T) {
+	b := newTestService(t)
+	done := b.BeginOperation("worker:sync")
+	defer done()
+
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b.SetShutdownReason(ShutdownReason{Code: ShutdownSignal, Detail: "terminated", At: at})
+
+	rec := serve(b, http.MethodGet, "/ready")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("/ready status = %d, want 503", rec.Code)
+	}
+	resp := decodeHealth(t, rec)
+	if resp.Status != "shutting_down" {
+		t.Fatalf("status = %q, want shutting_down", resp.Status)
+	}
+	if resp.Details["shutdown_code"] != ShutdownSignal || resp.Details["shutdown_detail"] != "terminated" {
+		t.Errorf("details = %v, want the shutdown reason", resp.Details)
+	}
+	if resp.Details["shutdown_at"] != at.Format(time.RFC3339) {
+		t.Errorf("shutdown_at = %v, want %s", resp.Details["shutdown_at"], at.Format(time.RFC3339))
+	}
+	inflight, _ := resp.Details["in_flight"].(map[string]any)
+	if inflight["worker:sync"] != float64(1) {
+		t.Errorf("in_flight = %v, want worker:sync counted", resp.Details["in_flight"])
+	}
+}
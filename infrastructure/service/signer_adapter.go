@@ -16,6 +16,18 @@ import (
 // BaseSignerAdapter provides common GlobalSigner client operations.
 type BaseSignerAdapter struct {
 	GSClient *gsclient.Client
+
+	// Scheme is the signature scheme to sign and fetch keys under. Empty
+	// means secp256r1, which on-chain verification requires; it is never
+	// left to GlobalSigner's configured default.
+	Scheme string
+}
+
+func (a *BaseSignerAdapter) scheme() string {
+	if a.Scheme == "" {
+		return "secp256r1"
+	}
+	return a.Scheme
 }
 
 // Sign signs data with a domain prefix using GlobalSigner.
@@ -27,6 +39,7 @@ func (a *BaseSignerAdapter) Sign(ctx context.Context, domain string, data []byte
 	resp, err := a.GSClient.Sign(ctx, &gsclient.SignRequest{
 		Domain: domain,
 		Data:   hex.EncodeToString(data),
+		Scheme: a.scheme(),
 	})
 	if err != nil {
 		return nil, "", fmt.Errorf("sign: %w", err)
@@ -46,7 +59,7 @@ func (a *BaseSignerAdapter) GetPublicKey(ctx context.Context) (pubKeyHex, keyVer
 		return "", "", fmt.Errorf("globalsigner client not configured")
 	}
 
-	att, err := a.GSClient.GetAttestation(ctx)
+	att, err := a.GSClient.GetSchemeAttestation(ctx, a.scheme())
 	if err != nil {
 		return "", "", fmt.Errorf("get attestation: %w", err)
 	}
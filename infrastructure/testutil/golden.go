@@ -0,0 +1,123 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to "1", makes
+// AssertGoldenJSON rewrite golden files instead of comparing against them.
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// AssertGoldenJSON marshals v and compares it with the committed golden file
+// testdata/golden/<name>.json (relative to the test's package directory).
+// The comparison is on decoded JSON, so formatting is irrelevant, and a
+// mismatch lists each added, removed or changed field by path, e.g. a
+// renamed tag shows as one removed and one added field. Run the tests with
+// UPDATE_GOLDEN=1 to write the golden files after an intended change.
+func AssertGoldenJSON(t testing.TB, name string, v any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %s: %v", name, err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with %s=1 to create it)", path, err, UpdateGoldenEnv)
+	}
+	if bytes.Equal(want, got) {
+		return
+	}
+
+	var wantDoc, gotDoc any
+	if err := json.Unmarshal(want, &wantDoc); err != nil {
+		t.Fatalf("decode golden %s: %v", path, err)
+	}
+	if err := json.Unmarshal(got, &gotDoc); err != nil {
+		t.Fatalf("decode %s: %v", name, err)
+	}
+	if diffs := diffJSON("$", wantDoc, gotDoc); len(diffs) > 0 {
+		t.Errorf("%s does not match %s (run with %s=1 if the change is intended):\n  %s",
+			name, path, UpdateGoldenEnv, strings.Join(diffs, "\n  "))
+	}
+}
+
+// diffJSON describes the differences between two decoded JSON documents.
+func diffJSON(path string, want, got any) []string {
+	wantObj, wantIsObj := want.(map[string]any)
+	gotObj, gotIsObj := got.(map[string]any)
+	if wantIsObj && gotIsObj {
+		keys := make(map[string]bool, len(wantObj)+len(gotObj))
+		for k := range wantObj {
+			keys[k] = true
+		}
+		for k := range gotObj {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		var diffs []string
+		for _, k := range sorted {
+			w, inWant := wantObj[k]
+			g, inGot := gotObj[k]
+			child := path + "." + k
+			switch {
+			case !inGot:
+				diffs = append(diffs, "removed "+child)
+			case !inWant:
+				diffs = append(diffs, "added "+child)
+			default:
+				diffs = append(diffs, diffJSON(child, w, g)...)
+			}
+		}
+		return diffs
+	}
+
+	wantArr, wantIsArr := want.([]any)
+	gotArr, gotIsArr := got.([]any)
+	if wantIsArr && gotIsArr && len(wantArr) == len(gotArr) {
+		var diffs []string
+		for i := range wantArr {
+			diffs = append(diffs, diffJSON(fmt.Sprintf("%s[%d]", path, i), wantArr[i], gotArr[i])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+	return []string{fmt.Sprintf("changed %s: %s -> %s", path, compactJSON(want), compactJSON(got))}
+}
+
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,7 @@
+-- =============================================================================
+-- Neo Service Layer - Email Verification State
+-- Tracks whether a user's email address has been confirmed via a signed token.
+-- =============================================================================
+
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
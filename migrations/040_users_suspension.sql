@@ -0,0 +1,16 @@
+-- =============================================================================
+-- Neo Service Layer - User Suspension
+-- Structured suspension with a reason and an optional end time. A suspension
+-- with suspended_until ends once that time passes; the repository clears the
+-- columns the next time it loads the user.
+-- =============================================================================
+
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+
+-- Supports listing suspended users by end time.
+CREATE INDEX IF NOT EXISTS idx_users_suspended_until
+    ON public.users (suspended_until)
+    WHERE suspended_at IS NOT NULL;
@@ -0,0 +1,17 @@
+-- =============================================================================
+-- Neo Service Layer - Secret Audit Log Hash Chain
+-- Each entry stores its own hash and the hash of the user's previous entry so
+-- that edits or deletions are detectable (see VerifyAuditChain).
+-- =============================================================================
+
+ALTER TABLE IF EXISTS public.secret_audit_logs
+    ADD COLUMN IF NOT EXISTS prev_hash TEXT,
+    ADD COLUMN IF NOT EXISTS hash TEXT;
+
+-- One successor per entry: concurrent appends for the same user cannot both
+-- link to the same predecessor (the loser retries). A chain start has no
+-- prev_hash, so NULL is folded into ''. Legacy rows without a hash are not
+-- part of the chain.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_secret_audit_logs_chain_link
+    ON public.secret_audit_logs (user_id, COALESCE(prev_hash, ''))
+    WHERE hash IS NOT NULL;
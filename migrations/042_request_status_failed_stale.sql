@@ -0,0 +1,16 @@
+-- =============================================================================
+-- Extend request status enum with failed_stale (oracle result max-age guard)
+-- =============================================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_type t
+        JOIN pg_enum e ON e.enumtypid = t.oid
+        WHERE t.typname = 'request_status'
+          AND e.enumlabel = 'failed_stale'
+    ) THEN
+        ALTER TYPE request_status ADD VALUE 'failed_stale';
+    END IF;
+END $$;
@@ -0,0 +1,13 @@
+-- =============================================================================
+-- Neo Service Layer - NeoFlow execution on-chain outcome
+-- Executions of contract actions record the submitted transaction and its VM
+-- state (HALT, or FAULT when the call reverted).
+-- =============================================================================
+
+ALTER TABLE IF EXISTS public.neoflow_executions
+    ADD COLUMN IF NOT EXISTS tx_hash TEXT,
+    ADD COLUMN IF NOT EXISTS vm_state TEXT;
+
+CREATE INDEX IF NOT EXISTS neoflow_executions_trigger_tx_idx
+    ON public.neoflow_executions(trigger_id, executed_at DESC)
+    WHERE tx_hash IS NOT NULL;
@@ -0,0 +1,11 @@
+-- =============================================================================
+-- Neo Service Layer - GasBank spending limits
+-- Per-account caps on a single spend and on total spend per UTC day (0 means
+-- unlimited), plus the running spend for the current day.
+-- =============================================================================
+
+ALTER TABLE IF EXISTS public.gasbank_accounts
+    ADD COLUMN IF NOT EXISTS max_per_tx BIGINT NOT NULL DEFAULT 0 CHECK (max_per_tx >= 0),
+    ADD COLUMN IF NOT EXISTS max_per_day BIGINT NOT NULL DEFAULT 0 CHECK (max_per_day >= 0),
+    ADD COLUMN IF NOT EXISTS daily_spent BIGINT NOT NULL DEFAULT 0 CHECK (daily_spent >= 0),
+    ADD COLUMN IF NOT EXISTS daily_spent_date DATE;
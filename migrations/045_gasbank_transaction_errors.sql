@@ -0,0 +1,12 @@
+-- =============================================================================
+-- Neo Service Layer - GasBank transaction failure reasons
+-- Sponsored transactions are recorded as pending until their on-chain outcome
+-- is known; failed ones are refunded and keep the reason they failed.
+-- =============================================================================
+
+ALTER TABLE IF EXISTS public.gasbank_transactions
+    ADD COLUMN IF NOT EXISTS error TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_gasbank_tx_pending
+    ON public.gasbank_transactions(created_at, id)
+    WHERE status = 'pending' AND tx_hash IS NOT NULL;
@@ -0,0 +1,21 @@
+-- =============================================================================
+-- Neo Service Layer - GlobalSigner replay protection
+-- Signatures issued for a RequestID, keyed by sha256(domain, service ID,
+-- RequestID), so a repeated request returns the original signature instead of
+-- signing twice. Rows are removed once expires_at passes.
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS signer_signed_requests (
+  request_key TEXT PRIMARY KEY,
+  data_hash TEXT NOT NULL,
+  signature TEXT NOT NULL,
+  key_version TEXT NOT NULL,
+  public_key TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS signer_signed_requests_expires_at_idx
+  ON signer_signed_requests (expires_at);
+
+COMMENT ON TABLE signer_signed_requests IS 'GlobalSigner RequestID replay protection';
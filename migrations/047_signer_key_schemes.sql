@@ -0,0 +1,14 @@
+-- =============================================================================
+-- Neo Service Layer - GlobalSigner signature schemes
+-- Each key version records the scheme it was created under (secp256r1 for
+-- Neo N3, or ed25519). Existing keys are secp256r1.
+-- =============================================================================
+
+ALTER TABLE IF EXISTS signer_key_rotations
+    ADD COLUMN IF NOT EXISTS scheme TEXT NOT NULL DEFAULT 'secp256r1';
+
+ALTER TABLE IF EXISTS signer_key_rotations
+    DROP CONSTRAINT IF EXISTS signer_key_rotations_scheme_check;
+
+ALTER TABLE IF EXISTS signer_key_rotations
+    ADD CONSTRAINT signer_key_rotations_scheme_check CHECK (scheme IN ('secp256r1', 'ed25519'));
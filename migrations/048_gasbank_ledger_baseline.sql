@@ -0,0 +1,26 @@
+-- =============================================================================
+-- Neo Service Layer - GasBank ledger baseline
+-- Committed reservations did not record a ledger entry before reconciliation
+-- was added, so older accounts have balances that the transaction history does
+-- not sum to. Record one 'adjustment' per such account so reconciliation
+-- starts from the balance as of this migration. Any other ledger gap present
+-- at this point is absorbed too; review it beforehand with the SELECT below.
+-- Re-running inserts nothing once the ledgers match.
+-- =============================================================================
+
+INSERT INTO public.gasbank_transactions
+    (account_id, tx_type, amount, balance_after, reference_id, status, created_at)
+SELECT a.id,
+       'adjustment',
+       a.balance - COALESCE(SUM(t.amount), 0),
+       a.balance,
+       'ledger-baseline-048',
+       'completed',
+       NOW()
+FROM public.gasbank_accounts a
+LEFT JOIN public.gasbank_transactions t ON t.account_id = a.id
+GROUP BY a.id, a.balance
+HAVING a.balance <> COALESCE(SUM(t.amount), 0);
+
+CREATE INDEX IF NOT EXISTS idx_gasbank_tx_account_created
+    ON public.gasbank_transactions(account_id, created_at, id);
@@ -0,0 +1,13 @@
+-- =============================================================================
+-- Neo Service Layer - NeoFeeds backfilled history
+-- Values recorded by the historical backfill come from third-party history
+-- endpoints rather than live observation; they are stored unsigned and marked
+-- so consumers can tell them apart. The (feed_id, timestamp) index serves the
+-- backfill's check for values already recorded at a timestamp.
+-- =============================================================================
+
+ALTER TABLE IF EXISTS public.price_feeds
+    ADD COLUMN IF NOT EXISTS backfilled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_price_feeds_feed_timestamp
+    ON public.price_feeds(feed_id, timestamp);
@@ -0,0 +1,15 @@
+-- =============================================================================
+-- Neo Service Layer - NeoFeeds signer sets
+-- Oracle node keys allowed to sign each feed and the number of signatures
+-- required. Rows are written when an operator changes a feed's signer set and
+-- override the signer_set/threshold from the feeds config at startup.
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS public.price_feed_signer_sets (
+  feed_id TEXT PRIMARY KEY,
+  signers JSONB NOT NULL DEFAULT '[]'::jsonb,
+  threshold INTEGER NOT NULL CHECK (threshold >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE public.price_feed_signer_sets IS 'NeoFeeds signer sets managed at runtime';
@@ -0,0 +1,9 @@
+-- =============================================================================
+-- Neo Service Layer - NeoFeeds signer set versions
+-- Signer set writes are version-checked: an update only applies when the
+-- stored version matches the one the writer loaded, and bumps it. This stops
+-- one neofeeds instance from silently overwriting a change made by another.
+-- =============================================================================
+
+ALTER TABLE IF EXISTS public.price_feed_signer_sets
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
@@ -1,6 +1,7 @@
 import { createClient } from "https://esm.sh/@supabase/supabase-js@2.49.1";
 import { getEnv, mustGetEnv } from "./env.ts";
 import { error } from "./response.ts";
+import { rejectSuspended } from "./suspension.ts";
 
 function parseBearerToken(req: Request): string | undefined {
   const auth = req.headers.get("Authorization")?.trim() ?? "";
@@ -38,7 +39,7 @@ export type AuthContext = {
   authType: "bearer" | "api_key";
 };
 
-export async function requireUser(req: Request): Promise<AuthContext | Response> {
+async function authenticateBearer(req: Request): Promise<AuthContext | Response> {
   const token = parseBearerToken(req);
   if (!token) return error(401, "missing Authorization: Bearer <jwt>", "AUTH_REQUIRED", req);
 
@@ -54,9 +55,22 @@ export async function requireUser(req: Request): Promise<AuthContext | Response>
   };
 }
 
+// withActiveAccount rejects suspended accounts with 403 once the caller is
+// authenticated.
+async function withActiveAccount(auth: AuthContext, req: Request): Promise<AuthContext | Response> {
+  const suspended = await rejectSuspended(supabaseServiceClient(), auth.userId, req);
+  return suspended ?? auth;
+}
+
+export async function requireUser(req: Request): Promise<AuthContext | Response> {
+  const bearer = await authenticateBearer(req);
+  if (bearer instanceof Response) return bearer;
+  return await withActiveAccount(bearer, req);
+}
+
 export async function requireAuth(req: Request): Promise<AuthContext | Response> {
-  const bearer = await requireUser(req);
-  if (!(bearer instanceof Response)) return bearer;
+  const bearer = await authenticateBearer(req);
+  if (!(bearer instanceof Response)) return await withActiveAccount(bearer, req);
 
   const apiKey = parseUserAPIKey(req);
   if (!apiKey) return error(401, "missing Authorization or X-API-Key", "AUTH_REQUIRED", req);
@@ -75,7 +89,7 @@ export async function requireAuth(req: Request): Promise<AuthContext | Response>
   const scopes = Array.isArray(row?.scopes) ? (row?.scopes as string[]) : undefined;
   const apiKeyId = String(row?.key_id ?? "").trim() || undefined;
 
-  return { userId, apiKeyId, scopes, authType: "api_key" };
+  return await withActiveAccount({ userId, apiKeyId, scopes, authType: "api_key" }, req);
 }
 
 export async function requirePrimaryWallet(userId: string, req?: Request): Promise<{ address: string } | Response> {
@@ -0,0 +1,60 @@
+import { error } from "./response.ts";
+
+// Suspension columns on public.users (migrations/040_users_suspension.sql).
+export type SuspensionRow = {
+  suspended_at?: string | null;
+  suspended_until?: string | null;
+  suspension_reason?: string | null;
+};
+
+export type Suspension = {
+  reason: string;
+  until?: string;
+};
+
+// Minimal query surface used here, so tests can pass a stub client.
+type UsersQueryClient = {
+  from(table: string): {
+    select(columns: string): {
+      eq(column: string, value: string): {
+        maybeSingle(): PromiseLike<{ data: SuspensionRow | null; error: { message: string } | null }>;
+      };
+    };
+  };
+};
+
+// activeSuspension mirrors database.User.IsSuspended: a user is suspended
+// once suspended_at is set, until suspended_until passes (no end means
+// indefinite).
+export function activeSuspension(row: SuspensionRow | null | undefined, now = new Date()): Suspension | undefined {
+  if (!row?.suspended_at) return undefined;
+  const until = row.suspended_until ?? undefined;
+  if (until && new Date(until).getTime() <= now.getTime()) return undefined;
+  return { reason: String(row.suspension_reason ?? "").trim(), until };
+}
+
+export function suspendedResponse(suspension: Suspension, req?: Request): Response {
+  let message = "account suspended";
+  if (suspension.reason) message += `: ${suspension.reason}`;
+  if (suspension.until) message += ` (until ${suspension.until})`;
+  return error(403, message, "ACCOUNT_SUSPENDED", req);
+}
+
+// rejectSuspended loads the user's suspension state and returns a 403
+// response if the account is suspended, or undefined if it may proceed.
+export async function rejectSuspended(
+  supabase: UsersQueryClient,
+  userId: string,
+  req?: Request,
+  now = new Date(),
+): Promise<Response | undefined> {
+  const { data, error: queryErr } = await supabase
+    .from("users")
+    .select("suspended_at,suspended_until,suspension_reason")
+    .eq("id", userId)
+    .maybeSingle();
+  if (queryErr) return error(500, `failed to load account status: ${queryErr.message}`, "DB_ERROR", req);
+
+  const suspension = activeSuspension(data, now);
+  return suspension ? suspendedResponse(suspension, req) : undefined;
+}
@@ -0,0 +1,82 @@
+/**
+ * Unit tests for account suspension checks
+ */
+
+import { assertEquals, assertExists } from "https://deno.land/std@0.208.0/assert/mod.ts";
+import { activeSuspension, rejectSuspended, type SuspensionRow } from "./suspension.ts";
+
+const NOW = new Date("2026-01-01T12:00:00Z");
+
+function createMockSupabase(data: SuspensionRow | null, queryError: { message: string } | null = null) {
+  const calls: { table?: string; column?: string; value?: string } = {};
+  return {
+    calls,
+    client: {
+      from: (table: string) => {
+        calls.table = table;
+        return {
+          select: (_columns: string) => ({
+            eq: (column: string, value: string) => {
+              calls.column = column;
+              calls.value = value;
+              return {
+                maybeSingle: () => Promise.resolve({ data, error: queryError }),
+              };
+            },
+          }),
+        };
+      },
+    },
+  };
+}
+
+Deno.test("activeSuspension - not suspended", () => {
+  assertEquals(activeSuspension(null, NOW), undefined);
+  assertEquals(activeSuspension({}, NOW), undefined);
+  assertEquals(activeSuspension({ suspended_at: null, suspension_reason: "old" }, NOW), undefined);
+});
+
+Deno.test("activeSuspension - indefinite", () => {
+  const s = activeSuspension({ suspended_at: "2026-01-01T00:00:00Z", suspension_reason: "fraud" }, NOW);
+  assertEquals(s, { reason: "fraud", until: undefined });
+});
+
+Deno.test("activeSuspension - time-boxed", () => {
+  const row = {
+    suspended_at: "2026-01-01T00:00:00Z",
+    suspended_until: "2026-01-02T00:00:00Z",
+    suspension_reason: "spam",
+  };
+  assertEquals(activeSuspension(row, NOW)?.until, "2026-01-02T00:00:00Z");
+  // Expired windows no longer block the user, even before the sweep clears them.
+  assertEquals(activeSuspension(row, new Date("2026-01-02T00:00:00Z")), undefined);
+});
+
+Deno.test("rejectSuspended - suspended user gets 403", async () => {
+  const mock = createMockSupabase({ suspended_at: "2026-01-01T00:00:00Z", suspension_reason: "chargeback" });
+  const res = await rejectSuspended(mock.client, "user-1", undefined, NOW);
+
+  assertExists(res);
+  assertEquals(res.status, 403);
+  const body = await res.json();
+  assertEquals(body.error.code, "ACCOUNT_SUSPENDED");
+  assertEquals(body.error.message, "account suspended: chargeback");
+  assertEquals(mock.calls, { table: "users", column: "id", value: "user-1" });
+});
+
+Deno.test("rejectSuspended - active user passes", async () => {
+  const mock = createMockSupabase({ suspended_at: null });
+  assertEquals(await rejectSuspended(mock.client, "user-1", undefined, NOW), undefined);
+});
+
+Deno.test("rejectSuspended - missing user row passes", async () => {
+  const mock = createMockSupabase(null);
+  assertEquals(await rejectSuspended(mock.client, "user-1", undefined, NOW), undefined);
+});
+
+Deno.test("rejectSuspended - query error fails closed", async () => {
+  const mock = createMockSupabase(null, { message: "timeout" });
+  const res = await rejectSuspended(mock.client, "user-1", undefined, NOW);
+  assertExists(res);
+  assertEquals(res.status, 500);
+});
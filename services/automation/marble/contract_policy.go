@@ -0,0 +1,96 @@
+package neoflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+)
+
+// ContractAllowlistEnv lists the contract hashes (comma-separated) that user
+// trigger actions may invoke. Contract actions are signed by the platform key
+// via TxProxy, so an empty list disables them.
+const ContractAllowlistEnv = "NEOFLOW_CONTRACT_ALLOWLIST"
+
+// Native contracts are never valid targets: the platform account holds GAS.
+var nativeContractHashes = []string{
+	"0xd2a4cff31913016155e38e474a2c06d08be276cf", // GAS
+	"0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5", // NEO
+}
+
+func normalizeContractTarget(hash string) string {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	return strings.TrimPrefix(hash, "0x")
+}
+
+func contractHashSet(hashes ...string) map[string]struct{} {
+	out := make(map[string]struct{}, len(hashes))
+	for _, h := range hashes {
+		if n := normalizeContractTarget(h); n != "" {
+			out[n] = struct{}{}
+		}
+	}
+	return out
+}
+
+func loadContractAllowlist(configured []string) map[string]struct{} {
+	if len(configured) == 0 {
+		configured = strings.Split(os.Getenv(ContractAllowlistEnv), ",")
+	}
+	return contractHashSet(configured...)
+}
+
+// platformContractSet collects the platform contracts a user trigger must
+// never drive with the platform key, even when they are listed in the
+// allowlist by mistake.
+func platformContractSet(priceFeedHash, automationAnchorHash string) map[string]struct{} {
+	c := chain.ContractAddressesFromEnv()
+	hashes := append([]string{
+		priceFeedHash,
+		automationAnchorHash,
+		c.PaymentHub,
+		c.Governance,
+		c.PriceFeed,
+		c.RandomnessLog,
+		c.AppRegistry,
+		c.AutomationAnchor,
+		c.ServiceLayerGateway,
+	}, nativeContractHashes...)
+	return contractHashSet(hashes...)
+}
+
+// checkContractTarget reports whether a user trigger may invoke contractHash.
+func (s *Service) checkContractTarget(contractHash string) error {
+	target := normalizeContractTarget(contractHash)
+	if target == "" {
+		return fmt.Errorf("contract_hash required")
+	}
+	if _, denied := s.platformContracts[target]; denied {
+		return fmt.Errorf("contract %s is a platform contract and cannot be invoked by triggers", contractHash)
+	}
+	if _, ok := s.contractAllowlist[target]; !ok {
+		return fmt.Errorf("contract %s is not in %s", contractHash, ContractAllowlistEnv)
+	}
+	return nil
+}
+
+// validateTriggerAction rejects contract actions that target contracts the
+// service will refuse to invoke at dispatch time.
+func (s *Service) validateTriggerAction(raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	var action Action
+	if err := json.Unmarshal(raw, &action); err != nil {
+		return fmt.Errorf("invalid action: %w", err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(action.Type), "contract") {
+		return nil
+	}
+	if strings.TrimSpace(action.ContractMethod) == "" {
+		return fmt.Errorf("contract_hash and contract_method required")
+	}
+	return s.checkContractTarget(action.ContractHash)
+}
@@ -56,6 +56,10 @@ func (s *Service) handleCreateTrigger(w http.ResponseWriter, r *http.Request) {
 		httputil.BadRequest(w, "name and trigger_type required")
 		return
 	}
+	if err := s.validateTriggerAction(req.Action); err != nil {
+		httputil.BadRequest(w, err.Error())
+		return
+	}
 
 	// Calculate next execution for cron triggers
 	var nextExec time.Time
@@ -122,6 +126,10 @@ func (s *Service) handleUpdateTrigger(w http.ResponseWriter, r *http.Request) {
 	if !httputil.DecodeJSON(w, r, &req) {
 		return
 	}
+	if err := s.validateTriggerAction(req.Action); err != nil {
+		httputil.BadRequest(w, err.Error())
+		return
+	}
 
 	trigger, err := s.repo.GetTrigger(r.Context(), id, userID)
 	if err != nil {
@@ -203,7 +211,13 @@ func (s *Service) handleListExecutions(w http.ResponseWriter, r *http.Request) {
 	if limit > 500 {
 		limit = 500
 	}
-	execs, err := s.repo.GetExecutions(r.Context(), id, limit)
+	var execs []neoflowsupabase.Execution
+	var err error
+	if httputil.QueryBool(r, "onchain", false) {
+		execs, err = s.repo.GetOnChainExecutions(r.Context(), id, limit)
+	} else {
+		execs, err = s.repo.GetExecutions(r.Context(), id, limit)
+	}
 	if err != nil {
 		httputil.InternalError(w, "failed to load executions")
 		return
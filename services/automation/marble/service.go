@@ -55,6 +55,10 @@ type Service struct {
 	eventListener        *chain.EventListener
 	enableChainExec      bool
 
+	// Contract targets user triggers may invoke, and platform contracts they never may.
+	contractAllowlist map[string]struct{}
+	platformContracts map[string]struct{}
+
 	// Service fee deduction
 	gasbank *gasbankclient.Client
 }
@@ -80,6 +84,10 @@ type Config struct {
 	EventListener        *chain.EventListener
 	EnableChainExec      bool
 
+	// ContractAllowlist lists the contracts user contract actions may invoke.
+	// Defaults to NEOFLOW_CONTRACT_ALLOWLIST; empty disables contract actions.
+	ContractAllowlist []string
+
 	// GasBank client for service fee deduction (optional)
 	GasBank *gasbankclient.Client
 }
@@ -114,6 +122,8 @@ func New(cfg Config) (*Service, error) { //nolint:gocritic // cfg is read once a
 		eventListener:        cfg.EventListener,
 		enableChainExec:      cfg.EnableChainExec,
 		gasbank:              cfg.GasBank,
+		contractAllowlist:    loadContractAllowlist(cfg.ContractAllowlist),
+		platformContracts:    platformContractSet(cfg.PriceFeedHash, cfg.AutomationAnchorHash),
 	}
 
 	if s.chainClient != nil && s.priceFeedHash != "" {
@@ -15,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/R3E-Network/service_layer/infrastructure/marble"
+	txproxytypes "github.com/R3E-Network/service_layer/infrastructure/txproxy/types"
 	neoflowsupabase "github.com/R3E-Network/service_layer/services/automation/supabase"
 )
 
@@ -136,6 +137,16 @@ func (m *mockNeoFlowRepo) GetExecutions(_ context.Context, triggerID string, lim
 	return execs, nil
 }
 
+func (m *mockNeoFlowRepo) GetOnChainExecutions(_ context.Context, triggerID string, limit int) ([]neoflowsupabase.Execution, error) {
+	var execs []neoflowsupabase.Execution
+	for _, exec := range m.executions[triggerID] {
+		if exec.TxHash != "" && len(execs) < limit {
+			execs = append(execs, exec)
+		}
+	}
+	return execs, nil
+}
+
 // =============================================================================
 // Service Tests
 // =============================================================================
@@ -1018,12 +1029,12 @@ func TestDispatchActionEmpty(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neoflow"})
 	svc, _ := New(Config{Marble: m})
 
-	err := svc.dispatchAction(context.Background(), nil)
+	_, err := svc.dispatchAction(context.Background(), nil)
 	if err != nil {
 		t.Errorf("dispatchAction(nil) error = %v, want nil", err)
 	}
 
-	err = svc.dispatchAction(context.Background(), json.RawMessage{})
+	_, err = svc.dispatchAction(context.Background(), json.RawMessage{})
 	if err != nil {
 		t.Errorf("dispatchAction(empty) error = %v, want nil", err)
 	}
@@ -1033,7 +1044,7 @@ func TestDispatchActionInvalidJSON(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neoflow"})
 	svc, _ := New(Config{Marble: m})
 
-	err := svc.dispatchAction(context.Background(), json.RawMessage(`{invalid`))
+	_, err := svc.dispatchAction(context.Background(), json.RawMessage(`{invalid`))
 	if err == nil {
 		t.Error("dispatchAction(invalid json) should return error")
 	}
@@ -1044,7 +1055,7 @@ func TestDispatchActionWebhookMissingURL(t *testing.T) {
 	svc, _ := New(Config{Marble: m})
 
 	action := json.RawMessage(`{"type":"webhook","method":"POST"}`)
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err == nil {
 		t.Error("dispatchAction(webhook without url) should return error")
 	}
@@ -1060,7 +1071,7 @@ func TestDispatchActionWebhookStrictModeRequiresHTTPS(t *testing.T) {
 	svc, _ := New(Config{Marble: m})
 
 	action := json.RawMessage(`{"type":"webhook","url":"http://example.com","method":"POST"}`)
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err == nil {
 		t.Fatal("dispatchAction() should return error in strict mode for http webhook url")
 	}
@@ -1076,7 +1087,7 @@ func TestDispatchActionWebhookStrictModeBlocksLoopbackIP(t *testing.T) {
 	svc, _ := New(Config{Marble: m})
 
 	action := json.RawMessage(`{"type":"webhook","url":"https://127.0.0.1","method":"POST"}`)
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err == nil {
 		t.Fatal("dispatchAction() should return error in strict mode for loopback webhook target")
 	}
@@ -1090,7 +1101,7 @@ func TestDispatchActionUnknownType(t *testing.T) {
 	svc, _ := New(Config{Marble: m})
 
 	action := json.RawMessage(`{"type":"unknown"}`)
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err != nil {
 		t.Errorf("dispatchAction(unknown type) error = %v, want nil", err)
 	}
@@ -1110,7 +1121,7 @@ func TestDispatchActionWebhookSuccess(t *testing.T) {
 	svc, _ := New(Config{Marble: m})
 
 	action := json.RawMessage(fmt.Sprintf(`{"type":"webhook","url":"%s","method":"POST"}`, server.URL))
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err != nil {
 		t.Errorf("dispatchAction() error = %v", err)
 	}
@@ -1130,7 +1141,7 @@ func TestDispatchActionWebhookDefaultMethod(t *testing.T) {
 
 	// No method specified - should default to POST
 	action := json.RawMessage(fmt.Sprintf(`{"type":"webhook","url":"%s"}`, server.URL))
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err != nil {
 		t.Errorf("dispatchAction() error = %v", err)
 	}
@@ -1146,12 +1157,153 @@ func TestDispatchActionWebhookError(t *testing.T) {
 	svc, _ := New(Config{Marble: m})
 
 	action := json.RawMessage(fmt.Sprintf(`{"type":"webhook","url":"%s"}`, server.URL))
-	err := svc.dispatchAction(context.Background(), action)
+	_, err := svc.dispatchAction(context.Background(), action)
 	if err == nil {
 		t.Error("dispatchAction() should return error for 500 status")
 	}
 }
 
+type stubInvoker struct {
+	resp *txproxytypes.InvokeResponse
+	reqs []*txproxytypes.InvokeRequest
+}
+
+func (s *stubInvoker) Invoke(_ context.Context, req *txproxytypes.InvokeRequest) (*txproxytypes.InvokeResponse, error) {
+	s.reqs = append(s.reqs, req)
+	return s.resp, nil
+}
+
+func TestExecuteTriggerRecordsContractOutcome(t *testing.T) {
+	tests := []struct {
+		name        string
+		resp        *txproxytypes.InvokeResponse
+		wantSuccess bool
+	}{
+		{"halt", &txproxytypes.InvokeResponse{TxHash: "0xaaa", VMState: "HALT"}, true},
+		{"fault", &txproxytypes.InvokeResponse{TxHash: "0xbbb", VMState: "FAULT", Exception: "ABORT"}, false},
+	}
+	for _, tt := range tests {
+		m, _ := marble.New(marble.Config{MarbleType: "neoflow"})
+		mockRepo := newMockNeoFlowRepo()
+		invoker := &stubInvoker{resp: tt.resp}
+		svc, _ := New(Config{Marble: m, NeoFlowRepo: mockRepo, TxProxy: invoker, ContractAllowlist: []string{"0x01"}})
+
+		trigger := &neoflowsupabase.Trigger{
+			ID:          "trigger-1",
+			TriggerType: "cron",
+			Action:      json.RawMessage(`{"type":"contract","contract_hash":"0x01","contract_method":"tick","params":[{"type":"Integer","value":"1"}]}`),
+		}
+		mockRepo.triggers[trigger.ID] = trigger
+		svc.executeTrigger(context.Background(), trigger)
+
+		if len(invoker.reqs) != 1 || invoker.reqs[0].Method != "tick" || !invoker.reqs[0].Wait {
+			t.Fatalf("%s: invocations = %+v", tt.name, invoker.reqs)
+		}
+		execs := mockRepo.executions[trigger.ID]
+		if len(execs) != 1 {
+			t.Fatalf("%s: executions = %d, want 1", tt.name, len(execs))
+		}
+		exec := execs[0]
+		if exec.Success != tt.wantSuccess || exec.TxHash != tt.resp.TxHash || exec.VMState != tt.resp.VMState {
+			t.Errorf("%s: execution = %+v", tt.name, exec)
+		}
+		if !tt.wantSuccess && !strings.Contains(exec.Error, "ABORT") {
+			t.Errorf("%s: error = %q, want exception recorded", tt.name, exec.Error)
+		}
+	}
+}
+
+func TestDispatchActionContractRequiresTxProxy(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neoflow"})
+	svc, _ := New(Config{Marble: m})
+
+	action := json.RawMessage(`{"type":"contract","contract_hash":"0x01","contract_method":"tick"}`)
+	if _, err := svc.dispatchAction(context.Background(), action); err == nil {
+		t.Fatal("dispatchAction() should return error without TxProxy")
+	}
+}
+
+func TestContractActionsRejectPlatformContracts(t *testing.T) {
+	const (
+		anchorHash = "0x1111111111111111111111111111111111111111"
+		userHash   = "0x2222222222222222222222222222222222222222"
+		otherHash  = "0x3333333333333333333333333333333333333333"
+	)
+	m, _ := marble.New(marble.Config{MarbleType: "neoflow"})
+	invoker := &stubInvoker{resp: &txproxytypes.InvokeResponse{VMState: "HALT"}}
+	// The anchor is allowlisted by mistake; the platform denylist still wins.
+	svc, _ := New(Config{
+		Marble:               m,
+		NeoFlowRepo:          newMockNeoFlowRepo(),
+		TxProxy:              invoker,
+		AutomationAnchorHash: anchorHash,
+		ContractAllowlist:    []string{anchorHash, userHash},
+	})
+
+	create := func(hash string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(TriggerRequest{
+			Name:        "contract trigger",
+			TriggerType: "cron",
+			Schedule:    "* * * * *",
+			Action:      json.RawMessage(`{"type":"contract","contract_hash":"` + hash + `","contract_method":"markExecuted"}`),
+		})
+		req := httptest.NewRequest(http.MethodPost, "/triggers", bytes.NewReader(body))
+		req.Header.Set("X-User-ID", "user-123")
+		rr := httptest.NewRecorder()
+		svc.handleCreateTrigger(rr, req)
+		return rr
+	}
+
+	if rr := create(anchorHash); rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "platform contract") {
+		t.Errorf("platform target: status = %d, body = %s; want 400", rr.Code, rr.Body.String())
+	}
+	if rr := create("0X" + strings.ToUpper(anchorHash[2:])); rr.Code != http.StatusBadRequest {
+		t.Errorf("platform target, other case: status = %d, want 400", rr.Code)
+	}
+	if rr := create(otherHash); rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), ContractAllowlistEnv) {
+		t.Errorf("unlisted target: status = %d, body = %s; want 400", rr.Code, rr.Body.String())
+	}
+	if rr := create(userHash); rr.Code != http.StatusCreated {
+		t.Errorf("allowlisted target: status = %d, body = %s; want 201", rr.Code, rr.Body.String())
+	}
+
+	// Triggers stored before the policy existed are refused at dispatch.
+	for _, hash := range []string{anchorHash, "0xd2a4cff31913016155e38e474a2c06d08be276cf"} {
+		action := json.RawMessage(`{"type":"contract","contract_hash":"` + hash + `","contract_method":"transfer"}`)
+		if _, err := svc.dispatchAction(context.Background(), action); err == nil {
+			t.Errorf("dispatchAction(%s) succeeded, want platform contract rejected", hash)
+		}
+	}
+	if len(invoker.reqs) != 0 {
+		t.Errorf("TxProxy invoked %d times, want 0", len(invoker.reqs))
+	}
+}
+
+func TestHandleListExecutionsOnChain(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neoflow"})
+	mockRepo := newMockNeoFlowRepo()
+	mockRepo.triggers["trigger-1"] = &neoflowsupabase.Trigger{ID: "trigger-1", UserID: "user-123"}
+	mockRepo.executions["trigger-1"] = []neoflowsupabase.Execution{
+		{ID: "e1", TriggerID: "trigger-1", Success: true, ActionType: "webhook"},
+		{ID: "e2", TriggerID: "trigger-1", ActionType: "contract", TxHash: "0xbbb", VMState: "FAULT"},
+	}
+	svc, _ := New(Config{Marble: m, NeoFlowRepo: mockRepo})
+
+	req := httptest.NewRequest("GET", "/triggers/trigger-1/executions?onchain=true", nil)
+	req.Header.Set("X-User-ID", "user-123")
+	req = mux.SetURLVars(req, map[string]string{"id": "trigger-1"})
+	rr := httptest.NewRecorder()
+	svc.handleListExecutions(rr, req)
+
+	var execs []neoflowsupabase.Execution
+	if err := json.Unmarshal(rr.Body.Bytes(), &execs); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(execs) != 1 || execs[0].ID != "e2" || execs[0].VMState != "FAULT" {
+		t.Fatalf("executions = %+v, want only the on-chain execution", execs)
+	}
+}
+
 func TestAllowPrivateWebhookTargets(t *testing.T) {
 	t.Setenv("NEOFLOW_WEBHOOK_ALLOW_PRIVATE_NETWORKS", "")
 	if allowPrivateWebhookTargets() {
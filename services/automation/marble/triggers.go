@@ -9,13 +9,14 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/R3E-Network/service_layer/infrastructure/cron"
 	"github.com/R3E-Network/service_layer/infrastructure/runtime"
+	txproxytypes "github.com/R3E-Network/service_layer/infrastructure/txproxy/types"
 	neoflowsupabase "github.com/R3E-Network/service_layer/services/automation/supabase"
 )
 
@@ -52,7 +53,7 @@ func (s *Service) executeTrigger(ctx context.Context, trigger *neoflowsupabase.T
 	}
 
 	// Execute the action (best-effort)
-	err := s.dispatchAction(ctx, trigger.Action)
+	result, err := s.dispatchAction(ctx, trigger.Action)
 
 	// Update last execution and calculate next
 	trigger.LastExecution = time.Now()
@@ -82,44 +83,52 @@ func (s *Service) executeTrigger(ctx context.Context, trigger *neoflowsupabase.T
 		if err != nil {
 			exec.Error = err.Error()
 		}
+		if result != nil {
+			exec.TxHash = result.TxHash
+			exec.VMState = result.VMState
+		}
 		if execErr := s.repo.CreateExecution(ctx, exec); execErr != nil {
 			s.Logger().WithContext(ctx).WithError(execErr).WithField("trigger_id", trigger.ID).Warn("failed to persist execution log")
 		}
 	}
 }
 
-func (s *Service) dispatchAction(ctx context.Context, actionRaw json.RawMessage) error {
+// dispatchAction runs a trigger's action. Contract actions return the
+// submitted transaction's result, including when the call faulted.
+func (s *Service) dispatchAction(ctx context.Context, actionRaw json.RawMessage) (*txproxytypes.InvokeResponse, error) {
 	if len(actionRaw) == 0 {
-		return nil
+		return nil, nil
 	}
 	var action Action
 	if err := json.Unmarshal(actionRaw, &action); err != nil {
-		return err
+		return nil, err
 	}
 
 	switch strings.ToLower(action.Type) {
+	case "contract":
+		return s.invokeContractAction(ctx, &action)
 	case "webhook":
 		method := strings.ToUpper(action.Method)
 		if method == "" {
 			method = http.MethodPost
 		}
 		if action.URL == "" {
-			return fmt.Errorf("webhook url required")
+			return nil, fmt.Errorf("webhook url required")
 		}
 
 		parsedURL, err := url.Parse(strings.TrimSpace(action.URL))
 		if err != nil {
-			return fmt.Errorf("invalid webhook url: %w", err)
+			return nil, fmt.Errorf("invalid webhook url: %w", err)
 		}
 		scheme := strings.ToLower(strings.TrimSpace(parsedURL.Scheme))
 		if scheme != "http" && scheme != "https" {
-			return fmt.Errorf("unsupported webhook url scheme: %q", parsedURL.Scheme)
+			return nil, fmt.Errorf("unsupported webhook url scheme: %q", parsedURL.Scheme)
 		}
 		if parsedURL.Hostname() == "" {
-			return fmt.Errorf("webhook url must include hostname")
+			return nil, fmt.Errorf("webhook url must include hostname")
 		}
 		if parsedURL.User != nil {
-			return fmt.Errorf("webhook url must not include userinfo")
+			return nil, fmt.Errorf("webhook url must not include userinfo")
 		}
 
 		useMeshClient := isMeshHostname(parsedURL.Hostname())
@@ -127,13 +136,13 @@ func (s *Service) dispatchAction(ctx context.Context, actionRaw json.RawMessage)
 
 		// In strict identity mode, never allow plaintext external webhooks.
 		if strict && !useMeshClient && scheme != "https" {
-			return fmt.Errorf("external webhook url must use https in strict identity mode")
+			return nil, fmt.Errorf("external webhook url must use https in strict identity mode")
 		}
 
 		// In strict identity mode, never allow internal (mesh) webhooks without mTLS.
 		if strict && useMeshClient {
 			if m := s.Marble(); m == nil || m.TLSConfig() == nil {
-				return fmt.Errorf("mesh webhook requires Marble mTLS in strict identity mode")
+				return nil, fmt.Errorf("mesh webhook requires Marble mTLS in strict identity mode")
 			}
 		}
 
@@ -141,7 +150,7 @@ func (s *Service) dispatchAction(ctx context.Context, actionRaw json.RawMessage)
 		// reaching loopback/link-local/private networks unless explicitly allowed.
 		if strict && !useMeshClient && !allowPrivateWebhookTargets() {
 			if validateErr := validateWebhookHostname(ctx, parsedURL.Hostname()); validateErr != nil {
-				return validateErr
+				return nil, validateErr
 			}
 		}
 
@@ -154,7 +163,7 @@ func (s *Service) dispatchAction(ctx context.Context, actionRaw json.RawMessage)
 		targetURL := parsedURL.String()
 		req, err := http.NewRequestWithContext(ctx, method, targetURL, bytes.NewReader(action.Body))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		req.Header.Set("Content-Type", "application/json")
 
@@ -171,16 +180,53 @@ func (s *Service) dispatchAction(ctx context.Context, actionRaw json.RawMessage)
 
 		resp, err := httpClient.Do(req)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		defer resp.Body.Close()
 		if resp.StatusCode >= 400 {
-			return fmt.Errorf("webhook status %d", resp.StatusCode)
+			return nil, fmt.Errorf("webhook status %d", resp.StatusCode)
 		}
 	default:
 		// Unknown action type; ignore
 	}
-	return nil
+	return nil, nil
+}
+
+// invokeContractAction submits a contract action through TxProxy and waits
+// for it to be included. A call that does not HALT is an error, returned
+// alongside the result so the FAULT state is still recorded.
+func (s *Service) invokeContractAction(ctx context.Context, action *Action) (*txproxytypes.InvokeResponse, error) {
+	if s.txProxy == nil {
+		return nil, fmt.Errorf("contract actions require TxProxy")
+	}
+	if strings.TrimSpace(action.ContractHash) == "" || strings.TrimSpace(action.ContractMethod) == "" {
+		return nil, fmt.Errorf("contract_hash and contract_method required")
+	}
+	// Re-checked at dispatch: the allowlist may have shrunk since creation.
+	if err := s.checkContractTarget(action.ContractHash); err != nil {
+		return nil, err
+	}
+
+	result, err := s.txProxy.Invoke(ctx, &txproxytypes.InvokeRequest{
+		RequestID:    "neoflow:trigger:" + uuid.NewString(),
+		ContractHash: action.ContractHash,
+		Method:       action.ContractMethod,
+		Params:       action.Params,
+		Wait:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("contract invocation returned no result")
+	}
+	if state := strings.TrimSpace(result.VMState); state != "" && !strings.HasPrefix(state, "HALT") {
+		if msg := strings.TrimSpace(result.Exception); msg != "" {
+			return result, fmt.Errorf("contract call faulted (%s): %s", state, msg)
+		}
+		return result, fmt.Errorf("contract call faulted (%s)", state)
+	}
+	return result, nil
 }
 
 func allowPrivateWebhookTargets() bool {
@@ -276,120 +322,9 @@ func isMeshHostname(rawHost string) bool {
 }
 
 // parseNextCronExecution parses a cron expression and returns the next execution time.
-// Supports standard 5-field cron: minute hour day-of-month month day-of-week
-// Supports: specific values (5), wildcards (*), ranges (1-5), lists (1,3,5), steps (*/15)
+// See infrastructure/cron for the supported syntax.
 func (s *Service) parseNextCronExecution(cronExpr string) (time.Time, error) {
-	parts := strings.Fields(cronExpr)
-	if len(parts) != 5 {
-		return time.Time{}, fmt.Errorf("invalid cron expression: expected 5 fields")
-	}
-
-	// Parse each field into allowed values
-	minutes, err := parseCronField(parts[0], 0, 59)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid minute field: %w", err)
-	}
-	hours, err := parseCronField(parts[1], 0, 23)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid hour field: %w", err)
-	}
-	days, err := parseCronField(parts[2], 1, 31)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid day field: %w", err)
-	}
-	months, err := parseCronField(parts[3], 1, 12)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid month field: %w", err)
-	}
-	weekdays, err := parseCronField(parts[4], 0, 6)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid weekday field: %w", err)
-	}
-
-	// Find next matching time (search up to 1 year ahead)
-	now := time.Now()
-	candidate := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, now.Location())
-	candidate = candidate.Add(time.Minute) // Start from next minute
-
-	maxIterations := 525600 // 1 year in minutes
-	for i := 0; i < maxIterations; i++ {
-		if months[int(candidate.Month())] &&
-			days[candidate.Day()] &&
-			weekdays[int(candidate.Weekday())] &&
-			hours[candidate.Hour()] &&
-			minutes[candidate.Minute()] {
-			return candidate, nil
-		}
-		candidate = candidate.Add(time.Minute)
-	}
-
-	return time.Time{}, fmt.Errorf("no matching time found within 1 year")
-}
-
-// parseCronField parses a single cron field and returns a map of allowed values.
-func parseCronField(field string, minValue, maxValue int) (map[int]bool, error) {
-	allowed := make(map[int]bool)
-
-	// Handle wildcard
-	if field == "*" {
-		for i := minValue; i <= maxValue; i++ {
-			allowed[i] = true
-		}
-		return allowed, nil
-	}
-
-	// Handle step with wildcard (*/n)
-	if strings.HasPrefix(field, "*/") {
-		step, err := strconv.Atoi(field[2:])
-		if err != nil || step <= 0 {
-			return nil, fmt.Errorf("invalid step: %s", field)
-		}
-		for i := minValue; i <= maxValue; i += step {
-			allowed[i] = true
-		}
-		return allowed, nil
-	}
-
-	// Handle comma-separated list
-	for _, part := range strings.Split(field, ",") {
-		part = strings.TrimSpace(part)
-
-		// Handle range (n-m) or range with step (n-m/s)
-		if strings.Contains(part, "-") {
-			rangeParts := strings.Split(part, "/")
-			rangeStr := rangeParts[0]
-			step := 1
-			if len(rangeParts) == 2 {
-				var err error
-				step, err = strconv.Atoi(rangeParts[1])
-				if err != nil || step <= 0 {
-					return nil, fmt.Errorf("invalid step in range: %s", part)
-				}
-			}
-
-			bounds := strings.Split(rangeStr, "-")
-			if len(bounds) != 2 {
-				return nil, fmt.Errorf("invalid range: %s", part)
-			}
-			start, err1 := strconv.Atoi(bounds[0])
-			end, err2 := strconv.Atoi(bounds[1])
-			if err1 != nil || err2 != nil || start < minValue || end > maxValue || start > end {
-				return nil, fmt.Errorf("invalid range values: %s", part)
-			}
-			for i := start; i <= end; i += step {
-				allowed[i] = true
-			}
-		} else {
-			// Single value
-			val, err := strconv.Atoi(part)
-			if err != nil || val < minValue || val > maxValue {
-				return nil, fmt.Errorf("invalid value: %s", part)
-			}
-			allowed[val] = true
-		}
-	}
-
-	return allowed, nil
+	return cron.Next(cronExpr, time.Now())
 }
 
 // Note: platform-anchored automation tasks live in anchored_tasks.go.
@@ -3,6 +3,8 @@ package neoflow
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
 )
 
 // TriggerRequest is the request body for creating/updating triggers.
@@ -34,6 +36,11 @@ type Action struct {
 	URL    string          `json:"url,omitempty"`
 	Method string          `json:"method,omitempty"`
 	Body   json.RawMessage `json:"body,omitempty"`
+
+	// Contract actions invoke ContractMethod on ContractHash through TxProxy.
+	ContractHash   string                `json:"contract_hash,omitempty"`
+	ContractMethod string                `json:"contract_method,omitempty"`
+	Params         []chain.ContractParam `json:"params,omitempty"`
 }
 
 // PriceCondition represents a price-based trigger condition.
@@ -21,7 +21,8 @@ type Trigger struct {
 	CreatedAt     time.Time       `json:"created_at"`
 }
 
-// Execution represents an execution log entry.
+// Execution represents an execution log entry. TxHash and VMState are set
+// for actions that submit a transaction; a reverted call records "FAULT".
 type Execution struct {
 	ID            string          `json:"id"`
 	TriggerID     string          `json:"trigger_id"`
@@ -30,4 +31,6 @@ type Execution struct {
 	Error         string          `json:"error,omitempty"`
 	ActionType    string          `json:"action_type,omitempty"`
 	ActionPayload json.RawMessage `json:"action_payload,omitempty"`
+	TxHash        string          `json:"tx_hash,omitempty"`
+	VMState       string          `json:"vm_state,omitempty"`
 }
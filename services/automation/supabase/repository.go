@@ -28,6 +28,7 @@ type RepositoryInterface interface {
 	// Execution Operations
 	CreateExecution(ctx context.Context, exec *Execution) error
 	GetExecutions(ctx context.Context, triggerID string, limit int) ([]Execution, error)
+	GetOnChainExecutions(ctx context.Context, triggerID string, limit int) ([]Execution, error)
 }
 
 // Ensure Repository implements RepositoryInterface
@@ -199,3 +200,23 @@ func (r *Repository) GetExecutions(ctx context.Context, triggerID string, limit
 
 	return database.GenericListWithQuery[Execution](r.base, ctx, executionsTable, query)
 }
+
+// GetOnChainExecutions lists executions for a trigger that submitted a
+// transaction, with their on-chain outcome.
+func (r *Repository) GetOnChainExecutions(ctx context.Context, triggerID string, limit int) ([]Execution, error) {
+	if triggerID == "" {
+		return nil, fmt.Errorf("trigger_id cannot be empty")
+	}
+	if limit <= 0 || limit > 1000 {
+		limit = 50
+	}
+
+	query := database.NewQuery().
+		Eq("trigger_id", triggerID).
+		NotNull("tx_hash").
+		OrderDesc("executed_at").
+		Limit(limit).
+		Build()
+
+	return database.GenericListWithQuery[Execution](r.base, ctx, executionsTable, query)
+}
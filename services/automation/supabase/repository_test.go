@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -374,6 +375,28 @@ func TestGetExecutions_DefaultLimit(t *testing.T) {
 	}
 }
 
+func TestGetOnChainExecutions_FiltersTxHash(t *testing.T) {
+	var query string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Execution{{ID: "e1", TriggerID: "t1", TxHash: "0xabc", VMState: "FAULT"}})
+	}
+	repo, server := newTestRepository(t, handler)
+	defer server.Close()
+
+	execs, err := repo.GetOnChainExecutions(context.Background(), "t1", 10)
+	if err != nil {
+		t.Fatalf("GetOnChainExecutions() error = %v", err)
+	}
+	if len(execs) != 1 || execs[0].VMState != "FAULT" {
+		t.Errorf("execs = %+v", execs)
+	}
+	if !strings.Contains(query, "tx_hash=not.is.null") {
+		t.Errorf("query = %q, want tx_hash filter", query)
+	}
+}
+
 // =============================================================================
 // Model Tests
 // =============================================================================
@@ -10,6 +10,7 @@ package neocompute
 func (s *Service) registerRoutes() {
 	router := s.Router()
 	router.HandleFunc("/execute", s.handleExecute).Methods("POST")
+	router.HandleFunc("/validate", s.handleValidate).Methods("POST")
 	router.HandleFunc("/jobs/{id}", s.handleGetJob).Methods("GET")
 	router.HandleFunc("/jobs", s.handleListJobs).Methods("GET")
 }
@@ -6,8 +6,8 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/dop251/goja"
@@ -20,16 +20,21 @@ import (
 // Core Logic
 // =============================================================================
 
+// ErrExecutionTimeout is returned when a script is interrupted because its
+// execution deadline passed, as opposed to failing on its own.
+var ErrExecutionTimeout = errors.New("execution timeout")
+
 // Execute runs code inside the TEE enclave and stores the result for later retrieval.
 func (s *Service) Execute(ctx context.Context, userID string, req *ExecuteRequest) (*ExecuteResponse, error) {
 	startTime := time.Now()
 	jobID := uuid.New().String()
 
 	response := &ExecuteResponse{
-		JobID:     jobID,
-		Status:    "running",
-		StartedAt: startTime,
-		Logs:      []string{},
+		JobID:         jobID,
+		Status:        "running",
+		StartedAt:     startTime,
+		Logs:          []string{},
+		Deterministic: req.Deterministic,
 	}
 
 	// Validate script
@@ -88,6 +93,8 @@ func (s *Service) Execute(ctx context.Context, userID string, req *ExecuteReques
 		defer cancel()
 	}
 
+	meter := newResourceMeter()
+
 	// Log execution start
 	response.Logs = append(response.Logs,
 		fmt.Sprintf("[%s] Starting execution", startTime.Format(time.RFC3339)),
@@ -103,19 +110,13 @@ func (s *Service) Execute(ctx context.Context, userID string, req *ExecuteReques
 			)
 		} else {
 			for _, ref := range req.SecretRefs {
-				secretName := strings.TrimSpace(ref)
+				// Backward-compatible: allow `secrets:<name>` references.
+				secretName := normalizeSecretRef(ref)
 				if secretName == "" {
 					continue
 				}
-				// Backward-compatible: allow `secrets:<name>` references.
-				if prefix, name, ok := strings.Cut(secretName, ":"); ok {
-					prefix = strings.ToLower(strings.TrimSpace(prefix))
-					name = strings.TrimSpace(name)
-					if name != "" && prefix == "secrets" {
-						secretName = name
-					}
-				}
 
+				meter.addNetworkCall()
 				secretValue, err := s.secretProvider.GetSecret(execCtx, userID, secretName)
 				if err != nil {
 					response.Logs = append(response.Logs,
@@ -132,11 +133,25 @@ func (s *Service) Execute(ctx context.Context, userID string, req *ExecuteReques
 	}
 
 	// Execute JavaScript using goja runtime
-	output, err := s.executeScript(execCtx, req.Script, req.EntryPoint, req.Input, secrets)
+	output, err := s.executeScript(execCtx, req.Script, req.EntryPoint, req.Input, secrets, meter, newReplayConfig(req))
 	if err != nil {
 		response.Status = "failed"
 		response.Error = err.Error()
 		response.Duration = time.Since(startTime).String()
+		timedOut := errors.Is(err, ErrExecutionTimeout)
+		if timedOut {
+			meter.exceed(LimitTimeout)
+			response.Status = "timeout"
+			response.Logs = append(response.Logs,
+				fmt.Sprintf("[%s] Execution timed out after %s", time.Now().Format(time.RFC3339), response.Duration),
+			)
+		}
+		response.Resources = meter.report()
+		if timedOut {
+			// Record timeouts so they can be told apart from script
+			// failures and audited.
+			s.storeJob(userID, response)
+		}
 		return response, nil
 	}
 
@@ -147,12 +162,15 @@ func (s *Service) Execute(ctx context.Context, userID string, req *ExecuteReques
 			response.Status = "failed"
 			response.Error = fmt.Sprintf("output is not JSON serializable: %v", err)
 			response.Duration = time.Since(startTime).String()
+			response.Resources = meter.report()
 			return response, nil
 		}
 		if len(outputJSON) > MaxOutputSize {
 			response.Status = "failed"
 			response.Error = fmt.Sprintf("output exceeds maximum size of %d bytes", MaxOutputSize)
 			response.Duration = time.Since(startTime).String()
+			meter.exceed(LimitOutputSize)
+			response.Resources = meter.report()
 			return response, nil
 		}
 	}
@@ -161,6 +179,7 @@ func (s *Service) Execute(ctx context.Context, userID string, req *ExecuteReques
 	response.Output = output
 	response.GasUsed = int64(len(req.Script)) * GasPerScriptByte
 	response.Duration = time.Since(startTime).String()
+	response.Resources = meter.report()
 
 	// Encrypt and sign the output if keys are available
 	if len(s.masterKey) > 0 && len(output) > 0 {
@@ -216,7 +235,9 @@ func (s *Service) protectOutput(response *ExecuteResponse) error {
 }
 
 // executeScript executes a JavaScript script inside the enclave using goja runtime.
-func (s *Service) executeScript(ctx context.Context, script, entryPoint string, input map[string]interface{}, secrets map[string]string) (map[string]interface{}, error) {
+// Usage is recorded in meter. A non-nil replay runs the script
+// deterministically.
+func (s *Service) executeScript(ctx context.Context, script, entryPoint string, input map[string]interface{}, secrets map[string]string, meter *resourceMeter, replay *replayConfig) (map[string]interface{}, error) {
 	// Validate script size
 	if len(script) > MaxScriptSize {
 		return nil, fmt.Errorf("script exceeds maximum size of %d bytes", MaxScriptSize)
@@ -224,19 +245,35 @@ func (s *Service) executeScript(ctx context.Context, script, entryPoint string,
 
 	// Create goja runtime
 	vm := goja.New()
+	if replay != nil {
+		replay.apply(vm)
+	}
 
-	// Set up interrupt for timeout
-	timeout := DefaultTimeout
-	if deadline, ok := ctx.Deadline(); ok {
-		timeout = time.Until(deadline)
+	// Interrupt the VM when the execution context ends so no script keeps
+	// running in the enclave after the caller has given up on it.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, contextError(err)
 	}
 
 	done := make(chan struct{})
 	go func() {
-		select {
-		case <-time.After(timeout):
-			vm.Interrupt("execution timeout")
-		case <-done:
+		ticker := time.NewTicker(memorySampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				vm.Interrupt(ctx.Err())
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				meter.sampleMemory()
+			}
 		}
 	}()
 	defer close(done)
@@ -263,6 +300,7 @@ func (s *Service) executeScript(ctx context.Context, script, entryPoint string,
 	if err := console.Set("log", func(call goja.FunctionCall) goja.Value {
 		// Enforce log entry limit
 		if len(logs) >= MaxLogEntries {
+			meter.exceed(LimitLogEntries)
 			return goja.Undefined()
 		}
 		args := make([]interface{}, len(call.Arguments))
@@ -295,7 +333,7 @@ func (s *Service) executeScript(ctx context.Context, script, entryPoint string,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to set crypto.sha256: %w", err)
 	}
-	if err := cryptoObj.Set("randomBytes", func(call goja.FunctionCall) goja.Value {
+	randomBytes := func(call goja.FunctionCall) goja.Value {
 		n := 32
 		if len(call.Arguments) > 0 {
 			n = int(call.Arguments[0].ToInteger())
@@ -308,16 +346,26 @@ func (s *Service) executeScript(ctx context.Context, script, entryPoint string,
 			return goja.Undefined()
 		}
 		return vm.ToValue(fmt.Sprintf("%x", bytes))
-	}); err != nil {
+	}
+	if replay != nil {
+		// Secure randomness cannot be seeded without weakening it.
+		randomBytes = replay.unavailable(vm, "crypto.randomBytes")
+	}
+	if err := cryptoObj.Set("randomBytes", randomBytes); err != nil {
 		return nil, fmt.Errorf("failed to set crypto.randomBytes: %w", err)
 	}
 	if err := vm.Set("crypto", cryptoObj); err != nil {
 		return nil, fmt.Errorf("failed to set crypto: %w", err)
 	}
 
-	// Execute the script
+	// Execute the script, accounting the wall time spent inside the VM.
+	vmStart := time.Now()
 	_, err := vm.RunString(script)
+	meter.addWallTime(time.Since(vmStart))
 	if err != nil {
+		if interruptErr := interruptError(ctx, err); interruptErr != nil {
+			return nil, interruptErr
+		}
 		return nil, fmt.Errorf("script error: %w", err)
 	}
 
@@ -327,8 +375,14 @@ func (s *Service) executeScript(ctx context.Context, script, entryPoint string,
 		return nil, fmt.Errorf("entry point '%s' is not a function", entryPoint)
 	}
 
+	vmStart = time.Now()
 	result, err := entryFn(goja.Undefined())
+	meter.addWallTime(time.Since(vmStart))
+	meter.sampleMemory()
 	if err != nil {
+		if interruptErr := interruptError(ctx, err); interruptErr != nil {
+			return nil, interruptErr
+		}
 		return nil, fmt.Errorf("execution error: %w", err)
 	}
 
@@ -352,6 +406,26 @@ func (s *Service) executeScript(ctx context.Context, script, entryPoint string,
 	return output, nil
 }
 
+// interruptError returns a typed error if err is a VM interrupt triggered by
+// the execution context, or nil otherwise.
+func interruptError(ctx context.Context, err error) error {
+	var interrupted *goja.InterruptedError
+	if !errors.As(err, &interrupted) {
+		return nil
+	}
+	if ctx.Err() == nil {
+		return nil
+	}
+	return contextError(ctx.Err())
+}
+
+func contextError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrExecutionTimeout
+	}
+	return fmt.Errorf("execution canceled: %w", err)
+}
+
 func getMapKeys(m map[string]interface{}) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
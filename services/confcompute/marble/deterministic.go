@@ -0,0 +1,54 @@
+// Package neocompute provides deterministic replay support for the neocompute service.
+package neocompute
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// ErrNondeterministic is returned when a script running in deterministic mode
+// calls an API whose result cannot be reproduced.
+var ErrNondeterministic = errors.New("nondeterministic API unavailable in deterministic mode")
+
+// replayConfig pins the nondeterministic inputs of a deterministic execution
+// so re-running the same request yields the same output.
+type replayConfig struct {
+	seed  [32]byte
+	clock time.Time
+}
+
+// newReplayConfig returns the replay settings for req, or nil when req is not
+// deterministic. Without an explicit seed, Math.random is seeded from the
+// script, so the request alone determines the output.
+func newReplayConfig(req *ExecuteRequest) *replayConfig {
+	if !req.Deterministic {
+		return nil
+	}
+	seed := req.Seed
+	if seed == "" {
+		seed = req.Script
+	}
+	return &replayConfig{
+		seed:  sha256.Sum256([]byte(seed)),
+		clock: time.Unix(req.Timestamp, 0).UTC(),
+	}
+}
+
+// apply seeds Math.random and freezes the Date clock on vm.
+func (c *replayConfig) apply(vm *goja.Runtime) {
+	rng := rand.New(rand.NewChaCha8(c.seed))
+	vm.SetRandSource(rng.Float64)
+	vm.SetTimeSource(func() time.Time { return c.clock })
+}
+
+// unavailable returns a JS function that throws ErrNondeterministic.
+func (c *replayConfig) unavailable(vm *goja.Runtime, name string) func(goja.FunctionCall) goja.Value {
+	return func(goja.FunctionCall) goja.Value {
+		panic(vm.NewGoError(fmt.Errorf("%s: %w", name, ErrNondeterministic)))
+	}
+}
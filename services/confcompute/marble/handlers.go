@@ -42,6 +42,30 @@ func (s *Service) handleExecute(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, result)
 }
 
+func (s *Service) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if _, ok := httputil.RequireUserID(w, r); !ok {
+		return
+	}
+
+	var req ValidateRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+
+	if req.Script == "" {
+		httputil.BadRequest(w, "script required")
+		return
+	}
+
+	warnings, err := s.ValidateFunction(r.Context(), req.Script, req.EntryPoint, req.SecretRefs)
+	resp := ValidateResponse{Valid: err == nil, Warnings: warnings}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
 func (s *Service) handleGetJob(w http.ResponseWriter, r *http.Request) {
 	userID, ok := httputil.RequireUserID(w, r)
 	if !ok {
@@ -0,0 +1,121 @@
+// Package neocompute provides per-execution resource accounting for the neocompute service.
+package neocompute
+
+import (
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// Resource limit names reported in ResourceUsage.LimitsExceeded.
+const (
+	LimitTimeout    = "timeout"     // execution deadline passed
+	LimitOutputSize = "output_size" // output larger than MaxOutputSize
+	LimitLogEntries = "log_entries" // console.log calls beyond MaxLogEntries were dropped
+)
+
+const (
+	// memorySampleInterval is how often live heap is sampled while a script runs.
+	memorySampleInterval = 5 * time.Millisecond
+	// heapObjectsMetric is the live heap size in bytes.
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+)
+
+// ResourceUsage reports what an execution consumed, so callers can bill or
+// audit it.
+//
+// ApproxPeakMemoryBytes is the peak growth of the enclave's process-wide
+// live heap while the script ran, sampled every few milliseconds. goja has
+// no per-VM allocation counter, so it includes allocations made by anything
+// else running at the time and is never used to enforce a limit.
+// WallTimeMs is the wall-clock time spent inside the JS runtime; it includes
+// any time the goroutine was descheduled. NetworkCalls counts outbound
+// requests made for the execution (secret store fetches); scripts themselves
+// have no network API.
+type ResourceUsage struct {
+	ApproxPeakMemoryBytes uint64   `json:"approx_peak_memory_bytes"`
+	WallTimeMs            int64    `json:"wall_time_ms"`
+	NetworkCalls          int      `json:"network_calls"`
+	LimitsExceeded        []string `json:"limits_exceeded,omitempty"`
+}
+
+// resourceMeter accumulates ResourceUsage for one execution. It is shared
+// between the executing goroutine and the memory sampler.
+type resourceMeter struct {
+	mu           sync.Mutex
+	baseline     uint64
+	peak         uint64
+	wallTime     time.Duration
+	networkCalls int
+	exceeded     []string
+	sample       []metrics.Sample
+}
+
+func newResourceMeter() *resourceMeter {
+	m := &resourceMeter{sample: []metrics.Sample{{Name: heapObjectsMetric}}}
+	m.baseline = m.readHeap()
+	return m
+}
+
+// readHeap returns the current live heap, or 0 if the metric is unsupported.
+// Callers must hold mu, except during construction.
+func (m *resourceMeter) readHeap() uint64 {
+	metrics.Read(m.sample)
+	if m.sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return m.sample[0].Value.Uint64()
+}
+
+// sampleMemory records the current heap growth.
+func (m *resourceMeter) sampleMemory() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if heap := m.readHeap(); heap > m.baseline && heap-m.baseline > m.peak {
+		m.peak = heap - m.baseline
+	}
+}
+
+// addWallTime records wall-clock time spent inside the JS runtime.
+func (m *resourceMeter) addWallTime(d time.Duration) {
+	m.mu.Lock()
+	m.wallTime += d
+	m.mu.Unlock()
+}
+
+// addNetworkCall records one outbound request made for the execution.
+func (m *resourceMeter) addNetworkCall() {
+	m.mu.Lock()
+	m.networkCalls++
+	m.mu.Unlock()
+}
+
+// exceed records that limit was hit. Each limit is reported once.
+func (m *resourceMeter) exceed(limit string) {
+	m.mu.Lock()
+	m.exceedLocked(limit)
+	m.mu.Unlock()
+}
+
+func (m *resourceMeter) exceedLocked(limit string) {
+	for _, l := range m.exceeded {
+		if l == limit {
+			return
+		}
+	}
+	m.exceeded = append(m.exceeded, limit)
+}
+
+// report returns the usage accumulated so far.
+func (m *resourceMeter) report() *ResourceUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return &ResourceUsage{
+		ApproxPeakMemoryBytes: m.peak,
+		WallTimeMs:            m.wallTime.Milliseconds(),
+		NetworkCalls:          m.networkCalls,
+		LimitsExceeded:        append([]string(nil), m.exceeded...),
+	}
+}
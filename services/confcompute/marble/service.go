@@ -164,12 +164,22 @@ func New(cfg Config) (*Service, error) {
 	base.WithStats(s.statistics)
 
 	// Register standard routes (/health, /info) plus service-specific routes
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 
 	return s, nil
 }
 
+// selfTest requires the secrets provider, without which jobs that reference
+// user secrets fail.
+func (s *Service) selfTest(ctx context.Context) error {
+	return s.CheckDependencies(ctx, commonservice.Dependency{
+		Name:    "secrets provider (SECRETS_MASTER_KEY)",
+		Missing: s.secretProvider == nil,
+	})
+}
+
 // statistics returns runtime statistics for the /info endpoint.
 func (s *Service) statistics() map[string]any {
 	jobCount := 0
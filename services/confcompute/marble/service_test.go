@@ -143,6 +143,152 @@ func TestExecuteEmptyScript(t *testing.T) {
 	}
 }
 
+func TestExecuteTimeout(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req := &ExecuteRequest{
+		Script:     "function main() { while (true) {} }",
+		EntryPoint: "main",
+	}
+
+	resp, err := svc.Execute(ctx, "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Status != "timeout" {
+		t.Errorf("Status = %s, want timeout", resp.Status)
+	}
+	if resp.Error != ErrExecutionTimeout.Error() {
+		t.Errorf("Error = %q, want %q", resp.Error, ErrExecutionTimeout.Error())
+	}
+	if job := svc.getJob("user-123", resp.JobID); job == nil {
+		t.Error("timed-out job should be recorded")
+	}
+}
+
+func TestExecuteScriptErrorNotTimeout(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+
+	req := &ExecuteRequest{
+		Script:     "function main() { throw new Error('boom'); }",
+		EntryPoint: "main",
+	}
+
+	resp, err := svc.Execute(context.Background(), "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Status != "failed" {
+		t.Errorf("Status = %s, want failed", resp.Status)
+	}
+}
+
+func TestExecuteResourceReport(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+
+	req := &ExecuteRequest{
+		Script:     "function main() { for (var i = 0; i < 150; i++) { console.log(i); } return 1; }",
+		EntryPoint: "main",
+	}
+	resp, err := svc.Execute(context.Background(), "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Resources == nil {
+		t.Fatal("Resources should be reported")
+	}
+	if resp.Resources.NetworkCalls != 0 {
+		t.Errorf("NetworkCalls = %d, want 0", resp.Resources.NetworkCalls)
+	}
+	if len(resp.Resources.LimitsExceeded) != 1 || resp.Resources.LimitsExceeded[0] != LimitLogEntries {
+		t.Errorf("LimitsExceeded = %v, want [%s]", resp.Resources.LimitsExceeded, LimitLogEntries)
+	}
+}
+
+func TestExecuteAllocationHeavyScriptNotInterrupted(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+
+	// Memory is sampled from the process-wide heap, so it is only reported;
+	// a script that allocates heavily must still run to completion.
+	req := &ExecuteRequest{
+		Script:     "function main() { var a = []; for (var i = 0; i < 20000; i++) { a.push('x'.repeat(1024)); } return a.length; }",
+		EntryPoint: "main",
+	}
+	resp, err := svc.Execute(context.Background(), "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Status != "completed" {
+		t.Fatalf("Status = %s, Error = %q; want completed", resp.Status, resp.Error)
+	}
+	if resp.Resources == nil {
+		t.Fatal("Resources should be reported")
+	}
+	if len(resp.Resources.LimitsExceeded) != 0 {
+		t.Errorf("LimitsExceeded = %v, want none", resp.Resources.LimitsExceeded)
+	}
+}
+
+func TestExecuteDeterministicReplay(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+
+	req := &ExecuteRequest{
+		Script:        "function main() { return { r: Math.random(), now: Date.now() }; }",
+		EntryPoint:    "main",
+		Deterministic: true,
+		Seed:          "audit-1",
+		Timestamp:     1700000000,
+	}
+	first, err := svc.Execute(context.Background(), "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	second, err := svc.Execute(context.Background(), "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if first.Status != "completed" || !first.Deterministic {
+		t.Fatalf("Status = %s, Deterministic = %v; want completed deterministic run", first.Status, first.Deterministic)
+	}
+	if first.Output["r"] != second.Output["r"] {
+		t.Errorf("Math.random differs between replays: %v vs %v", first.Output["r"], second.Output["r"])
+	}
+	if now, _ := first.Output["now"].(int64); now != 1700000000*1000 {
+		t.Errorf("Date.now() = %v, want %d", first.Output["now"], int64(1700000000*1000))
+	}
+
+	req.Seed = "audit-2"
+	other, _ := svc.Execute(context.Background(), "user-123", req)
+	if other.Output["r"] == first.Output["r"] {
+		t.Error("a different seed should give a different random stream")
+	}
+}
+
+func TestExecuteDeterministicRejectsRandomBytes(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+
+	req := &ExecuteRequest{
+		Script:        "function main() { return crypto.randomBytes(16); }",
+		EntryPoint:    "main",
+		Deterministic: true,
+	}
+	resp, err := svc.Execute(context.Background(), "user-123", req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Status != "failed" || !strings.Contains(resp.Error, ErrNondeterministic.Error()) {
+		t.Errorf("Status = %s, Error = %q; want failure mentioning %q", resp.Status, resp.Error, ErrNondeterministic.Error())
+	}
+}
+
 func TestExecuteWithSecretRefs(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
 	provider := testSecretProvider{
@@ -175,6 +321,9 @@ func TestExecuteWithSecretRefs(t *testing.T) {
 	if !foundAPI || !foundDB {
 		t.Fatalf("expected secret loading logs; got=%v", resp.Logs)
 	}
+	if resp.Resources == nil || resp.Resources.NetworkCalls != 2 {
+		t.Errorf("Resources = %+v, want 2 network calls", resp.Resources)
+	}
 }
 
 type testSecretProvider struct {
@@ -341,3 +490,73 @@ func BenchmarkExecute(b *testing.B) {
 		_, _ = svc.Execute(ctx, "user-123", req)
 	}
 }
+
+func TestValidateFunction(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+	ctx := context.Background()
+
+	if _, err := svc.ValidateFunction(ctx, "function main() { return 1; }", "main", nil); err != nil {
+		t.Errorf("ValidateFunction() error = %v, want nil", err)
+	}
+	if _, err := svc.ValidateFunction(ctx, "const run = (x) => x;", "run", nil); err != nil {
+		t.Errorf("ValidateFunction() arrow entry error = %v, want nil", err)
+	}
+	if _, err := svc.ValidateFunction(ctx, "function main( { return 1; }", "main", nil); err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("ValidateFunction() error = %v, want syntax error", err)
+	}
+	if _, err := svc.ValidateFunction(ctx, "function other() {}", "main", nil); err == nil {
+		t.Error("ValidateFunction() expected error for missing entry point")
+	}
+}
+
+func TestValidateFunctionEntryPointForms(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		script string
+		valid  bool
+	}{
+		{"declaration", "function main() { return 1; }", true},
+		{"assigned function", "main = function() { return 1; };", true},
+		{"assigned arrow", "main = () => 1;", true},
+		{"var function", "var main = function() { return 1; };", true},
+		{"var arrow", "var main = () => 1;", true},
+		{"let arrow", "let main = () => 1;", true},
+		{"const async arrow", "const main = async () => 1;", true},
+		{"compound assignment", "var main; main += function() {};", false},
+		{"assigned value", "main = 1;", false},
+		{"other name", "other = function() {};", false},
+	}
+	for _, tt := range tests {
+		_, err := svc.ValidateFunction(ctx, tt.script, "main", nil)
+		if tt.valid && err != nil {
+			t.Errorf("%s: ValidateFunction() error = %v, want nil", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: ValidateFunction() expected error", tt.name)
+		}
+	}
+}
+
+func TestValidateFunctionSecretWarnings(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neocompute"})
+	svc, _ := New(Config{Marble: m})
+	script := `function main() { return secrets.API_KEY + secrets["TOKEN"] + secrets.API_KEY; }`
+
+	warnings, err := svc.ValidateFunction(context.Background(), script, "main", []string{"secrets:API_KEY"})
+	if err != nil {
+		t.Fatalf("ValidateFunction() error = %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "TOKEN") {
+		t.Errorf("warnings = %v, want one warning for TOKEN", warnings)
+	}
+
+	warnings, err = svc.ValidateFunction(context.Background(), script, "main", []string{"API_KEY", "TOKEN"})
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("ValidateFunction() with all secrets declared = %v, %v; want no warnings", warnings, err)
+	}
+}
@@ -14,6 +14,26 @@ type ExecuteRequest struct {
 	Input      map[string]interface{} `json:"input,omitempty"`
 	SecretRefs []string               `json:"secret_refs,omitempty"`
 	Timeout    int                    `json:"timeout,omitempty"`
+	// Deterministic makes the execution reproducible for audit: Math.random
+	// is seeded from Seed (default: the script), the Date clock is fixed at
+	// Timestamp (Unix seconds) and crypto.randomBytes throws.
+	Deterministic bool   `json:"deterministic,omitempty"`
+	Seed          string `json:"seed,omitempty"`
+	Timestamp     int64  `json:"timestamp,omitempty"`
+}
+
+// ValidateRequest represents a script validation request.
+type ValidateRequest struct {
+	Script     string   `json:"script"`
+	EntryPoint string   `json:"entry_point,omitempty"`
+	SecretRefs []string `json:"secret_refs,omitempty"`
+}
+
+// ValidateResponse reports whether a script compiles and exposes its entry point.
+type ValidateResponse struct {
+	Valid    bool     `json:"valid"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ExecuteResponse represents a script execution response.
@@ -26,6 +46,9 @@ type ExecuteResponse struct {
 	GasUsed   int64                  `json:"gas_used"`
 	StartedAt time.Time              `json:"started_at"`
 	Duration  string                 `json:"duration,omitempty"`
+	Resources *ResourceUsage         `json:"resources,omitempty"`
+	// Deterministic reports that the execution ran in deterministic mode.
+	Deterministic bool `json:"deterministic,omitempty"`
 
 	// TEE attestation fields - prove result came from enclave
 	EncryptedOutput string `json:"encrypted_output,omitempty"` // AES-GCM encrypted output (base64)
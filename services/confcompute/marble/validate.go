@@ -0,0 +1,133 @@
+// Package neocompute provides script validation for the neocompute service.
+package neocompute
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/parser"
+	"github.com/dop251/goja/token"
+)
+
+// secretRefPattern matches `secrets.NAME` and `secrets["NAME"]` references.
+var secretRefPattern = regexp.MustCompile(`\bsecrets(?:\.([A-Za-z_$][\w$]*)|\[\s*["']([^"']+)["']\s*\])`)
+
+// ValidateFunction parses and compiles a script and checks that the entry
+// point is declared as a top-level function. Nothing is executed. It returns
+// warnings for secrets the script reads but that are not in secretRefs.
+func (s *Service) ValidateFunction(ctx context.Context, script, entryPoint string, secretRefs []string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if script == "" {
+		return nil, fmt.Errorf("script cannot be empty")
+	}
+	if len(script) > MaxScriptSize {
+		return nil, fmt.Errorf("script exceeds maximum size of %d bytes", MaxScriptSize)
+	}
+	if entryPoint == "" {
+		entryPoint = "main"
+	}
+
+	program, err := parser.ParseFile(nil, "", script, 0)
+	if err != nil {
+		return nil, fmt.Errorf("syntax error: %w", err)
+	}
+	if _, err := goja.CompileAST(program, false); err != nil {
+		return nil, fmt.Errorf("compile error: %w", err)
+	}
+	if !declaresFunction(program, entryPoint) {
+		return nil, fmt.Errorf("entry point '%s' is not declared as a function", entryPoint)
+	}
+
+	declared := make(map[string]bool, len(secretRefs))
+	for _, ref := range secretRefs {
+		if name := normalizeSecretRef(ref); name != "" {
+			declared[name] = true
+		}
+	}
+
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, match := range secretRefPattern.FindAllStringSubmatch(script, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		if declared[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		warnings = append(warnings, fmt.Sprintf("secret %q is referenced but not declared in secret_refs", name))
+	}
+
+	return warnings, nil
+}
+
+// declaresFunction reports whether name is a top-level function declaration,
+// a top-level variable initialised with a function expression, or a
+// top-level assignment of a function expression to name (`main = () => {}`).
+func declaresFunction(program *ast.Program, name string) bool {
+	isFunction := func(expr ast.Expression) bool {
+		switch expr.(type) {
+		case *ast.FunctionLiteral, *ast.ArrowFunctionLiteral:
+			return true
+		default:
+			return false
+		}
+	}
+	bindsFunction := func(bindings []*ast.Binding) bool {
+		for _, binding := range bindings {
+			id, ok := binding.Target.(*ast.Identifier)
+			if ok && string(id.Name) == name && isFunction(binding.Initializer) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, stmt := range program.Body {
+		switch st := stmt.(type) {
+		case *ast.FunctionDeclaration:
+			if st.Function != nil && st.Function.Name != nil && string(st.Function.Name.Name) == name {
+				return true
+			}
+		case *ast.VariableStatement:
+			if bindsFunction(st.List) {
+				return true
+			}
+		case *ast.LexicalDeclaration:
+			if bindsFunction(st.List) {
+				return true
+			}
+		case *ast.ExpressionStatement:
+			assign, ok := st.Expression.(*ast.AssignExpression)
+			if !ok || assign.Operator != token.ASSIGN {
+				continue
+			}
+			id, ok := assign.Left.(*ast.Identifier)
+			if ok && string(id.Name) == name && isFunction(assign.Right) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeSecretRef trims a secret reference and strips the legacy
+// `secrets:` prefix.
+func normalizeSecretRef(ref string) string {
+	secretName := strings.TrimSpace(ref)
+	if prefix, name, ok := strings.Cut(secretName, ":"); ok {
+		prefix = strings.ToLower(strings.TrimSpace(prefix))
+		name = strings.TrimSpace(name)
+		if name != "" && prefix == "secrets" {
+			secretName = name
+		}
+	}
+	return secretName
+}
@@ -12,4 +12,10 @@ func (s *Service) registerRoutes() {
 	r.HandleFunc("/query", s.handleQuery).Methods("POST")
 	// Backward-compatible alias used by older clients/UI.
 	r.HandleFunc("/fetch", s.handleQuery).Methods("POST")
+	// Queries fetch upstream data without writing state.
+	s.AllowInReadOnly("/query", "/fetch")
+	r.HandleFunc("/feeds", s.handleCreatePollFeed).Methods("POST")
+	r.HandleFunc("/feeds", s.handleListPollFeeds).Methods("GET")
+	r.HandleFunc("/feeds/{id}", s.handleGetPollFeed).Methods("GET")
+	r.HandleFunc("/feeds/{id}", s.handleDeletePollFeed).Methods("DELETE")
 }
@@ -0,0 +1,123 @@
+// Package neooracle provides upstream response caching for the neooracle service.
+package neooracle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// MaxCacheTTL caps how stale a cached response a query may accept.
+	MaxCacheTTL = time.Hour
+	// maxCacheEntries bounds the response cache; the oldest entry is evicted
+	// when it is full.
+	maxCacheEntries = 1024
+)
+
+// responseCache holds recent 2xx upstream responses, keyed by queryCacheKey.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type cachedResponse struct {
+	resp     QueryResponse
+	storedAt time.Time
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cachedResponse)}
+}
+
+// get returns a response stored no longer than maxAge before now.
+func (c *responseCache) get(key string, maxAge time.Duration, now time.Time) (*QueryResponse, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && now.Sub(entry.storedAt) > MaxCacheTTL {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	if !ok || now.Sub(entry.storedAt) > maxAge {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	resp := entry.resp
+	resp.Headers = copyHeaders(entry.resp.Headers)
+	resp.Cached = true
+	return &resp, true
+}
+
+func (c *responseCache) put(key string, resp *QueryResponse, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= maxCacheEntries {
+		c.evictLocked(now)
+	}
+	stored := *resp
+	stored.Headers = copyHeaders(resp.Headers)
+	stored.Cached = false
+	c.entries[key] = cachedResponse{resp: stored, storedAt: now}
+}
+
+// evictLocked drops expired entries, or the oldest one if none has expired.
+func (c *responseCache) evictLocked(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if now.Sub(entry.storedAt) > MaxCacheTTL {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.storedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.storedAt
+		}
+	}
+	if len(c.entries) >= maxCacheEntries && oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
+
+func copyHeaders(h map[string]string) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	return out
+}
+
+// queryCacheKey hashes the request as sent upstream: method, URL, body and
+// caller-supplied headers. Authenticated requests are scoped to the user and
+// identified by the secret's name, never its value, so one user's
+// credentials never answer another user's query.
+func queryCacheKey(userID, method string, input *QueryInput) string {
+	parts := []string{method, input.URL, input.Body}
+
+	headers := make([]string, 0, len(input.Headers))
+	for k, v := range input.Headers {
+		headers = append(headers, strings.ToLower(k)+":"+v)
+	}
+	sort.Strings(headers)
+	parts = append(parts, headers...)
+
+	if input.SecretName != "" || input.AuthType != "" {
+		parts = append(parts, "user:"+userID, "secret:"+input.SecretName, "as:"+input.SecretAsKey, "auth:"+input.AuthType)
+		if input.OAuth2 != nil {
+			parts = append(parts, "oauth2:"+oauth2CacheKey(userID, input.OAuth2))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
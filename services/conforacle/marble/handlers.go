@@ -3,13 +3,17 @@ package neooracle
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 
 	"github.com/R3E-Network/service_layer/infrastructure/httputil"
 )
@@ -30,20 +34,73 @@ func (s *Service) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if input.URL == "" {
-		httputil.BadRequest(w, "url required")
+	resp, err := s.runQuery(r.Context(), userID, &input)
+	if err != nil {
+		writeQueryError(w, r, err)
 		return
 	}
-	if httputil.StrictIdentityMode() {
-		parsed, err := url.Parse(input.URL)
-		if err != nil || parsed.Scheme == "" || parsed.Host == "" || !strings.EqualFold(parsed.Scheme, "https") {
-			httputil.BadRequest(w, "only https urls are allowed in strict identity mode")
-			return
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
+// queryError is a query failure and the HTTP status the query API reports
+// for it.
+type queryError struct {
+	status  int
+	message string
+	details map[string]any
+}
+
+func (e *queryError) Error() string { return e.message }
+
+func newQueryError(status int, format string, args ...any) *queryError {
+	return &queryError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+func writeQueryError(w http.ResponseWriter, r *http.Request, err error) {
+	var qe *queryError
+	if !errors.As(err, &qe) {
+		httputil.InternalError(w, err.Error())
+		return
+	}
+	switch qe.status {
+	case http.StatusBadRequest:
+		httputil.BadRequest(w, qe.message)
+	case http.StatusServiceUnavailable:
+		httputil.ServiceUnavailable(w, qe.message)
+	case http.StatusInternalServerError:
+		httputil.InternalError(w, qe.message)
+	default:
+		httputil.WriteErrorResponse(w, r, qe.status, "", qe.message, qe.details)
+	}
+}
+
+// runQuery validates input, injects the caller's credentials and performs
+// the upstream fetch on behalf of userID. Secrets are resolved inside the
+// enclave and never returned. Failures are *queryError values.
+func (s *Service) runQuery(ctx context.Context, userID string, input *QueryInput) (*QueryResponse, error) {
+	if input.URL == "" {
+		return nil, newQueryError(http.StatusBadRequest, "url required")
+	}
+	if msg := s.checkOutboundURL(input.URL); msg != "" {
+		return nil, newQueryError(http.StatusBadRequest, "%s", msg)
+	}
+	switch input.AuthType {
+	case "":
+	case AuthTypeOAuth2ClientCredentials:
+		if input.SecretName != "" {
+			return nil, newQueryError(http.StatusBadRequest, "secret_name cannot be combined with oauth2 auth")
+		}
+		if err := input.OAuth2.Validate(); err != nil {
+			return nil, newQueryError(http.StatusBadRequest, "%s", err.Error())
+		}
+		if msg := s.checkOutboundURL(input.OAuth2.TokenURL); msg != "" {
+			return nil, newQueryError(http.StatusBadRequest, "oauth2 token_url: %s", msg)
 		}
+	default:
+		return nil, newQueryError(http.StatusBadRequest, "unsupported auth_type %q", input.AuthType)
 	}
-	if !s.allowlist.Allows(input.URL) {
-		httputil.BadRequest(w, "url not allowed")
-		return
+	if input.CacheTTL < 0 || time.Duration(input.CacheTTL)*time.Second > MaxCacheTTL {
+		return nil, newQueryError(http.StatusBadRequest, "cache_ttl must be between 0 and %d seconds", int(MaxCacheTTL.Seconds()))
 	}
 	method := strings.ToUpper(strings.TrimSpace(input.Method))
 	if method == "" {
@@ -58,13 +115,11 @@ func (s *Service) handleQuery(w http.ResponseWriter, r *http.Request) {
 	// If a secret is requested, fetch it over mTLS and inject.
 	if input.SecretName != "" {
 		if s.secretProvider == nil {
-			httputil.ServiceUnavailable(w, "secret store not configured")
-			return
+			return nil, newQueryError(http.StatusServiceUnavailable, "secret store not configured")
 		}
-		secret, err := s.secretProvider.GetSecret(r.Context(), userID, input.SecretName)
+		secret, err := s.secretProvider.GetSecret(ctx, userID, input.SecretName)
 		if err != nil {
-			httputil.InternalError(w, fmt.Sprintf("failed to fetch secret: %v", err))
-			return
+			return nil, newQueryError(http.StatusInternalServerError, "failed to fetch secret: %v", err)
 		}
 		key := input.SecretAsKey
 		if key == "" {
@@ -74,36 +129,65 @@ func (s *Service) handleQuery(w http.ResponseWriter, r *http.Request) {
 		headers.Set(key, secret)
 	}
 
+	if input.AuthType == AuthTypeOAuth2ClientCredentials {
+		authorization, err := s.oauth2Authorization(ctx, userID, input.OAuth2)
+		if err != nil {
+			if errors.Is(err, ErrOAuth2Token) {
+				return nil, newQueryError(http.StatusBadGateway, "%s", err.Error())
+			}
+			return nil, newQueryError(http.StatusInternalServerError, "%s", err.Error())
+		}
+		headers.Set("Authorization", authorization)
+	}
+
+	// Credentials are resolved before the cache is consulted, so a cached
+	// authenticated response is only served while the caller can still
+	// resolve the secret or OAuth2 token it was fetched with.
+	var cacheKey string
+	if input.CacheTTL > 0 {
+		cacheKey = queryCacheKey(userID, method, input)
+		if cached, ok := s.cache.get(cacheKey, time.Duration(input.CacheTTL)*time.Second, time.Now()); ok {
+			return cached, nil
+		}
+	}
+
 	var body io.Reader
 	if input.Body != "" {
 		body = bytes.NewBufferString(input.Body)
 	}
 
-	req, err := http.NewRequestWithContext(r.Context(), method, input.URL, body)
+	req, err := http.NewRequestWithContext(ctx, method, input.URL, body)
 	if err != nil {
-		httputil.BadRequest(w, err.Error())
-		return
+		return nil, newQueryError(http.StatusBadRequest, "%s", err.Error())
 	}
 	req.Header = headers
 	req.Header.Set("X-Request-ID", uuid.New().String())
 
+	// Bound upstream parallelism so rate-limited providers are not flooded.
+	select {
+	case s.fetchSlots <- struct{}{}:
+		defer func() { <-s.fetchSlots }()
+	case <-ctx.Done():
+		return nil, newQueryError(http.StatusServiceUnavailable, "request canceled while waiting for fetch capacity")
+	}
+
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		httputil.InternalError(w, fmt.Sprintf("request failed: %v", err))
-		return
+		return nil, newQueryError(http.StatusInternalServerError, "request failed: %v", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, truncated, err := httputil.ReadAllWithLimit(resp.Body, s.maxBodyBytes)
+	fetchedAt := time.Now().UTC()
 	if err != nil {
-		httputil.InternalError(w, fmt.Sprintf("failed to read response body: %v", err))
-		return
+		return nil, newQueryError(http.StatusInternalServerError, "failed to read response body: %v", err)
 	}
 	if truncated {
-		httputil.WriteErrorResponse(w, r, http.StatusBadGateway, "", "upstream response too large", map[string]any{
-			"limit_bytes": s.maxBodyBytes,
-		})
-		return
+		return nil, &queryError{
+			status:  http.StatusBadGateway,
+			message: "upstream response too large",
+			details: map[string]any{"limit_bytes": s.maxBodyBytes},
+		}
 	}
 
 	outHeaders := map[string]string{}
@@ -113,9 +197,117 @@ func (s *Service) handleQuery(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	httputil.WriteJSON(w, http.StatusOK, QueryResponse{
+	out := &QueryResponse{
 		StatusCode: resp.StatusCode,
 		Headers:    outHeaders,
 		Body:       string(respBody),
-	})
+		FetchedAt:  fetchedAt,
+	}
+	if cacheKey != "" && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		s.cache.put(cacheKey, out, time.Now())
+	}
+	return out, nil
+}
+
+// handleCreatePollFeed registers a query fetched on a schedule for the caller.
+func (s *Service) handleCreatePollFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := httputil.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var input PollFeedRequest
+	if !httputil.DecodeJSON(w, r, &input) {
+		return
+	}
+
+	feed := PollFeed{
+		ID:       input.ID,
+		UserID:   userID,
+		Query:    input.Query,
+		Schedule: input.Schedule,
+		Active:   input.Active == nil || *input.Active,
+		NoCache:  input.NoCache,
+		Kind:     input.Kind,
+	}
+	if input.Interval != "" {
+		interval, err := time.ParseDuration(input.Interval)
+		if err != nil {
+			httputil.BadRequest(w, "invalid interval")
+			return
+		}
+		feed.Interval = interval
+	}
+	if feed.ID != "" {
+		if existing, found := s.GetPollFeed(feed.ID); found && existing.UserID != userID {
+			httputil.WriteErrorResponse(w, r, http.StatusConflict, "", "feed id already in use", nil)
+			return
+		}
+	}
+
+	created, err := s.AddPollFeed(feed)
+	if errors.Is(err, ErrPollFeedLimit) {
+		httputil.WriteErrorResponse(w, r, http.StatusTooManyRequests, "", err.Error(), nil)
+		return
+	}
+	if err != nil {
+		httputil.BadRequest(w, err.Error())
+		return
+	}
+	status, _ := s.GetPollFeed(created.ID)
+	httputil.WriteJSON(w, http.StatusCreated, status)
+}
+
+// handleListPollFeeds lists the caller's poll feeds.
+func (s *Service) handleListPollFeeds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := httputil.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, s.PollFeedStatuses(userID))
+}
+
+// handleGetPollFeed returns one of the caller's poll feeds.
+func (s *Service) handleGetPollFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := httputil.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+	status, found := s.GetPollFeed(mux.Vars(r)["id"])
+	if !found || status.UserID != userID {
+		httputil.NotFound(w, "feed not found")
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, status)
+}
+
+// handleDeletePollFeed stops polling one of the caller's feeds.
+func (s *Service) handleDeletePollFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := httputil.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+	id := mux.Vars(r)["id"]
+	status, found := s.GetPollFeed(id)
+	if !found || status.UserID != userID {
+		httputil.NotFound(w, "feed not found")
+		return
+	}
+	s.RemovePollFeed(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkOutboundURL returns a client-facing reason why rawURL may not be
+// fetched, or "" if it is allowed.
+func (s *Service) checkOutboundURL(rawURL string) string {
+	if httputil.StrictIdentityMode() {
+		parsed, err := url.Parse(rawURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || !strings.EqualFold(parsed.Scheme, "https") {
+			return "only https urls are allowed in strict identity mode"
+		}
+	}
+	if !s.URLAllowlist().Allows(rawURL) {
+		return "url not allowed"
+	}
+	return ""
 }
@@ -0,0 +1,211 @@
+// Package neooracle provides OAuth2 client-credentials support for the neooracle service.
+package neooracle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/httputil"
+)
+
+// AuthTypeOAuth2ClientCredentials authenticates the upstream fetch with an
+// access token obtained via the OAuth2 client-credentials grant.
+const AuthTypeOAuth2ClientCredentials = "oauth2_client_credentials"
+
+const (
+	// oauth2ExpirySkew refreshes tokens slightly before they expire so a
+	// token is never sent upstream in its last seconds of validity.
+	oauth2ExpirySkew = 30 * time.Second
+	// oauth2DefaultTTL caches tokens whose response omits expires_in.
+	oauth2DefaultTTL = time.Minute
+	// oauth2MaxResponseBytes caps token endpoint responses.
+	oauth2MaxResponseBytes = 64 << 10
+)
+
+// ErrOAuth2Token is returned when the token endpoint cannot issue a token.
+var ErrOAuth2Token = errors.New("oauth2 token request failed")
+
+// OAuth2Config configures the client-credentials grant. The client secret is
+// read from the caller's secret store and never accepted inline.
+type OAuth2Config struct {
+	TokenURL         string   `json:"token_url"`
+	ClientID         string   `json:"client_id"`
+	ClientSecretName string   `json:"client_secret_name"`
+	Scopes           []string `json:"scopes,omitempty"`
+}
+
+// Validate checks that the config is complete.
+func (c *OAuth2Config) Validate() error {
+	if c == nil {
+		return fmt.Errorf("oauth2 config required")
+	}
+	if strings.TrimSpace(c.TokenURL) == "" {
+		return fmt.Errorf("oauth2 token_url required")
+	}
+	if strings.TrimSpace(c.ClientID) == "" {
+		return fmt.Errorf("oauth2 client_id required")
+	}
+	if strings.TrimSpace(c.ClientSecretName) == "" {
+		return fmt.Errorf("oauth2 client_secret_name required")
+	}
+	return nil
+}
+
+type oauth2Token struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+// authorization returns the Authorization header value for the token.
+func (t oauth2Token) authorization() string {
+	return "Bearer " + t.accessToken
+}
+
+// oauth2TokenCache holds access tokens until shortly before they expire.
+type oauth2TokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]oauth2Token
+}
+
+func newOAuth2TokenCache() *oauth2TokenCache {
+	return &oauth2TokenCache{tokens: make(map[string]oauth2Token)}
+}
+
+func (c *oauth2TokenCache) get(key string, now time.Time) (oauth2Token, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tok, ok := c.tokens[key]
+	if !ok {
+		return oauth2Token{}, false
+	}
+	if !now.Before(tok.expiresAt) {
+		delete(c.tokens, key)
+		return oauth2Token{}, false
+	}
+	return tok, true
+}
+
+func (c *oauth2TokenCache) put(key string, tok oauth2Token) {
+	c.mu.Lock()
+	c.tokens[key] = tok
+	c.mu.Unlock()
+}
+
+// oauth2CacheKey scopes cached tokens to the caller and the grant parameters,
+// so users never share tokens.
+func oauth2CacheKey(userID string, cfg *OAuth2Config) string {
+	scopes := append([]string(nil), cfg.Scopes...)
+	sort.Strings(scopes)
+	return strings.Join([]string{userID, cfg.TokenURL, cfg.ClientID, cfg.ClientSecretName, strings.Join(scopes, " ")}, "\x00")
+}
+
+// oauth2Authorization returns the Authorization header value for an upstream
+// fetch on behalf of userID, using a cached token while it is valid.
+func (s *Service) oauth2Authorization(ctx context.Context, userID string, cfg *OAuth2Config) (string, error) {
+	key := oauth2CacheKey(userID, cfg)
+	if tok, ok := s.oauth2Tokens.get(key, time.Now()); ok {
+		return tok.authorization(), nil
+	}
+
+	if s.secretProvider == nil {
+		return "", fmt.Errorf("secret store not configured")
+	}
+	clientSecret, err := s.secretProvider.GetSecret(ctx, userID, cfg.ClientSecretName)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret: %w", err)
+	}
+
+	tok, err := requestOAuth2Token(ctx, s.httpClient, cfg, clientSecret, time.Now())
+	if err != nil {
+		return "", err
+	}
+	s.oauth2Tokens.put(key, tok)
+	return tok.authorization(), nil
+}
+
+// requestOAuth2Token performs the client-credentials grant (RFC 6749 §4.4),
+// authenticating the client with HTTP Basic auth. Failures wrap ErrOAuth2Token
+// and include the endpoint's status and error code, never the credentials.
+func requestOAuth2Token(ctx context.Context, client *http.Client, cfg *OAuth2Config, clientSecret string, now time.Time) (oauth2Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("%w: %v", ErrOAuth2Token, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(clientSecret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("%w: %v", ErrOAuth2Token, err)
+	}
+	defer resp.Body.Close()
+
+	body, truncated, err := httputil.ReadAllWithLimit(resp.Body, oauth2MaxResponseBytes)
+	if err != nil {
+		return oauth2Token{}, fmt.Errorf("%w: read response: %v", ErrOAuth2Token, err)
+	}
+	if truncated {
+		return oauth2Token{}, fmt.Errorf("%w: response too large", ErrOAuth2Token)
+	}
+
+	var parsed struct {
+		AccessToken      string      `json:"access_token"`
+		TokenType        string      `json:"token_type"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	decodeErr := json.Unmarshal(body, &parsed)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail := fmt.Sprintf("token endpoint returned HTTP %d", resp.StatusCode)
+		if decodeErr == nil && parsed.Error != "" {
+			detail += ": " + parsed.Error
+			if parsed.ErrorDescription != "" {
+				detail += " (" + parsed.ErrorDescription + ")"
+			}
+		}
+		return oauth2Token{}, fmt.Errorf("%w: %s", ErrOAuth2Token, detail)
+	}
+	if decodeErr != nil {
+		return oauth2Token{}, fmt.Errorf("%w: invalid token response: %v", ErrOAuth2Token, decodeErr)
+	}
+	if parsed.AccessToken == "" {
+		return oauth2Token{}, fmt.Errorf("%w: token response missing access_token", ErrOAuth2Token)
+	}
+	if parsed.TokenType != "" && !strings.EqualFold(parsed.TokenType, "bearer") {
+		return oauth2Token{}, fmt.Errorf("%w: unsupported token_type %q", ErrOAuth2Token, parsed.TokenType)
+	}
+
+	ttl := oauth2DefaultTTL
+	if parsed.ExpiresIn != "" {
+		seconds, err := parsed.ExpiresIn.Int64()
+		if err != nil || seconds <= 0 {
+			return oauth2Token{}, fmt.Errorf("%w: invalid expires_in %q", ErrOAuth2Token, parsed.ExpiresIn)
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	if ttl > oauth2ExpirySkew {
+		ttl -= oauth2ExpirySkew
+	}
+
+	return oauth2Token{
+		accessToken: parsed.AccessToken,
+		expiresAt:   now.Add(ttl),
+	}, nil
+}
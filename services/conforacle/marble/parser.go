@@ -0,0 +1,105 @@
+// Package neooracle provides typed response parsing for neooracle poll feeds.
+package neooracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Poll feed kinds. A feed's kind selects the ResponseParser applied to each
+// successful fetch; FeedKindRaw keeps only the raw response.
+const (
+	FeedKindRaw     = ""
+	FeedKindJSON    = "json"
+	FeedKindWeather = "weather"
+)
+
+// kelvinOffset converts Kelvin to Celsius.
+const kelvinOffset = 273.15
+
+// ResponseParser turns an upstream response body into a typed value.
+type ResponseParser interface {
+	Parse(body []byte) (any, error)
+}
+
+// responseParsers maps each parsed feed kind to its parser.
+var responseParsers = map[string]ResponseParser{
+	FeedKindJSON:    jsonParser{},
+	FeedKindWeather: weatherParser{},
+}
+
+// parseResponse applies the parser for kind to body. FeedKindRaw yields nil.
+func parseResponse(kind string, body []byte) (any, error) {
+	if kind == FeedKindRaw {
+		return nil, nil
+	}
+	parser, ok := responseParsers[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported feed kind %q", kind)
+	}
+	value, err := parser.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s response: %w", kind, err)
+	}
+	return value, nil
+}
+
+// jsonParser decodes any JSON document, keeping numbers exact.
+type jsonParser struct{}
+
+func (jsonParser) Parse(body []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// WeatherData is the parsed result of a weather feed.
+type WeatherData struct {
+	Location     string  `json:"location,omitempty"`
+	TemperatureC float64 `json:"temperature_c"`
+	Humidity     float64 `json:"humidity"`   // percent
+	Conditions   string  `json:"conditions"` // e.g. "Clouds"
+	Description  string  `json:"description,omitempty"`
+}
+
+// weatherParser reads OpenWeather current-weather responses in the default
+// ("standard") units, where temperatures are in Kelvin.
+type weatherParser struct{}
+
+type openWeatherResponse struct {
+	Name    string `json:"name"`
+	Weather []struct {
+		Main        string `json:"main"`
+		Description string `json:"description"`
+	} `json:"weather"`
+	Main *struct {
+		Temp     *float64 `json:"temp"`
+		Humidity float64  `json:"humidity"`
+	} `json:"main"`
+}
+
+func (weatherParser) Parse(body []byte) (any, error) {
+	var raw openWeatherResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Main == nil || raw.Main.Temp == nil {
+		return nil, fmt.Errorf("main.temp missing")
+	}
+
+	out := WeatherData{
+		Location:     raw.Name,
+		TemperatureC: *raw.Main.Temp - kelvinOffset,
+		Humidity:     raw.Main.Humidity,
+	}
+	if len(raw.Weather) > 0 {
+		out.Conditions = raw.Weather[0].Main
+		out.Description = raw.Weather[0].Description
+	}
+	return &out, nil
+}
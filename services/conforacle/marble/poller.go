@@ -0,0 +1,274 @@
+// Package neooracle provides scheduled polling for the neooracle service.
+package neooracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/R3E-Network/service_layer/infrastructure/cron"
+)
+
+const (
+	// pollTick is how often the poller checks for due feeds.
+	pollTick = 250 * time.Millisecond
+	// MinPollInterval is the shortest interval a poll feed may use.
+	MinPollInterval = 10 * time.Second
+	// MaxPollFeedsPerUser caps how many poll feeds one user may register.
+	MaxPollFeedsPerUser = 10
+	// MaxPollResponseBodyBytes caps the raw body kept as a feed's
+	// LastResponse; the upstream response itself may be up to MaxBodyBytes.
+	MaxPollResponseBodyBytes = 4 * 1024
+)
+
+// ErrPollFeedLimit is returned when a user already has MaxPollFeedsPerUser feeds.
+var ErrPollFeedLimit = errors.New("poll feed limit reached")
+
+// PollFeed is an oracle query fetched on a schedule instead of on request.
+// Exactly one of Interval or Schedule (5-field cron) is set. The query runs
+// on behalf of UserID, so SecretName and OAuth2 credentials are resolved from
+// that user's secret store inside the enclave, as for POST /query. Feeds
+// share the response cache with POST /query according to Query.CacheTTL
+// unless NoCache is set. Kind selects how each response is parsed into
+// PollFeedStatus.LastValue.
+type PollFeed struct {
+	ID       string        `json:"id"`
+	UserID   string        `json:"user_id"`
+	Query    QueryInput    `json:"query"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Schedule string        `json:"schedule,omitempty"`
+	Active   bool          `json:"active"`
+	NoCache  bool          `json:"no_cache,omitempty"`
+	Kind     string        `json:"kind,omitempty"`
+}
+
+// Validate checks the feed's schedule and query.
+func (f *PollFeed) Validate() error {
+	if strings.TrimSpace(f.UserID) == "" {
+		return fmt.Errorf("user_id required")
+	}
+	if f.Query.URL == "" {
+		return fmt.Errorf("query url required")
+	}
+	if _, ok := responseParsers[f.Kind]; !ok && f.Kind != FeedKindRaw {
+		return fmt.Errorf("unsupported feed kind %q", f.Kind)
+	}
+	switch {
+	case f.Interval > 0 && f.Schedule != "":
+		return fmt.Errorf("interval and schedule are mutually exclusive")
+	case f.Schedule != "":
+		if _, err := cron.Next(f.Schedule, time.Now()); err != nil {
+			return err
+		}
+	case f.Interval <= 0:
+		return fmt.Errorf("interval or schedule required")
+	case f.Interval < MinPollInterval:
+		return fmt.Errorf("interval must be at least %s", MinPollInterval)
+	}
+	return nil
+}
+
+// nextRun returns when the feed is next due after a run at now.
+func (f *PollFeed) nextRun(now time.Time) (time.Time, error) {
+	if f.Schedule != "" {
+		return cron.Next(f.Schedule, now)
+	}
+	return now.Add(f.Interval), nil
+}
+
+// PollFeedStatus is a poll feed and its fetch history. LastResponse is a
+// summary of the last successful response: for a parsed Kind its body is
+// dropped in favour of LastValue, otherwise it is cut to
+// MaxPollResponseBodyBytes and LastResponseTruncated is set.
+type PollFeedStatus struct {
+	PollFeed
+	LastFetched           time.Time      `json:"last_fetched"`
+	NextRun               time.Time      `json:"next_run"`
+	FetchCount            int64          `json:"fetch_count"`
+	ErrorCount            int64          `json:"error_count"`
+	LastError             string         `json:"last_error,omitempty"`
+	LastResponse          *QueryResponse `json:"last_response,omitempty"`
+	LastResponseTruncated bool           `json:"last_response_truncated,omitempty"`
+	LastValue             any            `json:"last_value,omitempty"` // response body parsed per Kind
+}
+
+// pollFeedState is the poller's view of one feed. Guarded by Service.pollMu.
+type pollFeedState struct {
+	status  PollFeedStatus
+	running bool
+}
+
+// AddPollFeed registers feed, or replaces the feed with the same ID and
+// resets its history. An empty ID is assigned. Interval feeds are first
+// fetched on the next poller tick. A user may own at most
+// MaxPollFeedsPerUser feeds; replacing one of them does not count.
+func (s *Service) AddPollFeed(feed PollFeed) (PollFeed, error) {
+	if err := feed.Validate(); err != nil {
+		return PollFeed{}, err
+	}
+	if msg := s.checkOutboundURL(feed.Query.URL); msg != "" {
+		return PollFeed{}, fmt.Errorf("%s", msg)
+	}
+	if feed.ID == "" {
+		feed.ID = uuid.New().String()
+	}
+
+	next := time.Now()
+	if feed.Schedule != "" {
+		var err error
+		if next, err = feed.nextRun(next); err != nil {
+			return PollFeed{}, err
+		}
+	}
+
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	owned := 0
+	for id, st := range s.pollFeeds {
+		if id != feed.ID && st.status.UserID == feed.UserID {
+			owned++
+		}
+	}
+	if owned >= MaxPollFeedsPerUser {
+		return PollFeed{}, fmt.Errorf("%w: at most %d feeds per user", ErrPollFeedLimit, MaxPollFeedsPerUser)
+	}
+	s.pollFeeds[feed.ID] = &pollFeedState{status: PollFeedStatus{PollFeed: feed, NextRun: next}}
+	return feed, nil
+}
+
+// RemovePollFeed unregisters a feed. A fetch already in flight completes but
+// is not recorded.
+func (s *Service) RemovePollFeed(id string) bool {
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	if _, ok := s.pollFeeds[id]; !ok {
+		return false
+	}
+	delete(s.pollFeeds, id)
+	return true
+}
+
+// GetPollFeed returns the status of one feed.
+func (s *Service) GetPollFeed(id string) (PollFeedStatus, bool) {
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	st, ok := s.pollFeeds[id]
+	if !ok {
+		return PollFeedStatus{}, false
+	}
+	return st.status, true
+}
+
+// PollFeedStatuses returns the status of every feed owned by userID, or of
+// all feeds when userID is empty, ordered by ID.
+func (s *Service) PollFeedStatuses(userID string) []PollFeedStatus {
+	s.pollMu.Lock()
+	out := make([]PollFeedStatus, 0, len(s.pollFeeds))
+	for _, st := range s.pollFeeds {
+		if userID == "" || st.status.UserID == userID {
+			out = append(out, st.status)
+		}
+	}
+	s.pollMu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// pollDueFeeds starts a fetch for every active feed whose next run has
+// passed. A feed is never fetched again while its previous fetch is running.
+func (s *Service) pollDueFeeds(ctx context.Context) error {
+	now := time.Now()
+
+	s.pollMu.Lock()
+	var due []*pollFeedState
+	for _, st := range s.pollFeeds {
+		if !st.status.Active || st.running || now.Before(st.status.NextRun) {
+			continue
+		}
+		next, err := st.status.nextRun(now)
+		if err != nil {
+			// Validated on registration; a cron with no match within a year
+			// stays idle rather than spinning.
+			next = now.Add(24 * time.Hour)
+		}
+		st.status.NextRun = next
+		st.running = true
+		due = append(due, st)
+	}
+	feeds := make([]PollFeed, len(due))
+	for i, st := range due {
+		feeds[i] = st.status.PollFeed
+	}
+	s.pollMu.Unlock()
+
+	for i := range due {
+		go s.pollFeed(ctx, due[i], feeds[i])
+	}
+	return nil
+}
+
+// pollFeed fetches one feed and records the outcome in st. Upstream
+// responses outside 2xx, and bodies the feed's parser rejects, count as
+// errors.
+func (s *Service) pollFeed(ctx context.Context, st *pollFeedState, feed PollFeed) {
+	query := feed.Query
+	if feed.NoCache {
+		query.CacheTTL = 0
+	}
+	resp, err := s.runQuery(ctx, feed.UserID, &query)
+	if err == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+		err = fmt.Errorf("upstream returned HTTP %d", resp.StatusCode)
+	}
+	var value any
+	if err == nil {
+		value, err = parseResponse(feed.Kind, []byte(resp.Body))
+	}
+
+	s.pollMu.Lock()
+	st.running = false
+	if s.pollFeeds[feed.ID] == st {
+		if err != nil {
+			st.status.ErrorCount++
+			st.status.LastError = err.Error()
+		} else {
+			st.status.FetchCount++
+			st.status.LastFetched = resp.FetchedAt
+			st.status.LastError = ""
+			st.status.LastResponse, st.status.LastResponseTruncated = summarizePollResponse(resp, feed.Kind)
+			st.status.LastValue = value
+		}
+	}
+	s.pollMu.Unlock()
+
+	if err != nil {
+		s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+			"feed_id": feed.ID,
+			"error":   err.Error(),
+		}).Debug("poll feed fetch failed")
+	}
+}
+
+// summarizePollResponse returns the copy of resp a feed keeps between
+// fetches, so a large upstream body is not held once per feed. resp itself
+// may be shared with the response cache and is not modified.
+func summarizePollResponse(resp *QueryResponse, kind string) (*QueryResponse, bool) {
+	out := *resp
+	if kind != FeedKindRaw {
+		out.Body = ""
+		return &out, false
+	}
+	if len(out.Body) <= MaxPollResponseBodyBytes {
+		return &out, false
+	}
+	out.Body = out.Body[:MaxPollResponseBodyBytes]
+	return &out, true
+}
@@ -2,8 +2,10 @@
 package neooracle
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/R3E-Network/service_layer/infrastructure/httputil"
@@ -17,6 +19,10 @@ const (
 	ServiceID   = "neooracle"
 	ServiceName = "NeoOracle Service"
 	Version     = "1.0.0"
+
+	// DefaultMaxConcurrency bounds concurrent upstream fetches when
+	// Config.MaxConcurrency is unset.
+	DefaultMaxConcurrency = 32
 )
 
 // Service implements the oracle.
@@ -25,7 +31,14 @@ type Service struct {
 	secretProvider secrets.Provider
 	httpClient     *http.Client
 	maxBodyBytes   int64
+	strict         bool
+	allowlistMu    sync.RWMutex
 	allowlist      URLAllowlist
+	fetchSlots     chan struct{}
+	oauth2Tokens   *oauth2TokenCache
+	pollMu         sync.Mutex
+	pollFeeds      map[string]*pollFeedState
+	cache          *responseCache
 }
 
 // Config configures the oracle.
@@ -35,6 +48,10 @@ type Config struct {
 	MaxBodyBytes   int64        // optional response cap; default 2MB
 	URLAllowlist   URLAllowlist // optional allowlist for outbound fetch
 	Timeout        time.Duration
+	MaxConcurrency int // optional cap on concurrent upstream fetches; default 32
+	// PollFeeds are registered at startup and fetched on their schedule
+	// while the service runs.
+	PollFeeds []PollFeed
 }
 
 // New creates a new NeoOracle service.
@@ -47,16 +64,8 @@ func New(cfg Config) (*Service, error) {
 	})
 
 	strict := runtime.StrictIdentityMode() || (cfg.Marble != nil && cfg.Marble.IsEnclave())
-	if strict {
-		validAllowlistEntries := 0
-		for _, raw := range cfg.URLAllowlist.Prefixes {
-			if _, ok := parseURLAllowlistEntry(raw); ok {
-				validAllowlistEntries++
-			}
-		}
-		if validAllowlistEntries == 0 {
-			return nil, fmt.Errorf("neooracle: URL allowlist is required in strict identity mode (set ORACLE_HTTP_ALLOWLIST)")
-		}
+	if err := validateAllowlist(strict, cfg.URLAllowlist); err != nil {
+		return nil, err
 	}
 
 	maxBytes := cfg.MaxBodyBytes
@@ -69,6 +78,11 @@ func New(cfg Config) (*Service, error) {
 		timeout = 20 * time.Second
 	}
 
+	maxConcurrency := cfg.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
 	s := &Service{
 		BaseService:    base,
 		secretProvider: cfg.SecretProvider,
@@ -80,10 +94,89 @@ func New(cfg Config) (*Service, error) {
 			return client
 		}(),
 		maxBodyBytes: maxBytes,
+		strict:       strict,
 		allowlist:    cfg.URLAllowlist,
+		fetchSlots:   make(chan struct{}, maxConcurrency),
+		oauth2Tokens: newOAuth2TokenCache(),
+		pollFeeds:    make(map[string]*pollFeedState),
+		cache:        newResponseCache(),
+	}
+
+	for _, feed := range cfg.PollFeeds {
+		if _, err := s.AddPollFeed(feed); err != nil {
+			return nil, fmt.Errorf("neooracle: poll feed %s: %w", feed.ID, err)
+		}
 	}
+	base.AddTickerWorker(pollTick, s.pollDueFeeds, commonservice.WithTickerWorkerName("oracle-poller"))
+	base.WithStats(s.statistics)
 
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 	return s, nil
 }
+
+// URLAllowlist returns the current outbound URL allowlist.
+func (s *Service) URLAllowlist() URLAllowlist {
+	s.allowlistMu.RLock()
+	defer s.allowlistMu.RUnlock()
+	return URLAllowlist{Prefixes: append([]string(nil), s.allowlist.Prefixes...)}
+}
+
+// SetURLAllowlist replaces the outbound URL allowlist for subsequent
+// requests. In strict identity mode the allowlist must keep at least one
+// valid entry; otherwise the current allowlist is left in place.
+func (s *Service) SetURLAllowlist(allowlist URLAllowlist) error {
+	if err := validateAllowlist(s.strict, allowlist); err != nil {
+		return err
+	}
+	s.allowlistMu.Lock()
+	s.allowlist = URLAllowlist{Prefixes: append([]string(nil), allowlist.Prefixes...)}
+	s.allowlistMu.Unlock()
+	return nil
+}
+
+func validateAllowlist(strict bool, allowlist URLAllowlist) error {
+	if !strict {
+		return nil
+	}
+	for _, raw := range allowlist.Prefixes {
+		if _, ok := parseURLAllowlistEntry(raw); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("neooracle: URL allowlist is required in strict identity mode (set ORACLE_HTTP_ALLOWLIST)")
+}
+
+// selfTest requires the secrets provider, without which queries that use
+// secret_name or OAuth2 fail, and an outbound URL allowlist.
+func (s *Service) selfTest(ctx context.Context) error {
+	return s.CheckDependencies(ctx,
+		commonservice.Dependency{Name: "secrets provider (SECRETS_MASTER_KEY)", Missing: s.secretProvider == nil},
+		commonservice.Dependency{Name: "URL allowlist (ORACLE_HTTP_ALLOWLIST)", Missing: len(s.URLAllowlist().Prefixes) == 0},
+	)
+}
+
+// statistics provides runtime statistics for /info.
+func (s *Service) statistics() map[string]any {
+	s.pollMu.Lock()
+	defer s.pollMu.Unlock()
+
+	active := 0
+	var fetches, failures int64
+	for _, st := range s.pollFeeds {
+		if st.status.Active {
+			active++
+		}
+		fetches += st.status.FetchCount
+		failures += st.status.ErrorCount
+	}
+	return map[string]any{
+		"poll_feeds":        len(s.pollFeeds),
+		"poll_feeds_active": active,
+		"poll_fetches":      fetches,
+		"poll_errors":       failures,
+		"cache_hits":        s.cache.hits.Load(),
+		"cache_misses":      s.cache.misses.Load(),
+	}
+}
@@ -1,11 +1,19 @@
 package neooracle
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
 
 	internalhttputil "github.com/R3E-Network/service_layer/infrastructure/httputil"
 	"github.com/R3E-Network/service_layer/infrastructure/marble"
@@ -24,6 +32,27 @@ func TestAllowlistBlocksURL(t *testing.T) {
 	}
 }
 
+func TestSetURLAllowlist(t *testing.T) {
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{"https://allowed.example"}})
+	if err := svc.SetURLAllowlist(URLAllowlist{Prefixes: []string{"https://forbidden.example"}}); err != nil {
+		t.Fatalf("SetURLAllowlist() err = %v", err)
+	}
+	if !svc.URLAllowlist().Allows("https://forbidden.example/data") {
+		t.Fatal("expected replaced allowlist to allow new prefix")
+	}
+	if svc.URLAllowlist().Allows("https://allowed.example/data") {
+		t.Fatal("expected replaced allowlist to drop old prefix")
+	}
+
+	svc.strict = true
+	if err := svc.SetURLAllowlist(URLAllowlist{}); err == nil {
+		t.Fatal("expected empty allowlist to be rejected in strict mode")
+	}
+	if !svc.URLAllowlist().Allows("https://forbidden.example/data") {
+		t.Fatal("expected rejected update to keep current allowlist")
+	}
+}
+
 func TestBodyLimitApplied(t *testing.T) {
 	// Mock upstream returning large body.
 	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +81,486 @@ func TestBodyLimitApplied(t *testing.T) {
 	}
 }
 
+func TestQueryResponseIncludesFetchedAt(t *testing.T) {
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"price":1}`))
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+
+	before := time.Now()
+	body := `{"url":"` + up.URL + `"}`
+	req := httptest.NewRequest("POST", "/query", strings.NewReader(body))
+	req.Header.Set("X-User-ID", "user1")
+	rr := httptest.NewRecorder()
+	svc.handleQuery(rr, req)
+	if rr.Result().StatusCode != http.StatusOK {
+		t.Fatalf("status=%d want 200", rr.Result().StatusCode)
+	}
+
+	var resp QueryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.FetchedAt.Before(before.Add(-time.Second)) || resp.FetchedAt.After(time.Now()) {
+		t.Fatalf("fetched_at=%v not within request window", resp.FetchedAt)
+	}
+}
+
+func TestMaxConcurrencyDefault(t *testing.T) {
+	svc := newTestOracle(t, URLAllowlist{})
+	if cap(svc.fetchSlots) != DefaultMaxConcurrency {
+		t.Fatalf("fetch slots=%d want %d", cap(svc.fetchSlots), DefaultMaxConcurrency)
+	}
+}
+
+func TestMaxConcurrencyBlocksWhenSaturated(t *testing.T) {
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer up.Close()
+
+	m, _ := marble.New(marble.Config{MarbleType: "neooracle"})
+	svc, err := New(Config{
+		Marble:         m,
+		URLAllowlist:   URLAllowlist{Prefixes: []string{up.URL}},
+		MaxConcurrency: 1,
+	})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	svc.fetchSlots <- struct{}{} // occupy the only slot
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	body := `{"url":"` + up.URL + `"}`
+	req := httptest.NewRequest("POST", "/query", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set("X-User-ID", "user1")
+	rr := httptest.NewRecorder()
+	svc.handleQuery(rr, req)
+	if rr.Result().StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status=%d want 503", rr.Result().StatusCode)
+	}
+}
+
+func TestRequestOAuth2Token(t *testing.T) {
+	tokenServer := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_request"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"tok-1","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	cfg := &OAuth2Config{TokenURL: tokenServer.URL, ClientID: "client", ClientSecretName: "oauth", Scopes: []string{"read", "write"}}
+	now := time.Unix(1_700_000_000, 0)
+
+	tok, err := requestOAuth2Token(context.Background(), http.DefaultClient, cfg, "s3cret", now)
+	if err != nil {
+		t.Fatalf("requestOAuth2Token() err = %v", err)
+	}
+	if got := tok.authorization(); got != "Bearer tok-1" {
+		t.Fatalf("authorization=%q want %q", got, "Bearer tok-1")
+	}
+	if want := now.Add(time.Hour - oauth2ExpirySkew); !tok.expiresAt.Equal(want) {
+		t.Fatalf("expiresAt=%v want %v", tok.expiresAt, want)
+	}
+
+	_, err = requestOAuth2Token(context.Background(), http.DefaultClient, cfg, "wrong", now)
+	if !errors.Is(err, ErrOAuth2Token) {
+		t.Fatalf("err=%v want ErrOAuth2Token", err)
+	}
+	if !strings.Contains(err.Error(), "HTTP 401") || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("error %q should name the status and error code", err)
+	}
+	if strings.Contains(err.Error(), "wrong") {
+		t.Fatalf("error %q leaks the client secret", err)
+	}
+}
+
+func TestOAuth2TokenCacheExpiry(t *testing.T) {
+	cache := newOAuth2TokenCache()
+	now := time.Unix(1_700_000_000, 0)
+	cache.put("k", oauth2Token{accessToken: "tok", expiresAt: now.Add(time.Minute)})
+
+	if _, ok := cache.get("k", now); !ok {
+		t.Fatal("token should be cached before expiry")
+	}
+	if _, ok := cache.get("k", now.Add(time.Minute)); ok {
+		t.Fatal("token should be dropped at expiry")
+	}
+	if _, ok := cache.get("k", now); ok {
+		t.Fatal("expired token should have been evicted")
+	}
+}
+
+func TestQueryOAuth2RequiresConfig(t *testing.T) {
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{"https://allowed.example"}})
+	body := `{"url":"https://allowed.example/data","auth_type":"oauth2_client_credentials"}`
+	req := httptest.NewRequest("POST", "/query", strings.NewReader(body))
+	req.Header.Set("X-User-ID", "user1")
+	rr := httptest.NewRecorder()
+	svc.handleQuery(rr, req)
+	if rr.Result().StatusCode != http.StatusBadRequest {
+		t.Fatalf("status=%d want 400", rr.Result().StatusCode)
+	}
+}
+
+type staticSecretProvider map[string]string
+
+func (p staticSecretProvider) GetSecret(_ context.Context, userID, name string) (string, error) {
+	if v, ok := p[userID+"/"+name]; ok {
+		return v, nil
+	}
+	return "", errors.New("secret not found")
+}
+
+func TestPollFeedIntervalFetches(t *testing.T) {
+	var hits atomic.Int32
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		hits.Add(1)
+		w.Write([]byte(`{"price":"1.23"}`))
+	}))
+	defer up.Close()
+
+	m, _ := marble.New(marble.Config{MarbleType: "neooracle"})
+	svc, err := New(Config{
+		Marble:         m,
+		URLAllowlist:   URLAllowlist{Prefixes: []string{up.URL}},
+		SecretProvider: staticSecretProvider{"user1/api": "api-key"},
+		PollFeeds: []PollFeed{{
+			ID:       "price",
+			UserID:   "user1",
+			Query:    QueryInput{URL: up.URL, SecretName: "api"},
+			Interval: MinPollInterval,
+			Active:   true,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start() err = %v", err)
+	}
+	defer svc.Stop()
+
+	// The first fetch runs on the first tick; then pull the next run forward
+	// instead of waiting out MinPollInterval.
+	waitForPollFetches(t, svc, "price", 1)
+	svc.pollMu.Lock()
+	svc.pollFeeds["price"].status.NextRun = time.Now()
+	svc.pollMu.Unlock()
+	waitForPollFetches(t, svc, "price", 2)
+
+	status, ok := svc.GetPollFeed("price")
+	if !ok {
+		t.Fatal("feed not found")
+	}
+	if status.FetchCount < 2 || hits.Load() < 2 {
+		t.Fatalf("FetchCount=%d hits=%d, want at least 2 (last error %q)", status.FetchCount, hits.Load(), status.LastError)
+	}
+	if status.ErrorCount != 0 || status.LastFetched.IsZero() {
+		t.Fatalf("ErrorCount=%d LastFetched=%v, want successful fetches", status.ErrorCount, status.LastFetched)
+	}
+	if status.LastResponse == nil || status.LastResponse.Body != `{"price":"1.23"}` {
+		t.Fatalf("LastResponse=%+v", status.LastResponse)
+	}
+}
+
+func waitForPollFetches(t *testing.T, svc *Service, id string, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, _ := svc.GetPollFeed(id); status.FetchCount >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	status, _ := svc.GetPollFeed(id)
+	t.Fatalf("feed %s: FetchCount=%d, want %d (last error %q)", id, status.FetchCount, n, status.LastError)
+}
+
+func TestPollFeedRecordsErrors(t *testing.T) {
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+	if _, err := svc.AddPollFeed(PollFeed{ID: "f", UserID: "user1", Query: QueryInput{URL: up.URL}, Interval: MinPollInterval, Active: true}); err != nil {
+		t.Fatalf("AddPollFeed() err = %v", err)
+	}
+
+	if err := svc.pollDueFeeds(context.Background()); err != nil {
+		t.Fatalf("pollDueFeeds() err = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, _ := svc.GetPollFeed("f"); status.ErrorCount == 1 {
+			if status.FetchCount != 0 || !strings.Contains(status.LastError, "HTTP 503") {
+				t.Fatalf("status=%+v, want one upstream error", status)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("poll error was not recorded")
+}
+
+func TestPollFeedValidate(t *testing.T) {
+	base := PollFeed{UserID: "user1", Query: QueryInput{URL: "https://allowed.example/data"}}
+	tests := []struct {
+		name    string
+		mutate  func(*PollFeed)
+		wantErr bool
+	}{
+		{"interval", func(f *PollFeed) { f.Interval = time.Minute }, false},
+		{"cron", func(f *PollFeed) { f.Schedule = "*/5 * * * *" }, false},
+		{"neither", func(f *PollFeed) {}, true},
+		{"both", func(f *PollFeed) { f.Interval = time.Minute; f.Schedule = "* * * * *" }, true},
+		{"too frequent", func(f *PollFeed) { f.Interval = 100 * time.Millisecond }, true},
+		{"below minimum", func(f *PollFeed) { f.Interval = MinPollInterval - time.Second }, true},
+		{"at minimum", func(f *PollFeed) { f.Interval = MinPollInterval }, false},
+		{"bad cron", func(f *PollFeed) { f.Schedule = "every minute" }, true},
+		{"no user", func(f *PollFeed) { f.Interval = time.Minute; f.UserID = "" }, true},
+	}
+	for _, tt := range tests {
+		feed := base
+		tt.mutate(&feed)
+		if err := feed.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPollFeedHandlersScopedToUser(t *testing.T) {
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{"https://allowed.example"}})
+
+	body := `{"id":"f1","query":{"url":"https://allowed.example/data"},"interval":"30s"}`
+	req := httptest.NewRequest("POST", "/feeds", strings.NewReader(body))
+	req.Header.Set("X-User-ID", "user1")
+	rr := httptest.NewRecorder()
+	svc.handleCreatePollFeed(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create status=%d body=%s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest("POST", "/feeds", strings.NewReader(body))
+	req.Header.Set("X-User-ID", "user2")
+	rr = httptest.NewRecorder()
+	svc.handleCreatePollFeed(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("other user's create status=%d want 409", rr.Code)
+	}
+
+	req = httptest.NewRequest("DELETE", "/feeds/f1", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "f1"})
+	req.Header.Set("X-User-ID", "user2")
+	rr = httptest.NewRecorder()
+	svc.handleDeletePollFeed(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("other user's delete status=%d want 404", rr.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/feeds", nil)
+	req.Header.Set("X-User-ID", "user1")
+	rr = httptest.NewRecorder()
+	svc.handleListPollFeeds(rr, req)
+	var feeds []PollFeedStatus
+	if err := json.Unmarshal(rr.Body.Bytes(), &feeds); err != nil || len(feeds) != 1 || feeds[0].Interval != 30*time.Second {
+		t.Fatalf("list err=%v feeds=%+v", err, feeds)
+	}
+}
+
+func TestQueryCacheServesRepeatedRequests(t *testing.T) {
+	var hits atomic.Int32
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(`{"price":1}`))
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+	ctx := context.Background()
+	input := QueryInput{URL: up.URL, Headers: map[string]string{"Accept": "application/json"}, CacheTTL: 60}
+
+	first, err := svc.runQuery(ctx, "user1", &input)
+	if err != nil {
+		t.Fatalf("runQuery() err = %v", err)
+	}
+	second, err := svc.runQuery(ctx, "user2", &input)
+	if err != nil {
+		t.Fatalf("runQuery() err = %v", err)
+	}
+	if hits.Load() != 1 || first.Cached || !second.Cached || !second.FetchedAt.Equal(first.FetchedAt) {
+		t.Fatalf("hits=%d first.Cached=%v second=%+v, want second served from cache", hits.Load(), first.Cached, second)
+	}
+
+	input.Body = `{"pair":"NEO/USD"}`
+	if _, err := svc.runQuery(ctx, "user1", &input); err != nil {
+		t.Fatalf("runQuery() err = %v", err)
+	}
+	input.Body = ""
+	input.CacheTTL = 0
+	if _, err := svc.runQuery(ctx, "user1", &input); err != nil {
+		t.Fatalf("runQuery() err = %v", err)
+	}
+	if hits.Load() != 3 {
+		t.Fatalf("hits=%d, want a different body and cache_ttl=0 to fetch fresh", hits.Load())
+	}
+	if stats := svc.statistics(); stats["cache_hits"] != int64(1) {
+		t.Fatalf("cache_hits=%v want 1", stats["cache_hits"])
+	}
+
+	input.CacheTTL = int(MaxCacheTTL.Seconds()) + 1
+	if _, err := svc.runQuery(ctx, "user1", &input); err == nil {
+		t.Fatal("expected cache_ttl above the maximum to be rejected")
+	}
+}
+
+func TestQueryCacheScopesSecretRequestsToUser(t *testing.T) {
+	var hits atomic.Int32
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+	svc.secretProvider = staticSecretProvider{"user1/api": "key-1", "user2/api": "key-2"}
+	ctx := context.Background()
+	input := QueryInput{URL: up.URL, SecretName: "api", CacheTTL: 60}
+
+	for _, user := range []string{"user1", "user2", "user1"} {
+		if _, err := svc.runQuery(ctx, user, &input); err != nil {
+			t.Fatalf("runQuery(%s) err = %v", user, err)
+		}
+	}
+	resp, err := svc.runQuery(ctx, "user2", &input)
+	if err != nil {
+		t.Fatalf("runQuery() err = %v", err)
+	}
+	if hits.Load() != 2 || !resp.Cached || resp.Body != "Bearer key-2" {
+		t.Fatalf("hits=%d resp=%+v, want one fetch per user", hits.Load(), resp)
+	}
+
+	// A revoked secret must not keep serving the response it fetched.
+	delete(svc.secretProvider.(staticSecretProvider), "user1/api")
+	if _, err := svc.runQuery(ctx, "user1", &input); err == nil {
+		t.Fatal("expected cached response to be withheld once the secret is gone")
+	}
+
+	key := queryCacheKey("user1", http.MethodGet, &input)
+	if strings.Contains(key, "key-1") || key != queryCacheKey("user1", http.MethodGet, &input) {
+		t.Fatalf("cache key %q must be stable and free of secret values", key)
+	}
+}
+
+func TestPollFeedNoCacheFetchesFresh(t *testing.T) {
+	var hits atomic.Int32
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(`{"price":1}`))
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+	query := QueryInput{URL: up.URL, CacheTTL: 60}
+	if _, err := svc.runQuery(context.Background(), "user1", &query); err != nil {
+		t.Fatalf("runQuery() err = %v", err)
+	}
+
+	feed := PollFeed{ID: "fresh", UserID: "user1", Query: query, Interval: time.Second, Active: true, NoCache: true}
+	st := &pollFeedState{status: PollFeedStatus{PollFeed: feed}}
+	svc.pollFeeds[feed.ID] = st
+	svc.pollFeed(context.Background(), st, feed)
+
+	if hits.Load() != 2 || st.status.LastResponse == nil || st.status.LastResponse.Cached {
+		t.Fatalf("hits=%d last=%+v, want no_cache feed to bypass the cache", hits.Load(), st.status.LastResponse)
+	}
+}
+
+func TestParseWeatherResponse(t *testing.T) {
+	body := []byte(`{
+		"coord": {"lon": -0.1257, "lat": 51.5085},
+		"weather": [{"id": 804, "main": "Clouds", "description": "overcast clouds", "icon": "04d"}],
+		"main": {"temp": 288.15, "feels_like": 287.6, "pressure": 1012, "humidity": 72},
+		"name": "London",
+		"cod": 200
+	}`)
+
+	value, err := parseResponse(FeedKindWeather, body)
+	if err != nil {
+		t.Fatalf("parseResponse() err = %v", err)
+	}
+	weather, ok := value.(*WeatherData)
+	if !ok {
+		t.Fatalf("value = %T, want *WeatherData", value)
+	}
+	if math.Abs(weather.TemperatureC-15) > 1e-9 || weather.Humidity != 72 || weather.Conditions != "Clouds" || weather.Location != "London" {
+		t.Fatalf("weather = %+v", weather)
+	}
+
+	if _, err := parseResponse(FeedKindWeather, []byte(`{"main":{"humidity":50}}`)); err == nil {
+		t.Fatal("expected missing temperature to be rejected")
+	}
+	if _, err := parseResponse(FeedKindJSON, []byte(`not json`)); err == nil {
+		t.Fatal("expected invalid JSON to be rejected")
+	}
+}
+
+func TestPollFeedStoresParsedValue(t *testing.T) {
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"weather":[{"main":"Rain"}],"main":{"temp":273.15,"humidity":90}}`))
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+	feed := PollFeed{ID: "weather", UserID: "user1", Query: QueryInput{URL: up.URL}, Interval: MinPollInterval, Active: true, Kind: FeedKindWeather}
+	if _, err := svc.AddPollFeed(feed); err != nil {
+		t.Fatalf("AddPollFeed() err = %v", err)
+	}
+	svc.pollMu.Lock()
+	st := svc.pollFeeds[feed.ID]
+	svc.pollMu.Unlock()
+	svc.pollFeed(context.Background(), st, feed)
+
+	req := httptest.NewRequest("GET", "/feeds/weather", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "weather"})
+	req.Header.Set("X-User-ID", "user1")
+	rr := httptest.NewRecorder()
+	svc.handleGetPollFeed(rr, req)
+
+	var status struct {
+		LastValue WeatherData `json:"last_value"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	if status.LastValue.Conditions != "Rain" || status.LastValue.TemperatureC != 0 || status.LastValue.Humidity != 90 {
+		t.Fatalf("last_value = %+v (body %s)", status.LastValue, rr.Body.String())
+	}
+
+	feed.Kind = "xml"
+	if _, err := svc.AddPollFeed(feed); err == nil {
+		t.Fatal("expected unknown feed kind to be rejected")
+	}
+}
+
 // newTestOracle returns a service with minimal deps; secrets client won't be used.
 func newTestOracle(t *testing.T, allowlist URLAllowlist) *Service {
 	t.Helper()
@@ -65,3 +574,70 @@ func newTestOracle(t *testing.T, allowlist URLAllowlist) *Service {
 	}
 	return svc
 }
+
+func TestPollFeedPerUserLimit(t *testing.T) {
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{"https://allowed.example"}})
+	feed := func(id, userID string) PollFeed {
+		return PollFeed{ID: id, UserID: userID, Query: QueryInput{URL: "https://allowed.example/data"}, Interval: time.Minute}
+	}
+
+	for i := 0; i < MaxPollFeedsPerUser; i++ {
+		if _, err := svc.AddPollFeed(feed(fmt.Sprintf("f%d", i), "user1")); err != nil {
+			t.Fatalf("AddPollFeed(%d) err = %v", i, err)
+		}
+	}
+	if _, err := svc.AddPollFeed(feed("one-too-many", "user1")); !errors.Is(err, ErrPollFeedLimit) {
+		t.Fatalf("AddPollFeed() over limit err = %v, want ErrPollFeedLimit", err)
+	}
+	// Replacing an existing feed and other users' feeds are not limited.
+	if _, err := svc.AddPollFeed(feed("f0", "user1")); err != nil {
+		t.Fatalf("replace AddPollFeed() err = %v", err)
+	}
+	if _, err := svc.AddPollFeed(feed("other", "user2")); err != nil {
+		t.Fatalf("AddPollFeed() for another user err = %v", err)
+	}
+
+	body := `{"id":"http-extra","query":{"url":"https://allowed.example/data"},"interval":"1m"}`
+	req := httptest.NewRequest("POST", "/feeds", strings.NewReader(body))
+	req.Header.Set("X-User-ID", "user1")
+	rr := httptest.NewRecorder()
+	svc.handleCreatePollFeed(rr, req)
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("create over limit status=%d body=%s, want 429", rr.Code, rr.Body.String())
+	}
+}
+
+func TestPollFeedKeepsBoundedLastResponse(t *testing.T) {
+	large := `{"data":"` + strings.Repeat("x", 2*MaxPollResponseBodyBytes) + `"}`
+	up := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(large))
+	}))
+	defer up.Close()
+
+	svc := newTestOracle(t, URLAllowlist{Prefixes: []string{up.URL}})
+	for _, kind := range []string{FeedKindRaw, FeedKindJSON} {
+		feed := PollFeed{ID: "large-" + kind, UserID: "user1", Query: QueryInput{URL: up.URL}, Interval: MinPollInterval, Active: true, Kind: kind}
+		if _, err := svc.AddPollFeed(feed); err != nil {
+			t.Fatalf("AddPollFeed(%q) err = %v", kind, err)
+		}
+		svc.pollMu.Lock()
+		st := svc.pollFeeds[feed.ID]
+		svc.pollMu.Unlock()
+		svc.pollFeed(context.Background(), st, feed)
+
+		status, _ := svc.GetPollFeed(feed.ID)
+		if status.FetchCount != 1 || status.LastResponse == nil {
+			t.Fatalf("kind %q: status=%+v, want one successful fetch", kind, status)
+		}
+		switch kind {
+		case FeedKindRaw:
+			if len(status.LastResponse.Body) != MaxPollResponseBodyBytes || !status.LastResponseTruncated {
+				t.Errorf("raw feed kept %d body bytes (truncated=%v), want %d", len(status.LastResponse.Body), status.LastResponseTruncated, MaxPollResponseBodyBytes)
+			}
+		default:
+			if status.LastResponse.Body != "" || status.LastValue == nil {
+				t.Errorf("parsed feed kept %d body bytes, want only last_value", len(status.LastResponse.Body))
+			}
+		}
+	}
+}
@@ -1,6 +1,8 @@
 // Package neooracle provides a simple data-fetching neooracle service.
 package neooracle
 
+import "time"
+
 // QueryInput is the request payload to fetch external data.
 type QueryInput struct {
 	URL         string            `json:"url"`
@@ -9,6 +11,9 @@ type QueryInput struct {
 	SecretName  string            `json:"secret_name,omitempty"`   // optional: fetch secret and send as Authorization bearer
 	SecretAsKey string            `json:"secret_as_key,omitempty"` // optional: header key to place secret in (default Authorization: Bearer <secret>)
 	Body        string            `json:"body,omitempty"`          // optional body for POST/PUT
+	AuthType    string            `json:"auth_type,omitempty"`     // optional: oauth2_client_credentials
+	OAuth2      *OAuth2Config     `json:"oauth2,omitempty"`        // required when auth_type is oauth2_client_credentials
+	CacheTTL    int               `json:"cache_ttl,omitempty"`     // optional: seconds a cached 2xx response may be reused (max 3600)
 }
 
 // QueryResponse returns the fetched data.
@@ -16,4 +21,17 @@ type QueryResponse struct {
 	StatusCode int               `json:"status_code"`
 	Headers    map[string]string `json:"headers"`
 	Body       string            `json:"body"`
+	FetchedAt  time.Time         `json:"fetched_at"`       // when the upstream response was read
+	Cached     bool              `json:"cached,omitempty"` // served from the response cache
+}
+
+// PollFeedRequest is the request payload to register a poll feed.
+type PollFeedRequest struct {
+	ID       string     `json:"id,omitempty"` // optional; replaces the caller's feed with this ID
+	Query    QueryInput `json:"query"`
+	Interval string     `json:"interval,omitempty"` // Go duration, e.g. "30s"
+	Schedule string     `json:"schedule,omitempty"` // 5-field cron
+	Active   *bool      `json:"active,omitempty"`   // default: true
+	NoCache  bool       `json:"no_cache,omitempty"` // always fetch fresh, ignoring query.cache_ttl
+	Kind     string     `json:"kind,omitempty"`     // optional: json or weather; parsed into last_value
 }
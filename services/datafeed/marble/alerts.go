@@ -0,0 +1,75 @@
+// Package neofeeds provides price alert rule evaluation for the price feed aggregation service.
+package neofeeds
+
+import (
+	"fmt"
+	"time"
+)
+
+// Alert comparisons.
+const (
+	AlertAbove = "above" // Trigger when price > threshold
+	AlertBelow = "below" // Trigger when price < threshold
+)
+
+// AlertRule fires when a feed's price crosses a threshold. Threshold uses the
+// feed's decimals (same scale as PriceResponse.Price).
+type AlertRule struct {
+	ID            string        `json:"id"`
+	FeedID        string        `json:"feed_id"`
+	Comparison    string        `json:"comparison"` // above or below
+	Threshold     int64         `json:"threshold,string"`
+	Cooldown      time.Duration `json:"cooldown"`
+	LastTriggered time.Time     `json:"last_triggered,omitempty"`
+}
+
+// Validate checks that the rule is well formed.
+func (r *AlertRule) Validate() error {
+	if r.FeedID == "" {
+		return fmt.Errorf("alert rule %s: feed_id required", r.ID)
+	}
+	if r.Comparison != AlertAbove && r.Comparison != AlertBelow {
+		return fmt.Errorf("alert rule %s: unknown comparison %q", r.ID, r.Comparison)
+	}
+	if r.Cooldown < 0 {
+		return fmt.Errorf("alert rule %s: cooldown must be non-negative", r.ID)
+	}
+	return nil
+}
+
+// EvaluateAlerts returns the rules for value's feed whose condition holds and
+// whose cooldown has elapsed since they last fired. The returned rules carry
+// LastTriggered = now; callers persist them so subsequent evaluations within
+// the cooldown are suppressed.
+func EvaluateAlerts(rules []AlertRule, value PriceResponse, now time.Time) ([]AlertRule, error) {
+	feedID := normalizePair(value.FeedID)
+
+	var triggered []AlertRule
+	for i := range rules {
+		rule := rules[i]
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if normalizePair(rule.FeedID) != feedID {
+			continue
+		}
+
+		var hit bool
+		switch rule.Comparison {
+		case AlertAbove:
+			hit = value.Price > rule.Threshold
+		case AlertBelow:
+			hit = value.Price < rule.Threshold
+		}
+		if !hit {
+			continue
+		}
+		if !rule.LastTriggered.IsZero() && now.Sub(rule.LastTriggered) < rule.Cooldown {
+			continue
+		}
+
+		rule.LastTriggered = now
+		triggered = append(triggered, rule)
+	}
+	return triggered, nil
+}
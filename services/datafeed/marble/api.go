@@ -13,7 +13,17 @@ func (s *Service) registerRoutes() {
 	// Note: `{pair:.+}` is required so Gorilla mux matches slashes in the path segment.
 	router.HandleFunc("/price/{pair:.+}", s.handleGetPrice).Methods("GET")
 	router.HandleFunc("/prices", s.handleGetPrices).Methods("GET")
+	router.HandleFunc("/history/{pair:.+}", s.handleGetHistory).Methods("GET")
 	router.HandleFunc("/feeds", s.handleListFeeds).Methods("GET")
+	router.HandleFunc("/feeds/{id}/sources", s.handleGetFeedSources).Methods("GET")
 	router.HandleFunc("/config", s.handleGetConfig).Methods("GET")
 	router.HandleFunc("/sources", s.handleListSources).Methods("GET")
+
+	// Admin endpoints (role checked in handler via httputil.RequireAdminRole)
+	router.HandleFunc("/admin/feeds/{id}/backfill", s.handleBackfill).Methods("POST")
+	router.HandleFunc("/admin/feeds/{id}/backfill", s.handleGetBackfill).Methods("GET")
+	router.HandleFunc("/admin/feeds/{id}/signers", s.handleGetSigners).Methods("GET")
+	router.HandleFunc("/admin/feeds/{id}/signers", s.handleAddSigner).Methods("POST")
+	router.HandleFunc("/admin/feeds/{id}/signers/{signer}", s.handleRemoveSigner).Methods("DELETE")
+	router.HandleFunc("/admin/feeds/{id}/threshold", s.handleSetThreshold).Methods("PUT")
 }
@@ -0,0 +1,264 @@
+package neofeeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+// MaxBackfillPoints caps the number of timestamps a single Backfill call
+// queries; each point costs one request per history-capable source.
+const MaxBackfillPoints = 1000
+
+// ErrHistoryUnsupported is matched (via errors.Is) by every
+// *HistoryUnsupportedError.
+var ErrHistoryUnsupported = errors.New("historical values not supported")
+
+// HistoryUnsupportedError reports a source that cannot answer time-range
+// queries (it has no history_url).
+type HistoryUnsupportedError struct {
+	FeedID   string
+	SourceID string
+}
+
+func (e *HistoryUnsupportedError) Error() string {
+	return fmt.Sprintf("%s: source %s (feed %s)", ErrHistoryUnsupported, e.SourceID, e.FeedID)
+}
+
+func (e *HistoryUnsupportedError) Unwrap() error { return ErrHistoryUnsupported }
+
+// errInvalidBackfill wraps Backfill argument errors.
+var errInvalidBackfill = errors.New("invalid backfill")
+
+// formatHistoryURL fills the time placeholders of a history URL template.
+func formatHistoryURL(url string, at time.Time) string {
+	at = at.UTC()
+	url = strings.ReplaceAll(url, "{timestamp_ms}", strconv.FormatInt(at.UnixMilli(), 10))
+	url = strings.ReplaceAll(url, "{timestamp}", strconv.FormatInt(at.Unix(), 10))
+	return strings.ReplaceAll(url, "{date}", at.Format(time.DateOnly))
+}
+
+// fetchHistoricalValue fetches a source's value for feed at the given instant.
+// Sources without a history URL return a *HistoryUnsupportedError.
+func (s *Service) fetchHistoricalValue(ctx context.Context, feed *FeedConfig, src *SourceConfig, at time.Time) (float64, error) {
+	if src.HistoryURL == "" {
+		return 0, &HistoryUnsupportedError{FeedID: feed.ID, SourceID: src.ID}
+	}
+
+	jsonPath := src.HistoryJSONPath
+	if jsonPath == "" {
+		jsonPath = src.JSONPath
+	}
+	url := formatHistoryURL(formatSourceURLNew(src.HistoryURL, feed.ID, feed, src), at)
+	return s.fetchSourceValue(ctx, url, jsonPath, feed, src)
+}
+
+// backfillSources validates a backfill range and returns the feed's sources
+// that support time-range queries.
+func (s *Service) backfillSources(feed *FeedConfig, from, to time.Time, step time.Duration) ([]*SourceConfig, error) {
+	if feed == nil {
+		return nil, fmt.Errorf("%w: feed required", errInvalidBackfill)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: step must be positive", errInvalidBackfill)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: to must be after from", errInvalidBackfill)
+	}
+	if points := to.Sub(from) / step; points >= MaxBackfillPoints {
+		return nil, fmt.Errorf("%w: range covers more than %d points; use a larger step", errInvalidBackfill, MaxBackfillPoints)
+	}
+
+	var sources []*SourceConfig
+	var unsupported error
+	for _, src := range s.getSourcesForFeed(feed) {
+		if src.HistoryURL == "" {
+			if unsupported == nil {
+				unsupported = &HistoryUnsupportedError{FeedID: feed.ID, SourceID: src.ID}
+			}
+			continue
+		}
+		sources = append(sources, src)
+	}
+	if len(sources) == 0 {
+		if unsupported == nil {
+			return nil, fmt.Errorf("%w: no sources configured for %s", errInvalidBackfill, feed.ID)
+		}
+		return nil, unsupported
+	}
+	return sources, nil
+}
+
+// hasPriceAt reports whether the feed's history already holds a value
+// recorded at exactly at.
+func (s *Service) hasPriceAt(ctx context.Context, feedID string, at time.Time) (bool, error) {
+	rows, err := s.DB().GetPriceHistory(ctx, feedID, at, at.Add(time.Microsecond), 1)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// Backfill fetches a feed's historical values every step over [from, to) from
+// the feed's sources that support time-range queries, aggregates them like
+// live prices and records them in the feed's history. If none of the feed's
+// sources support history it returns the first source's
+// *HistoryUnsupportedError. Timestamps that already have a recorded value, or
+// for which no source returned a value, are skipped. The new values are
+// recorded in one all-or-nothing insert after the whole range is fetched, so a
+// cancelled or failed backfill records nothing.
+//
+// Backfilled values are marked Backfilled and left unsigned: the live signing
+// key attests to prices the enclave observed, not to third-party history.
+func (s *Service) Backfill(ctx context.Context, feed *FeedConfig, from, to time.Time, step time.Duration) ([]PriceResponse, error) {
+	sources, err := s.backfillSources(feed, from, to, step)
+	if err != nil {
+		return nil, err
+	}
+
+	decimals := feed.Decimals
+	if decimals <= 0 {
+		decimals = 8
+	}
+
+	var updates []PriceResponse
+	for at := from; at.Before(to); at = at.Add(step) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if s.DB() != nil {
+			exists, err := s.hasPriceAt(ctx, feed.ID, at)
+			if err != nil {
+				return nil, fmt.Errorf("check %s at %s: %w", feed.ID, at.Format(time.RFC3339), err)
+			}
+			if exists {
+				continue
+			}
+		}
+
+		var samples []priceSample
+		for _, src := range sources {
+			value, err := s.fetchHistoricalValue(ctx, feed, src, at)
+			if err != nil {
+				s.Logger().WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
+					"feed_id":   feed.ID,
+					"source_id": src.ID,
+					"at":        at,
+				}).Debug("historical value unavailable")
+				continue
+			}
+			samples = append(samples, priceSample{source: src.ID, value: value, weight: src.Weight})
+		}
+		if len(samples) == 0 {
+			continue
+		}
+
+		aggregated, err := s.aggregateFeed(samples)
+		if err != nil {
+			continue
+		}
+		names := make([]string, 0, len(aggregated.kept))
+		for _, sample := range aggregated.kept {
+			names = append(names, sample.source)
+		}
+
+		update := PriceResponse{
+			FeedID:      feed.ID,
+			Pair:        feed.ID,
+			Price:       int64(aggregated.value * float64(pow10(decimals))),
+			Decimals:    decimals,
+			Timestamp:   at,
+			Sources:     names,
+			SourceCount: len(names),
+			Confidence:  aggregated.confidence(),
+			Backfilled:  true,
+		}
+		updates = append(updates, update)
+	}
+
+	if s.DB() != nil && len(updates) > 0 {
+		records := make([]database.PriceFeed, len(updates))
+		for i := range updates {
+			records[i] = priceRecord(&updates[i])
+		}
+		if err := s.DB().CreatePriceFeeds(ctx, records); err != nil {
+			return nil, fmt.Errorf("record %d values for %s: %w", len(records), feed.ID, err)
+		}
+	}
+	return updates, nil
+}
+
+// Backfill job states.
+const (
+	BackfillRunning   = "running"
+	BackfillCompleted = "completed"
+	BackfillFailed    = "failed"
+)
+
+// startBackfill runs Backfill in the background and records its progress as
+// the feed's latest BackfillJob. Only one backfill runs per feed at a time;
+// ok is false if one is already running.
+func (s *Service) startBackfill(feed *FeedConfig, from, to time.Time, step time.Duration) (job BackfillJob, ok bool) {
+	s.backfillMu.Lock()
+	defer s.backfillMu.Unlock()
+
+	if current := s.backfillJobs[feed.ID]; current != nil && current.Status == BackfillRunning {
+		return *current, false
+	}
+	running := &BackfillJob{
+		FeedID:    feed.ID,
+		From:      from,
+		To:        to,
+		Step:      step.String(),
+		Status:    BackfillRunning,
+		StartedAt: time.Now().UTC(),
+	}
+	s.backfillJobs[feed.ID] = running
+
+	done := s.BeginOperation("backfill")
+	go func() {
+		defer done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-s.StopChan():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		updates, err := s.Backfill(ctx, feed, from, to, step)
+
+		s.backfillMu.Lock()
+		defer s.backfillMu.Unlock()
+		finished := time.Now().UTC()
+		running.Recorded = len(updates)
+		running.FinishedAt = &finished
+		running.Status = BackfillCompleted
+		if err != nil {
+			running.Status = BackfillFailed
+			running.Error = err.Error()
+			s.Logger().WithError(err).WithField("feed_id", feed.ID).Warn("backfill failed")
+		}
+	}()
+	return *running, true
+}
+
+// backfillJob returns the feed's latest backfill job, if any.
+func (s *Service) backfillJob(feedID string) (BackfillJob, bool) {
+	s.backfillMu.Lock()
+	defer s.backfillMu.Unlock()
+
+	job := s.backfillJobs[feedID]
+	if job == nil {
+		return BackfillJob{}, false
+	}
+	return *job, true
+}
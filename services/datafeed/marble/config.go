@@ -38,6 +38,20 @@ type SourceConfig struct {
 	// for this particular source (e.g., USD -> USDT on exchanges).
 	BaseOverride  string `json:"base_override,omitempty" yaml:"base_override,omitempty"`
 	QuoteOverride string `json:"quote_override,omitempty" yaml:"quote_override,omitempty"`
+
+	// Transform optionally normalizes the extracted value before aggregation.
+	Transform *TransformConfig `json:"transform,omitempty" yaml:"transform,omitempty"`
+	// Signing optionally signs each request with an HMAC (signed exchange APIs).
+	Signing *RequestSigningConfig `json:"signing,omitempty" yaml:"signing,omitempty"`
+
+	// HistoryURL optionally queries the value at a past instant, for Backfill.
+	// Besides the URL placeholders it accepts {timestamp} (unix seconds),
+	// {timestamp_ms} and {date} (YYYY-MM-DD, UTC). Sources without it do not
+	// support historical queries.
+	HistoryURL string `json:"history_url,omitempty" yaml:"history_url,omitempty"`
+	// HistoryJSONPath extracts the value from HistoryURL responses
+	// (default: JSONPath).
+	HistoryJSONPath string `json:"history_json_path,omitempty" yaml:"history_json_path,omitempty"`
 }
 
 // FeedConfig defines a data feed configuration.
@@ -52,6 +66,8 @@ type FeedConfig struct {
 	Sources        []string      `json:"sources" yaml:"sources"`                                     // Source IDs to use
 	UpdateInterval time.Duration `json:"update_interval,omitempty" yaml:"update_interval,omitempty"` // Per-feed update interval
 	Enabled        bool          `json:"enabled" yaml:"enabled"`                                     // Whether feed is active
+	SignerSet      []string      `json:"signer_set,omitempty" yaml:"signer_set,omitempty"`           // Oracle node public keys (hex, compressed)
+	Threshold      int           `json:"threshold,omitempty" yaml:"threshold,omitempty"`             // Signatures required from SignerSet
 }
 
 // PublishPolicyConfig controls when prices are anchored on-chain.
@@ -69,6 +85,82 @@ type PublishPolicyConfig struct {
 	// MaxPerMinute caps publish frequency per symbol (soft cap; enforced in-process).
 	// Default: 30.
 	MaxPerMinute int `json:"max_per_minute,omitempty" yaml:"max_per_minute,omitempty"`
+	// Heartbeat forces a publish when a symbol has not been published for this
+	// long, even if the price has not moved past ThresholdBps.
+	// Default: 0 (disabled).
+	Heartbeat time.Duration `json:"heartbeat,omitempty" yaml:"heartbeat,omitempty"`
+}
+
+// applyDefaults fills unset policy fields with their defaults.
+func (p *PublishPolicyConfig) applyDefaults() {
+	if p.ThresholdBps <= 0 {
+		p.ThresholdBps = 10
+	}
+	if p.HysteresisBps <= 0 {
+		p.HysteresisBps = 8
+	}
+	if p.MinInterval <= 0 {
+		p.MinInterval = 5 * time.Second
+	}
+	if p.MaxPerMinute <= 0 {
+		p.MaxPerMinute = 30
+	}
+	if p.Heartbeat < 0 {
+		p.Heartbeat = 0
+	}
+}
+
+// Aggregation methods for combining per-source prices into one value.
+const (
+	AggregationMedian      = "median"       // Weighted median (default)
+	AggregationMean        = "mean"         // Unweighted mean
+	AggregationWeighted    = "weighted"     // Weighted mean using source weights
+	AggregationTrimmedMean = "trimmed_mean" // Mean after dropping outliers from both ends
+	AggregationMin         = "min"          // Lowest observation
+	AggregationMax         = "max"          // Highest observation
+)
+
+// defaultTrimPercent is the share of observations trimmed from each end for
+// trimmed_mean when not configured.
+const defaultTrimPercent = 20
+
+// AggregationConfig selects how per-source prices are combined.
+type AggregationConfig struct {
+	// Method is one of median, mean, weighted, trimmed_mean, min or max.
+	// Default: median. Unknown methods fall back to median.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// TrimPercent is the share of observations dropped from each end for
+	// trimmed_mean. Default: 20.
+	TrimPercent int `json:"trim_percent,omitempty" yaml:"trim_percent,omitempty"`
+	// OutlierMAD rejects sources deviating from the median by more than this
+	// many scaled median absolute deviations before aggregating.
+	// Default: 0 (disabled); 3 is a common choice.
+	OutlierMAD float64 `json:"outlier_mad,omitempty" yaml:"outlier_mad,omitempty"`
+	// MinSources is the number of sources that must remain after outlier
+	// rejection for a price to be produced. Default: 1.
+	MinSources int `json:"min_sources,omitempty" yaml:"min_sources,omitempty"`
+}
+
+// Stale input policies for derived feeds.
+const (
+	StalePolicyExclude = "exclude" // Drop stale inputs and aggregate the rest (default)
+	StalePolicyError   = "error"   // Fail the derived value if any input is stale
+)
+
+// DerivedFeedConfig defines a composite feed computed from other feeds,
+// e.g. the median of several source feeds.
+type DerivedFeedConfig struct {
+	ID     string   `json:"id" yaml:"id"`                         // Derived feed identifier
+	Name   string   `json:"name,omitempty" yaml:"name,omitempty"` // Human-readable name
+	Inputs []string `json:"inputs" yaml:"inputs"`                 // Input feed IDs
+	// Method is one of median, weighted or trimmed_mean. Default: median.
+	Method string `json:"method,omitempty" yaml:"method,omitempty"`
+	// Heartbeat is the maximum age of an input value. Default: 5m.
+	Heartbeat time.Duration `json:"heartbeat,omitempty" yaml:"heartbeat,omitempty"`
+	// StalePolicy is exclude or error. Default: exclude.
+	StalePolicy string `json:"stale_policy,omitempty" yaml:"stale_policy,omitempty"`
+	// MinInputs is the number of fresh inputs required. Default: 1.
+	MinInputs int `json:"min_inputs,omitempty" yaml:"min_inputs,omitempty"`
 }
 
 // FeedsConfig is the root configuration for the neofeeds service.
@@ -79,6 +171,9 @@ type FeedsConfig struct {
 	DefaultSources []string            `json:"default_sources,omitempty" yaml:"default_sources,omitempty"` // Default sources for feeds that don't specify
 	UpdateInterval time.Duration       `json:"update_interval,omitempty" yaml:"update_interval,omitempty"` // Global update interval
 	PublishPolicy  PublishPolicyConfig `json:"publish_policy,omitempty" yaml:"publish_policy,omitempty"`
+	Aggregation    AggregationConfig   `json:"aggregation,omitempty" yaml:"aggregation,omitempty"`
+	DerivedFeeds   []DerivedFeedConfig `json:"derived_feeds,omitempty" yaml:"derived_feeds,omitempty"`
+	SourceHealth   SourceHealthConfig  `json:"source_health,omitempty" yaml:"source_health,omitempty"`
 }
 
 // NeoFeedsConfig is kept for backward compatibility.
@@ -135,6 +230,14 @@ func (c *FeedsConfig) Validate() error {
 		if src.Timeout <= 0 {
 			src.Timeout = 10 * time.Second
 		}
+		if src.Transform != nil && src.Transform.Multiply < 0 {
+			return fmt.Errorf("source[%d]: transform.multiply must be non-negative", i)
+		}
+		if src.Signing != nil {
+			if err := src.Signing.Validate(); err != nil {
+				return fmt.Errorf("source[%d]: %w", i, err)
+			}
+		}
 		sourceMap[src.ID] = true
 	}
 
@@ -174,6 +277,9 @@ func (c *FeedsConfig) Validate() error {
 				return fmt.Errorf("feed[%d]: unknown source %q", i, srcID)
 			}
 		}
+		if err := normalizeSignerSet(feed); err != nil {
+			return fmt.Errorf("feed[%d]: %w", i, err)
+		}
 	}
 
 	if c.UpdateInterval <= 0 {
@@ -182,17 +288,72 @@ func (c *FeedsConfig) Validate() error {
 		c.UpdateInterval = 1 * time.Second
 	}
 
-	if c.PublishPolicy.ThresholdBps <= 0 {
-		c.PublishPolicy.ThresholdBps = 10
+	c.PublishPolicy.applyDefaults()
+	c.SourceHealth.applyDefaults()
+
+	c.Aggregation.Method = strings.ToLower(strings.TrimSpace(c.Aggregation.Method))
+	if c.Aggregation.Method == "" {
+		c.Aggregation.Method = AggregationMedian
 	}
-	if c.PublishPolicy.HysteresisBps <= 0 {
-		c.PublishPolicy.HysteresisBps = 8
+	if c.Aggregation.TrimPercent <= 0 || c.Aggregation.TrimPercent >= 50 {
+		c.Aggregation.TrimPercent = defaultTrimPercent
 	}
-	if c.PublishPolicy.MinInterval <= 0 {
-		c.PublishPolicy.MinInterval = 5 * time.Second
+	if c.Aggregation.OutlierMAD < 0 {
+		c.Aggregation.OutlierMAD = 0
 	}
-	if c.PublishPolicy.MaxPerMinute <= 0 {
-		c.PublishPolicy.MaxPerMinute = 30
+	if c.Aggregation.MinSources <= 0 {
+		c.Aggregation.MinSources = 1
+	}
+
+	feedIDs := make(map[string]bool, len(c.Feeds))
+	for i := range c.Feeds {
+		feedIDs[c.Feeds[i].ID] = true
+	}
+	for i := range c.DerivedFeeds {
+		derived := &c.DerivedFeeds[i]
+		derived.ID = normalizePair(derived.ID)
+		if derived.ID == "" {
+			return fmt.Errorf("derived_feed[%d]: id required", i)
+		}
+		if feedIDs[derived.ID] {
+			return fmt.Errorf("derived_feed[%d]: id %q conflicts with a feed", i, derived.ID)
+		}
+		if len(derived.Inputs) == 0 {
+			return fmt.Errorf("derived_feed[%d]: at least one input required", i)
+		}
+		for j, input := range derived.Inputs {
+			input = normalizePair(input)
+			if !feedIDs[input] {
+				return fmt.Errorf("derived_feed[%d]: unknown input feed %q", i, derived.Inputs[j])
+			}
+			derived.Inputs[j] = input
+		}
+
+		derived.Method = strings.ToLower(strings.TrimSpace(derived.Method))
+		if derived.Method == "" {
+			derived.Method = AggregationMedian
+		}
+		if !isKnownAggregation(derived.Method) {
+			return fmt.Errorf("derived_feed[%d]: unknown method %q", i, derived.Method)
+		}
+		if derived.Heartbeat <= 0 {
+			derived.Heartbeat = 5 * time.Minute
+		}
+
+		derived.StalePolicy = strings.ToLower(strings.TrimSpace(derived.StalePolicy))
+		switch derived.StalePolicy {
+		case "":
+			derived.StalePolicy = StalePolicyExclude
+		case StalePolicyExclude, StalePolicyError:
+		default:
+			return fmt.Errorf("derived_feed[%d]: unknown stale_policy %q", i, derived.StalePolicy)
+		}
+		if derived.MinInputs <= 0 {
+			derived.MinInputs = 1
+		}
+		if derived.MinInputs > len(derived.Inputs) {
+			return fmt.Errorf("derived_feed[%d]: min_inputs exceeds number of inputs", i)
+		}
 	}
 
 	return nil
@@ -218,6 +379,17 @@ func (c *FeedsConfig) GetFeed(id string) *FeedConfig {
 	return nil
 }
 
+// GetDerivedFeed returns a derived feed by ID.
+func (c *FeedsConfig) GetDerivedFeed(id string) *DerivedFeedConfig {
+	id = normalizePair(id)
+	for i := range c.DerivedFeeds {
+		if c.DerivedFeeds[i].ID == id {
+			return &c.DerivedFeeds[i]
+		}
+	}
+	return nil
+}
+
 // GetEnabledFeeds returns all enabled feeds.
 func (c *FeedsConfig) GetEnabledFeeds() []FeedConfig {
 	var feeds []FeedConfig
@@ -306,6 +478,21 @@ func DefaultConfig() *FeedsConfig {
 			MinInterval:   5 * time.Second,
 			MaxPerMinute:  30,
 		},
+		Aggregation: AggregationConfig{
+			Method:      AggregationMedian,
+			TrimPercent: 20,
+			MinSources:  1,
+		},
+	}
+}
+
+func isKnownAggregation(method string) bool {
+	switch method {
+	case AggregationMedian, AggregationMean, AggregationWeighted, AggregationTrimmedMean,
+		AggregationMin, AggregationMax:
+		return true
+	default:
+		return false
 	}
 }
 
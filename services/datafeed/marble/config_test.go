@@ -4,8 +4,11 @@ package neofeeds
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	neokeys "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -373,3 +376,81 @@ func TestFeedDefaultSources(t *testing.T) {
 		t.Errorf("Feed should inherit default sources, got %d", len(cfg.Feeds[0].Sources))
 	}
 }
+
+func TestConfigValidateDerivedFeeds(t *testing.T) {
+	base := func(derived DerivedFeedConfig) *FeedsConfig {
+		return &FeedsConfig{
+			Sources: []SourceConfig{{ID: "test", URL: "http://example.com", JSONPath: "price"}},
+			Feeds: []FeedConfig{
+				{ID: "BTC-USD", Sources: []string{"test"}, Enabled: true},
+				{ID: "WBTC-USD", Sources: []string{"test"}, Enabled: true},
+			},
+			DerivedFeeds: []DerivedFeedConfig{derived},
+		}
+	}
+
+	cfg := base(DerivedFeedConfig{ID: "btc/usd/composite", Inputs: []string{"BTC/USD", "wbtc-usd"}})
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	derived := cfg.GetDerivedFeed("BTC-USD-COMPOSITE")
+	if derived == nil {
+		t.Fatal("GetDerivedFeed() returned nil")
+	}
+	if derived.Method != AggregationMedian || derived.StalePolicy != StalePolicyExclude || derived.Heartbeat != 5*time.Minute {
+		t.Errorf("defaults = %s/%s/%v", derived.Method, derived.StalePolicy, derived.Heartbeat)
+	}
+	if derived.Inputs[0] != "BTC-USD" {
+		t.Errorf("Inputs[0] = %s, want BTC-USD", derived.Inputs[0])
+	}
+
+	invalid := []DerivedFeedConfig{
+		{ID: "X", Inputs: []string{"ETH-USD"}},
+		{ID: "X", Inputs: []string{"BTC-USD"}, Method: "mode"},
+		{ID: "X", Inputs: []string{"BTC-USD"}, StalePolicy: "ignore"},
+		{ID: "BTC-USD", Inputs: []string{"WBTC-USD"}},
+		{ID: "X"},
+	}
+	for i, d := range invalid {
+		if err := base(d).Validate(); err == nil {
+			t.Errorf("case %d: Validate() expected error", i)
+		}
+	}
+}
+
+func TestConfigValidateSignerSet(t *testing.T) {
+	priv, err := neokeys.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	key := priv.PublicKey().StringCompressed()
+
+	base := func(feed FeedConfig) *FeedsConfig {
+		feed.ID = "BTC-USD"
+		feed.Sources = []string{"test"}
+		return &FeedsConfig{
+			Sources: []SourceConfig{{ID: "test", URL: "http://example.com", JSONPath: "price"}},
+			Feeds:   []FeedConfig{feed},
+		}
+	}
+
+	cfg := base(FeedConfig{SignerSet: []string{"0x" + strings.ToUpper(key)}})
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := cfg.Feeds[0]; got.SignerSet[0] != key || got.Threshold != 1 {
+		t.Errorf("signer set not normalized: %+v", got)
+	}
+
+	invalid := []FeedConfig{
+		{SignerSet: []string{key}, Threshold: 2},
+		{SignerSet: []string{key, key}},
+		{SignerSet: []string{"deadbeef"}},
+		{Threshold: 1},
+	}
+	for i, feed := range invalid {
+		if err := base(feed).Validate(); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
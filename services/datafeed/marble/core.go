@@ -7,6 +7,7 @@ import (
 	"crypto/elliptic"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"net/http"
 	"os"
@@ -30,7 +31,8 @@ import (
 // GetPrice fetches and aggregates price from multiple sources.
 //
 // Default behavior is to query the configured HTTP sources and aggregate via
-// (weighted) median. If Chainlink is configured, it is treated as an optional
+// the method in AggregationConfig (weighted median unless configured
+// otherwise). If Chainlink is configured, it is treated as an optional
 // additional source (it does not replace HTTP sources).
 func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, error) {
 	normalizedPair := normalizePair(pair)
@@ -48,8 +50,7 @@ func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, er
 		responsePair = feed.ID
 	}
 
-	var prices []float64
-	var sources []string
+	var samples []priceSample
 	decimals := 8
 	if feed != nil && feed.Decimals > 0 {
 		decimals = feed.Decimals
@@ -58,7 +59,14 @@ func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, er
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	sourcesToUse := s.getSourcesForFeed(feed)
+	now := time.Now()
+	configured := s.getSourcesForFeed(feed)
+	sourcesToUse := make([]*SourceConfig, 0, len(configured))
+	for _, src := range configured {
+		if s.sourceAvailable(feedID, src.ID, now) {
+			sourcesToUse = append(sourcesToUse, src)
+		}
+	}
 
 	for _, srcConfig := range sourcesToUse {
 		wg.Add(1)
@@ -67,14 +75,13 @@ func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, er
 
 			price, err := s.fetchPriceFromSource(ctx, normalizedPair, feed, src)
 			if err != nil {
+				s.recordSourceError(ctx, feedID, src.ID, err)
 				return
 			}
+			s.recordSourceSuccess(feedID, src.ID, time.Now())
 
 			mu.Lock()
-			for i := 0; i < src.Weight; i++ {
-				prices = append(prices, price)
-			}
-			sources = append(sources, src.ID)
+			samples = append(samples, priceSample{source: src.ID, value: price, weight: src.Weight})
 			mu.Unlock()
 		}(srcConfig)
 	}
@@ -91,28 +98,47 @@ func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, er
 			}
 
 			mu.Lock()
-			prices = append(prices, price)
-			sources = append(sources, "chainlink")
+			samples = append(samples, priceSample{source: "chainlink", value: price, weight: 1})
 			mu.Unlock()
 		}()
 	}
 
 	wg.Wait()
 
-	if len(prices) == 0 {
+	if len(samples) == 0 {
+		if len(configured) > 0 && len(sourcesToUse) == 0 {
+			return nil, fmt.Errorf("no prices available for %s: all sources unhealthy", normalizedPair)
+		}
 		return nil, fmt.Errorf("no prices available for %s", normalizedPair)
 	}
 
-	medianPrice := s.calculateMedian(prices)
-	priceInt := int64(medianPrice * float64(pow10(decimals)))
+	aggregated, err := s.aggregateFeed(samples)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate %s: %w", normalizedPair, err)
+	}
+	for _, sample := range aggregated.rejected {
+		s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+			"feed_id":   feedID,
+			"source_id": sample.source,
+			"price":     sample.value,
+		}).Debug("price source rejected as outlier")
+	}
+
+	sources := make([]string, 0, len(aggregated.kept))
+	for _, sample := range aggregated.kept {
+		sources = append(sources, sample.source)
+	}
+	priceInt := int64(aggregated.value * float64(pow10(decimals)))
 
 	response := &PriceResponse{
-		FeedID:    feedID,
-		Pair:      responsePair,
-		Price:     priceInt,
-		Decimals:  decimals,
-		Timestamp: time.Now(),
-		Sources:   sources,
+		FeedID:      feedID,
+		Pair:        responsePair,
+		Price:       priceInt,
+		Decimals:    decimals,
+		Timestamp:   time.Now(),
+		Sources:     sources,
+		SourceCount: len(sources),
+		Confidence:  aggregated.confidence(),
 	}
 
 	if len(s.signingKey) > 0 {
@@ -125,16 +151,7 @@ func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, er
 	}
 
 	if s.DB() != nil {
-		if err := s.DB().CreatePriceFeed(ctx, &database.PriceFeed{
-			ID:        uuid.New().String(),
-			FeedID:    feedID,
-			Pair:      responsePair,
-			Price:     priceInt,
-			Decimals:  response.Decimals,
-			Timestamp: response.Timestamp,
-			Sources:   response.Sources,
-			Signature: response.Signature,
-		}); err != nil {
+		if err := s.persistPrice(ctx, response); err != nil {
 			s.Logger().WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
 				"feed_id": feedID,
 				"pair":    pair,
@@ -145,6 +162,27 @@ func (s *Service) GetPrice(ctx context.Context, pair string) (*PriceResponse, er
 	return response, nil
 }
 
+// persistPrice records a price in the feed's history.
+func (s *Service) persistPrice(ctx context.Context, price *PriceResponse) error {
+	record := priceRecord(price)
+	return s.DB().CreatePriceFeed(ctx, &record)
+}
+
+// priceRecord converts a price into a feed history row.
+func priceRecord(price *PriceResponse) database.PriceFeed {
+	return database.PriceFeed{
+		ID:         uuid.New().String(),
+		FeedID:     price.FeedID,
+		Pair:       price.Pair,
+		Price:      price.Price,
+		Decimals:   price.Decimals,
+		Timestamp:  price.Timestamp,
+		Sources:    price.Sources,
+		Signature:  price.Signature,
+		Backfilled: price.Backfilled,
+	}
+}
+
 // findFeedByPair finds a feed config by pair or feed ID.
 func (s *Service) findFeedByPair(pair string) *FeedConfig {
 	query := normalizePair(pair)
@@ -187,8 +225,12 @@ func (s *Service) getSourcesForFeed(feed *FeedConfig) []*SourceConfig {
 
 // fetchPriceFromSource fetches price from a single source.
 func (s *Service) fetchPriceFromSource(ctx context.Context, pair string, feed *FeedConfig, src *SourceConfig) (float64, error) {
-	url := formatSourceURLNew(src.URL, pair, feed, src)
+	return s.fetchSourceValue(ctx, formatSourceURLNew(src.URL, pair, feed, src), src.JSONPath, feed, src)
+}
 
+// fetchSourceValue requests url with the source's headers, signing and
+// timeout and extracts the value at jsonPath.
+func (s *Service) fetchSourceValue(ctx context.Context, url, jsonPath string, feed *FeedConfig, src *SourceConfig) (float64, error) {
 	timeout := src.Timeout
 	if timeout <= 0 {
 		timeout = 10 * time.Second
@@ -205,6 +247,11 @@ func (s *Service) fetchPriceFromSource(ctx context.Context, pair string, feed *F
 	for k, v := range src.Headers {
 		req.Header.Set(k, resolveEnvVar(v))
 	}
+	if src.Signing != nil {
+		if err := signRequest(req, nil, src.Signing, time.Now()); err != nil {
+			return 0, fmt.Errorf("sign request: %w", err)
+		}
+	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
@@ -229,13 +276,11 @@ func (s *Service) fetchPriceFromSource(ctx context.Context, pair string, feed *F
 		return 0, err
 	}
 
-	jsonPath := formatJSONPath(src.JSONPath, feed, src)
-	result := gjson.GetBytes(body, jsonPath)
-	if !result.Exists() {
-		return 0, fmt.Errorf("price not found in response")
+	dataType := DataTypePrice
+	if feed != nil && feed.DataType != "" {
+		dataType = feed.DataType
 	}
-
-	return result.Float(), nil
+	return extractSourceValue(body, formatJSONPath(jsonPath, feed, src), dataType, src.Transform)
 }
 
 func (s *Service) fetchPrice(ctx context.Context, pair string, source PriceSource) (float64, error) {
@@ -280,7 +325,197 @@ func (s *Service) fetchPrice(ctx context.Context, pair string, source PriceSourc
 	return result.Float(), nil
 }
 
-func (s *Service) calculateMedian(prices []float64) float64 {
+// priceSample is a single source observation and its aggregation weight.
+type priceSample struct {
+	source string
+	value  float64
+	weight int
+}
+
+const (
+	// madScale converts the median absolute deviation into a standard
+	// deviation estimate for normally distributed quotes.
+	madScale = 1.4826
+	// minOutlierScale floors the deviation scale at a fraction of the median
+	// (5 bps), so that identical quotes (MAD 0) do not reject every source
+	// that differs by a tick.
+	minOutlierScale = 0.0005
+	// minOutlierSamples is the smallest set outlier rejection applies to; with
+	// two sources there is no majority to deviate from.
+	minOutlierSamples = 3
+)
+
+// rejectOutliers drops samples whose distance from the median exceeds k scaled
+// median absolute deviations. k <= 0 disables rejection.
+func rejectOutliers(samples []priceSample, k float64) (kept, rejected []priceSample) {
+	if k <= 0 || len(samples) < minOutlierSamples {
+		return samples, nil
+	}
+
+	values := make([]float64, len(samples))
+	for i := range samples {
+		values[i] = samples[i].value
+	}
+	mid := median(values)
+
+	deviations := make([]float64, len(samples))
+	for i := range samples {
+		deviations[i] = math.Abs(samples[i].value - mid)
+	}
+	scale := madScale * median(append([]float64(nil), deviations...))
+	if floor := math.Abs(mid) * minOutlierScale; scale < floor {
+		scale = floor
+	}
+	if scale == 0 {
+		return samples, nil
+	}
+
+	kept = make([]priceSample, 0, len(samples))
+	for i := range samples {
+		if deviations[i] > k*scale {
+			rejected = append(rejected, samples[i])
+			continue
+		}
+		kept = append(kept, samples[i])
+	}
+	return kept, rejected
+}
+
+// aggregationResult is a combined value and the sources that contributed.
+type aggregationResult struct {
+	value    float64
+	kept     []priceSample
+	rejected []priceSample
+}
+
+// confidence is the share of observing sources that agreed with the result.
+func (r aggregationResult) confidence() float64 {
+	total := len(r.kept) + len(r.rejected)
+	if total == 0 {
+		return 0
+	}
+	return float64(len(r.kept)) / float64(total)
+}
+
+// aggregateFeed rejects outliers and combines the remaining observations
+// using the configured method. It fails when fewer than MinSources remain.
+func (s *Service) aggregateFeed(samples []priceSample) (aggregationResult, error) {
+	cfg := AggregationConfig{Method: AggregationMedian, TrimPercent: defaultTrimPercent, MinSources: 1}
+	if s.config != nil {
+		cfg = s.config.Aggregation
+	}
+
+	kept, rejected := rejectOutliers(samples, cfg.OutlierMAD)
+	if len(kept) == 0 || len(kept) < cfg.MinSources {
+		return aggregationResult{}, fmt.Errorf("%d of %d sources usable after outlier rejection, need %d",
+			len(kept), len(samples), max(cfg.MinSources, 1))
+	}
+
+	return aggregationResult{
+		value:    aggregateSamples(kept, cfg.Method, cfg.TrimPercent),
+		kept:     kept,
+		rejected: rejected,
+	}, nil
+}
+
+// aggregateSamples combines observations with the given method. Unknown
+// methods use the weighted median.
+func aggregateSamples(samples []priceSample, method string, trimPercent int) float64 {
+	switch method {
+	case AggregationMean:
+		return calculateMean(samples)
+	case AggregationWeighted:
+		return calculateWeightedMean(samples)
+	case AggregationMin, AggregationMax:
+		return calculateExtreme(samples, method == AggregationMax)
+	case AggregationTrimmedMean:
+		return calculateTrimmedMean(expandSamples(samples), trimPercent)
+	default:
+		return median(expandSamples(samples))
+	}
+}
+
+// expandSamples repeats each observation by its weight so that median and
+// trimmed mean honour source weights.
+func expandSamples(samples []priceSample) []float64 {
+	var prices []float64
+	for _, sample := range samples {
+		weight := sample.weight
+		if weight <= 0 {
+			weight = 1
+		}
+		for i := 0; i < weight; i++ {
+			prices = append(prices, sample.value)
+		}
+	}
+	return prices
+}
+
+func calculateMean(samples []priceSample) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, sample := range samples {
+		sum += sample.value
+	}
+	return sum / float64(len(samples))
+}
+
+// calculateExtreme returns the lowest observation, or the highest if highest.
+func calculateExtreme(samples []priceSample, highest bool) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	result := samples[0].value
+	for _, sample := range samples[1:] {
+		if (highest && sample.value > result) || (!highest && sample.value < result) {
+			result = sample.value
+		}
+	}
+	return result
+}
+
+func calculateWeightedMean(samples []priceSample) float64 {
+	var sum float64
+	var total int
+	for _, sample := range samples {
+		weight := sample.weight
+		if weight <= 0 {
+			weight = 1
+		}
+		sum += sample.value * float64(weight)
+		total += weight
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / float64(total)
+}
+
+// calculateTrimmedMean drops trimPercent of observations from each end before
+// averaging. Sets too small to trim degrade to a plain mean.
+func calculateTrimmedMean(prices []float64, trimPercent int) float64 {
+	n := len(prices)
+	if n == 0 {
+		return 0
+	}
+	sort.Float64s(prices)
+
+	trim := n * trimPercent / 100
+	if 2*trim >= n {
+		trim = 0
+	}
+
+	var sum float64
+	for _, p := range prices[trim : n-trim] {
+		sum += p
+	}
+	return sum / float64(n-2*trim)
+}
+
+// median sorts prices in place and returns the middle value.
+func median(prices []float64) float64 {
 	sort.Float64s(prices)
 	n := len(prices)
 	if n%2 == 0 {
@@ -436,3 +671,41 @@ func resolveEnvVar(value string) string {
 	}
 	return value
 }
+
+// GetHistory returns stored values for a feed with from <= timestamp < to,
+// newest first. Page backwards by passing the oldest returned timestamp as the
+// next `to`. A range with no data returns an empty slice.
+func (s *Service) GetHistory(ctx context.Context, feedID string, from, to time.Time, limit int) ([]PriceResponse, error) {
+	if s.DB() == nil {
+		return nil, fmt.Errorf("price history unavailable: database not configured")
+	}
+
+	id := normalizePair(feedID)
+	if feed := s.findFeedByPair(id); feed != nil {
+		id = feed.ID
+	}
+	if id == "" {
+		return nil, fmt.Errorf("feed id required")
+	}
+
+	rows, err := s.DB().GetPriceHistory(ctx, id, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get price history: %w", err)
+	}
+
+	history := make([]PriceResponse, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		history = append(history, PriceResponse{
+			FeedID:     row.FeedID,
+			Pair:       row.Pair,
+			Price:      row.Price,
+			Decimals:   row.Decimals,
+			Timestamp:  row.Timestamp,
+			Sources:    row.Sources,
+			Signature:  row.Signature,
+			Backfilled: row.Backfilled,
+		})
+	}
+	return history, nil
+}
@@ -0,0 +1,147 @@
+// Package neofeeds provides derived (composite) feeds for the price feed aggregation service.
+package neofeeds
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ComputeDerivedValue combines input feed values into one composite value
+// using method (median, weighted or trimmed_mean). Inputs with different
+// decimals are rescaled to the highest precision among them. The composite
+// timestamp is that of the oldest input, since the result is only as fresh
+// as its stalest component.
+func ComputeDerivedValue(inputs []PriceResponse, method string) (*PriceResponse, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no input values")
+	}
+	if !isKnownAggregation(method) {
+		return nil, fmt.Errorf("unknown aggregation method %q", method)
+	}
+
+	decimals := 0
+	for i := range inputs {
+		if inputs[i].Decimals > decimals {
+			decimals = inputs[i].Decimals
+		}
+	}
+
+	samples := make([]priceSample, 0, len(inputs))
+	sources := make([]string, 0, len(inputs))
+	oldest := inputs[0].Timestamp
+	for i := range inputs {
+		in := &inputs[i]
+		if in.Price <= 0 {
+			return nil, fmt.Errorf("input %s: non-positive price", in.FeedID)
+		}
+		samples = append(samples, priceSample{
+			value:  float64(in.Price) / float64(pow10(in.Decimals)),
+			weight: 1,
+		})
+		sources = append(sources, in.FeedID)
+		if in.Timestamp.Before(oldest) {
+			oldest = in.Timestamp
+		}
+	}
+
+	value := aggregateSamples(samples, method, defaultTrimPercent)
+	return &PriceResponse{
+		Price:     int64(math.Round(value * float64(pow10(decimals)))),
+		Decimals:  decimals,
+		Timestamp: oldest,
+		Sources:   sources,
+	}, nil
+}
+
+// Compute applies the feed's stale policy to inputs as of now and returns the
+// composite value. Inputs older than Heartbeat are dropped under the exclude
+// policy, or fail the computation under the error policy.
+func (d *DerivedFeedConfig) Compute(inputs []PriceResponse, now time.Time) (*PriceResponse, error) {
+	fresh := make([]PriceResponse, 0, len(inputs))
+	for i := range inputs {
+		if now.Sub(inputs[i].Timestamp) > d.Heartbeat {
+			if d.StalePolicy == StalePolicyError {
+				return nil, fmt.Errorf("derived feed %s: input %s is stale", d.ID, inputs[i].FeedID)
+			}
+			continue
+		}
+		fresh = append(fresh, inputs[i])
+	}
+
+	minInputs := d.MinInputs
+	if minInputs <= 0 {
+		minInputs = 1
+	}
+	if len(fresh) < minInputs {
+		return nil, fmt.Errorf("derived feed %s: no prices available (%d fresh inputs, need %d)", d.ID, len(fresh), minInputs)
+	}
+
+	value, err := ComputeDerivedValue(fresh, d.Method)
+	if err != nil {
+		return nil, fmt.Errorf("derived feed %s: %w", d.ID, err)
+	}
+	value.FeedID = d.ID
+	value.Pair = d.ID
+	return value, nil
+}
+
+// GetDerivedPrice computes a derived feed from the latest stored value of
+// each input feed. Without a database, inputs are fetched live. An input that
+// cannot be loaded is dropped under the exclude stale policy and fails the
+// computation under the error policy, like a stale one.
+func (s *Service) GetDerivedPrice(ctx context.Context, id string) (*PriceResponse, error) {
+	derived := s.config.GetDerivedFeed(id)
+	if derived == nil {
+		return nil, fmt.Errorf("unknown feed: %s", id)
+	}
+
+	inputs := make([]PriceResponse, 0, len(derived.Inputs))
+	for _, feedID := range derived.Inputs {
+		input, err := s.derivedInput(ctx, feedID)
+		if err != nil {
+			if derived.StalePolicy == StalePolicyError {
+				return nil, fmt.Errorf("derived feed %s: input %s unavailable: %w", derived.ID, feedID, err)
+			}
+			continue
+		}
+		inputs = append(inputs, *input)
+	}
+
+	response, err := derived.Compute(inputs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.signingKey) > 0 {
+		sig, pub, err := s.signPrice(response)
+		if err != nil {
+			return nil, fmt.Errorf("sign price: %w", err)
+		}
+		response.Signature = append([]byte{}, sig...)
+		response.PublicKey = append([]byte{}, pub...)
+	}
+
+	return response, nil
+}
+
+// derivedInput loads the latest value of an input feed.
+func (s *Service) derivedInput(ctx context.Context, feedID string) (*PriceResponse, error) {
+	if s.DB() == nil {
+		return s.GetPrice(ctx, feedID)
+	}
+
+	latest, err := s.DB().GetLatestPrice(ctx, feedID)
+	if err != nil {
+		return nil, err
+	}
+	return &PriceResponse{
+		FeedID:    latest.FeedID,
+		Pair:      latest.Pair,
+		Price:     latest.Price,
+		Decimals:  latest.Decimals,
+		Timestamp: latest.Timestamp,
+		Sources:   latest.Sources,
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Package neofeeds provides source response extraction for the price feed aggregation service.
+package neofeeds
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// TransformConfig normalizes a value extracted from a source response before
+// aggregation, e.g. to convert units. String values are trimmed first; the
+// multiplier is applied after numeric coercion.
+type TransformConfig struct {
+	// Trim lists characters stripped from both ends of string values, in
+	// addition to whitespace (e.g. "$" or "%").
+	Trim string `json:"trim,omitempty" yaml:"trim,omitempty"`
+	// Multiply scales the numeric value (e.g. 0.01 for cents to dollars).
+	// Zero means no scaling.
+	Multiply float64 `json:"multiply,omitempty" yaml:"multiply,omitempty"`
+}
+
+// extractSourceValue evaluates path against body and coerces the result for
+// dataType. Numeric values may be JSON numbers or numeric strings. The
+// returned value is unscaled; GetPrice applies the feed's Decimals after
+// aggregation.
+func extractSourceValue(body []byte, path string, dataType DataType, transform *TransformConfig) (float64, error) {
+	if !gjson.ValidBytes(body) {
+		return 0, fmt.Errorf("response is not valid JSON")
+	}
+	result := gjson.GetBytes(body, path)
+	if !result.Exists() {
+		return 0, fmt.Errorf("json path %q not found in response", path)
+	}
+
+	if dataType == DataTypeString {
+		return 0, fmt.Errorf("string feeds cannot be aggregated")
+	}
+
+	var value float64
+	switch result.Type {
+	case gjson.Number:
+		value = result.Num
+	case gjson.String:
+		raw := strings.TrimSpace(result.Str)
+		if transform != nil && transform.Trim != "" {
+			raw = strings.TrimSpace(strings.Trim(raw, transform.Trim))
+		}
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return 0, fmt.Errorf("json path %q: value %q is not numeric", path, result.Str)
+		}
+		value = parsed
+	default:
+		return 0, fmt.Errorf("json path %q: expected number, got %s", path, result.Type)
+	}
+
+	if transform != nil && transform.Multiply != 0 {
+		value *= transform.Multiply
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("json path %q: value is not finite", path)
+	}
+	if dataType == DataTypePrice && value <= 0 {
+		return 0, fmt.Errorf("json path %q: price must be positive, got %v", path, value)
+	}
+	return value, nil
+}
+
+// feedErrorState counts fetch/extraction failures for a feed.
+type feedErrorState struct {
+	count     int64
+	lastError string
+	lastAt    time.Time
+}
+
+// recordSourceError records a failed fetch or extraction from source for
+// feedID. Failures are expected while a source is flaky, so they are logged at
+// debug level and surfaced through /info and the debug state endpoint; a
+// warning is logged when the failures open the source's circuit.
+func (s *Service) recordSourceError(ctx context.Context, feedID, sourceID string, err error) {
+	msg := fmt.Sprintf("%s: %v", sourceID, err)
+	now := time.Now()
+
+	s.errorsMu.Lock()
+	state := s.feedErrors[feedID]
+	if state == nil {
+		state = &feedErrorState{}
+		s.feedErrors[feedID] = state
+	}
+	state.count++
+	state.lastError = msg
+	state.lastAt = now
+	opened := s.recordSourceFailureLocked(feedID, sourceID, err.Error(), now)
+	s.errorsMu.Unlock()
+
+	logger := s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+		"feed_id":   feedID,
+		"source_id": sourceID,
+		"error":     err.Error(),
+	})
+	if opened {
+		logger.Warn("price source unhealthy; skipping until cooldown ends")
+		return
+	}
+	logger.Debug("price source fetch failed")
+}
+
+// feedErrorCounts returns the failure count per feed.
+func (s *Service) feedErrorCounts() map[string]int64 {
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+
+	counts := make(map[string]int64, len(s.feedErrors))
+	for feedID, state := range s.feedErrors {
+		counts[feedID] = state.count
+	}
+	return counts
+}
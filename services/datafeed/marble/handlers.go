@@ -2,11 +2,14 @@
 package neofeeds
 
 import (
+	"errors"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/R3E-Network/service_layer/infrastructure/database"
 	"github.com/R3E-Network/service_layer/infrastructure/httputil"
 )
 
@@ -44,14 +47,21 @@ func (s *Service) handleGetPrice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	price, err := s.GetPrice(r.Context(), pair)
+	var price *PriceResponse
+	var err error
+	if s.config != nil && s.config.GetDerivedFeed(pair) != nil {
+		price, err = s.GetDerivedPrice(r.Context(), pair)
+	} else {
+		price, err = s.GetPrice(r.Context(), pair)
+	}
 	if err != nil {
 		// Distinguish error types for appropriate HTTP status codes
 		errMsg := err.Error()
 		switch {
 		case contains(errMsg, "not found"), contains(errMsg, "unsupported"), contains(errMsg, "unknown feed"):
 			httputil.NotFound(w, errMsg)
-		case contains(errMsg, "no sources"), contains(errMsg, "no prices"):
+		case contains(errMsg, "no sources"), contains(errMsg, "no prices"), contains(errMsg, "stale"),
+			contains(errMsg, "sources usable"):
 			httputil.WriteJSON(w, http.StatusServiceUnavailable, map[string]string{"error": errMsg})
 		default:
 			httputil.InternalError(w, errMsg)
@@ -102,6 +112,47 @@ func (s *Service) handleGetPrices(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, responses)
 }
 
+// handleGetHistory returns stored values for a feed over a time range.
+// Query: from, to (RFC3339; default last 24h) and limit (default 100, max 1000).
+func (s *Service) handleGetHistory(w http.ResponseWriter, r *http.Request) {
+	pair := mux.Vars(r)["pair"]
+	if pair == "" {
+		httputil.BadRequest(w, "pair required")
+		return
+	}
+
+	to := time.Now()
+	if raw := httputil.QueryString(r, "to", ""); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httputil.BadRequest(w, "invalid to: must be RFC3339")
+			return
+		}
+		to = parsed
+	}
+	from := to.Add(-24 * time.Hour)
+	if raw := httputil.QueryString(r, "from", ""); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httputil.BadRequest(w, "invalid from: must be RFC3339")
+			return
+		}
+		from = parsed
+	}
+	if !to.After(from) {
+		httputil.BadRequest(w, "to must be after from")
+		return
+	}
+
+	history, err := s.GetHistory(r.Context(), pair, from, to, httputil.QueryInt(r, "limit", 100))
+	if err != nil {
+		httputil.WriteJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, history)
+}
+
 func (s *Service) handleListFeeds(w http.ResponseWriter, r *http.Request) {
 	// Return configured feeds, not sources
 	enabledFeeds := s.GetEnabledFeeds()
@@ -118,7 +169,172 @@ func (s *Service) handleListFeeds(w http.ResponseWriter, r *http.Request) {
 			SourcePair: sourcePair,
 			Enabled:    feed.Enabled,
 			Decimals:   feed.Decimals,
+			Status:     feedStatus(s.FeedSourceHealth(feed)),
 		})
 	}
 	httputil.WriteJSON(w, http.StatusOK, feeds)
 }
+
+func (s *Service) handleGetFeedSources(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	feed := s.findFeedByPair(id)
+	if feed == nil {
+		httputil.NotFound(w, "unknown feed: "+id)
+		return
+	}
+
+	sources := s.FeedSourceHealth(feed)
+	httputil.WriteJSON(w, http.StatusOK, FeedSourcesResponse{
+		FeedID:  feed.ID,
+		Status:  feedStatus(sources),
+		Sources: sources,
+	})
+}
+
+// handleBackfill starts a background job that fetches and records historical
+// values for a feed. The range is validated before the job starts.
+func (s *Service) handleBackfill(w http.ResponseWriter, r *http.Request) {
+	if !httputil.RequireAdminRole(w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	feed := s.findFeedByPair(id)
+	if feed == nil {
+		httputil.NotFound(w, "unknown feed: "+id)
+		return
+	}
+
+	var req BackfillRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+	step, err := time.ParseDuration(req.Step)
+	if err != nil {
+		httputil.BadRequest(w, "invalid step: must be a duration such as 1h")
+		return
+	}
+
+	if _, err := s.backfillSources(feed, req.From, req.To, step); err != nil {
+		switch {
+		case errors.Is(err, ErrHistoryUnsupported):
+			httputil.WriteErrorResponse(w, r, http.StatusUnprocessableEntity, "HISTORY_UNSUPPORTED", err.Error(), nil)
+		default:
+			httputil.BadRequest(w, err.Error())
+		}
+		return
+	}
+
+	job, ok := s.startBackfill(feed, req.From, req.To, step)
+	if !ok {
+		httputil.WriteErrorResponse(w, r, http.StatusConflict, "BACKFILL_RUNNING", "a backfill is already running for "+feed.ID, nil)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusAccepted, job)
+}
+
+// handleGetBackfill reports the feed's latest backfill job.
+func (s *Service) handleGetBackfill(w http.ResponseWriter, r *http.Request) {
+	if !httputil.RequireAdminRole(w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	feed := s.findFeedByPair(id)
+	if feed == nil {
+		httputil.NotFound(w, "unknown feed: "+id)
+		return
+	}
+	job, ok := s.backfillJob(feed.ID)
+	if !ok {
+		httputil.NotFound(w, "no backfill for "+feed.ID)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, job)
+}
+
+// handleGetSigners reports a feed's current signer set.
+func (s *Service) handleGetSigners(w http.ResponseWriter, r *http.Request) {
+	feed, ok := s.requireAdminFeed(w, r)
+	if !ok {
+		return
+	}
+	set, err := s.FeedSignerSet(feed.ID)
+	if err != nil {
+		httputil.NotFound(w, err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, set)
+}
+
+// handleAddSigner adds an oracle node key to a feed's signer set.
+func (s *Service) handleAddSigner(w http.ResponseWriter, r *http.Request) {
+	feed, ok := s.requireAdminFeed(w, r)
+	if !ok {
+		return
+	}
+	var req AddSignerRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+	s.writeSignerSetUpdate(w, feed.ID, s.AddSigner(r.Context(), feed.ID, req.Signer))
+}
+
+// handleRemoveSigner removes an oracle node key from a feed's signer set.
+func (s *Service) handleRemoveSigner(w http.ResponseWriter, r *http.Request) {
+	feed, ok := s.requireAdminFeed(w, r)
+	if !ok {
+		return
+	}
+	s.writeSignerSetUpdate(w, feed.ID, s.RemoveSigner(r.Context(), feed.ID, mux.Vars(r)["signer"]))
+}
+
+// handleSetThreshold sets the number of signatures a feed requires.
+func (s *Service) handleSetThreshold(w http.ResponseWriter, r *http.Request) {
+	feed, ok := s.requireAdminFeed(w, r)
+	if !ok {
+		return
+	}
+	var req SetThresholdRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+	s.writeSignerSetUpdate(w, feed.ID, s.SetThreshold(r.Context(), feed.ID, req.Threshold))
+}
+
+// requireAdminFeed checks the admin role and resolves the {id} feed.
+func (s *Service) requireAdminFeed(w http.ResponseWriter, r *http.Request) (*FeedConfig, bool) {
+	if !httputil.RequireAdminRole(w, r) {
+		return nil, false
+	}
+	id := mux.Vars(r)["id"]
+	feed := s.findFeedByPair(id)
+	if feed == nil {
+		httputil.NotFound(w, "unknown feed: "+id)
+		return nil, false
+	}
+	return feed, true
+}
+
+// writeSignerSetUpdate reports the outcome of a signer set change: the new
+// set, a 400 for a rejected change, a 409 if another instance changed the set
+// first or a 500 if it could not be persisted.
+func (s *Service) writeSignerSetUpdate(w http.ResponseWriter, feedID string, err error) {
+	switch {
+	case database.IsConflict(err):
+		httputil.Conflict(w, err.Error())
+		return
+	case errors.Is(err, database.ErrDatabaseError):
+		httputil.InternalError(w, err.Error())
+		return
+	case err != nil:
+		httputil.BadRequest(w, err.Error())
+		return
+	}
+	set, err := s.FeedSignerSet(feedID)
+	if err != nil {
+		httputil.NotFound(w, err.Error())
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, set)
+}
@@ -0,0 +1,202 @@
+// Package neofeeds provides per-source health tracking for the price feed aggregation service.
+package neofeeds
+
+import "time"
+
+// Circuit breaker states for a feed's source.
+const (
+	CircuitClosed   = "closed"    // healthy; fetched every round
+	CircuitOpen     = "open"      // skipped until the cooldown ends
+	CircuitHalfOpen = "half_open" // cooldown over; the next fetch decides
+)
+
+// Feed statuses derived from source health.
+const (
+	FeedStatusActive = "active"
+	FeedStatusError  = "error" // every source's circuit is open
+)
+
+const (
+	defaultSourceFailureThreshold = 5
+	defaultSourceCooldown         = time.Minute
+)
+
+// SourceHealthConfig controls when a failing source is skipped.
+type SourceHealthConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens a
+	// source's circuit for a feed. Default: 5.
+	FailureThreshold int `json:"failure_threshold,omitempty" yaml:"failure_threshold,omitempty"`
+	// Cooldown is how long an open circuit skips the source before it is
+	// tried again. Default: 1m.
+	Cooldown time.Duration `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
+}
+
+// applyDefaults fills unset fields with their defaults.
+func (c *SourceHealthConfig) applyDefaults() {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaultSourceFailureThreshold
+	}
+	if c.Cooldown <= 0 {
+		c.Cooldown = defaultSourceCooldown
+	}
+}
+
+// SourceHealth is the health of one source for one feed.
+type SourceHealth struct {
+	SourceID            string     `json:"source_id"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	OpenUntil           *time.Time `json:"open_until,omitempty"`
+}
+
+// FeedSourcesResponse is returned by GET /feeds/{id}/sources.
+type FeedSourcesResponse struct {
+	FeedID  string         `json:"feed_id"`
+	Status  string         `json:"status"`
+	Sources []SourceHealth `json:"sources"`
+}
+
+type sourceKey struct {
+	feedID   string
+	sourceID string
+}
+
+// sourceHealthState is the mutable circuit state behind SourceHealth.
+type sourceHealthState struct {
+	failures    int
+	lastSuccess time.Time
+	lastError   string
+	openUntil   time.Time
+}
+
+// state returns the circuit state as of now.
+func (h *sourceHealthState) state(now time.Time) string {
+	switch {
+	case h.openUntil.IsZero():
+		return CircuitClosed
+	case now.Before(h.openUntil):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+func (s *Service) sourceHealthConfig() SourceHealthConfig {
+	var cfg SourceHealthConfig
+	if s.config != nil {
+		cfg = s.config.SourceHealth
+	}
+	cfg.applyDefaults()
+	return cfg
+}
+
+// sourceAvailable reports whether sourceID should be fetched for feedID.
+// Sources with an open circuit are skipped until their cooldown ends.
+func (s *Service) sourceAvailable(feedID, sourceID string, now time.Time) bool {
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+
+	h := s.sourceHealth[sourceKey{feedID, sourceID}]
+	return h == nil || h.state(now) != CircuitOpen
+}
+
+// recordSourceSuccess closes the source's circuit for feedID.
+func (s *Service) recordSourceSuccess(feedID, sourceID string, now time.Time) {
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+
+	key := sourceKey{feedID, sourceID}
+	h := s.sourceHealth[key]
+	if h == nil {
+		h = &sourceHealthState{}
+		s.sourceHealth[key] = h
+	}
+	h.failures = 0
+	h.openUntil = time.Time{}
+	h.lastSuccess = now
+}
+
+// recordSourceFailureLocked counts a failure and opens the circuit once the
+// threshold is reached, or again when a half-open probe fails. It reports
+// whether the circuit opened. Callers must hold errorsMu.
+func (s *Service) recordSourceFailureLocked(feedID, sourceID, msg string, now time.Time) bool {
+	cfg := s.sourceHealthConfig()
+
+	key := sourceKey{feedID, sourceID}
+	h := s.sourceHealth[key]
+	if h == nil {
+		h = &sourceHealthState{}
+		s.sourceHealth[key] = h
+	}
+	probeFailed := h.state(now) == CircuitHalfOpen
+	h.failures++
+	h.lastError = msg
+
+	if probeFailed || h.failures >= cfg.FailureThreshold {
+		h.openUntil = now.Add(cfg.Cooldown)
+		return true
+	}
+	return false
+}
+
+// FeedSourceHealth returns the health of each of the feed's sources, in
+// configured order.
+func (s *Service) FeedSourceHealth(feed *FeedConfig) []SourceHealth {
+	now := time.Now()
+	sources := s.getSourcesForFeed(feed)
+
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+
+	out := make([]SourceHealth, 0, len(sources))
+	for _, src := range sources {
+		entry := SourceHealth{SourceID: src.ID, State: CircuitClosed}
+		if h := s.sourceHealth[sourceKey{feed.ID, src.ID}]; h != nil {
+			entry.State = h.state(now)
+			entry.ConsecutiveFailures = h.failures
+			entry.LastError = h.lastError
+			if !h.lastSuccess.IsZero() {
+				lastSuccess := h.lastSuccess
+				entry.LastSuccess = &lastSuccess
+			}
+			if entry.State == CircuitOpen {
+				openUntil := h.openUntil
+				entry.OpenUntil = &openUntil
+			}
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// feedStatus is FeedStatusError when every source of the feed has an open
+// circuit, and FeedStatusActive otherwise.
+func feedStatus(sources []SourceHealth) string {
+	if len(sources) == 0 {
+		return FeedStatusError
+	}
+	for i := range sources {
+		if sources[i].State != CircuitOpen {
+			return FeedStatusActive
+		}
+	}
+	return FeedStatusError
+}
+
+// openCircuitCount returns the number of feed/source pairs currently skipped.
+func (s *Service) openCircuitCount() int {
+	now := time.Now()
+
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+
+	open := 0
+	for _, h := range s.sourceHealth {
+		if h.state(now) == CircuitOpen {
+			open++
+		}
+	}
+	return open
+}
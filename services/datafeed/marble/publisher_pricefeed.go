@@ -131,8 +131,9 @@ func (s *Service) pushPricesToPriceFeed(ctx context.Context) {
 
 func (s *Service) tryPublishPrice(ctx context.Context, symbol string, newPrice int64, timestamp uint64, sourceSetID *big.Int) {
 	now := time.Now()
-	thresholdBps := int64(s.publishPolicy.ThresholdBps)
-	hysteresisBps := int64(s.publishPolicy.HysteresisBps)
+	policy := s.PublishPolicy()
+	thresholdBps := int64(policy.ThresholdBps)
+	hysteresisBps := int64(policy.HysteresisBps)
 
 	if thresholdBps <= 0 {
 		thresholdBps = 10
@@ -141,12 +142,12 @@ func (s *Service) tryPublishPrice(ctx context.Context, symbol string, newPrice i
 		hysteresisBps = 8
 	}
 
-	minInterval := s.publishPolicy.MinInterval
+	minInterval := policy.MinInterval
 	if minInterval <= 0 {
 		minInterval = 5 * time.Second
 	}
 
-	maxPerMinute := s.publishPolicy.MaxPerMinute
+	maxPerMinute := policy.MaxPerMinute
 	if maxPerMinute <= 0 {
 		maxPerMinute = 30
 	}
@@ -184,24 +185,29 @@ func (s *Service) tryPublishPrice(ctx context.Context, symbol string, newPrice i
 	lastAt = state.lastPublishedAt
 
 	change := changeBps(lastPrice, newPrice)
+	reason := shouldPush(change, thresholdBps, lastAt, now, policy.Heartbeat)
 
-	// Two-step publish confirmation:
+	// Heartbeat publishes go out immediately. Deviation publishes use a
+	// two-step confirmation:
 	// - first observation must cross threshold (0.1% default)
 	// - second observation must stay beyond hysteresis (0.08% default)
-	if state.pending == nil {
-		if change < thresholdBps {
+	if reason != pushReasonHeartbeat {
+		if state.pending == nil {
+			if reason == "" {
+				s.publishMu.Unlock()
+				return
+			}
+			state.pending = &pendingPublish{startedAt: now}
 			s.publishMu.Unlock()
 			return
 		}
-		state.pending = &pendingPublish{startedAt: now}
-		s.publishMu.Unlock()
-		return
-	}
 
-	if change < hysteresisBps {
-		state.pending = nil
-		s.publishMu.Unlock()
-		return
+		if change < hysteresisBps {
+			state.pending = nil
+			s.publishMu.Unlock()
+			return
+		}
+		reason = pushReasonDeviation
 	}
 
 	// Confirm publish.
@@ -261,6 +267,33 @@ func (s *Service) tryPublishPrice(ctx context.Context, symbol string, newPrice i
 	state.lastPublishedAt = now
 	state.publishTimes = append(state.publishTimes, now)
 	s.publishMu.Unlock()
+
+	if reason == pushReasonHeartbeat {
+		s.pushesOnHeartbeat.Add(1)
+	} else {
+		s.pushesOnDeviation.Add(1)
+	}
+}
+
+// Reasons a price is pushed on-chain.
+const (
+	pushReasonDeviation = "deviation"
+	pushReasonHeartbeat = "heartbeat"
+)
+
+// shouldPush reports why a price with change bps of deviation from the last
+// published value should be pushed, or "" if it should not. A heartbeat is
+// due once heartbeat has elapsed since lastAt; otherwise the change must
+// reach thresholdBps (a change exactly equal to the threshold qualifies).
+// A zero heartbeat or unknown lastAt disables heartbeat pushes.
+func shouldPush(change, thresholdBps int64, lastAt, now time.Time, heartbeat time.Duration) string {
+	if heartbeat > 0 && !lastAt.IsZero() && now.Sub(lastAt) >= heartbeat {
+		return pushReasonHeartbeat
+	}
+	if change >= thresholdBps {
+		return pushReasonDeviation
+	}
+	return ""
 }
 
 func (s *Service) resyncRoundID(ctx context.Context, symbol string) bool {
@@ -343,15 +376,84 @@ func sourceSetIDFromSources(sources []string) *big.Int {
 	return new(big.Int).SetUint64(u)
 }
 
+// PublishPolicy returns the current on-chain publish policy.
+func (s *Service) PublishPolicy() PublishPolicyConfig {
+	s.policyMu.RLock()
+	defer s.policyMu.RUnlock()
+	return s.publishPolicy
+}
+
+// SetPublishPolicy merges update over the on-chain publish policy: zero
+// fields keep their current value and a negative Heartbeat disables the
+// heartbeat. The change applies from the next push tick.
+func (s *Service) SetPublishPolicy(update PublishPolicyConfig) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+
+	policy := s.publishPolicy
+	if update.ThresholdBps != 0 {
+		policy.ThresholdBps = update.ThresholdBps
+	}
+	if update.HysteresisBps != 0 {
+		policy.HysteresisBps = update.HysteresisBps
+	}
+	if update.MinInterval != 0 {
+		policy.MinInterval = update.MinInterval
+	}
+	if update.MaxPerMinute != 0 {
+		policy.MaxPerMinute = update.MaxPerMinute
+	}
+	if update.Heartbeat != 0 {
+		policy.Heartbeat = update.Heartbeat
+	}
+	policy.applyDefaults()
+	s.publishPolicy = policy
+}
+
 func (s *Service) publishPolicySummary() map[string]any {
 	if s == nil {
 		return map[string]any{}
 	}
+	policy := s.PublishPolicy()
 	return map[string]any{
-		"threshold_bps":    s.publishPolicy.ThresholdBps,
-		"hysteresis_bps":   s.publishPolicy.HysteresisBps,
-		"min_interval":     s.publishPolicy.MinInterval.String(),
-		"max_per_minute":   s.publishPolicy.MaxPerMinute,
+		"threshold_bps":    policy.ThresholdBps,
+		"hysteresis_bps":   policy.HysteresisBps,
+		"min_interval":     policy.MinInterval.String(),
+		"max_per_minute":   policy.MaxPerMinute,
+		"heartbeat":        policy.Heartbeat.String(),
 		"attestation_hash": fmt.Sprintf("%x", s.attestationHash),
 	}
 }
+
+// debugState reports in-memory state for the internal debug endpoint: feeds
+// with tracked publish state, publishes still in flight and source failures.
+func (s *Service) debugState() map[string]any {
+	now := time.Now()
+
+	s.publishMu.Lock()
+	tracked := len(s.publishState)
+	inFlight := make(map[string]any)
+	for feedID, state := range s.publishState {
+		if state != nil && state.pending != nil {
+			inFlight[feedID] = now.Sub(state.pending.startedAt).String()
+		}
+	}
+	s.publishMu.Unlock()
+
+	s.errorsMu.Lock()
+	feedErrors := make(map[string]any, len(s.feedErrors))
+	for feedID, errState := range s.feedErrors {
+		feedErrors[feedID] = map[string]any{
+			"count":      errState.count,
+			"last_error": errState.lastError,
+			"last_at":    errState.lastAt.Format(time.RFC3339),
+		}
+	}
+	s.errorsMu.Unlock()
+
+	return map[string]any{
+		"tracked_feeds":      tracked,
+		"publishes_inflight": inFlight,
+		"feed_errors":        feedErrors,
+	}
+}
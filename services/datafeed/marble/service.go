@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/R3E-Network/service_layer/infrastructure/chain"
@@ -53,12 +54,28 @@ type Service struct {
 	priceFeed       *chain.PriceFeedContract
 	txProxy         txproxytypes.Invoker
 	attestationHash []byte
+	policyMu        sync.RWMutex
 	publishPolicy   PublishPolicyConfig
 	publishMu       sync.Mutex
 	publishState    map[string]*pricePublishState
 	updateInterval  time.Duration
 	enableChainPush bool
 
+	// On-chain push counters by reason
+	pushesOnDeviation atomic.Int64
+	pushesOnHeartbeat atomic.Int64
+
+	// Signer sets, source failures and source health per feed
+	signersMu    sync.RWMutex
+	signerSets   map[string]SignerSet
+	errorsMu     sync.Mutex
+	feedErrors   map[string]*feedErrorState
+	sourceHealth map[sourceKey]*sourceHealthState
+
+	// Latest backfill job per feed
+	backfillMu   sync.Mutex
+	backfillJobs map[string]*BackfillJob
+
 	// Service fee deduction
 	gasbank *gasbankclient.Client
 }
@@ -70,6 +87,7 @@ type Config struct {
 	ConfigFile  string          // Path to YAML/JSON config file (optional)
 	FeedsConfig *NeoFeedsConfig // Direct config (optional, takes precedence over file)
 	ArbitrumRPC string          // Arbitrum RPC URL for Chainlink feeds
+	Aggregation string          // Aggregation method override (median, weighted, trimmed_mean)
 
 	// Chain configuration for push pattern
 	ChainClient     *chain.Client
@@ -121,6 +139,10 @@ func New(cfg Config) (*Service, error) {
 		feedsConfig = DefaultConfig()
 	}
 
+	if method := strings.TrimSpace(cfg.Aggregation); method != "" {
+		feedsConfig.Aggregation.Method = method
+	}
+
 	// Ensure defaults are applied consistently regardless of whether the config
 	// came from a file or was provided programmatically (tests, embedding, etc.).
 	if err := feedsConfig.Validate(); err != nil {
@@ -161,6 +183,10 @@ func New(cfg Config) (*Service, error) {
 		txProxy:         cfg.TxProxy,
 		publishPolicy:   feedsConfig.PublishPolicy,
 		publishState:    make(map[string]*pricePublishState),
+		signerSets:      signerSetsFromConfig(feedsConfig),
+		feedErrors:      make(map[string]*feedErrorState),
+		sourceHealth:    make(map[sourceKey]*sourceHealthState),
+		backfillJobs:    make(map[string]*BackfillJob),
 		updateInterval:  updateInterval,
 		enableChainPush: cfg.EnableChainPush,
 		gasbank:         cfg.GasBank,
@@ -172,6 +198,13 @@ func New(cfg Config) (*Service, error) {
 		s.priceFeed = chain.NewPriceFeedContract(s.chainClient, s.priceFeedHash)
 	}
 
+	if !isKnownAggregation(feedsConfig.Aggregation.Method) {
+		s.Logger().WithFields(map[string]interface{}{
+			"method": feedsConfig.Aggregation.Method,
+		}).Warn("unknown aggregation method; falling back to median")
+		feedsConfig.Aggregation.Method = AggregationMedian
+	}
+
 	// Load signing key
 	if key, ok := cfg.Marble.Secret("NEOFEEDS_SIGNING_KEY"); ok && len(key) >= 32 {
 		s.signingKey = key
@@ -227,11 +260,9 @@ func New(cfg Config) (*Service, error) {
 		s.enableChainPush = false
 	}
 
-	if s.enableChainPush && s.priceFeedHash != "" {
-		if s.priceFeedHash != "" {
-			base.WithHydrate(s.hydratePriceFeedState)
-		}
+	base.WithHydrate(s.hydrate)
 
+	if s.enableChainPush && s.priceFeedHash != "" {
 		base.AddTickerWorker(s.updateInterval, func(ctx context.Context) error {
 			s.pushPricesToChain(ctx)
 			return nil
@@ -240,14 +271,38 @@ func New(cfg Config) (*Service, error) {
 
 	// Register statistics provider for /info endpoint
 	base.WithStats(s.statistics)
+	base.WithDebugState(s.debugState)
 
 	// Register standard routes (/health, /info) plus service-specific routes
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 
 	return s, nil
 }
 
+// hydrate loads persisted signer sets and, when anchoring on-chain, the
+// latest PriceFeed round of each feed.
+func (s *Service) hydrate(ctx context.Context) error {
+	if err := s.loadSignerSets(ctx); err != nil {
+		return err
+	}
+	if s.enableChainPush {
+		return s.hydratePriceFeedState(ctx)
+	}
+	return nil
+}
+
+// selfTest requires what on-chain anchoring needs: prices that are never
+// pushed to the PriceFeed contract are not usable by MiniApps.
+func (s *Service) selfTest(ctx context.Context) error {
+	return s.CheckDependencies(ctx,
+		commonservice.Dependency{Name: "chain client (NEO_RPC_URL)", Missing: s.chainClient == nil},
+		commonservice.Dependency{Name: "PriceFeed hash (CONTRACT_PRICE_FEED_HASH)", Missing: strings.TrimSpace(s.priceFeedHash) == ""},
+		commonservice.Dependency{Name: "txproxy (TXPROXY_URL)", Missing: s.txProxy == nil},
+	)
+}
+
 // statistics returns runtime statistics for the /info endpoint.
 func (s *Service) statistics() map[string]any {
 	enabledFeeds := s.GetEnabledFeeds()
@@ -264,9 +319,20 @@ func (s *Service) statistics() map[string]any {
 		"service_fee":     ServiceFeePerUpdate,
 	}
 
+	if errors := s.feedErrorCounts(); len(errors) > 0 {
+		stats["feed_errors"] = errors
+	}
+	if open := s.openCircuitCount(); open > 0 {
+		stats["sources_unhealthy"] = open
+	}
+
 	if s.priceFeedHash != "" {
 		stats["pricefeed_hash"] = s.priceFeedHash
 		stats["publish_policy"] = s.publishPolicySummary()
+		stats["pushes"] = map[string]int64{
+			pushReasonDeviation: s.pushesOnDeviation.Load(),
+			pushReasonHeartbeat: s.pushesOnHeartbeat.Load(),
+		}
 	}
 
 	return stats
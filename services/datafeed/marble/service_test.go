@@ -3,17 +3,28 @@ package neofeeds
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	neokeys "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 
 	"github.com/R3E-Network/service_layer/infrastructure/database"
 	"github.com/R3E-Network/service_layer/infrastructure/marble"
 	"github.com/R3E-Network/service_layer/infrastructure/testutil"
+	txproxytypes "github.com/R3E-Network/service_layer/infrastructure/txproxy/types"
 )
 
 // =============================================================================
@@ -73,55 +84,772 @@ func TestInitDefaultSources(t *testing.T) {
 }
 
 // =============================================================================
-// calculateMedian Tests
+// aggregateFeed Tests
 // =============================================================================
 
-func TestCalculateMedianOdd(t *testing.T) {
+func TestAggregateFeedMedian(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	svc, _ := New(Config{Marble: m})
 
-	prices := []float64{10.0, 20.0, 30.0}
-	median := svc.calculateMedian(prices)
+	tests := []struct {
+		name   string
+		prices []float64
+		want   float64
+	}{
+		{"odd", []float64{10.0, 20.0, 30.0}, 20.0},
+		{"even", []float64{10.0, 20.0, 30.0, 40.0}, 25.0},
+		{"single", []float64{50.0}, 50.0},
+		{"unsorted", []float64{30.0, 10.0, 20.0}, 20.0},
+	}
+	for _, tt := range tests {
+		samples := make([]priceSample, len(tt.prices))
+		for i, p := range tt.prices {
+			samples[i] = priceSample{value: p, weight: 1}
+		}
+		result, err := svc.aggregateFeed(samples)
+		if err != nil {
+			t.Fatalf("%s: aggregateFeed() error = %v", tt.name, err)
+		}
+		if result.value != tt.want {
+			t.Errorf("%s: aggregateFeed() = %f, want %f", tt.name, result.value, tt.want)
+		}
+	}
+}
+
+func TestAggregateFeedMethods(t *testing.T) {
+	// Weights expand to 7 observations; trimmed_mean drops 20% (one) from
+	// each end: mean(20, 20, 20, 30, 40).
+	samples := []priceSample{
+		{value: 1.0, weight: 1},
+		{value: 20.0, weight: 3},
+		{value: 30.0, weight: 1},
+		{value: 40.0, weight: 1},
+		{value: 1000.0, weight: 1},
+	}
+
+	tests := []struct {
+		method string
+		want   float64
+	}{
+		{AggregationMedian, 20.0},
+		{AggregationMean, 218.2},
+		{AggregationWeighted, 1131.0 / 7},
+		{AggregationTrimmedMean, 26.0},
+		{AggregationMin, 1.0},
+		{AggregationMax, 1000.0},
+	}
+	for _, tt := range tests {
+		m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+		svc, err := New(Config{Marble: m, Aggregation: tt.method})
+		if err != nil {
+			t.Fatalf("%s: New() error = %v", tt.method, err)
+		}
+
+		result, err := svc.aggregateFeed(samples)
+		if err != nil {
+			t.Fatalf("%s: aggregateFeed() error = %v", tt.method, err)
+		}
+		if math.Abs(result.value-tt.want) > 1e-9 {
+			t.Errorf("%s: aggregateFeed() = %f, want %f", tt.method, result.value, tt.want)
+		}
+	}
+}
+
+func TestAggregateFeedUnknownFallsBackToMedian(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, err := New(Config{Marble: m, Aggregation: "mode"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if svc.config.Aggregation.Method != AggregationMedian {
+		t.Errorf("Aggregation.Method = %s, want %s", svc.config.Aggregation.Method, AggregationMedian)
+	}
+
+	samples := []priceSample{{value: 10.0, weight: 1}, {value: 20.0, weight: 2}, {value: 90.0, weight: 1}}
+	result, err := svc.aggregateFeed(samples)
+	if err != nil {
+		t.Fatalf("aggregateFeed() error = %v", err)
+	}
+	if result.value != 20.0 {
+		t.Errorf("aggregateFeed() = %f, want 20.0", result.value)
+	}
+}
 
-	if median != 20.0 {
-		t.Errorf("calculateMedian() = %f, want 20.0", median)
+func TestAggregateSamplesMeanMinMax(t *testing.T) {
+	samples := []priceSample{{value: 10.0, weight: 3}, {value: 20.0, weight: 1}, {value: 30.0, weight: 1}}
+
+	tests := []struct {
+		method string
+		want   float64
+	}{
+		{AggregationMean, 20.0},
+		{AggregationMin, 10.0},
+		{AggregationMax, 30.0},
+	}
+	for _, tt := range tests {
+		if got := aggregateSamples(samples, tt.method, defaultTrimPercent); got != tt.want {
+			t.Errorf("aggregateSamples(%s) = %f, want %f", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestRejectOutliers(t *testing.T) {
+	samples := []priceSample{
+		{source: "a", value: 100.0, weight: 1},
+		{source: "b", value: 101.0, weight: 1},
+		{source: "c", value: 99.0, weight: 1},
+		{source: "d", value: 100.5, weight: 1},
+		{source: "e", value: 150.0, weight: 1},
+	}
+
+	kept, rejected := rejectOutliers(samples, 3)
+	if len(kept) != 4 || len(rejected) != 1 || rejected[0].source != "e" {
+		t.Fatalf("rejectOutliers() kept %d, rejected %+v; want e rejected", len(kept), rejected)
+	}
+
+	if kept, rejected := rejectOutliers(samples, 0); len(kept) != 5 || len(rejected) != 0 {
+		t.Errorf("rejectOutliers(k=0) kept %d, rejected %d; want filtering disabled", len(kept), len(rejected))
+	}
+
+	// Identical quotes give MAD 0; the scale floor keeps tick-level differences.
+	flat := []priceSample{{value: 100.0}, {value: 100.0}, {value: 100.0}, {value: 100.01}, {value: 120.0}}
+	kept, rejected = rejectOutliers(flat, 3)
+	if len(kept) != 4 || len(rejected) != 1 || rejected[0].value != 120.0 {
+		t.Errorf("rejectOutliers(flat) kept %d, rejected %+v; want only 120 rejected", len(kept), rejected)
+	}
+
+	// Fewer than three sources have no majority to deviate from.
+	if kept, _ := rejectOutliers(samples[:1:1], 3); len(kept) != 1 {
+		t.Errorf("rejectOutliers(single) kept %d, want 1", len(kept))
+	}
+	pair := []priceSample{{value: 100.0}, {value: 200.0}}
+	if kept, _ := rejectOutliers(pair, 3); len(kept) != 2 {
+		t.Errorf("rejectOutliers(pair) kept %d, want 2", len(kept))
 	}
 }
 
-func TestCalculateMedianEven(t *testing.T) {
+func TestAggregateFeedOutliersAndMinSources(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, err := New(Config{Marble: m})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	svc.config.Aggregation.OutlierMAD = 3
+	svc.config.Aggregation.MinSources = 3
+
+	samples := []priceSample{
+		{source: "binance", value: 100.0, weight: 1},
+		{source: "coinbase", value: 102.0, weight: 1},
+		{source: "okx", value: 101.0, weight: 1},
+		{source: "bad", value: 500.0, weight: 1},
+	}
+	result, err := svc.aggregateFeed(samples)
+	if err != nil {
+		t.Fatalf("aggregateFeed() error = %v", err)
+	}
+	if result.value != 101.0 {
+		t.Errorf("value = %f, want 101.0", result.value)
+	}
+	if len(result.kept) != 3 || len(result.rejected) != 1 || result.rejected[0].source != "bad" {
+		t.Errorf("kept %d, rejected %+v; want bad rejected", len(result.kept), result.rejected)
+	}
+	if got := result.confidence(); got != 0.75 {
+		t.Errorf("confidence() = %f, want 0.75", got)
+	}
+
+	svc.config.Aggregation.MinSources = 4
+	if _, err := svc.aggregateFeed(samples); err == nil {
+		t.Error("aggregateFeed() should fail when fewer than MinSources remain")
+	}
+}
+
+// =============================================================================
+// Derived feed Tests
+// =============================================================================
+
+func TestComputeDerivedValueRescalesDecimals(t *testing.T) {
+	now := time.Now()
+	inputs := []PriceResponse{
+		{FeedID: "A", Price: 100_00000000, Decimals: 8, Timestamp: now},
+		{FeedID: "B", Price: 102_000000, Decimals: 6, Timestamp: now.Add(-time.Minute)},
+		{FeedID: "C", Price: 200_00000000, Decimals: 8, Timestamp: now},
+	}
+
+	got, err := ComputeDerivedValue(inputs, AggregationMedian)
+	if err != nil {
+		t.Fatalf("ComputeDerivedValue() error = %v", err)
+	}
+	if got.Price != 102_00000000 || got.Decimals != 8 {
+		t.Errorf("Price = %d (decimals %d), want 10200000000 (8)", got.Price, got.Decimals)
+	}
+	if !got.Timestamp.Equal(now.Add(-time.Minute)) {
+		t.Error("Timestamp should be the oldest input timestamp")
+	}
+
+	if _, err := ComputeDerivedValue(nil, AggregationMedian); err == nil {
+		t.Error("ComputeDerivedValue() expected error for no inputs")
+	}
+}
+
+func TestDerivedFeedStalePolicy(t *testing.T) {
+	now := time.Now()
+	inputs := []PriceResponse{
+		{FeedID: "A", Price: 100, Decimals: 0, Timestamp: now},
+		{FeedID: "B", Price: 500, Decimals: 0, Timestamp: now.Add(-time.Hour)},
+	}
+
+	exclude := &DerivedFeedConfig{ID: "D", Method: AggregationWeighted, Heartbeat: time.Minute, StalePolicy: StalePolicyExclude}
+	got, err := exclude.Compute(inputs, now)
+	if err != nil {
+		t.Fatalf("Compute() error = %v", err)
+	}
+	if got.Price != 100 || got.FeedID != "D" {
+		t.Errorf("got %s=%d, want D=100 (stale input excluded)", got.FeedID, got.Price)
+	}
+
+	strict := &DerivedFeedConfig{ID: "D", Method: AggregationWeighted, Heartbeat: time.Minute, StalePolicy: StalePolicyError}
+	if _, err := strict.Compute(inputs, now); err == nil {
+		t.Error("Compute() expected error for stale input under error policy")
+	}
+
+	exclude.MinInputs = 2
+	if _, err := exclude.Compute(inputs, now); err == nil {
+		t.Error("Compute() expected error when fresh inputs are below min_inputs")
+	}
+}
+
+func TestGetDerivedPriceMissingInput(t *testing.T) {
+	newService := func(policy string) *Service {
+		t.Helper()
+		m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+		mockDB := database.NewMockRepository()
+		svc, err := New(Config{Marble: m, DB: mockDB, FeedsConfig: &FeedsConfig{
+			Sources: []SourceConfig{{ID: "test", URL: "http://example.com", JSONPath: "price"}},
+			Feeds: []FeedConfig{
+				{ID: "BTC-USD", Sources: []string{"test"}, Enabled: true},
+				{ID: "WBTC-USD", Sources: []string{"test"}, Enabled: true},
+			},
+			DerivedFeeds: []DerivedFeedConfig{{ID: "BTC-COMPOSITE", Inputs: []string{"BTC-USD", "WBTC-USD"}, StalePolicy: policy}},
+		}})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		// Only BTC-USD has a stored price; WBTC-USD cannot be loaded.
+		mockDB.CreatePriceFeed(context.Background(), &database.PriceFeed{
+			FeedID: "BTC-USD", Pair: "BTC-USD", Price: 100, Decimals: 8, Timestamp: time.Now(),
+		})
+		return svc
+	}
+
+	exclude := newService(StalePolicyExclude)
+	got, err := exclude.GetDerivedPrice(context.Background(), "BTC-COMPOSITE")
+	if err != nil {
+		t.Fatalf("exclude policy: GetDerivedPrice() error = %v", err)
+	}
+	if got.Price != 100 || len(got.Sources) != 1 {
+		t.Errorf("exclude policy: got %d from %v, want 100 from BTC-USD only", got.Price, got.Sources)
+	}
+
+	strict := newService(StalePolicyError)
+	_, err = strict.GetDerivedPrice(context.Background(), "BTC-COMPOSITE")
+	if err == nil || !strings.Contains(err.Error(), "input WBTC-USD unavailable") {
+		t.Fatalf("error policy: GetDerivedPrice() error = %v, want WBTC-USD unavailable", err)
+	}
+}
+
+// =============================================================================
+// Extraction Tests
+// =============================================================================
+
+func TestExtractSourceValue(t *testing.T) {
+	body := []byte(`{"data":{"tickers":[{"last":"$1,234"},{"last":" 50000.5 ","cents":5000050}]},"flag":true}`)
+
+	tests := []struct {
+		name      string
+		path      string
+		dataType  DataType
+		transform *TransformConfig
+		want      float64
+		wantErr   bool
+	}{
+		{name: "nested string", path: "data.tickers.1.last", dataType: DataTypePrice, want: 50000.5},
+		{name: "nested number with multiply", path: "data.tickers.1.cents", dataType: DataTypePrice, transform: &TransformConfig{Multiply: 0.01}, want: 50000.5},
+		{name: "thousands separator", path: "data.tickers.0.last", dataType: DataTypeNumber, transform: &TransformConfig{Trim: "$"}, wantErr: true},
+		{name: "missing path", path: "data.tickers.5.last", dataType: DataTypePrice, wantErr: true},
+		{name: "non-numeric type", path: "flag", dataType: DataTypePrice, wantErr: true},
+		{name: "string feed", path: "data.tickers.1.last", dataType: DataTypeString, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractSourceValue(body, tt.path, tt.dataType, tt.transform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractSourceValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("extractSourceValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	trimmed, err := extractSourceValue([]byte(`{"price":"$42.5"}`), "price", DataTypePrice, &TransformConfig{Trim: "$"})
+	if err != nil || trimmed != 42.5 {
+		t.Errorf("extractSourceValue() with trim = %v, %v; want 42.5", trimmed, err)
+	}
+}
+
+// =============================================================================
+// Request Signing Tests
+// =============================================================================
+
+func TestSignRequest(t *testing.T) {
+	t.Setenv("TEST_FEED_SIGNING_SECRET", "s3cret")
+
+	cfg := &RequestSigningConfig{
+		Secret:          "${TEST_FEED_SIGNING_SECRET}",
+		SignatureHeader: "X-Signature",
+		TimestampHeader: "X-Timestamp",
+		TimestampFormat: TimestampUnixMs,
+		Payload:         "{timestamp}\n{method}\n{path}\n{query}",
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/v1/ticker?symbol=BTCUSDT", http.NoBody)
+	now := time.UnixMilli(1_700_000_000_123)
+	if err := signRequest(req, nil, cfg, now); err != nil {
+		t.Fatalf("signRequest() error = %v", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte("1700000000123\nGET\n/v1/ticker\nsymbol=BTCUSDT"))
+	if got, want := req.Header.Get("X-Signature"), hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("X-Signature = %s, want %s", got, want)
+	}
+	if got := req.Header.Get("X-Timestamp"); got != "1700000000123" {
+		t.Errorf("X-Timestamp = %s, want 1700000000123", got)
+	}
+}
+
+func TestSignRequestUnresolvedSecret(t *testing.T) {
+	cfg := &RequestSigningConfig{Secret: "${TEST_FEED_SIGNING_SECRET_UNSET}", SignatureHeader: "X-Signature"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.example.com/v1/ticker", http.NoBody)
+	if err := signRequest(req, nil, cfg, time.Now()); err == nil {
+		t.Fatal("signRequest() expected error for unset secret")
+	}
+	if req.Header.Get("X-Signature") != "" {
+		t.Error("request must not be signed with an unresolved secret")
+	}
+}
+
+func TestRequestSigningConfigValidate(t *testing.T) {
+	invalid := []RequestSigningConfig{
+		{SignatureHeader: "X-Signature"},
+		{Secret: "k"},
+		{Secret: "k", SignatureHeader: "X-Signature", Algorithm: "md5"},
+		{Secret: "k", SignatureHeader: "X-Signature", Encoding: "base32"},
+		{Secret: "k", SignatureHeader: "X-Signature", TimestampFormat: "iso"},
+	}
+	for i := range invalid {
+		if err := invalid[i].Validate(); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+}
+
+// =============================================================================
+// Alert rule Tests
+// =============================================================================
+
+func TestEvaluateAlertsCooldown(t *testing.T) {
+	now := time.Now()
+	rules := []AlertRule{
+		{ID: "up", FeedID: "BTC/USD", Comparison: AlertAbove, Threshold: 100, Cooldown: time.Hour},
+		{ID: "down", FeedID: "BTC-USD", Comparison: AlertBelow, Threshold: 50},
+		{ID: "other", FeedID: "ETH-USD", Comparison: AlertAbove, Threshold: 1},
+	}
+	value := PriceResponse{FeedID: "BTC-USD", Price: 150}
+
+	triggered, err := EvaluateAlerts(rules, value, now)
+	if err != nil {
+		t.Fatalf("EvaluateAlerts() error = %v", err)
+	}
+	if len(triggered) != 1 || triggered[0].ID != "up" || !triggered[0].LastTriggered.Equal(now) {
+		t.Fatalf("triggered = %+v, want [up] with LastTriggered set", triggered)
+	}
+
+	// Within the cooldown the same rule is suppressed.
+	rules[0] = triggered[0]
+	if again, _ := EvaluateAlerts(rules, value, now.Add(30*time.Minute)); len(again) != 0 {
+		t.Errorf("triggered within cooldown = %+v, want none", again)
+	}
+	if again, _ := EvaluateAlerts(rules, value, now.Add(2*time.Hour)); len(again) != 1 {
+		t.Errorf("triggered after cooldown = %d, want 1", len(again))
+	}
+}
+
+func TestEvaluateAlertsInvalidRule(t *testing.T) {
+	rules := []AlertRule{{ID: "bad", FeedID: "BTC-USD", Comparison: "equals"}}
+	if _, err := EvaluateAlerts(rules, PriceResponse{FeedID: "BTC-USD"}, time.Now()); err == nil {
+		t.Error("EvaluateAlerts() expected error for unknown comparison")
+	}
+}
+
+// =============================================================================
+// Publish Policy Tests
+// =============================================================================
+
+func TestSetPublishPolicy(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	svc, _ := New(Config{Marble: m})
 
-	prices := []float64{10.0, 20.0, 30.0, 40.0}
-	median := svc.calculateMedian(prices)
+	svc.SetPublishPolicy(PublishPolicyConfig{MinInterval: 30 * time.Second})
+	policy := svc.PublishPolicy()
+	if policy.MinInterval != 30*time.Second {
+		t.Errorf("MinInterval = %v, want 30s", policy.MinInterval)
+	}
+	if policy.ThresholdBps != 10 || policy.HysteresisBps != 8 || policy.MaxPerMinute != 30 {
+		t.Errorf("unset fields not defaulted: %+v", policy)
+	}
 
-	if median != 25.0 {
-		t.Errorf("calculateMedian() = %f, want 25.0", median)
+	// A partial update keeps every field it omits.
+	svc.SetPublishPolicy(PublishPolicyConfig{Heartbeat: time.Hour})
+	svc.SetPublishPolicy(PublishPolicyConfig{ThresholdBps: 25})
+	policy = svc.PublishPolicy()
+	if policy.ThresholdBps != 25 || policy.Heartbeat != time.Hour || policy.MinInterval != 30*time.Second {
+		t.Errorf("partial update reset omitted fields: %+v", policy)
+	}
+
+	svc.SetPublishPolicy(PublishPolicyConfig{Heartbeat: -1})
+	if policy = svc.PublishPolicy(); policy.Heartbeat != 0 {
+		t.Errorf("Heartbeat = %v, want a negative value to disable it", policy.Heartbeat)
 	}
 }
 
-func TestCalculateMedianSingle(t *testing.T) {
+func TestFeedSignerSetManagement(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	svc, _ := New(Config{Marble: m})
+	ctx := context.Background()
+
+	keys := make([]string, 3)
+	for i := range keys {
+		priv, err := neokeys.NewPrivateKey()
+		if err != nil {
+			t.Fatalf("NewPrivateKey: %v", err)
+		}
+		keys[i] = priv.PublicKey().StringCompressed()
+	}
+
+	for _, key := range keys {
+		if err := svc.AddSigner(ctx, "BTC-USD", key); err != nil {
+			t.Fatalf("AddSigner: %v", err)
+		}
+	}
+	if err := svc.AddSigner(ctx, "BTC-USD", keys[0]); err == nil {
+		t.Error("duplicate signer should be rejected")
+	}
+	if err := svc.AddSigner(ctx, "BTC-USD", "not-a-key"); err == nil {
+		t.Error("invalid signer should be rejected")
+	}
+
+	if err := svc.SetThreshold(ctx, "BTC-USD", 4); err == nil {
+		t.Error("threshold above signer count should be rejected")
+	}
+	if err := svc.SetThreshold(ctx, "BTC-USD", 3); err != nil {
+		t.Fatalf("SetThreshold: %v", err)
+	}
+
+	if err := svc.RemoveSigner(ctx, "BTC-USD", keys[2]); err == nil {
+		t.Error("removal leaving threshold unreachable should be rejected")
+	}
+	if err := svc.SetThreshold(ctx, "BTC-USD", 2); err != nil {
+		t.Fatalf("SetThreshold: %v", err)
+	}
+	if err := svc.RemoveSigner(ctx, "BTC-USD", keys[2]); err != nil {
+		t.Fatalf("RemoveSigner: %v", err)
+	}
+
+	set, err := svc.FeedSignerSet("btc/usd")
+	if err != nil {
+		t.Fatalf("FeedSignerSet: %v", err)
+	}
+	if len(set.Signers) != 2 || set.Threshold != 2 {
+		t.Errorf("signer set = %+v, want 2 signers with threshold 2", set)
+	}
+
+	if err := svc.AddSigner(ctx, "UNKNOWN-FEED", keys[0]); err == nil {
+		t.Error("unknown feed should be rejected")
+	}
+}
+
+func TestFeedSignerSetPersistence(t *testing.T) {
+	db := database.NewMockRepository()
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, _ := New(Config{Marble: m, DB: db})
+	ctx := context.Background()
+
+	priv, err := neokeys.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey: %v", err)
+	}
+	key := priv.PublicKey().StringCompressed()
+	if err := svc.AddSigner(ctx, "BTC-USD", key); err != nil {
+		t.Fatalf("AddSigner: %v", err)
+	}
+
+	db.ErrorOnNextCall = errors.New("db down")
+	if err := svc.SetThreshold(ctx, "BTC-USD", 1); err == nil {
+		t.Error("SetThreshold should fail when the set cannot be persisted")
+	}
+
+	// A restarted service loads the persisted set over the configured one.
+	restarted, _ := New(Config{Marble: m, DB: db})
+	if err := restarted.hydrate(ctx); err != nil {
+		t.Fatalf("hydrate: %v", err)
+	}
+	set, err := restarted.FeedSignerSet("BTC-USD")
+	if err != nil {
+		t.Fatalf("FeedSignerSet: %v", err)
+	}
+	if len(set.Signers) != 1 || set.Signers[0] != key || set.Threshold != 1 {
+		t.Errorf("restored signer set = %+v, want [%s] with threshold 1", set, key)
+	}
+}
+
+func TestFeedSignerSetVersionConflict(t *testing.T) {
+	db := database.NewMockRepository()
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	ctx := context.Background()
+
+	keys := make([]string, 2)
+	for i := range keys {
+		priv, err := neokeys.NewPrivateKey()
+		if err != nil {
+			t.Fatalf("NewPrivateKey: %v", err)
+		}
+		keys[i] = priv.PublicKey().StringCompressed()
+	}
+
+	// Two instances share the database; the second loads before the first
+	// changes the set.
+	first, _ := New(Config{Marble: m, DB: db})
+	second, _ := New(Config{Marble: m, DB: db})
+	if err := second.hydrate(ctx); err != nil {
+		t.Fatalf("hydrate: %v", err)
+	}
+	if err := first.AddSigner(ctx, "BTC-USD", keys[0]); err != nil {
+		t.Fatalf("AddSigner: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/admin/feeds/BTC-USD/signers", strings.NewReader(`{"signer":"`+keys[1]+`"}`))
+	req.Header.Set("X-User-Role", "admin")
+	rr := httptest.NewRecorder()
+	second.Router().ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("stale add status=%d (%s), want 409", rr.Code, rr.Body.String())
+	}
+	// The conflict reloads the stored set, so a retry applies on top of the
+	// first change.
+	if set, _ := second.FeedSignerSet("BTC-USD"); len(set.Signers) != 1 || set.Signers[0] != keys[0] || set.Version != 1 {
+		t.Errorf("signer set after conflict = %+v, want the stored set", set)
+	}
+	if err := second.AddSigner(ctx, "BTC-USD", keys[1]); err != nil {
+		t.Fatalf("AddSigner retry: %v", err)
+	}
+	if set, _ := second.FeedSignerSet("BTC-USD"); len(set.Signers) != 2 || set.Version != 2 {
+		t.Errorf("signer set = %+v, want both signers at version 2", set)
+	}
+}
+
+func TestHandleFeedSigners(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, _ := New(Config{Marble: m, DB: database.NewMockRepository()})
+
+	keys := make([]string, 2)
+	for i := range keys {
+		priv, err := neokeys.NewPrivateKey()
+		if err != nil {
+			t.Fatalf("NewPrivateKey: %v", err)
+		}
+		keys[i] = priv.PublicKey().StringCompressed()
+	}
+
+	do := func(method, path, body, role string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if role != "" {
+			req.Header.Set("X-User-Role", role)
+		}
+		rr := httptest.NewRecorder()
+		svc.Router().ServeHTTP(rr, req)
+		return rr
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		role       string
+		wantStatus int
+	}{
+		{"requires admin", "POST", "/admin/feeds/BTC-USD/signers", `{"signer":"` + keys[0] + `"}`, "user", http.StatusForbidden},
+		{"unknown feed", "POST", "/admin/feeds/NOPE-USD/signers", `{"signer":"` + keys[0] + `"}`, "admin", http.StatusNotFound},
+		{"add first", "POST", "/admin/feeds/BTC-USD/signers", `{"signer":"` + keys[0] + `"}`, "admin", http.StatusOK},
+		{"add second", "POST", "/admin/feeds/BTC-USD/signers", `{"signer":"` + keys[1] + `"}`, "admin", http.StatusOK},
+		{"add duplicate", "POST", "/admin/feeds/BTC-USD/signers", `{"signer":"` + keys[1] + `"}`, "admin", http.StatusBadRequest},
+		{"threshold too high", "PUT", "/admin/feeds/BTC-USD/threshold", `{"threshold":3}`, "admin", http.StatusBadRequest},
+		{"threshold", "PUT", "/admin/feeds/BTC-USD/threshold", `{"threshold":2}`, "admin", http.StatusOK},
+		{"remove below threshold", "DELETE", "/admin/feeds/BTC-USD/signers/" + keys[1], "", "admin", http.StatusBadRequest},
+		{"lower threshold", "PUT", "/admin/feeds/BTC-USD/threshold", `{"threshold":1}`, "admin", http.StatusOK},
+		{"remove", "DELETE", "/admin/feeds/BTC-USD/signers/" + keys[1], "", "admin", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rr := do(tt.method, tt.path, tt.body, tt.role); rr.Code != tt.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", tt.name, rr.Code, tt.wantStatus, rr.Body.String())
+		}
+	}
+
+	rr := do("GET", "/admin/feeds/BTC-USD/signers", "", "admin")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("GET status = %d", rr.Code)
+	}
+	var set SignerSet
+	if err := json.Unmarshal(rr.Body.Bytes(), &set); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(set.Signers) != 1 || set.Signers[0] != keys[0] || set.Threshold != 1 {
+		t.Errorf("signer set = %+v, want [%s] with threshold 1", set, keys[0])
+	}
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	message := []byte("BTC-USD:42:6500000000000")
+
+	privs := make([]*neokeys.PrivateKey, 3)
+	pubs := make(neokeys.PublicKeys, 3)
+	set := SignerSet{Threshold: 2}
+	for i := range privs {
+		priv, err := neokeys.NewPrivateKey()
+		if err != nil {
+			t.Fatalf("NewPrivateKey: %v", err)
+		}
+		privs[i] = priv
+		pubs[i] = priv.PublicKey()
+		set.Signers = append(set.Signers, priv.PublicKey().StringCompressed())
+	}
+	outsider, _ := neokeys.NewPrivateKey()
 
-	prices := []float64{50.0}
-	median := svc.calculateMedian(prices)
+	updates := []SignedUpdate{
+		{Signer: outsider.PublicKey().StringCompressed(), Signature: outsider.Sign(message)},
+		{Signer: set.Signers[0], Signature: privs[1].Sign(message)}, // wrong key
+	}
+	for i := len(privs) - 1; i >= 0; i-- {
+		updates = append(updates, SignedUpdate{Signer: "0x" + set.Signers[i], Signature: privs[i].Sign(message)})
+	}
+
+	signatures, signers, err := AggregateSignatures(message, updates, set)
+	if err != nil {
+		t.Fatalf("AggregateSignatures: %v", err)
+	}
+
+	sort.Sort(pubs)
+	if len(signers) != 2 || signers[0] != pubs[0].StringCompressed() || signers[1] != pubs[1].StringCompressed() {
+		t.Fatalf("signers = %v, want the two lowest keys in ascending order", signers)
+	}
+	if len(signatures) != 2*neokeys.SignatureLen {
+		t.Fatalf("signatures length = %d", len(signatures))
+	}
+	digest := hash.Sha256(message).BytesBE()
+	for i := range signers {
+		if !pubs[i].Verify(signatures[i*neokeys.SignatureLen:(i+1)*neokeys.SignatureLen], digest) {
+			t.Errorf("signature %d does not belong to signer %s", i, signers[i])
+		}
+	}
+
+	if _, _, err := AggregateSignatures(message, updates[:3], set); err == nil {
+		t.Error("expected error when fewer than threshold valid signatures")
+	}
+	if _, _, err := AggregateSignatures(message, updates, SignerSet{Signers: set.Signers}); err == nil {
+		t.Error("expected error for missing threshold")
+	}
+}
 
-	if median != 50.0 {
-		t.Errorf("calculateMedian() = %f, want 50.0", median)
+func TestShouldPush(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name      string
+		change    int64
+		lastAt    time.Time
+		heartbeat time.Duration
+		want      string
+	}{
+		{name: "below threshold", change: 9, lastAt: now.Add(-time.Minute), heartbeat: time.Hour, want: ""},
+		{name: "exactly threshold", change: 10, lastAt: now.Add(-time.Minute), heartbeat: time.Hour, want: pushReasonDeviation},
+		{name: "above threshold", change: 50, lastAt: now.Add(-time.Minute), want: pushReasonDeviation},
+		{name: "heartbeat due", change: 0, lastAt: now.Add(-time.Hour), heartbeat: time.Hour, want: pushReasonHeartbeat},
+		{name: "heartbeat disabled", change: 0, lastAt: now.Add(-24 * time.Hour), want: ""},
+		{name: "no previous publish", change: 0, heartbeat: time.Hour, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldPush(tt.change, 10, tt.lastAt, now, tt.heartbeat); got != tt.want {
+				t.Errorf("shouldPush() = %q, want %q", got, tt.want)
+			}
+		})
 	}
 }
 
-func TestCalculateMedianUnsorted(t *testing.T) {
+type countingInvoker struct {
+	calls int
+}
+
+func (c *countingInvoker) Invoke(context.Context, *txproxytypes.InvokeRequest) (*txproxytypes.InvokeResponse, error) {
+	c.calls++
+	return &txproxytypes.InvokeResponse{TxHash: "0x01"}, nil
+}
+
+func TestTryPublishPriceHeartbeat(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	svc, _ := New(Config{Marble: m})
 
-	// Unsorted input should still work
-	prices := []float64{30.0, 10.0, 20.0}
-	median := svc.calculateMedian(prices)
+	invoker := &countingInvoker{}
+	svc.txProxy = invoker
+	svc.priceFeedHash = "0x0102030405060708090a0b0c0d0e0f1011121314"
+	svc.attestationHash = []byte{0x01}
+	svc.SetPublishPolicy(PublishPolicyConfig{Heartbeat: time.Hour})
+
+	svc.publishState["BTC-USD"] = &pricePublishState{
+		lastRoundID:        7,
+		lastPublishedPrice: 5000000000000,
+		lastPublishedAt:    time.Now().Add(-2 * time.Hour),
+	}
+
+	// Unchanged price: only the heartbeat can trigger, and it publishes
+	// without waiting for a confirming observation.
+	svc.tryPublishPrice(context.Background(), "BTC-USD", 5000000000000, uint64(time.Now().Unix()), nil)
+	if invoker.calls != 1 {
+		t.Fatalf("invoke calls = %d, want 1", invoker.calls)
+	}
+	if got := svc.pushesOnHeartbeat.Load(); got != 1 {
+		t.Errorf("heartbeat pushes = %d, want 1", got)
+	}
+	if got := svc.publishState["BTC-USD"].lastRoundID; got != 8 {
+		t.Errorf("lastRoundID = %d, want 8", got)
+	}
 
-	if median != 20.0 {
-		t.Errorf("calculateMedian() = %f, want 20.0", median)
+	// Just published: neither heartbeat nor deviation applies.
+	svc.publishState["BTC-USD"].lastPublishedAt = time.Now().Add(-time.Minute)
+	svc.tryPublishPrice(context.Background(), "BTC-USD", 5000000000000, uint64(time.Now().Unix()), nil)
+	if invoker.calls != 1 {
+		t.Errorf("invoke calls = %d, want 1", invoker.calls)
 	}
 }
 
@@ -225,6 +953,232 @@ func TestHandleGetPrices(t *testing.T) {
 	}
 }
 
+func TestGetHistory(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB})
+
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 3; i++ {
+		mockDB.CreatePriceFeed(ctx, &database.PriceFeed{
+			FeedID:    "BTC-USD",
+			Pair:      "BTC-USD",
+			Price:     int64(100 + i),
+			Decimals:  8,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	history, err := svc.GetHistory(ctx, "BTC/USD", base, base.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if len(history) != 3 || history[0].Price != 102 {
+		t.Errorf("GetHistory() = %+v, want 3 values newest first", history)
+	}
+
+	empty, err := svc.GetHistory(ctx, "BTC-USD", base.Add(-48*time.Hour), base.Add(-24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("GetHistory() = %v, want empty slice", empty)
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	// The history source returns 100 + the hour of day at the requested time.
+	historyServer := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts, err := time.Parse(time.RFC3339, r.URL.Query().Get("at"))
+		if err != nil {
+			t.Errorf("bad at parameter %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"close": 100 + ts.UTC().Hour()})
+	}))
+	defer historyServer.Close()
+
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, FeedsConfig: &NeoFeedsConfig{
+		Version: "1.0",
+		Sources: []SourceConfig{
+			{ID: "hist", URL: historyServer.URL, JSONPath: "price", HistoryURL: historyServer.URL + "?pair={pair}&at={date}T00:00:00Z", HistoryJSONPath: "close", Weight: 1},
+			{ID: "live", URL: historyServer.URL, JSONPath: "price", Weight: 1},
+		},
+		Feeds: []FeedConfig{
+			{ID: "BTC-USD", Sources: []string{"hist", "live"}, Decimals: 2, Enabled: true},
+		},
+		UpdateInterval: time.Minute,
+	}})
+	// {date} resolves to midnight, so every point reads 100; exercise
+	// {timestamp} separately below.
+	feed := svc.config.GetFeed("BTC-USD")
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	updates, err := svc.Backfill(context.Background(), feed, from, from.Add(3*24*time.Hour), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+	if len(updates) != 3 {
+		t.Fatalf("len(updates) = %d, want 3", len(updates))
+	}
+	for i, u := range updates {
+		if want := from.Add(time.Duration(i) * 24 * time.Hour); !u.Timestamp.Equal(want) || u.Price != 10000 || len(u.Sources) != 1 || u.Sources[0] != "hist" {
+			t.Errorf("updates[%d] = %+v, want 10000 at %s from hist", i, u, want)
+		}
+		if !u.Backfilled || len(u.Signature) != 0 {
+			t.Errorf("updates[%d] backfilled=%v signature=%x, want marked and unsigned", i, u.Backfilled, u.Signature)
+		}
+	}
+
+	history, err := svc.GetHistory(context.Background(), "BTC-USD", from, from.Add(4*24*time.Hour), 10)
+	if err != nil || len(history) != 3 || !history[0].Backfilled {
+		t.Errorf("GetHistory() = %+v, %v; want 3 backfilled values recorded", history, err)
+	}
+
+	// Re-running an overlapping range only fills the missing timestamps.
+	updates, err = svc.Backfill(context.Background(), feed, from, from.Add(4*24*time.Hour), 24*time.Hour)
+	if err != nil || len(updates) != 1 || !updates[0].Timestamp.Equal(from.Add(3*24*time.Hour)) {
+		t.Fatalf("second Backfill() = %+v, %v; want only the new day recorded", updates, err)
+	}
+	if history, _ := svc.GetHistory(context.Background(), "BTC-USD", from, from.Add(4*24*time.Hour), 10); len(history) != 4 {
+		t.Errorf("GetHistory() = %d values, want 4 without duplicates", len(history))
+	}
+
+	if got := formatHistoryURL("x?t={timestamp}&ms={timestamp_ms}&d={date}", from.Add(90*time.Minute)); got != "x?t=1767231000&ms=1767231000000&d=2026-01-01" {
+		t.Errorf("formatHistoryURL() = %q", got)
+	}
+}
+
+// failingBulkDB fails every bulk price insert.
+type failingBulkDB struct {
+	*database.MockRepository
+}
+
+func (failingBulkDB) CreatePriceFeeds(context.Context, []database.PriceFeed) error {
+	return database.ErrDatabaseError
+}
+
+func TestBackfillRecordsAllOrNothing(t *testing.T) {
+	historyServer := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"close": 100})
+	}))
+	defer historyServer.Close()
+
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: failingBulkDB{mockDB}, FeedsConfig: &NeoFeedsConfig{
+		Version: "1.0",
+		Sources: []SourceConfig{{ID: "hist", URL: historyServer.URL, JSONPath: "price", HistoryURL: historyServer.URL + "?at={timestamp}", HistoryJSONPath: "close", Weight: 1}},
+		Feeds:   []FeedConfig{{ID: "BTC-USD", Sources: []string{"hist"}, Decimals: 2, Enabled: true}},
+	}})
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updates, err := svc.Backfill(context.Background(), svc.config.GetFeed("BTC-USD"), from, from.Add(3*time.Hour), time.Hour)
+	if !errors.Is(err, database.ErrDatabaseError) || updates != nil {
+		t.Fatalf("Backfill() = %v, %v; want no updates and the insert error", updates, err)
+	}
+	if history, _ := mockDB.GetPriceHistory(context.Background(), "BTC-USD", from, from.Add(3*time.Hour), 10); len(history) != 0 {
+		t.Errorf("history = %d values, want none after a failed insert", len(history))
+	}
+}
+
+func TestHandleBackfillRunsInBackground(t *testing.T) {
+	historyServer := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"close": 100})
+	}))
+	defer historyServer.Close()
+
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, _ := New(Config{Marble: m, DB: database.NewMockRepository(), FeedsConfig: &NeoFeedsConfig{
+		Version: "1.0",
+		Sources: []SourceConfig{{ID: "hist", URL: historyServer.URL, JSONPath: "price", HistoryURL: historyServer.URL + "?t={timestamp}", HistoryJSONPath: "close", Weight: 1}},
+		Feeds:   []FeedConfig{{ID: "BTC-USD", Sources: []string{"hist"}, Decimals: 2, Enabled: true}},
+	}})
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/admin/feeds/BTC-USD/backfill", strings.NewReader(body))
+		req.Header.Set("X-User-Role", "admin")
+		rr := httptest.NewRecorder()
+		svc.Router().ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := do("GET", ""); rr.Code != http.StatusNotFound {
+		t.Fatalf("GET before any backfill status = %d, want 404", rr.Code)
+	}
+	if rr := do("POST", `{"from":"2026-01-01T00:00:00Z","to":"2026-03-01T00:00:00Z","step":"1m"}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("oversized range status = %d, want 400", rr.Code)
+	}
+
+	rr := do("POST", `{"from":"2026-01-01T00:00:00Z","to":"2026-01-02T00:00:00Z","step":"1h"}`)
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("POST status = %d, want 202: %s", rr.Code, rr.Body.String())
+	}
+
+	var job BackfillJob
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rr := do("GET", "")
+		if err := json.Unmarshal(rr.Body.Bytes(), &job); err != nil {
+			t.Fatalf("decode job: %v", err)
+		}
+		if job.Status != BackfillRunning || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if job.Status != BackfillCompleted || job.Recorded != 24 || job.FinishedAt == nil {
+		t.Fatalf("job = %+v, want completed with 24 values recorded", job)
+	}
+}
+
+func TestBackfillHistoryUnsupported(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, _ := New(Config{Marble: m, FeedsConfig: &NeoFeedsConfig{
+		Version: "1.0",
+		Sources: []SourceConfig{{ID: "live", URL: "http://invalid", JSONPath: "price"}},
+		Feeds:   []FeedConfig{{ID: "BTC-USD", Sources: []string{"live"}, Enabled: true}},
+	}})
+
+	from := time.Now().Add(-time.Hour)
+	_, err := svc.Backfill(context.Background(), svc.config.GetFeed("BTC-USD"), from, from.Add(time.Hour), time.Minute)
+	var unsupported *HistoryUnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.SourceID != "live" {
+		t.Fatalf("Backfill() error = %v, want *HistoryUnsupportedError for live", err)
+	}
+	if !errors.Is(err, ErrHistoryUnsupported) {
+		t.Error("HistoryUnsupportedError should match ErrHistoryUnsupported")
+	}
+
+	req := httptest.NewRequest("POST", "/admin/feeds/BTC-USD/backfill", strings.NewReader(`{"from":"2026-01-01T00:00:00Z","to":"2026-01-02T00:00:00Z","step":"1h"}`))
+	req.Header.Set("X-User-Role", "admin")
+	rr := httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestHandleGetHistoryInvalidRange(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, _ := New(Config{Marble: m, DB: database.NewMockRepository()})
+
+	req := httptest.NewRequest("GET", "/history/BTC-USD?from=2025-01-02T00:00:00Z&to=2025-01-01T00:00:00Z", nil)
+	req = mux.SetURLVars(req, map[string]string{"pair": "BTC-USD"})
+	rr := httptest.NewRecorder()
+
+	svc.handleGetHistory(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
 func TestHandleListFeeds(t *testing.T) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	svc, _ := New(Config{Marble: m})
@@ -416,6 +1370,101 @@ func TestGetPriceAllSourcesFail(t *testing.T) {
 	if err == nil {
 		t.Error("GetPrice() expected error when all sources fail")
 	}
+	if got := svc.feedErrorCounts()["BTCUSDT"]; got != 1 {
+		t.Errorf("feed error count = %d, want 1", got)
+	}
+}
+
+func TestGetPriceSkipsUnhealthySources(t *testing.T) {
+	var hits atomic.Int32
+	mockServer := testutil.NewHTTPTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer mockServer.Close()
+
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	failConfig := &NeoFeedsConfig{
+		Version: "1.0",
+		Sources: []SourceConfig{
+			{ID: "failing", Name: "Failing", URL: mockServer.URL, JSONPath: "price", Weight: 1},
+		},
+		Feeds: []FeedConfig{
+			{ID: "BTCUSDT", Pair: "BTCUSDT", Sources: []string{"failing"}, Enabled: true},
+		},
+		UpdateInterval: 60 * time.Second,
+		SourceHealth:   SourceHealthConfig{FailureThreshold: 2, Cooldown: time.Minute},
+	}
+	svc, _ := New(Config{Marble: m, FeedsConfig: failConfig})
+
+	for i := 0; i < 3; i++ {
+		_, _ = svc.GetPrice(context.Background(), "BTCUSDT")
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("source fetched %d times, want 2 before the circuit opens", got)
+	}
+	if _, err := svc.GetPrice(context.Background(), "BTCUSDT"); err == nil || !strings.Contains(err.Error(), "all sources unhealthy") {
+		t.Errorf("GetPrice() error = %v, want all sources unhealthy", err)
+	}
+
+	req := httptest.NewRequest("GET", "/feeds/BTCUSDT/sources", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "BTCUSDT"})
+	rr := httptest.NewRecorder()
+	svc.handleGetFeedSources(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rr.Code, http.StatusOK)
+	}
+	var resp FeedSourcesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Status != FeedStatusError {
+		t.Errorf("Status = %s, want %s", resp.Status, FeedStatusError)
+	}
+	if len(resp.Sources) != 1 || resp.Sources[0].State != CircuitOpen || resp.Sources[0].ConsecutiveFailures != 2 {
+		t.Errorf("Sources = %+v, want failing source open after 2 failures", resp.Sources)
+	}
+	if resp.Sources[0].OpenUntil == nil {
+		t.Error("OpenUntil should be set for an open circuit")
+	}
+}
+
+func TestSourceCircuitHalfOpen(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
+	svc, _ := New(Config{Marble: m})
+	svc.config.SourceHealth = SourceHealthConfig{FailureThreshold: 1, Cooldown: time.Minute}
+
+	now := time.Now()
+	svc.errorsMu.Lock()
+	opened := svc.recordSourceFailureLocked("BTC-USD", "binance", "boom", now)
+	svc.errorsMu.Unlock()
+	if !opened {
+		t.Fatal("circuit should open at the failure threshold")
+	}
+	if svc.sourceAvailable("BTC-USD", "binance", now.Add(30*time.Second)) {
+		t.Error("source should be skipped during the cooldown")
+	}
+	if !svc.sourceAvailable("BTC-USD", "coinbase", now) {
+		t.Error("other sources should be unaffected")
+	}
+
+	// After the cooldown one probe is allowed; a failed probe reopens the circuit.
+	probeAt := now.Add(2 * time.Minute)
+	if !svc.sourceAvailable("BTC-USD", "binance", probeAt) {
+		t.Fatal("source should be probed after the cooldown")
+	}
+	svc.errorsMu.Lock()
+	reopened := svc.recordSourceFailureLocked("BTC-USD", "binance", "boom", probeAt)
+	svc.errorsMu.Unlock()
+	if !reopened || svc.sourceAvailable("BTC-USD", "binance", probeAt.Add(time.Second)) {
+		t.Error("failed probe should reopen the circuit")
+	}
+
+	svc.recordSourceSuccess("BTC-USD", "binance", probeAt)
+	if !svc.sourceAvailable("BTC-USD", "binance", probeAt) {
+		t.Error("success should close the circuit")
+	}
 }
 
 func TestGetPriceWithSigningKey(t *testing.T) {
@@ -679,21 +1728,18 @@ func TestHandleGetPriceError(t *testing.T) {
 // Benchmarks
 // =============================================================================
 
-func BenchmarkCalculateMedian(b *testing.B) {
+func BenchmarkAggregateFeed(b *testing.B) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	svc, _ := New(Config{Marble: m})
 
-	prices := make([]float64, 100)
-	for i := range prices {
-		prices[i] = float64(i * 100)
+	samples := make([]priceSample, 100)
+	for i := range samples {
+		samples[i] = priceSample{value: float64(i * 100), weight: 1}
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		// Make a copy since calculateMedian sorts in place
-		pricesCopy := make([]float64, len(prices))
-		copy(pricesCopy, prices)
-		_ = svc.calculateMedian(pricesCopy)
+		_, _ = svc.aggregateFeed(samples)
 	}
 }
 
@@ -0,0 +1,319 @@
+// Package neofeeds provides signer-set management for the price feed aggregation service.
+package neofeeds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	neokeys "github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+// SignerSet is the set of oracle node keys allowed to sign a feed and the
+// number of their signatures required to accept a value. Version is the
+// persisted version the set was loaded at; it is 0 until first persisted.
+type SignerSet struct {
+	Signers   []string `json:"signers"`
+	Threshold int      `json:"threshold"`
+	Version   int      `json:"version"`
+}
+
+// normalizeSigner validates a compressed public key and returns it as
+// lowercase hex without a 0x prefix.
+func normalizeSigner(signer string) (string, error) {
+	signer = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(signer), "0x"))
+	if signer == "" {
+		return "", fmt.Errorf("signer required")
+	}
+	if _, err := neokeys.NewPublicKeyFromString(signer); err != nil {
+		return "", fmt.Errorf("invalid signer public key %q: %w", signer, err)
+	}
+	return signer, nil
+}
+
+// normalizeSignerSet validates feed.SignerSet and feed.Threshold in place.
+// Without signers the threshold must be unset. With signers it defaults to a
+// simple majority and may not exceed the signer count.
+func normalizeSignerSet(feed *FeedConfig) error {
+	if len(feed.SignerSet) == 0 {
+		if feed.Threshold != 0 {
+			return fmt.Errorf("threshold set without signer_set")
+		}
+		return nil
+	}
+
+	seen := make(map[string]bool, len(feed.SignerSet))
+	for j, signer := range feed.SignerSet {
+		normalized, err := normalizeSigner(signer)
+		if err != nil {
+			return err
+		}
+		if seen[normalized] {
+			return fmt.Errorf("duplicate signer %q", normalized)
+		}
+		seen[normalized] = true
+		feed.SignerSet[j] = normalized
+	}
+
+	if feed.Threshold < 0 {
+		return fmt.Errorf("threshold must be non-negative")
+	}
+	if feed.Threshold == 0 {
+		feed.Threshold = len(feed.SignerSet)/2 + 1
+	}
+	if feed.Threshold > len(feed.SignerSet) {
+		return fmt.Errorf("threshold %d exceeds signer count %d", feed.Threshold, len(feed.SignerSet))
+	}
+	return nil
+}
+
+func signerSetsFromConfig(cfg *FeedsConfig) map[string]SignerSet {
+	sets := make(map[string]SignerSet)
+	if cfg == nil {
+		return sets
+	}
+	for i := range cfg.Feeds {
+		feed := &cfg.Feeds[i]
+		sets[feed.ID] = SignerSet{
+			Signers:   append([]string(nil), feed.SignerSet...),
+			Threshold: feed.Threshold,
+		}
+	}
+	return sets
+}
+
+// loadSignerSets replaces configured signer sets with the ones persisted by
+// earlier AddSigner, RemoveSigner and SetThreshold calls. Persisted sets for
+// feeds no longer configured, or that fail validation, are skipped.
+func (s *Service) loadSignerSets(ctx context.Context) error {
+	if s.DB() == nil {
+		return nil
+	}
+	persisted, err := s.DB().ListFeedSignerSets(ctx)
+	if err != nil {
+		return fmt.Errorf("load feed signer sets: %w", err)
+	}
+
+	s.signersMu.Lock()
+	defer s.signersMu.Unlock()
+	s.applySignerSetsLocked(ctx, persisted)
+	return nil
+}
+
+// applySignerSetsLocked stores persisted signer sets over the current ones.
+// The caller must hold signersMu.
+func (s *Service) applySignerSetsLocked(ctx context.Context, persisted []database.FeedSignerSet) {
+	for _, row := range persisted {
+		id := normalizePair(row.FeedID)
+		feed := FeedConfig{ID: id, SignerSet: append([]string(nil), row.Signers...), Threshold: row.Threshold}
+		_, known := s.signerSets[id]
+		if !known || normalizeSignerSet(&feed) != nil {
+			s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+				"feed_id": row.FeedID,
+			}).Warn("ignoring persisted feed signer set")
+			continue
+		}
+		s.signerSets[id] = SignerSet{Signers: feed.SignerSet, Threshold: feed.Threshold, Version: row.Version}
+	}
+}
+
+// FeedSignerSet returns a copy of the current signer set for feedID.
+func (s *Service) FeedSignerSet(feedID string) (SignerSet, error) {
+	s.signersMu.RLock()
+	defer s.signersMu.RUnlock()
+
+	set, ok := s.signerSets[normalizePair(feedID)]
+	if !ok {
+		return SignerSet{}, fmt.Errorf("unknown feed: %s", feedID)
+	}
+	set.Signers = append([]string(nil), set.Signers...)
+	return set, nil
+}
+
+// AddSigner adds an oracle node key to feedID's signer set. The threshold is
+// unchanged, except that the first signer of an empty set sets it to 1.
+func (s *Service) AddSigner(ctx context.Context, feedID, signer string) error {
+	normalized, err := normalizeSigner(signer)
+	if err != nil {
+		return err
+	}
+
+	return s.updateSignerSet(ctx, feedID, func(set *SignerSet) error {
+		for _, existing := range set.Signers {
+			if existing == normalized {
+				return fmt.Errorf("signer %s already in set", normalized)
+			}
+		}
+		set.Signers = append(set.Signers, normalized)
+		if set.Threshold == 0 {
+			set.Threshold = 1
+		}
+		return nil
+	})
+}
+
+// RemoveSigner removes an oracle node key from feedID's signer set. It fails
+// if the remaining signers could no longer meet the threshold; when rotating
+// keys, add the replacement first or lower the threshold.
+func (s *Service) RemoveSigner(ctx context.Context, feedID, signer string) error {
+	normalized, err := normalizeSigner(signer)
+	if err != nil {
+		return err
+	}
+
+	return s.updateSignerSet(ctx, feedID, func(set *SignerSet) error {
+		idx := -1
+		for i, existing := range set.Signers {
+			if existing == normalized {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return fmt.Errorf("signer %s not in set", normalized)
+		}
+		if remaining := len(set.Signers) - 1; set.Threshold > remaining {
+			return fmt.Errorf("removing signer would leave %d signers for threshold %d", remaining, set.Threshold)
+		}
+		set.Signers = append(set.Signers[:idx], set.Signers[idx+1:]...)
+		return nil
+	})
+}
+
+// SetThreshold sets the number of signatures required for feedID. The
+// threshold must be at least 1 and at most the signer count.
+func (s *Service) SetThreshold(ctx context.Context, feedID string, threshold int) error {
+	return s.updateSignerSet(ctx, feedID, func(set *SignerSet) error {
+		if threshold < 1 {
+			return fmt.Errorf("threshold must be at least 1")
+		}
+		if threshold > len(set.Signers) {
+			return fmt.Errorf("threshold %d exceeds signer count %d", threshold, len(set.Signers))
+		}
+		set.Threshold = threshold
+		return nil
+	})
+}
+
+// updateSignerSet applies fn to a copy of feedID's signer set and stores the
+// result only if fn succeeds and, with a database, the result is persisted.
+// The write is version-checked, so a set changed by another instance since it
+// was loaded fails with a database.VersionConflictError instead of being
+// overwritten; the stored sets are then reloaded so a retry applies to the
+// current set.
+func (s *Service) updateSignerSet(ctx context.Context, feedID string, fn func(*SignerSet) error) error {
+	id := normalizePair(feedID)
+
+	s.signersMu.Lock()
+	defer s.signersMu.Unlock()
+
+	current, ok := s.signerSets[id]
+	if !ok {
+		return fmt.Errorf("unknown feed: %s", feedID)
+	}
+	next := SignerSet{
+		Signers:   append([]string(nil), current.Signers...),
+		Threshold: current.Threshold,
+		Version:   current.Version,
+	}
+	if err := fn(&next); err != nil {
+		return fmt.Errorf("feed %s: %w", id, err)
+	}
+	if s.DB() != nil {
+		record := &database.FeedSignerSet{
+			FeedID:    id,
+			Signers:   next.Signers,
+			Threshold: next.Threshold,
+			Version:   next.Version,
+		}
+		if err := s.DB().SaveFeedSignerSet(ctx, record); err != nil {
+			if database.IsConflict(err) {
+				if persisted, listErr := s.DB().ListFeedSignerSets(ctx); listErr == nil {
+					s.applySignerSetsLocked(ctx, persisted)
+				}
+			}
+			return fmt.Errorf("feed %s: persist signer set: %w", id, err)
+		}
+		next.Version = record.Version
+	}
+	s.signerSets[id] = next
+
+	s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+		"feed_id":   id,
+		"signers":   len(next.Signers),
+		"threshold": next.Threshold,
+	}).Info("feed signer set updated")
+	return nil
+}
+
+// SignedUpdate is one oracle node's signature over a feed update message.
+type SignedUpdate struct {
+	Signer    string `json:"signer"`    // compressed public key (hex)
+	Signature []byte `json:"signature"` // 64-byte r || s over sha256(message)
+}
+
+// AggregateSignatures selects Threshold valid signatures over message from
+// members of set and concatenates them in canonical order: ascending public
+// key, the order CheckMultisig expects for a multisig script built from the
+// signer set. It returns the signatures and their signers in that order.
+// Updates from non-members, duplicates and invalid signatures are ignored;
+// too few remaining signatures is an error.
+func AggregateSignatures(message []byte, updates []SignedUpdate, set SignerSet) ([]byte, []string, error) {
+	if set.Threshold < 1 {
+		return nil, nil, fmt.Errorf("signer set has no threshold")
+	}
+
+	members := make(map[string]bool, len(set.Signers))
+	for _, signer := range set.Signers {
+		members[signer] = true
+	}
+
+	digest := hash.Sha256(message).BytesBE()
+	valid := make(map[string][]byte, len(updates))
+	var keys neokeys.PublicKeys
+	for _, u := range updates {
+		signer, err := normalizeSigner(u.Signer)
+		if err != nil || !members[signer] || valid[signer] != nil {
+			continue
+		}
+		key, err := neokeys.NewPublicKeyFromString(signer)
+		if err != nil || len(u.Signature) != neokeys.SignatureLen || !key.Verify(u.Signature, digest) {
+			continue
+		}
+		valid[signer] = u.Signature
+		keys = append(keys, key)
+	}
+	if len(keys) < set.Threshold {
+		return nil, nil, fmt.Errorf("%d valid signatures, threshold is %d", len(keys), set.Threshold)
+	}
+
+	sort.Sort(keys)
+	keys = keys[:set.Threshold]
+
+	signatures := make([]byte, 0, len(keys)*neokeys.SignatureLen)
+	signers := make([]string, len(keys))
+	for i, key := range keys {
+		signer := key.StringCompressed()
+		signatures = append(signatures, valid[signer]...)
+		signers[i] = signer
+	}
+	return signatures, signers, nil
+}
+
+// AggregateSignatures aggregates updates for feedID against its current
+// signer set.
+func (s *Service) AggregateSignatures(feedID string, message []byte, updates []SignedUpdate) ([]byte, []string, error) {
+	set, err := s.FeedSignerSet(feedID)
+	if err != nil {
+		return nil, nil, err
+	}
+	signatures, signers, err := AggregateSignatures(message, updates, set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("feed %s: %w", normalizePair(feedID), err)
+	}
+	return signatures, signers, nil
+}
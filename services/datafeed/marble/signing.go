@@ -0,0 +1,156 @@
+// Package neofeeds provides outbound request signing for the price feed aggregation service.
+package neofeeds
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request signing algorithms.
+const (
+	SigningHMACSHA256 = "hmac-sha256" // default
+	SigningHMACSHA512 = "hmac-sha512"
+)
+
+// Signature encodings.
+const (
+	SignatureHex    = "hex" // default
+	SignatureBase64 = "base64"
+)
+
+// Timestamp formats for signed requests.
+const (
+	TimestampUnix    = "unix" // seconds (default)
+	TimestampUnixMs  = "unix_ms"
+	TimestampRFC3339 = "rfc3339"
+)
+
+// defaultSigningPayload is the canonical string signed when Payload is unset.
+const defaultSigningPayload = "{timestamp}{method}{path}{query}{body}"
+
+// RequestSigningConfig signs outbound source requests with an HMAC, for
+// exchange APIs that require signed requests rather than a static key.
+//
+// Payload is the canonical string template. Placeholders: {timestamp},
+// {method}, {path}, {query} (raw query string without "?") and {body}.
+type RequestSigningConfig struct {
+	// Secret is the HMAC key; use a "${ENV_VAR}" reference so it is injected
+	// into the enclave rather than stored in config.
+	Secret          string `json:"secret" yaml:"secret"`
+	Algorithm       string `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	Encoding        string `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	Payload         string `json:"payload,omitempty" yaml:"payload,omitempty"`
+	SignatureHeader string `json:"signature_header" yaml:"signature_header"`
+	// TimestampHeader optionally carries the signed timestamp.
+	TimestampHeader string `json:"timestamp_header,omitempty" yaml:"timestamp_header,omitempty"`
+	TimestampFormat string `json:"timestamp_format,omitempty" yaml:"timestamp_format,omitempty"`
+}
+
+// Validate checks the signing scheme and fills defaults.
+func (c *RequestSigningConfig) Validate() error {
+	if strings.TrimSpace(c.Secret) == "" {
+		return fmt.Errorf("signing secret required")
+	}
+	if strings.TrimSpace(c.SignatureHeader) == "" {
+		return fmt.Errorf("signing signature_header required")
+	}
+
+	c.Algorithm = strings.ToLower(strings.TrimSpace(c.Algorithm))
+	switch c.Algorithm {
+	case "":
+		c.Algorithm = SigningHMACSHA256
+	case SigningHMACSHA256, SigningHMACSHA512:
+	default:
+		return fmt.Errorf("unknown signing algorithm %q", c.Algorithm)
+	}
+
+	c.Encoding = strings.ToLower(strings.TrimSpace(c.Encoding))
+	switch c.Encoding {
+	case "":
+		c.Encoding = SignatureHex
+	case SignatureHex, SignatureBase64:
+	default:
+		return fmt.Errorf("unknown signature encoding %q", c.Encoding)
+	}
+
+	c.TimestampFormat = strings.ToLower(strings.TrimSpace(c.TimestampFormat))
+	switch c.TimestampFormat {
+	case "":
+		c.TimestampFormat = TimestampUnix
+	case TimestampUnix, TimestampUnixMs, TimestampRFC3339:
+	default:
+		return fmt.Errorf("unknown timestamp format %q", c.TimestampFormat)
+	}
+
+	if c.Payload == "" {
+		c.Payload = defaultSigningPayload
+	}
+	return nil
+}
+
+// signRequest computes the HMAC over the canonical string for req and body
+// at now and sets the signature (and timestamp) headers.
+func signRequest(req *http.Request, body []byte, cfg *RequestSigningConfig, now time.Time) error {
+	// resolveEnvVar returns unset "${VAR}" references unchanged; never sign
+	// with the reference itself.
+	secret := resolveEnvVar(cfg.Secret)
+	if secret == "" || (secret == cfg.Secret && strings.HasPrefix(secret, "${")) {
+		return fmt.Errorf("signing secret not configured")
+	}
+
+	var newHash func() hash.Hash
+	switch cfg.Algorithm {
+	case SigningHMACSHA256, "":
+		newHash = sha256.New
+	case SigningHMACSHA512:
+		newHash = sha512.New
+	default:
+		return fmt.Errorf("unknown signing algorithm %q", cfg.Algorithm)
+	}
+
+	var timestamp string
+	switch cfg.TimestampFormat {
+	case TimestampUnixMs:
+		timestamp = strconv.FormatInt(now.UnixMilli(), 10)
+	case TimestampRFC3339:
+		timestamp = now.UTC().Format(time.RFC3339)
+	default:
+		timestamp = strconv.FormatInt(now.Unix(), 10)
+	}
+
+	payload := cfg.Payload
+	if payload == "" {
+		payload = defaultSigningPayload
+	}
+	canonical := strings.NewReplacer(
+		"{timestamp}", timestamp,
+		"{method}", req.Method,
+		"{path}", req.URL.EscapedPath(),
+		"{query}", req.URL.RawQuery,
+		"{body}", string(body),
+	).Replace(payload)
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write([]byte(canonical))
+	sum := mac.Sum(nil)
+
+	signature := hex.EncodeToString(sum)
+	if cfg.Encoding == SignatureBase64 {
+		signature = base64.StdEncoding.EncodeToString(sum)
+	}
+
+	req.Header.Set(cfg.SignatureHeader, signature)
+	if cfg.TimestampHeader != "" {
+		req.Header.Set(cfg.TimestampHeader, timestamp)
+	}
+	return nil
+}
@@ -17,15 +17,54 @@ type PriceSource struct {
 
 // PriceResponse represents a price response.
 // Note: Price uses string serialization to avoid JS Number precision loss for large values.
+// Sources lists the sources that contributed after outlier rejection and
+// Confidence is the share of responding sources that agreed (0-1).
 type PriceResponse struct {
-	FeedID    string    `json:"feed_id"`
-	Pair      string    `json:"pair"`
-	Price     int64     `json:"price,string"`
-	Decimals  int       `json:"decimals"`
-	Timestamp time.Time `json:"timestamp"`
-	Sources   []string  `json:"sources"`
-	Signature []byte    `json:"signature,omitempty"`
-	PublicKey []byte    `json:"public_key,omitempty"`
+	FeedID      string    `json:"feed_id"`
+	Pair        string    `json:"pair"`
+	Price       int64     `json:"price,string"`
+	Decimals    int       `json:"decimals"`
+	Timestamp   time.Time `json:"timestamp"`
+	Sources     []string  `json:"sources"`
+	SourceCount int       `json:"source_count,omitempty"`
+	Confidence  float64   `json:"confidence,omitempty"`
+	Signature   []byte    `json:"signature,omitempty"`
+	PublicKey   []byte    `json:"public_key,omitempty"`
+	// Backfilled marks a historical value recorded by Backfill; it is never
+	// signed.
+	Backfilled bool `json:"backfilled,omitempty"`
+}
+
+// BackfillRequest is the body of POST /admin/feeds/{id}/backfill. Step is a
+// Go duration string (e.g. "1h").
+type BackfillRequest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Step string    `json:"step"`
+}
+
+// AddSignerRequest is the body of POST /admin/feeds/{id}/signers.
+type AddSignerRequest struct {
+	Signer string `json:"signer"` // compressed public key (hex)
+}
+
+// SetThresholdRequest is the body of PUT /admin/feeds/{id}/threshold.
+type SetThresholdRequest struct {
+	Threshold int `json:"threshold"`
+}
+
+// BackfillJob reports the progress of a feed's latest backfill. Recorded is
+// set once the job has finished.
+type BackfillJob struct {
+	FeedID     string     `json:"feed_id"`
+	From       time.Time  `json:"from"`
+	To         time.Time  `json:"to"`
+	Step       string     `json:"step"`
+	Status     string     `json:"status"` // running, completed or failed
+	Recorded   int        `json:"recorded"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
 }
 
 // FeedSummary represents a feed entry returned by GET /feeds.
@@ -35,6 +74,7 @@ type FeedSummary struct {
 	SourcePair string `json:"source_pair,omitempty"`
 	Enabled    bool   `json:"enabled"`
 	Decimals   int    `json:"decimals"`
+	Status     string `json:"status"` // active, or error when every source is unhealthy
 }
 
 // SourceSummary represents a configured source returned by GET /sources.
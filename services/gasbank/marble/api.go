@@ -16,6 +16,10 @@ func (s *Service) registerRoutes() {
 	router.HandleFunc("/transactions", s.handleGetTransactions).Methods(http.MethodGet)
 	router.HandleFunc("/deposits", s.handleGetDeposits).Methods(http.MethodGet)
 
+	// Admin endpoints (role checked in handler via httputil.RequireAdminRole)
+	router.HandleFunc("/admin/accounts/{user_id}/limits", s.handleGetSpendingLimits).Methods(http.MethodGet)
+	router.HandleFunc("/admin/accounts/{user_id}/limits", s.handleSetSpendingLimits).Methods(http.MethodPut)
+
 	// Service-to-service endpoints (require mTLS service authentication)
 	router.Handle("/deduct", middleware.RequireServiceAuth(http.HandlerFunc(s.handleDeductFee))).Methods(http.MethodPost)
 	router.Handle("/reserve", middleware.RequireServiceAuth(http.HandlerFunc(s.handleReserveFunds))).Methods(http.MethodPost)
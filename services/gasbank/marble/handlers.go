@@ -1,8 +1,11 @@
 package neogasbank
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/R3E-Network/service_layer/infrastructure/httputil"
 )
 
@@ -43,6 +46,10 @@ func (s *Service) handleDeductFee(w http.ResponseWriter, r *http.Request) {
 	req.ServiceID = serviceID
 
 	resp, err := s.DeductFee(r.Context(), &req)
+	if errors.Is(err, ErrSpendingLimitExceeded) {
+		httputil.WriteJSON(w, http.StatusTooManyRequests, resp)
+		return
+	}
 	if err != nil {
 		httputil.InternalError(w, err.Error())
 		return
@@ -70,6 +77,10 @@ func (s *Service) handleReserveFunds(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp, err := s.ReserveFunds(r.Context(), &req)
+	if errors.Is(err, ErrSpendingLimitExceeded) {
+		httputil.WriteJSON(w, http.StatusTooManyRequests, resp)
+		return
+	}
 	if err != nil {
 		httputil.InternalError(w, err.Error())
 		return
@@ -180,3 +191,42 @@ func (s *Service) handleGetDeposits(w http.ResponseWriter, r *http.Request) {
 
 	httputil.WriteJSON(w, http.StatusOK, map[string]any{"deposits": result})
 }
+
+// handleGetSpendingLimits returns a user's spending limits (admin only).
+func (s *Service) handleGetSpendingLimits(w http.ResponseWriter, r *http.Request) {
+	if !httputil.RequireAdminRole(w, r) {
+		return
+	}
+
+	limits, err := s.GetSpendingLimits(r.Context(), mux.Vars(r)["user_id"])
+	if err != nil {
+		httputil.InternalError(w, err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, limits)
+}
+
+// handleSetSpendingLimits replaces a user's spending limits (admin only).
+func (s *Service) handleSetSpendingLimits(w http.ResponseWriter, r *http.Request) {
+	if !httputil.RequireAdminRole(w, r) {
+		return
+	}
+
+	var req SetSpendingLimitsRequest
+	if !httputil.DecodeJSON(w, r, &req) {
+		return
+	}
+	if req.MaxPerTx < 0 || req.MaxPerDay < 0 {
+		httputil.BadRequest(w, "limits cannot be negative")
+		return
+	}
+
+	limits, err := s.SetSpendingLimits(r.Context(), mux.Vars(r)["user_id"], &req)
+	if err != nil {
+		httputil.InternalError(w, err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, limits)
+}
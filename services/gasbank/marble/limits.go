@@ -0,0 +1,141 @@
+package neogasbank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+// Spending limits reported by SpendingLimitError.
+const (
+	LimitPerTx  = "max_per_tx"
+	LimitPerDay = "max_per_day"
+)
+
+// ErrSpendingLimitExceeded is matched (via errors.Is) by every
+// *SpendingLimitError.
+var ErrSpendingLimitExceeded = errors.New("spending limit exceeded")
+
+// SpendingLimitError reports a spend rejected by an account's spending policy.
+type SpendingLimitError struct {
+	Limit     string // LimitPerTx or LimitPerDay
+	Max       int64
+	Requested int64
+	Spent     int64 // spent so far today; only set for LimitPerDay
+}
+
+func (e *SpendingLimitError) Error() string {
+	if e.Limit == LimitPerDay {
+		return fmt.Sprintf("%s: daily limit %d, spent today %d, requested %d", ErrSpendingLimitExceeded, e.Max, e.Spent, e.Requested)
+	}
+	return fmt.Sprintf("%s: per-transaction limit %d, requested %d", ErrSpendingLimitExceeded, e.Max, e.Requested)
+}
+
+func (e *SpendingLimitError) Unwrap() error { return ErrSpendingLimitExceeded }
+
+// utcDay returns the UTC calendar day of t as YYYY-MM-DD. Daily spend resets
+// when it changes, at UTC midnight.
+func utcDay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// spentToday returns the account's spend for the UTC day containing now.
+func spentToday(account *database.GasBankAccount, now time.Time) int64 {
+	if account.DailySpentDate != utcDay(now) {
+		return 0
+	}
+	return account.DailySpent
+}
+
+// checkSpendingLimits returns a *SpendingLimitError if spending amount at now
+// would exceed the account's per-transaction or daily limit.
+func checkSpendingLimits(account *database.GasBankAccount, amount int64, now time.Time) error {
+	if account.MaxPerTx > 0 && amount > account.MaxPerTx {
+		return &SpendingLimitError{Limit: LimitPerTx, Max: account.MaxPerTx, Requested: amount}
+	}
+	if account.MaxPerDay > 0 {
+		spent := spentToday(account, now)
+		if amount > account.MaxPerDay-spent {
+			return &SpendingLimitError{Limit: LimitPerDay, Max: account.MaxPerDay, Requested: amount, Spent: spent}
+		}
+	}
+	return nil
+}
+
+// recordDailySpend adds amount to the account's spend for today. Failures are
+// logged: the spend itself has already been committed.
+func (s *Service) recordDailySpend(ctx context.Context, account *database.GasBankAccount, amount int64, now time.Time) {
+	spent := spentToday(account, now) + amount
+	if err := s.db.UpdateGasBankDailySpend(ctx, account.UserID, utcDay(now), spent); err != nil {
+		s.Logger().WithContext(ctx).WithError(err).WithField("user_id", account.UserID).Warn("failed to record daily spend")
+	}
+}
+
+// creditDailySpend takes amount, spent at spentAt, back off the account's
+// spend for the day containing now. Spend from an earlier day has already
+// rolled over, so there is nothing to credit.
+func (s *Service) creditDailySpend(ctx context.Context, account *database.GasBankAccount, amount int64, spentAt, now time.Time) {
+	if utcDay(spentAt) != utcDay(now) || account.DailySpentDate != utcDay(now) {
+		return
+	}
+	spent := account.DailySpent - amount
+	if spent < 0 {
+		spent = 0
+	}
+	if err := s.db.UpdateGasBankDailySpend(ctx, account.UserID, utcDay(now), spent); err != nil {
+		s.Logger().WithContext(ctx).WithError(err).WithField("user_id", account.UserID).Warn("failed to credit daily spend")
+	}
+}
+
+// GetSpendingLimits returns a user's spending limits and today's spend.
+func (s *Service) GetSpendingLimits(ctx context.Context, userID string) (*SpendingLimitsResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+
+	account, err := s.db.GetOrCreateGasBankAccount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+	return spendingLimitsResponse(account, time.Now()), nil
+}
+
+// SetSpendingLimits replaces a user's spending limits. Zero disables a limit.
+func (s *Service) SetSpendingLimits(ctx context.Context, userID string, req *SetSpendingLimitsRequest) (*SpendingLimitsResponse, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if req.MaxPerTx < 0 || req.MaxPerDay < 0 {
+		return nil, fmt.Errorf("limits cannot be negative")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, err := s.db.GetOrCreateGasBankAccount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+	if err := s.db.UpdateGasBankSpendingLimits(ctx, userID, req.MaxPerTx, req.MaxPerDay); err != nil {
+		return nil, fmt.Errorf("update limits: %w", err)
+	}
+
+	updated := *account
+	updated.MaxPerTx = req.MaxPerTx
+	updated.MaxPerDay = req.MaxPerDay
+	return spendingLimitsResponse(&updated, time.Now()), nil
+}
+
+func spendingLimitsResponse(account *database.GasBankAccount, now time.Time) *SpendingLimitsResponse {
+	y, m, d := now.UTC().Date()
+	return &SpendingLimitsResponse{
+		UserID:     account.UserID,
+		MaxPerTx:   account.MaxPerTx,
+		MaxPerDay:  account.MaxPerDay,
+		SpentToday: spentToday(account, now),
+		ResetsAt:   time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,229 @@
+package neogasbank
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+func TestCheckSpendingLimitsExactlyMaxPerTx(t *testing.T) {
+	account := &database.GasBankAccount{MaxPerTx: 1000}
+	now := time.Now()
+
+	if err := checkSpendingLimits(account, 1000, now); err != nil {
+		t.Fatalf("spend of exactly MaxPerTx rejected: %v", err)
+	}
+
+	err := checkSpendingLimits(account, 1001, now)
+	var limitErr *SpendingLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != LimitPerTx || limitErr.Max != 1000 || limitErr.Requested != 1001 {
+		t.Fatalf("checkSpendingLimits() = %v, want per-tx SpendingLimitError", err)
+	}
+	if !errors.Is(err, ErrSpendingLimitExceeded) {
+		t.Error("SpendingLimitError should match ErrSpendingLimitExceeded")
+	}
+}
+
+func TestCheckSpendingLimitsDailyRollover(t *testing.T) {
+	lastInstant := time.Date(2026, 3, 14, 23, 59, 59, 999999999, time.UTC)
+	midnight := lastInstant.Add(time.Nanosecond)
+	account := &database.GasBankAccount{
+		MaxPerDay:      1000,
+		DailySpent:     900,
+		DailySpentDate: utcDay(lastInstant),
+	}
+
+	// Still the same UTC day: only 100 remains.
+	if err := checkSpendingLimits(account, 100, lastInstant); err != nil {
+		t.Fatalf("spend of remaining budget rejected: %v", err)
+	}
+	var limitErr *SpendingLimitError
+	if err := checkSpendingLimits(account, 101, lastInstant); !errors.As(err, &limitErr) || limitErr.Limit != LimitPerDay || limitErr.Spent != 900 {
+		t.Fatalf("checkSpendingLimits() = %v, want daily SpendingLimitError with spent 900", err)
+	}
+
+	// At UTC midnight the daily spend resets.
+	if got := spentToday(account, midnight); got != 0 {
+		t.Errorf("spentToday() at midnight = %d, want 0", got)
+	}
+	if err := checkSpendingLimits(account, 1000, midnight); err != nil {
+		t.Fatalf("full daily budget rejected after rollover: %v", err)
+	}
+
+	// The day boundary is UTC regardless of the caller's zone.
+	east := time.FixedZone("UTC+9", 9*60*60)
+	if got := spentToday(account, midnight.In(east)); got != 0 {
+		t.Errorf("spentToday() in UTC+9 = %d, want 0", got)
+	}
+}
+
+func TestDeductFeeEnforcesSpendingLimits(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{
+		ID: "acc1", UserID: "user1", Balance: 10000, MaxPerTx: 500, MaxPerDay: 800,
+	})
+
+	resp, err := svc.DeductFee(ctx, &DeductFeeRequest{UserID: "user1", Amount: 500, ServiceID: "neofeeds"})
+	if err != nil || !resp.Success {
+		t.Fatalf("DeductFee(500) = %+v, %v; want success", resp, err)
+	}
+
+	// 500 spent, 300 left today.
+	resp, err = svc.DeductFee(ctx, &DeductFeeRequest{UserID: "user1", Amount: 400, ServiceID: "neofeeds"})
+	var limitErr *SpendingLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != LimitPerDay {
+		t.Fatalf("DeductFee(400) error = %v, want daily SpendingLimitError", err)
+	}
+	if resp == nil || resp.Success || resp.BalanceAfter != 9500 {
+		t.Errorf("DeductFee(400) resp = %+v, want failure with balance 9500", resp)
+	}
+
+	limits, err := svc.GetSpendingLimits(ctx, "user1")
+	if err != nil {
+		t.Fatalf("GetSpendingLimits() error = %v", err)
+	}
+	if limits.SpentToday != 500 {
+		t.Errorf("SpentToday = %d, want 500", limits.SpentToday)
+	}
+}
+
+func TestReserveFundsEnforcesSpendingLimits(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 10000, MaxPerTx: 500})
+
+	resp, err := svc.ReserveFunds(ctx, &ReserveFundsRequest{UserID: "user1", Amount: 501})
+	if !errors.Is(err, ErrSpendingLimitExceeded) || resp.Success || resp.Error == "" {
+		t.Fatalf("ReserveFunds(501) = %+v, %v; want spending limit rejection", resp, err)
+	}
+
+	if _, err := svc.ReserveFunds(ctx, &ReserveFundsRequest{UserID: "user1", Amount: 500}); err != nil {
+		t.Fatalf("ReserveFunds(500) error = %v", err)
+	}
+	if _, err := svc.ReleaseFunds(ctx, &ReleaseFundsRequest{UserID: "user1", Amount: 500, Commit: true}); err != nil {
+		t.Fatalf("ReleaseFunds() error = %v", err)
+	}
+	limits, _ := svc.GetSpendingLimits(ctx, "user1")
+	if limits.SpentToday != 500 {
+		t.Errorf("SpentToday after commit = %d, want 500", limits.SpentToday)
+	}
+}
+
+func TestConcurrentReservationsShareDailyLimit(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 10000, MaxPerDay: 800})
+
+	// Two outstanding 500 reservations would together exceed the 800 daily
+	// limit, so exactly one of them may succeed.
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = svc.ReserveFunds(ctx, &ReserveFundsRequest{UserID: "user1", Amount: 500})
+		}(i)
+	}
+	wg.Wait()
+
+	var limited int
+	for _, err := range errs {
+		if errors.Is(err, ErrSpendingLimitExceeded) {
+			limited++
+		} else if err != nil {
+			t.Fatalf("ReserveFunds() error = %v", err)
+		}
+	}
+	if limited != 1 {
+		t.Fatalf("ReserveFunds() errors = %v, want exactly one daily limit rejection", errs)
+	}
+	if limits, _ := svc.GetSpendingLimits(ctx, "user1"); limits.SpentToday != 500 {
+		t.Errorf("SpentToday with a reservation outstanding = %d, want 500", limits.SpentToday)
+	}
+
+	// Releasing without commit gives the budget back.
+	if resp, err := svc.ReleaseFunds(ctx, &ReleaseFundsRequest{UserID: "user1", Amount: 500}); err != nil || !resp.Success {
+		t.Fatalf("ReleaseFunds() = %+v, %v", resp, err)
+	}
+	if limits, _ := svc.GetSpendingLimits(ctx, "user1"); limits.SpentToday != 0 {
+		t.Errorf("SpentToday after release = %d, want 0", limits.SpentToday)
+	}
+	if _, err := svc.ReserveFunds(ctx, &ReserveFundsRequest{UserID: "user1", Amount: 800}); err != nil {
+		t.Errorf("ReserveFunds(800) after release error = %v", err)
+	}
+}
+
+func TestHandleDeductFeeSpendingLimit(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB})
+	mockDB.CreateGasBankAccount(context.Background(), &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 10000, MaxPerTx: 10})
+
+	body, _ := json.Marshal(DeductFeeRequest{UserID: "user1", Amount: 11})
+	req := httptest.NewRequest(http.MethodPost, "/deduct", bytes.NewReader(body))
+	req.Header.Set("X-Service-ID", "neofeeds")
+	w := httptest.NewRecorder()
+	svc.handleDeductFee(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestHandleSpendingLimitsAdmin(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB})
+	mockDB.CreateGasBankAccount(context.Background(), &database.GasBankAccount{ID: "acc1", UserID: "user1"})
+
+	body := []byte(`{"max_per_tx":"100","max_per_day":"1000"}`)
+
+	// Non-admin callers are rejected.
+	req := httptest.NewRequest(http.MethodPut, "/admin/accounts/user1/limits", bytes.NewReader(body))
+	req.Header.Set("X-User-Role", "user")
+	w := httptest.NewRecorder()
+	svc.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("non-admin status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/admin/accounts/user1/limits", bytes.NewReader(body))
+	req.Header.Set("X-User-Role", "admin")
+	w = httptest.NewRecorder()
+	svc.Router().ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/accounts/user1/limits", nil)
+	req.Header.Set("X-User-Role", "admin")
+	w = httptest.NewRecorder()
+	svc.Router().ServeHTTP(w, req)
+	var limits SpendingLimitsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &limits); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if limits.MaxPerTx != 100 || limits.MaxPerDay != 1000 {
+		t.Errorf("limits = %+v, want 100/1000", limits)
+	}
+}
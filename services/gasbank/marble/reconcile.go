@@ -0,0 +1,415 @@
+package neogasbank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/metrics"
+)
+
+const (
+	// ReconcileInterval is how often accounts are reconciled.
+	ReconcileInterval = time.Hour
+
+	// ReconcileBatchSize is the maximum number of accounts reconciled per run.
+	// Queued accounts go first; the rest of the batch continues a scan over
+	// all accounts so untouched ones are reconciled too.
+	ReconcileBatchSize = 100
+
+	// ReconcileDriftThreshold is the largest drift (in 8 decimals) tolerated
+	// before an account is flagged. 0.001 GAS = 100000 (10^5)
+	ReconcileDriftThreshold = 100000
+
+	// reconcilePageSize is how many ledger or deposit rows are read per query.
+	reconcilePageSize = 1000
+)
+
+// Discrepancy kinds reported by Reconcile.
+const (
+	// DiscrepancyDepositNotOnChain: a credited deposit has no matching
+	// confirmed GAS transfer on chain.
+	DiscrepancyDepositNotOnChain = "deposit_not_on_chain"
+	// DiscrepancyLedgerMismatch: the recorded balance differs from the sum of
+	// the account's transactions.
+	DiscrepancyLedgerMismatch = "ledger_mismatch"
+	// DiscrepancyBalanceDrift: the recorded balance differs from the balance
+	// backed by on-chain deposits by more than ReconcileDriftThreshold.
+	DiscrepancyBalanceDrift = "balance_drift"
+)
+
+// Discrepancy is one reconciliation finding with its suggested correction.
+// Amount is the signed adjustment that would resolve it.
+type Discrepancy struct {
+	Kind        string `json:"kind"`
+	ReferenceID string `json:"reference_id,omitempty"`
+	TxHash      string `json:"tx_hash,omitempty"`
+	Amount      int64  `json:"amount,string"`
+	Detail      string `json:"detail"`
+	Correction  string `json:"correction"`
+}
+
+// PendingDeposit is a deposit whose transfer is not yet confirmed on chain, or
+// is confirmed but not yet credited. Pending deposits never count as drift.
+type PendingDeposit struct {
+	DepositID     string `json:"deposit_id,omitempty"`
+	TxHash        string `json:"tx_hash"`
+	Amount        int64  `json:"amount,string"`
+	Confirmations int    `json:"confirmations"`
+	Credited      bool   `json:"credited"`
+}
+
+// ReconcileReport compares an account's recorded balance against its ledger
+// and the on-chain GAS transfers that back its deposits.
+type ReconcileReport struct {
+	UserID    string `json:"user_id"`
+	AccountID string `json:"account_id"`
+
+	RecordedBalance int64 `json:"recorded_balance,string"`
+	LedgerBalance   int64 `json:"ledger_balance,string"`
+	// ExpectedBalance is the ledger balance with deposits that could not be
+	// verified on chain removed.
+	ExpectedBalance int64 `json:"expected_balance,string"`
+	// Drift is RecordedBalance - ExpectedBalance.
+	Drift         int64 `json:"drift,string"`
+	DriftDetected bool  `json:"drift_detected"`
+
+	Discrepancies   []Discrepancy    `json:"discrepancies,omitempty"`
+	PendingDeposits []PendingDeposit `json:"pending_deposits,omitempty"`
+	CheckedAt       time.Time        `json:"checked_at"`
+}
+
+// Reconcile checks a user's gas bank account against on-chain state. Every
+// credited deposit is re-verified against its GAS transfer; deposits that no
+// longer verify are excluded from the expected balance and reported with a
+// suggested debit. Deposits whose transfer has not confirmed yet are listed
+// as pending and do not count as drift. Reconcile only reports; it never
+// changes balances.
+func (s *Service) Reconcile(ctx context.Context, userID string) (*ReconcileReport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if s.chainClient == nil {
+		return nil, fmt.Errorf("chain client not configured")
+	}
+	if s.db == nil {
+		return nil, fmt.Errorf("database not configured")
+	}
+
+	account, err := s.db.GetGasBankAccount(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+	txs, err := s.accountTransactions(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+	deposits, err := s.userDeposits(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get deposits: %w", err)
+	}
+
+	report := &ReconcileReport{
+		UserID:          userID,
+		AccountID:       account.ID,
+		RecordedBalance: account.Balance,
+		CheckedAt:       time.Now(),
+	}
+
+	credited := make(map[string]bool)
+	var unverified int64
+	for i := range txs {
+		tx := &txs[i]
+		report.LedgerBalance += tx.Amount
+		if tx.TxType != string(TxTypeDeposit) || tx.Amount <= 0 {
+			continue
+		}
+		credited[tx.ReferenceID] = true
+
+		if tx.TxHash == "" {
+			unverified += tx.Amount
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:        DiscrepancyDepositNotOnChain,
+				ReferenceID: tx.ReferenceID,
+				Amount:      -tx.Amount,
+				Detail:      "credited deposit has no transaction hash",
+				Correction:  fmt.Sprintf("debit %d unless the deposit can be traced on chain", tx.Amount),
+			})
+			continue
+		}
+
+		state, err := s.depositChainState(ctx, tx.TxHash, tx.FromAddress, tx.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("verify deposit %s: %w", tx.TxHash, err)
+		}
+		switch {
+		case state.mismatch != nil:
+			unverified += tx.Amount
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:        DiscrepancyDepositNotOnChain,
+				ReferenceID: tx.ReferenceID,
+				TxHash:      tx.TxHash,
+				Amount:      -tx.Amount,
+				Detail:      state.mismatch.Error(),
+				Correction:  fmt.Sprintf("debit %d credited for an unverifiable transfer", tx.Amount),
+			})
+		case !state.confirmed:
+			// Credited before the transfer reached the required confirmations
+			// (e.g. after a reorg); recheck on the next run.
+			report.PendingDeposits = append(report.PendingDeposits, PendingDeposit{
+				DepositID:     tx.ReferenceID,
+				TxHash:        tx.TxHash,
+				Amount:        tx.Amount,
+				Confirmations: state.confirmations,
+				Credited:      true,
+			})
+		}
+	}
+
+	for i := range deposits {
+		deposit := &deposits[i]
+		if credited[deposit.ID] || deposit.TxHash == "" {
+			continue
+		}
+		if deposit.Status != string(DepositStatusPending) && deposit.Status != string(DepositStatusConfirming) {
+			continue
+		}
+		state, err := s.depositChainState(ctx, deposit.TxHash, deposit.FromAddress, deposit.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("verify deposit %s: %w", deposit.TxHash, err)
+		}
+		if state.mismatch != nil {
+			// The deposit verifier marks these failed; nothing was credited.
+			continue
+		}
+		report.PendingDeposits = append(report.PendingDeposits, PendingDeposit{
+			DepositID:     deposit.ID,
+			TxHash:        deposit.TxHash,
+			Amount:        deposit.Amount,
+			Confirmations: state.confirmations,
+		})
+	}
+
+	if report.LedgerBalance != report.RecordedBalance {
+		diff := report.LedgerBalance - report.RecordedBalance
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:       DiscrepancyLedgerMismatch,
+			Amount:     diff,
+			Detail:     fmt.Sprintf("recorded balance %d, transactions sum to %d", report.RecordedBalance, report.LedgerBalance),
+			Correction: "record the missing transactions or " + describeAdjustment(diff),
+		})
+	}
+
+	report.ExpectedBalance = report.LedgerBalance - unverified
+	report.Drift = report.RecordedBalance - report.ExpectedBalance
+	if report.Drift > ReconcileDriftThreshold || report.Drift < -ReconcileDriftThreshold {
+		report.DriftDetected = true
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:       DiscrepancyBalanceDrift,
+			Amount:     -report.Drift,
+			Detail:     fmt.Sprintf("recorded balance %d, on-chain backed balance %d", report.RecordedBalance, report.ExpectedBalance),
+			Correction: describeAdjustment(-report.Drift),
+		})
+	}
+
+	return report, nil
+}
+
+// accountTransactions reads an account's full ledger, oldest first.
+func (s *Service) accountTransactions(ctx context.Context, accountID string) ([]database.GasBankTransaction, error) {
+	var all []database.GasBankTransaction
+	var afterCreatedAt time.Time
+	var afterID string
+	for {
+		page, err := s.db.GetGasBankTransactionsAfter(ctx, accountID, afterCreatedAt, afterID, reconcilePageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < reconcilePageSize {
+			return all, nil
+		}
+		last := page[len(page)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+	}
+}
+
+// userDeposits reads all of a user's deposit requests, oldest first.
+func (s *Service) userDeposits(ctx context.Context, userID string) ([]database.DepositRequest, error) {
+	var all []database.DepositRequest
+	var afterCreatedAt time.Time
+	var afterID string
+	for {
+		page, err := s.db.GetDepositRequestsAfter(ctx, userID, afterCreatedAt, afterID, reconcilePageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < reconcilePageSize {
+			return all, nil
+		}
+		last := page[len(page)-1]
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+	}
+}
+
+func describeAdjustment(amount int64) string {
+	if amount < 0 {
+		return fmt.Sprintf("debit %d", -amount)
+	}
+	return fmt.Sprintf("credit %d", amount)
+}
+
+// depositState is the on-chain state of a deposit's GAS transfer.
+type depositState struct {
+	confirmed     bool
+	confirmations int
+	mismatch      error // set when the transfer does not back the deposit
+}
+
+// depositChainState looks up a deposit transfer on chain. A transaction the
+// node does not know yet is reported as unconfirmed; transport failures are
+// returned as errors so a flaky RPC endpoint is never reported as drift.
+func (s *Service) depositChainState(ctx context.Context, txHash, fromAddress string, amount int64) (depositState, error) {
+	confirmed, confirmations, err := s.verifyTransaction(ctx, txHash, fromAddress, amount)
+	var rpcErr *chain.RPCError
+	switch {
+	case err == nil:
+		return depositState{confirmed: confirmed, confirmations: confirmations}, nil
+	case errors.Is(err, errDepositMismatch):
+		return depositState{mismatch: err}, nil
+	case errors.As(err, &rpcErr):
+		return depositState{}, nil
+	default:
+		return depositState{}, err
+	}
+}
+
+// =============================================================================
+// Reconciliation Worker
+// =============================================================================
+
+// markForReconcile queues a user's account for the next reconciliation run.
+func (s *Service) markForReconcile(userID string) {
+	if userID == "" {
+		return
+	}
+	s.reconcileMu.Lock()
+	s.reconcileQueue[userID] = struct{}{}
+	s.reconcileMu.Unlock()
+}
+
+// takeReconcileBatch removes up to ReconcileBatchSize queued users.
+// Queued users are only held in memory; accounts lost on restart are still
+// reached by the account scan.
+func (s *Service) takeReconcileBatch() []string {
+	s.reconcileMu.Lock()
+	defer s.reconcileMu.Unlock()
+
+	batch := make([]string, 0, min(len(s.reconcileQueue), ReconcileBatchSize))
+	for userID := range s.reconcileQueue {
+		if len(batch) >= ReconcileBatchSize {
+			break
+		}
+		batch = append(batch, userID)
+		delete(s.reconcileQueue, userID)
+	}
+	return batch
+}
+
+// scanReconcileBatch appends accounts from the rolling scan over all accounts
+// until batch holds ReconcileBatchSize users. The scan wraps around once it
+// reaches the last account.
+func (s *Service) scanReconcileBatch(ctx context.Context, batch []string) ([]string, error) {
+	seen := make(map[string]bool, len(batch))
+	for _, userID := range batch {
+		seen[userID] = true
+	}
+
+	s.reconcileMu.Lock()
+	cursor := s.reconcileCursor
+	s.reconcileMu.Unlock()
+
+	want := ReconcileBatchSize - len(batch)
+	if want <= 0 {
+		return batch, nil
+	}
+	accounts, err := s.db.ListGasBankAccounts(ctx, cursor, want)
+	if err != nil {
+		return batch, err
+	}
+	next := ""
+	if len(accounts) == want {
+		next = accounts[len(accounts)-1].ID
+	}
+	s.reconcileMu.Lock()
+	s.reconcileCursor = next
+	s.reconcileMu.Unlock()
+
+	for i := range accounts {
+		if userID := accounts[i].UserID; !seen[userID] {
+			seen[userID] = true
+			batch = append(batch, userID)
+		}
+	}
+	return batch, nil
+}
+
+// processReconciliation reconciles accounts whose balance changed since the
+// last run, then continues the scan over all accounts. Drifting accounts are
+// logged and counted in the reconcile_drift statistic and, when metrics are
+// enabled, as balance_drift errors.
+func (s *Service) processReconciliation(ctx context.Context) {
+	if s.chainClient == nil || s.db == nil {
+		return
+	}
+
+	batch, err := s.scanReconcileBatch(ctx, s.takeReconcileBatch())
+	if err != nil {
+		s.Logger().WithContext(ctx).WithError(err).Warn("failed to scan gas bank accounts for reconciliation")
+	}
+	if len(batch) == 0 {
+		return
+	}
+	s.reconcileRuns.Add(1)
+
+	drifted := 0
+	for _, userID := range batch {
+		report, err := s.Reconcile(ctx, userID)
+		if err != nil {
+			s.reconcileErrors.Add(1)
+			if !database.IsNotFound(err) {
+				// Retry on the next run.
+				s.markForReconcile(userID)
+			}
+			s.Logger().WithContext(ctx).WithError(err).WithField("user_id", userID).Warn("failed to reconcile account")
+			continue
+		}
+		s.reconcileChecked.Add(1)
+		if !report.DriftDetected {
+			continue
+		}
+
+		drifted++
+		s.reconcileDrift.Add(1)
+		if metrics.Enabled() {
+			metrics.Global().RecordError(ServiceID, "balance_drift", "reconcile")
+		}
+		s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+			"user_id":          userID,
+			"account_id":       report.AccountID,
+			"recorded_balance": report.RecordedBalance,
+			"expected_balance": report.ExpectedBalance,
+			"drift":            report.Drift,
+			"discrepancies":    len(report.Discrepancies),
+		}).Error("gas bank balance drift detected")
+	}
+
+	s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+		"checked": len(batch),
+		"drifted": drifted,
+	}).Info("reconciliation run completed")
+}
@@ -0,0 +1,265 @@
+package neogasbank
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+// newReconcileRPC serves getapplicationlog and getrawtransaction for the
+// given GAS transfers (tx hash -> amount). Unknown hashes get the node's
+// "unknown transaction" error, as for a transfer not yet in a block.
+func newReconcileRPC(t *testing.T, transfers map[string]int64) *chain.Client {
+	t.Helper()
+
+	hash := base64.StdEncoding.EncodeToString(make([]byte, 20))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		txHash, _ := req.Params[0].(string)
+		amount, known := transfers[txHash]
+		if !known {
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-100,"message":"Unknown transaction"}}`)
+			return
+		}
+		switch req.Method {
+		case "getapplicationlog":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"txid":%q,"executions":[{"vmstate":"HALT","notifications":[{"contract":%q,"eventname":"Transfer","state":{"type":"Array","value":[{"type":"ByteString","value":%q},{"type":"ByteString","value":%q},{"type":"Integer","value":"%d"}]}}]}]}}`,
+				txHash, GASContractHash, hash, hash, amount)
+		case "getrawtransaction":
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":{"confirmations":5}}`)
+		default:
+			http.Error(w, "unexpected method", http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := chain.NewClient(chain.Config{RPCURL: server.URL})
+	if err != nil {
+		t.Fatalf("chain.NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestReconcileFlagsUnverifiedDeposit(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	client := newReconcileRPC(t, map[string]int64{
+		"0xok":  500000000,
+		"0xbad": 1, // on chain, but not the credited amount
+	})
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: client})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 699000000})
+	for _, tx := range []database.GasBankTransaction{
+		{ID: "t1", AccountID: "acc1", TxType: string(TxTypeDeposit), Amount: 500000000, ReferenceID: "dep-ok", TxHash: "0xok"},
+		{ID: "t2", AccountID: "acc1", TxType: string(TxTypeDeposit), Amount: 200000000, ReferenceID: "dep-bad", TxHash: "0xbad"},
+		{ID: "t3", AccountID: "acc1", TxType: string(TxTypeServiceFee), Amount: -1000000},
+	} {
+		tx := tx
+		mockDB.CreateGasBankTransaction(ctx, &tx)
+	}
+
+	report, err := svc.Reconcile(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if report.LedgerBalance != 699000000 || report.ExpectedBalance != 499000000 {
+		t.Errorf("ledger = %d, expected = %d; want 699000000, 499000000", report.LedgerBalance, report.ExpectedBalance)
+	}
+	if !report.DriftDetected || report.Drift != 200000000 {
+		t.Errorf("drift = %d (detected %v), want 200000000", report.Drift, report.DriftDetected)
+	}
+
+	kinds := map[string]Discrepancy{}
+	for _, d := range report.Discrepancies {
+		kinds[d.Kind] = d
+	}
+	if d, ok := kinds[DiscrepancyDepositNotOnChain]; !ok || d.TxHash != "0xbad" || d.Amount != -200000000 {
+		t.Errorf("deposit discrepancy = %+v, want debit of 0xbad", d)
+	}
+	if d, ok := kinds[DiscrepancyBalanceDrift]; !ok || d.Amount != -200000000 {
+		t.Errorf("drift discrepancy = %+v, want suggested debit of 200000000", d)
+	}
+	if _, ok := kinds[DiscrepancyLedgerMismatch]; ok {
+		t.Error("unexpected ledger mismatch")
+	}
+}
+
+func TestReconcilePendingDepositIsNotDrift(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	client := newReconcileRPC(t, map[string]int64{"0xok": 500000000})
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: client})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 500000000})
+	mockDB.CreateGasBankTransaction(ctx, &database.GasBankTransaction{
+		ID: "t1", AccountID: "acc1", TxType: string(TxTypeDeposit), Amount: 500000000, ReferenceID: "dep-ok", TxHash: "0xok",
+	})
+	mockDB.CreateDepositRequest(ctx, &database.DepositRequest{
+		ID: "dep-ok", UserID: "user1", Amount: 500000000, TxHash: "0xok", Status: string(DepositStatusConfirmed),
+	})
+	// Submitted but not yet in a block: the node does not know the tx.
+	mockDB.CreateDepositRequest(ctx, &database.DepositRequest{
+		ID: "dep-new", UserID: "user1", Amount: 300000000, TxHash: "0xnew", Status: string(DepositStatusPending),
+	})
+
+	report, err := svc.Reconcile(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if report.DriftDetected || report.Drift != 0 || len(report.Discrepancies) != 0 {
+		t.Errorf("report = %+v, want no drift or discrepancies", report)
+	}
+	if len(report.PendingDeposits) != 1 {
+		t.Fatalf("pending deposits = %+v, want 1", report.PendingDeposits)
+	}
+	if p := report.PendingDeposits[0]; p.DepositID != "dep-new" || p.Amount != 300000000 || p.Confirmations != 0 || p.Credited {
+		t.Errorf("pending deposit = %+v", p)
+	}
+}
+
+func TestReconcileLedgerMismatch(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: newReconcileRPC(t, nil)})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 50})
+
+	report, err := svc.Reconcile(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(report.Discrepancies) != 1 || report.Discrepancies[0].Kind != DiscrepancyLedgerMismatch || report.Discrepancies[0].Amount != -50 {
+		t.Errorf("discrepancies = %+v, want ledger mismatch of -50", report.Discrepancies)
+	}
+	if report.DriftDetected {
+		t.Error("drift below threshold should not be flagged")
+	}
+}
+
+func TestReconcileValidation(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	svc, _ := New(Config{Marble: m, DB: database.NewMockRepository()})
+
+	if _, err := svc.Reconcile(context.Background(), ""); err == nil {
+		t.Error("Reconcile() expected error for empty user_id")
+	}
+	if _, err := svc.Reconcile(context.Background(), "user1"); err == nil {
+		t.Error("Reconcile() expected error without chain client")
+	}
+}
+
+func TestProcessReconciliation(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: newReconcileRPC(t, nil)})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 500000000})
+
+	// Untouched accounts are reached by the account scan.
+	svc.processReconciliation(ctx)
+
+	stats := svc.statistics()
+	if stats["reconcile_runs"] != int64(1) || stats["reconcile_accounts_checked"] != int64(1) {
+		t.Errorf("stats = %v, want one run checking one account", stats)
+	}
+	// Balance credited without any deposit on the ledger.
+	if stats["reconcile_drift"] != int64(1) {
+		t.Errorf("reconcile_drift = %v, want 1", stats["reconcile_drift"])
+	}
+
+	resp, _ := svc.DeductFee(ctx, &DeductFeeRequest{UserID: "user1", Amount: 1000, ServiceID: "neofeeds"})
+	if !resp.Success {
+		t.Fatalf("DeductFee() failed: %s", resp.Error)
+	}
+	svc.processReconciliation(ctx)
+
+	// The queued account and the scan's wrap-around pick the same account;
+	// it is checked once per run.
+	if got := svc.reconcileChecked.Load(); got != 2 {
+		t.Errorf("reconcile_accounts_checked = %d, want 2", got)
+	}
+	if len(svc.takeReconcileBatch()) != 0 {
+		t.Error("queue should be drained after a run")
+	}
+}
+
+func TestReconcileScanCoversAllAccounts(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: newReconcileRPC(t, nil)})
+
+	ctx := context.Background()
+	total := ReconcileBatchSize + ReconcileBatchSize/2
+	for i := 0; i < total; i++ {
+		mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{
+			ID:     fmt.Sprintf("acc-%04d", i),
+			UserID: fmt.Sprintf("user-%04d", i),
+		})
+	}
+
+	seen := make(map[string]bool)
+	for run := 0; run < 2; run++ {
+		batch, err := svc.scanReconcileBatch(ctx, nil)
+		if err != nil {
+			t.Fatalf("scanReconcileBatch() error = %v", err)
+		}
+		for _, userID := range batch {
+			seen[userID] = true
+		}
+	}
+	if len(seen) != total {
+		t.Fatalf("scanned %d accounts in two runs, want %d", len(seen), total)
+	}
+	if svc.reconcileCursor != "" {
+		t.Errorf("cursor = %q, want the scan to wrap around", svc.reconcileCursor)
+	}
+}
+
+func TestReconcileReadsFullHistory(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: newReconcileRPC(t, nil)})
+
+	ctx := context.Background()
+	entries := reconcilePageSize + 500
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: int64(entries)})
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < entries; i++ {
+		mockDB.CreateGasBankTransaction(ctx, &database.GasBankTransaction{
+			ID:        fmt.Sprintf("tx-%05d", i),
+			AccountID: "acc1",
+			TxType:    string(TxTypeAdjustment),
+			Amount:    1,
+			CreatedAt: base.Add(time.Duration(i/10) * time.Second),
+		})
+	}
+
+	report, err := svc.Reconcile(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if report.LedgerBalance != int64(entries) || len(report.Discrepancies) != 0 {
+		t.Errorf("ledger = %d discrepancies = %+v, want %d and none", report.LedgerBalance, report.Discrepancies, entries)
+	}
+}
@@ -0,0 +1,182 @@
+package neogasbank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+const (
+	// RefundCheckInterval is how often pending sponsored transactions are
+	// checked on chain.
+	RefundCheckInterval = 30 * time.Second
+	// MaxPendingSponsorshipsPerRun caps the sponsored transactions checked
+	// per run.
+	MaxPendingSponsorshipsPerRun = 100
+	// SponsorshipExpiry is how long a sponsored transaction may stay
+	// unexecuted before its fee is refunded. It is longer than the maximum
+	// valid-until-block window, so by then the transaction can no longer be
+	// included in a block.
+	SponsorshipExpiry = 24 * time.Hour
+)
+
+// sponsorOutcome is the on-chain outcome of a sponsored transaction.
+type sponsorOutcome int
+
+const (
+	sponsorUnknown sponsorOutcome = iota // not executed yet
+	sponsorSucceeded
+	sponsorFailed
+)
+
+// sponsorChainOutcome looks up a sponsored transaction's execution. A
+// transaction the node does not know yet is reported as unknown; for a failed
+// one it also returns the reason.
+func (s *Service) sponsorChainOutcome(ctx context.Context, txHash string) (sponsorOutcome, string, error) {
+	appLog, err := s.chainClient.GetApplicationLog(ctx, txHash)
+	if err != nil {
+		var rpcErr *chain.RPCError
+		if errors.As(err, &rpcErr) {
+			return sponsorUnknown, "", nil
+		}
+		return sponsorUnknown, "", err
+	}
+	if appLog == nil || len(appLog.Executions) == 0 {
+		return sponsorUnknown, "", nil
+	}
+
+	exec := appLog.Executions[0]
+	if exec.VMState == "HALT" {
+		return sponsorSucceeded, "", nil
+	}
+	reason := "vm state " + exec.VMState
+	if exec.Exception != "" {
+		reason += ": " + exec.Exception
+	}
+	return sponsorFailed, reason, nil
+}
+
+// processSponsorRefunds settles pending sponsored transactions: succeeded ones
+// are marked completed, failed ones are refunded, and ones still not executed
+// after SponsorshipExpiry are refunded as expired. Each run continues after the
+// last transaction checked by the previous one and starts over from the oldest
+// once the end is reached, so transactions that stay pending cannot keep newer
+// ones from being checked.
+func (s *Service) processSponsorRefunds(ctx context.Context) {
+	if s.chainClient == nil || s.db == nil {
+		return
+	}
+
+	s.refundMu.Lock()
+	defer s.refundMu.Unlock()
+
+	pending, err := s.db.GetPendingGasBankTransactions(ctx, s.refundAfter, s.refundAfterID, MaxPendingSponsorshipsPerRun)
+	if err != nil {
+		s.Logger().WithContext(ctx).WithError(err).Warn("failed to get pending sponsored transactions")
+		return
+	}
+	if len(pending) < MaxPendingSponsorshipsPerRun {
+		s.refundAfter, s.refundAfterID = time.Time{}, ""
+	} else {
+		last := pending[len(pending)-1]
+		s.refundAfter, s.refundAfterID = last.CreatedAt, last.ID
+	}
+
+	now := time.Now()
+	for i := range pending {
+		tx := &pending[i]
+		outcome, reason, err := s.sponsorChainOutcome(ctx, tx.TxHash)
+		if err != nil {
+			s.Logger().WithContext(ctx).WithError(err).WithField("tx_hash", tx.TxHash).Debug("failed to check sponsored transaction")
+			continue
+		}
+		if outcome == sponsorUnknown && now.Sub(tx.CreatedAt) > SponsorshipExpiry {
+			outcome = sponsorFailed
+			reason = fmt.Sprintf("expired: not executed on chain within %s", SponsorshipExpiry)
+		}
+
+		switch outcome {
+		case sponsorSucceeded:
+			if err := s.db.UpdateGasBankTransactionStatus(ctx, tx.ID, string(TxStatusCompleted), ""); err != nil {
+				s.Logger().WithContext(ctx).WithError(err).WithField("tx_id", tx.ID).Warn("failed to complete sponsored transaction")
+			}
+		case sponsorFailed:
+			if _, err := s.refundSponsorship(ctx, tx, reason); err != nil {
+				s.Logger().WithContext(ctx).WithError(err).WithField("tx_id", tx.ID).Error("failed to refund sponsored transaction")
+			}
+		}
+	}
+}
+
+// refundSponsorship credits back the fee of a sponsored transaction that
+// failed on chain. The original transaction is marked failed with reason
+// before the account is credited, so a refund is never issued twice; if the
+// credit fails the transaction is returned to pending for the next run.
+func (s *Service) refundSponsorship(ctx context.Context, tx *database.GasBankTransaction, reason string) (*database.GasBankTransaction, error) {
+	amount := -tx.Amount
+	if amount <= 0 {
+		return nil, fmt.Errorf("transaction %s is not a debit", tx.ID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, err := s.db.GetGasBankAccountByID(ctx, tx.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+
+	if err := s.db.UpdateGasBankTransactionStatus(ctx, tx.ID, string(TxStatusFailed), reason); err != nil {
+		return nil, fmt.Errorf("mark transaction failed: %w", err)
+	}
+	restorePending := func() {
+		if err := s.db.UpdateGasBankTransactionStatus(ctx, tx.ID, string(TxStatusPending), ""); err != nil {
+			s.Logger().WithContext(ctx).WithError(err).WithField("tx_id", tx.ID).Error("CRITICAL: failed to restore pending status, refund will not be retried")
+		}
+	}
+
+	newBalance := account.Balance + amount
+	if err := s.db.UpdateGasBankBalance(ctx, account.UserID, newBalance, account.Reserved); err != nil {
+		restorePending()
+		return nil, fmt.Errorf("update balance: %w", err)
+	}
+
+	refund := &database.GasBankTransaction{
+		ID:           uuid.New().String(),
+		AccountID:    account.ID,
+		TxType:       string(TxTypeRefund),
+		Amount:       amount,
+		BalanceAfter: newBalance,
+		ReferenceID:  tx.ID,
+		TxHash:       tx.TxHash,
+		Status:       string(TxStatusCompleted),
+		CreatedAt:    time.Now(),
+	}
+	if err := s.db.CreateGasBankTransaction(ctx, refund); err != nil {
+		// Rollback balance update to maintain consistency
+		if rollbackErr := s.db.UpdateGasBankBalance(ctx, account.UserID, account.Balance, account.Reserved); rollbackErr != nil {
+			s.Logger().WithContext(ctx).WithError(rollbackErr).Error("CRITICAL: rollback failed, balance inconsistent")
+		}
+		restorePending()
+		return nil, fmt.Errorf("record refund: %w", err)
+	}
+
+	s.creditDailySpend(ctx, account, amount, tx.CreatedAt, refund.CreatedAt)
+	s.refundsIssued.Add(1)
+	s.totalRefunded.Add(amount)
+	s.markForReconcile(account.UserID)
+	s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+		"user_id": account.UserID,
+		"tx_id":   tx.ID,
+		"tx_hash": tx.TxHash,
+		"amount":  amount,
+		"reason":  reason,
+	}).Info("refunded failed sponsored transaction")
+	return refund, nil
+}
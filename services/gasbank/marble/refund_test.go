@@ -0,0 +1,190 @@
+package neogasbank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+// newSponsorRPC serves getapplicationlog with the given VM state per tx hash.
+// Unknown hashes get the node's "unknown transaction" error.
+func newSponsorRPC(t *testing.T, states map[string]string) *chain.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		txHash, _ := req.Params[0].(string)
+		state, known := states[txHash]
+		if !known {
+			fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-100,"message":"Unknown transaction"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"txid":%q,"executions":[{"vmstate":%q,"exception":"insufficient GAS"}]}}`, txHash, state)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := chain.NewClient(chain.Config{RPCURL: server.URL})
+	if err != nil {
+		t.Fatalf("chain.NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestFailedSponsorshipIsRefunded(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	client := newSponsorRPC(t, map[string]string{"0xfail": "FAULT", "0xok": "HALT"})
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: client})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 10000})
+
+	failed, err := svc.DeductFee(ctx, &DeductFeeRequest{UserID: "user1", Amount: 300, ServiceID: "neoflow", TxHash: "0xfail"})
+	if err != nil || !failed.Success {
+		t.Fatalf("DeductFee(0xfail) = %+v, %v", failed, err)
+	}
+	ok, err := svc.DeductFee(ctx, &DeductFeeRequest{UserID: "user1", Amount: 200, ServiceID: "neoflow", TxHash: "0xok"})
+	if err != nil || !ok.Success {
+		t.Fatalf("DeductFee(0xok) = %+v, %v", ok, err)
+	}
+	pendingOnly, _ := svc.DeductFee(ctx, &DeductFeeRequest{UserID: "user1", Amount: 100, ServiceID: "neoflow", TxHash: "0xunknown"})
+
+	svc.processSponsorRefunds(ctx)
+
+	account, _ := mockDB.GetGasBankAccount(ctx, "user1")
+	if account.Balance != 9700 {
+		t.Errorf("Balance = %d, want 9700 (300 refunded)", account.Balance)
+	}
+	if limits, _ := svc.GetSpendingLimits(ctx, "user1"); limits.SpentToday != 300 {
+		t.Errorf("SpentToday = %d, want 300 (refund credited back)", limits.SpentToday)
+	}
+
+	txs, _ := mockDB.GetGasBankTransactions(ctx, "acc1", 100)
+	byID := make(map[string]database.GasBankTransaction, len(txs))
+	var refunds []database.GasBankTransaction
+	for _, tx := range txs {
+		byID[tx.ID] = tx
+		if tx.TxType == string(TxTypeRefund) {
+			refunds = append(refunds, tx)
+		}
+	}
+
+	if len(refunds) != 1 {
+		t.Fatalf("refunds = %d, want 1", len(refunds))
+	}
+	refund := refunds[0]
+	if refund.Amount != 300 || refund.ReferenceID != failed.TransactionID || refund.TxHash != "0xfail" || refund.BalanceAfter != 9700 {
+		t.Errorf("refund = %+v, want +300 referencing %s", refund, failed.TransactionID)
+	}
+
+	if got := byID[failed.TransactionID]; got.Status != string(TxStatusFailed) || got.Error == "" {
+		t.Errorf("failed sponsorship status = %q, error = %q; want failed with reason", got.Status, got.Error)
+	}
+	if got := byID[ok.TransactionID]; got.Status != string(TxStatusCompleted) {
+		t.Errorf("succeeded sponsorship status = %q, want completed", got.Status)
+	}
+	if got := byID[pendingOnly.TransactionID]; got.Status != string(TxStatusPending) {
+		t.Errorf("unexecuted sponsorship status = %q, want pending", got.Status)
+	}
+
+	if got := svc.totalRefunded.Load(); got != 300 {
+		t.Errorf("totalRefunded = %d, want 300", got)
+	}
+
+	// A second run must not refund again.
+	svc.processSponsorRefunds(ctx)
+	account, _ = mockDB.GetGasBankAccount(ctx, "user1")
+	if account.Balance != 9700 || svc.refundsIssued.Load() != 1 {
+		t.Errorf("after second run: balance = %d, refunds = %d; want 9700, 1", account.Balance, svc.refundsIssued.Load())
+	}
+}
+
+func TestExpiredSponsorshipIsRefunded(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: newSponsorRPC(t, nil)})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 9500})
+	mockDB.CreateGasBankTransaction(ctx, &database.GasBankTransaction{
+		ID: "stale", AccountID: "acc1", TxType: string(TxTypeServiceFee), Amount: -500,
+		TxHash: "0xdropped", Status: string(TxStatusPending), CreatedAt: time.Now().Add(-SponsorshipExpiry - time.Minute),
+	})
+	mockDB.CreateGasBankTransaction(ctx, &database.GasBankTransaction{
+		ID: "recent", AccountID: "acc1", TxType: string(TxTypeServiceFee), Amount: -100,
+		TxHash: "0xinflight", Status: string(TxStatusPending), CreatedAt: time.Now(),
+	})
+
+	svc.processSponsorRefunds(ctx)
+
+	account, _ := mockDB.GetGasBankAccount(ctx, "user1")
+	if account.Balance != 10000 {
+		t.Errorf("Balance = %d, want 10000 (expired fee refunded)", account.Balance)
+	}
+	txs, _ := mockDB.GetGasBankTransactions(ctx, "acc1", 100)
+	for _, tx := range txs {
+		switch tx.ID {
+		case "stale":
+			if tx.Status != string(TxStatusFailed) || !strings.HasPrefix(tx.Error, "expired") {
+				t.Errorf("expired sponsorship status = %q, error = %q; want failed with expiry reason", tx.Status, tx.Error)
+			}
+		case "recent":
+			if tx.Status != string(TxStatusPending) {
+				t.Errorf("in-flight sponsorship status = %q, want pending", tx.Status)
+			}
+		}
+	}
+}
+
+func TestSponsorRefundsSkipPastUnresolved(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	svc, _ := New(Config{Marble: m, DB: mockDB, ChainClient: newSponsorRPC(t, map[string]string{"0xfail": "FAULT"})})
+
+	ctx := context.Background()
+	mockDB.CreateGasBankAccount(ctx, &database.GasBankAccount{ID: "acc1", UserID: "user1", Balance: 10000})
+
+	// A full batch of older transactions the node does not know yet, then a
+	// newer one that failed.
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < MaxPendingSponsorshipsPerRun; i++ {
+		mockDB.CreateGasBankTransaction(ctx, &database.GasBankTransaction{
+			ID: fmt.Sprintf("unknown-%03d", i), AccountID: "acc1", TxType: string(TxTypeServiceFee), Amount: -1,
+			TxHash: fmt.Sprintf("0xunknown%03d", i), Status: string(TxStatusPending), CreatedAt: base.Add(time.Duration(i) * time.Second),
+		})
+	}
+	mockDB.CreateGasBankTransaction(ctx, &database.GasBankTransaction{
+		ID: "failed", AccountID: "acc1", TxType: string(TxTypeServiceFee), Amount: -300,
+		TxHash: "0xfail", Status: string(TxStatusPending), CreatedAt: time.Now(),
+	})
+
+	svc.processSponsorRefunds(ctx)
+	if got := svc.refundsIssued.Load(); got != 0 {
+		t.Fatalf("first run refunds = %d, want 0 (only the oldest batch checked)", got)
+	}
+
+	svc.processSponsorRefunds(ctx)
+	if got := svc.refundsIssued.Load(); got != 1 {
+		t.Fatalf("second run refunds = %d, want 1 (newer failed transaction reached)", got)
+	}
+
+	// Having reached the end, the next run starts over from the oldest.
+	if svc.refundAfterID != "" || !svc.refundAfter.IsZero() {
+		t.Errorf("cursor = %v/%q, want reset after the last page", svc.refundAfter, svc.refundAfterID)
+	}
+}
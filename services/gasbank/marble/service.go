@@ -15,6 +15,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -53,6 +54,24 @@ type Service struct {
 	db          database.RepositoryInterface
 
 	depositAddress string
+	onSettlement   SettlementHandler
+
+	// reconciliation: users whose balance changed since the last run
+	reconcileMu      sync.Mutex
+	reconcileQueue   map[string]struct{}
+	reconcileCursor  string // last account ID reached by the reconcile scan
+	reconcileRuns    atomic.Int64
+	reconcileChecked atomic.Int64
+	reconcileDrift   atomic.Int64
+	reconcileErrors  atomic.Int64
+
+	// sponsored transaction refunds: the cursor is the last pending
+	// transaction checked, so unresolved ones do not starve newer ones
+	refundMu      sync.Mutex
+	refundAfter   time.Time
+	refundAfterID string
+	refundsIssued atomic.Int64
+	totalRefunded atomic.Int64
 }
 
 // Config holds NeoGasBank service configuration.
@@ -61,6 +80,7 @@ type Config struct {
 	DB             database.RepositoryInterface
 	ChainClient    *chain.Client
 	DepositAddress string
+	OnSettlement   SettlementHandler // optional; called for each settled or failed deposit
 }
 
 // New creates a new NeoGasBank service.
@@ -100,6 +120,8 @@ func New(cfg Config) (*Service, error) {
 		chainClient:    cfg.ChainClient,
 		db:             cfg.DB,
 		depositAddress: depositAddress,
+		onSettlement:   cfg.OnSettlement,
+		reconcileQueue: make(map[string]struct{}),
 	}
 
 	// Register deposit verification worker
@@ -120,18 +142,40 @@ func New(cfg Config) (*Service, error) {
 			s.processAutoTopUp(ctx)
 			return nil
 		}, commonservice.WithTickerWorkerName("auto-topup"))
+
+		// Register balance reconciliation worker (runs every hour)
+		base.AddTickerWorker(ReconcileInterval, func(ctx context.Context) error {
+			s.processReconciliation(ctx)
+			return nil
+		}, commonservice.WithTickerWorkerName("balance-reconcile"))
+
+		// Register sponsored transaction refund worker
+		base.AddTickerWorker(RefundCheckInterval, func(ctx context.Context) error {
+			s.processSponsorRefunds(ctx)
+			return nil
+		}, commonservice.WithTickerWorkerName("sponsor-refund"))
 	}
 
 	// Register statistics provider for /info endpoint
 	base.WithStats(s.statistics)
 
 	// Register standard routes (/health, /info) plus service-specific routes
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 
 	return s, nil
 }
 
+// selfTest requires the chain client: without it deposits are never
+// verified and settlements never reconciled.
+func (s *Service) selfTest(ctx context.Context) error {
+	return s.CheckDependencies(ctx, commonservice.Dependency{
+		Name:    "chain client (NEO_RPC_URL)",
+		Missing: s.chainClient == nil,
+	})
+}
+
 // statistics returns runtime statistics for the /info endpoint.
 func (s *Service) statistics() map[string]any {
 	return map[string]any{
@@ -144,6 +188,15 @@ func (s *Service) statistics() map[string]any {
 		"topup_check_interval":       TopUpCheckInterval.String(),
 		"topup_threshold":            TopUpThreshold,
 		"topup_target_amount":        TopUpTargetAmount,
+		"reconcile_interval":         ReconcileInterval.String(),
+		"reconcile_drift_threshold":  ReconcileDriftThreshold,
+		"reconcile_runs":             s.reconcileRuns.Load(),
+		"reconcile_accounts_checked": s.reconcileChecked.Load(),
+		"reconcile_drift":            s.reconcileDrift.Load(),
+		"reconcile_errors":           s.reconcileErrors.Load(),
+		"refund_check_interval":      RefundCheckInterval.String(),
+		"refunds_issued":             s.refundsIssued.Load(),
+		"total_refunded":             s.totalRefunded.Load(),
 	}
 }
 
@@ -175,6 +228,8 @@ func (s *Service) GetAccount(ctx context.Context, userID string) (*GetAccountRes
 
 // DeductFee deducts a service fee from a user's gas bank balance.
 // This is called by other TEE services (neofeeds, neoflow, etc.) via mTLS.
+// A fee beyond the account's spending limits is rejected with a
+// *SpendingLimitError alongside the failed response.
 func (s *Service) DeductFee(ctx context.Context, req *DeductFeeRequest) (*DeductFeeResponse, error) {
 	if req.UserID == "" {
 		return &DeductFeeResponse{Success: false, Error: "user_id is required"}, nil
@@ -195,6 +250,11 @@ func (s *Service) DeductFee(ctx context.Context, req *DeductFeeRequest) (*Deduct
 		return &DeductFeeResponse{Success: false, Error: fmt.Sprintf("get account: %v", err)}, nil
 	}
 
+	now := time.Now()
+	if err := checkSpendingLimits(account, req.Amount, now); err != nil {
+		return &DeductFeeResponse{Success: false, BalanceAfter: account.Balance, Error: err.Error()}, err
+	}
+
 	// Check available balance
 	available := account.Balance - account.Reserved
 	if available < req.Amount {
@@ -211,7 +271,12 @@ func (s *Service) DeductFee(ctx context.Context, req *DeductFeeRequest) (*Deduct
 		return &DeductFeeResponse{Success: false, Error: fmt.Sprintf("update balance: %v", err)}, nil
 	}
 
-	// Record transaction - if this fails, rollback the balance update
+	// Record transaction - if this fails, rollback the balance update.
+	// Sponsored fees stay pending until the refund monitor sees the outcome.
+	status := TxStatusCompleted
+	if req.TxHash != "" {
+		status = TxStatusPending
+	}
 	txID := uuid.New().String()
 	tx := &database.GasBankTransaction{
 		ID:           txID,
@@ -220,7 +285,8 @@ func (s *Service) DeductFee(ctx context.Context, req *DeductFeeRequest) (*Deduct
 		Amount:       -req.Amount,
 		BalanceAfter: newBalance,
 		ReferenceID:  req.ReferenceID,
-		Status:       "completed",
+		TxHash:       req.TxHash,
+		Status:       string(status),
 		CreatedAt:    time.Now(),
 	}
 	if err := s.db.CreateGasBankTransaction(ctx, tx); err != nil {
@@ -232,6 +298,8 @@ func (s *Service) DeductFee(ctx context.Context, req *DeductFeeRequest) (*Deduct
 		return &DeductFeeResponse{Success: false, Error: fmt.Sprintf("record transaction: %v", err)}, nil
 	}
 
+	s.recordDailySpend(ctx, account, req.Amount, now)
+	s.markForReconcile(req.UserID)
 	return &DeductFeeResponse{
 		Success:       true,
 		TransactionID: txID,
@@ -239,7 +307,11 @@ func (s *Service) DeductFee(ctx context.Context, req *DeductFeeRequest) (*Deduct
 	}, nil
 }
 
-// ReserveFunds reserves funds for a pending operation.
+// ReserveFunds reserves funds for a pending operation. Like DeductFee, it
+// rejects amounts beyond the account's spending limits with a
+// *SpendingLimitError. The reservation counts toward the daily limit as soon
+// as it is made, so outstanding reservations cannot together exceed it; a
+// release without commit credits it back.
 func (s *Service) ReserveFunds(ctx context.Context, req *ReserveFundsRequest) (*ReserveFundsResponse, error) {
 	if req.UserID == "" || req.Amount <= 0 {
 		return &ReserveFundsResponse{Success: false}, nil
@@ -253,6 +325,11 @@ func (s *Service) ReserveFunds(ctx context.Context, req *ReserveFundsRequest) (*
 		return &ReserveFundsResponse{Success: false}, nil
 	}
 
+	now := time.Now()
+	if err := checkSpendingLimits(account, req.Amount, now); err != nil {
+		return &ReserveFundsResponse{Success: false, BalanceAfter: account.Balance, Error: err.Error()}, err
+	}
+
 	available := account.Balance - account.Reserved
 	if available < req.Amount {
 		return &ReserveFundsResponse{Success: false, BalanceAfter: account.Balance}, nil
@@ -262,6 +339,7 @@ func (s *Service) ReserveFunds(ctx context.Context, req *ReserveFundsRequest) (*
 	if err := s.db.UpdateGasBankBalance(ctx, req.UserID, account.Balance, newReserved); err != nil {
 		return &ReserveFundsResponse{Success: false}, nil
 	}
+	s.recordDailySpend(ctx, account, req.Amount, now)
 
 	return &ReserveFundsResponse{
 		Success:      true,
@@ -270,7 +348,8 @@ func (s *Service) ReserveFunds(ctx context.Context, req *ReserveFundsRequest) (*
 	}, nil
 }
 
-// ReleaseFunds releases or commits reserved funds.
+// ReleaseFunds releases or commits reserved funds. The reservation already
+// counts toward the daily limit, so a commit records no further spend.
 func (s *Service) ReleaseFunds(ctx context.Context, req *ReleaseFundsRequest) (*ReleaseFundsResponse, error) {
 	if req.UserID == "" || req.Amount <= 0 {
 		return &ReleaseFundsResponse{Success: false}, nil
@@ -298,6 +377,28 @@ func (s *Service) ReleaseFunds(ctx context.Context, req *ReleaseFundsRequest) (*
 		return &ReleaseFundsResponse{Success: false}, nil
 	}
 
+	if req.Commit {
+		// Record the committed spend so the ledger still sums to the balance.
+		tx := &database.GasBankTransaction{
+			ID:           uuid.New().String(),
+			AccountID:    account.ID,
+			TxType:       string(TxTypeServiceFee),
+			Amount:       -req.Amount,
+			BalanceAfter: newBalance,
+			ReferenceID:  req.ReferenceID,
+			Status:       string(TxStatusCompleted),
+			CreatedAt:    time.Now(),
+		}
+		if err := s.db.CreateGasBankTransaction(ctx, tx); err != nil {
+			s.Logger().WithContext(ctx).WithError(err).WithField("user_id", req.UserID).Warn("failed to record committed reservation")
+		}
+		s.markForReconcile(req.UserID)
+	} else {
+		// Reservations carry no timestamp, so an uncommitted release is
+		// credited against today's spend.
+		now := time.Now()
+		s.creditDailySpend(ctx, account, req.Amount, now, now)
+	}
 	return &ReleaseFundsResponse{
 		Success:      true,
 		BalanceAfter: newBalance,
@@ -337,6 +438,7 @@ func (s *Service) processDepositVerification(ctx context.Context) {
 		if err != nil {
 			if errors.Is(err, errDepositMismatch) {
 				_ = s.db.UpdateDepositStatus(ctx, deposit.ID, string(DepositStatusFailed), confirmations)
+				s.notifySettlement(ctx, &deposit, nil, err)
 				continue
 			}
 			s.Logger().WithContext(ctx).WithError(err).WithField("tx_hash", deposit.TxHash).Debug("failed to verify transaction")
@@ -344,7 +446,8 @@ func (s *Service) processDepositVerification(ctx context.Context) {
 		}
 
 		if confirmed {
-			s.confirmDeposit(ctx, &deposit)
+			tx, confirmErr := s.confirmDeposit(ctx, &deposit)
+			s.notifySettlement(ctx, &deposit, tx, confirmErr)
 		} else if confirmations > 0 {
 			// Update confirmation count
 			_ = s.db.UpdateDepositStatus(ctx, deposit.ID, string(DepositStatusConfirming), confirmations)
@@ -492,27 +595,28 @@ func addressFromScriptHash(hash []byte) string {
 }
 
 // confirmDeposit marks a deposit as confirmed and credits the user's balance.
-func (s *Service) confirmDeposit(ctx context.Context, deposit *database.DepositRequest) {
+// It returns the credit transaction, or an error if the deposit was not credited.
+func (s *Service) confirmDeposit(ctx context.Context, deposit *database.DepositRequest) (*database.GasBankTransaction, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Update deposit status
 	if err := s.db.UpdateDepositStatus(ctx, deposit.ID, string(DepositStatusConfirmed), RequiredConfirmations); err != nil {
 		s.Logger().WithContext(ctx).WithError(err).WithField("deposit_id", deposit.ID).Warn("failed to update deposit status")
-		return
+		return nil, fmt.Errorf("update deposit status: %w", err)
 	}
 
 	// Credit user's balance
 	account, err := s.db.GetOrCreateGasBankAccount(ctx, deposit.UserID)
 	if err != nil {
 		s.Logger().WithContext(ctx).WithError(err).WithField("user_id", deposit.UserID).Warn("failed to get account for deposit credit")
-		return
+		return nil, fmt.Errorf("get account: %w", err)
 	}
 
 	newBalance := account.Balance + deposit.Amount
 	if err := s.db.UpdateGasBankBalance(ctx, deposit.UserID, newBalance, account.Reserved); err != nil {
 		s.Logger().WithContext(ctx).WithError(err).WithField("user_id", deposit.UserID).Warn("failed to credit deposit")
-		return
+		return nil, fmt.Errorf("credit balance: %w", err)
 	}
 
 	// Record transaction
@@ -525,14 +629,25 @@ func (s *Service) confirmDeposit(ctx context.Context, deposit *database.DepositR
 		ReferenceID:  deposit.ID,
 		TxHash:       deposit.TxHash,
 		FromAddress:  deposit.FromAddress,
-		Status:       "completed",
+		Status:       string(TxStatusCompleted),
 		CreatedAt:    time.Now(),
 	}
 	if err := s.db.CreateGasBankTransaction(ctx, tx); err != nil {
 		s.Logger().WithContext(ctx).WithError(err).Warn("failed to record deposit transaction")
 	}
 
+	s.markForReconcile(deposit.UserID)
 	s.Logger().WithContext(ctx).WithField("user_id", deposit.UserID).WithField("amount", deposit.Amount).Info("deposit confirmed and credited")
+	return tx, nil
+}
+
+// notifySettlement invokes the configured settlement hook, if any. It is
+// called outside s.mu so hooks may call back into the service.
+func (s *Service) notifySettlement(ctx context.Context, deposit *database.DepositRequest, tx *database.GasBankTransaction, err error) {
+	if s.onSettlement == nil {
+		return
+	}
+	s.onSettlement(ctx, deposit, tx, err)
 }
 
 // cleanupExpiredDeposits marks expired pending deposits as expired.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -1223,3 +1224,58 @@ func TestReleaseFundsBalanceAfterOnFailure(t *testing.T) {
 		t.Errorf("BalanceAfter = %d, want 1000", resp.BalanceAfter)
 	}
 }
+
+func TestDepositVerificationSettlementHook(t *testing.T) {
+	m, _ := marble.New(marble.Config{MarbleType: ServiceID})
+	mockDB := database.NewMockRepository()
+	client := newReconcileRPC(t, map[string]int64{
+		"0xok":  500,
+		"0xbad": 1, // on chain, but not the requested amount
+	})
+
+	type settlement struct {
+		tx  *database.GasBankTransaction
+		err error
+	}
+	got := make(map[string]settlement)
+	svc, _ := New(Config{
+		Marble:      m,
+		DB:          mockDB,
+		ChainClient: client,
+		OnSettlement: func(ctx context.Context, deposit *database.DepositRequest, tx *database.GasBankTransaction, err error) {
+			got[deposit.ID] = settlement{tx: tx, err: err}
+		},
+	})
+
+	ctx := context.Background()
+	for _, deposit := range []database.DepositRequest{
+		{ID: "dep-ok", UserID: "user1", Amount: 500, TxHash: "0xok", Status: "pending"},
+		{ID: "dep-bad", UserID: "user1", Amount: 500, TxHash: "0xbad", Status: "pending"},
+		{ID: "dep-unseen", UserID: "user1", Amount: 500, TxHash: "0xunseen", Status: "pending"},
+	} {
+		deposit := deposit
+		mockDB.CreateDepositRequest(ctx, &deposit)
+	}
+
+	svc.processDepositVerification(ctx)
+
+	if len(got) != 2 {
+		t.Fatalf("settlement hook calls = %v, want dep-ok and dep-bad", got)
+	}
+	ok := got["dep-ok"]
+	if ok.err != nil {
+		t.Fatalf("dep-ok settlement err = %v, want nil", ok.err)
+	}
+	if ok.tx == nil || ok.tx.Amount != 500 || ok.tx.ReferenceID != "dep-ok" {
+		t.Errorf("dep-ok settlement tx = %+v, want deposit credit of 500", ok.tx)
+	}
+	bad := got["dep-bad"]
+	if bad.tx != nil || !errors.Is(bad.err, errDepositMismatch) {
+		t.Errorf("dep-bad settlement = %+v, want mismatch error and no credit", bad)
+	}
+
+	account, err := mockDB.GetGasBankAccount(ctx, "user1")
+	if err != nil || account.Balance != 500 {
+		t.Errorf("balance = %+v, %v; want only dep-ok credited", account, err)
+	}
+}
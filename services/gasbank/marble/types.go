@@ -1,7 +1,12 @@
 // Package neogasbank provides GasBank service for managing user gas balances.
 package neogasbank
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
 
 // DepositStatus represents the status of a deposit request.
 type DepositStatus string
@@ -14,6 +19,11 @@ const (
 	DepositStatusExpired    DepositStatus = "expired"
 )
 
+// SettlementHandler is invoked after the deposit verifier settles a deposit.
+// On success tx is the credit transaction and err is nil; on failure tx is nil
+// and err describes why the deposit could not be settled.
+type SettlementHandler func(ctx context.Context, deposit *database.DepositRequest, tx *database.GasBankTransaction, err error)
+
 // TransactionType represents the type of a gas bank transaction.
 type TransactionType string
 
@@ -22,6 +32,22 @@ const (
 	TxTypeWithdraw   TransactionType = "withdraw"
 	TxTypeServiceFee TransactionType = "service_fee"
 	TxTypeRefund     TransactionType = "refund"
+	// TxTypeAdjustment is a ledger-only entry that brings the transaction
+	// history in line with the recorded balance (see migration 048).
+	TxTypeAdjustment TransactionType = "adjustment"
+)
+
+// TransactionStatus represents the status of a gas bank transaction.
+type TransactionStatus string
+
+const (
+	// TxStatusPending marks a sponsored transaction whose on-chain outcome is
+	// not yet known.
+	TxStatusPending   TransactionStatus = "pending"
+	TxStatusCompleted TransactionStatus = "completed"
+	// TxStatusFailed marks a sponsored transaction that failed on chain and
+	// was refunded.
+	TxStatusFailed TransactionStatus = "failed"
 )
 
 // GetAccountRequest is the request for getting account info.
@@ -48,6 +74,10 @@ type DeductFeeRequest struct {
 	ServiceID   string `json:"service_id"`
 	ReferenceID string `json:"reference_id"`
 	Description string `json:"description,omitempty"`
+	// TxHash, if set, is the on-chain transaction the fee sponsors. The fee
+	// stays pending until the transaction executes and is refunded if it
+	// fails.
+	TxHash string `json:"tx_hash,omitempty"`
 }
 
 // DeductFeeResponse is the response for deducting service fees.
@@ -67,9 +97,10 @@ type ReserveFundsRequest struct {
 
 // ReserveFundsResponse is the response for reserving funds.
 type ReserveFundsResponse struct {
-	Success      bool  `json:"success"`
-	Reserved     int64 `json:"reserved,string"`
-	BalanceAfter int64 `json:"balance_after,string"`
+	Success      bool   `json:"success"`
+	Reserved     int64  `json:"reserved,string"`
+	BalanceAfter int64  `json:"balance_after,string"`
+	Error        string `json:"error,omitempty"`
 }
 
 // ReleaseFundsRequest is the request for releasing reserved funds.
@@ -86,6 +117,23 @@ type ReleaseFundsResponse struct {
 	BalanceAfter int64 `json:"balance_after,string"`
 }
 
+// SetSpendingLimitsRequest is the admin request for setting an account's
+// spending limits. Zero disables a limit.
+type SetSpendingLimitsRequest struct {
+	MaxPerTx  int64 `json:"max_per_tx,string"`
+	MaxPerDay int64 `json:"max_per_day,string"`
+}
+
+// SpendingLimitsResponse reports an account's spending limits and its spend
+// for the current UTC day, which resets at ResetsAt.
+type SpendingLimitsResponse struct {
+	UserID     string    `json:"user_id"`
+	MaxPerTx   int64     `json:"max_per_tx,string"`
+	MaxPerDay  int64     `json:"max_per_day,string"`
+	SpentToday int64     `json:"spent_today,string"`
+	ResetsAt   time.Time `json:"resets_at"`
+}
+
 // DepositInfo represents deposit information for API responses.
 type DepositInfo struct {
 	ID            string        `json:"id"`
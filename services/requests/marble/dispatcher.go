@@ -15,6 +15,7 @@ import (
 
 	"github.com/R3E-Network/service_layer/infrastructure/chain"
 	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/metrics"
 	txproxytypes "github.com/R3E-Network/service_layer/infrastructure/txproxy/types"
 	neorequestsupabase "github.com/R3E-Network/service_layer/services/requests/supabase"
 )
@@ -22,6 +23,24 @@ import (
 type serviceResult struct {
 	ResultBytes []byte
 	AuditJSON   json.RawMessage
+	// FetchedAt is when the underlying data was fetched (oracle only).
+	FetchedAt time.Time
+}
+
+// errStaleResult marks a result that aged past the configured window between
+// fetch and callback.
+var errStaleResult = errors.New("result is stale")
+
+// checkResultAge rejects results fetched longer ago than the oracle max age.
+// Age is measured from the fetch timestamp, not from request creation.
+func (s *Service) checkResultAge(result serviceResult, now time.Time) error {
+	if s.oracleMaxAge <= 0 || result.FetchedAt.IsZero() {
+		return nil
+	}
+	if age := now.Sub(result.FetchedAt); age > s.oracleMaxAge {
+		return fmt.Errorf("%w: fetched %s ago (max %s)", errStaleResult, age.Round(time.Second), s.oracleMaxAge)
+	}
+	return nil
 }
 
 func (s *Service) handleServiceRequested(ctx context.Context, event *chain.ContractEvent) error {
@@ -119,7 +138,6 @@ func (s *Service) handleServiceRequested(ctx context.Context, event *chain.Contr
 	if execErr == nil && len(result.ResultBytes) > s.maxResult {
 		execErr = fmt.Errorf("result exceeds max size")
 	}
-
 	success := execErr == nil
 	fulfillErr := s.fulfillRequest(ctx, parsed, app.DeveloperUserID, result, execErr, serviceReq)
 	if fulfillErr != nil {
@@ -181,16 +199,41 @@ func (s *Service) executeService(ctx context.Context, userID, appID, requestID,
 		return serviceResult{}, fmt.Errorf("payload too large: %d bytes (max %d)", len(payload), maxPayloadSize)
 	}
 
+	var (
+		result serviceResult
+		err    error
+	)
+	start := time.Now()
 	switch serviceType {
 	case "rng":
-		return s.executeRNG(ctx, userID, appID, requestID, payload)
+		result, err = s.executeRNG(ctx, userID, appID, requestID, payload)
 	case "oracle":
-		return s.executeOracle(ctx, userID, payload)
+		result, err = s.executeOracle(ctx, userID, payload)
 	case "compute":
-		return s.executeCompute(ctx, userID, payload)
+		result, err = s.executeCompute(ctx, userID, payload)
 	default:
 		return serviceResult{}, fmt.Errorf("unsupported service type: %s", serviceType)
 	}
+	recordDispatch(serviceType, err, time.Since(start))
+	return result, err
+}
+
+// dispatchTargets maps service types to the downstream service and endpoint
+// used as metric labels.
+var dispatchTargets = map[string][2]string{
+	"rng":     {"neovrf", "/random"},
+	"oracle":  {"neooracle", "/query"},
+	"compute": {"neocompute", "/execute"},
+}
+
+// recordDispatch records the latency and outcome (success, error or timeout)
+// of a routed request when metrics are enabled.
+func recordDispatch(serviceType string, err error, duration time.Duration) {
+	target, ok := dispatchTargets[serviceType]
+	if !ok || !metrics.Enabled() {
+		return
+	}
+	metrics.Global().RecordDispatch(target[0], target[1], err, duration)
 }
 
 func (s *Service) executeRNG(ctx context.Context, userID, appID, requestID string, payload []byte) (serviceResult, error) {
@@ -305,7 +348,18 @@ func (s *Service) executeOracle(ctx context.Context, userID string, payload []by
 		result = trimmed
 	}
 
-	return serviceResult{ResultBytes: resultBytes, AuditJSON: neorequestsupabase.MarshalParams(result)}, nil
+	fetchedAt := resp.FetchedAt
+	if fetchedAt.IsZero() {
+		// Older neooracle builds omit fetched_at; the response arrival is the
+		// closest available bound.
+		fetchedAt = time.Now()
+	}
+
+	return serviceResult{
+		ResultBytes: resultBytes,
+		AuditJSON:   neorequestsupabase.MarshalParams(result),
+		FetchedAt:   fetchedAt,
+	}, nil
 }
 
 func (s *Service) executeCompute(ctx context.Context, userID string, payload []byte) (serviceResult, error) {
@@ -396,6 +450,16 @@ func (s *Service) fulfillRequest(ctx context.Context, req *chain.ServiceRequeste
 		return fmt.Errorf("txproxy not configured")
 	}
 
+	// Check freshness here, right before the callback is built, so the age
+	// covers everything between fetch and submission.
+	if execErr == nil {
+		if err := s.checkResultAge(result, time.Now()); err != nil {
+			s.Logger().WithContext(ctx).WithError(err).Warn("oracle result went stale before callback")
+			execErr = err
+			result.ResultBytes = nil
+		}
+	}
+
 	success := execErr == nil
 	errorMsg := ""
 	if execErr != nil {
@@ -466,6 +530,9 @@ func (s *Service) fulfillRequest(ctx context.Context, req *chain.ServiceRequeste
 	if !success || status == "failed" {
 		finalStatus = "failed"
 	}
+	if errors.Is(execErr, errStaleResult) {
+		finalStatus = "failed_stale"
+	}
 
 	completedAt := time.Now().UTC()
 	if serviceReq != nil {
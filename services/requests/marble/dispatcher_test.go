@@ -0,0 +1,179 @@
+package neorequests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	commonservice "github.com/R3E-Network/service_layer/infrastructure/service"
+	txproxytypes "github.com/R3E-Network/service_layer/infrastructure/txproxy/types"
+	neorequestsupabase "github.com/R3E-Network/service_layer/services/requests/supabase"
+)
+
+type recordingInvoker struct {
+	calls []*txproxytypes.InvokeRequest
+}
+
+func (r *recordingInvoker) Invoke(_ context.Context, req *txproxytypes.InvokeRequest) (*txproxytypes.InvokeResponse, error) {
+	r.calls = append(r.calls, req)
+	return &txproxytypes.InvokeResponse{RequestID: req.RequestID, TxHash: "0xabc"}, nil
+}
+
+// requestsRepo records service request and chain tx writes; other methods
+// are not used by fulfillRequest.
+type requestsRepo struct {
+	neorequestsupabase.RepositoryInterface
+	updated []neorequestsupabase.ServiceRequest
+}
+
+func (r *requestsRepo) CreateChainTx(_ context.Context, tx *neorequestsupabase.ChainTx) error {
+	tx.ID = 1
+	return nil
+}
+
+func (r *requestsRepo) UpdateChainTx(context.Context, *neorequestsupabase.ChainTx) error {
+	return nil
+}
+
+func (r *requestsRepo) UpdateServiceRequest(_ context.Context, req *neorequestsupabase.ServiceRequest) error {
+	r.updated = append(r.updated, *req)
+	return nil
+}
+
+func newFulfillTestService(maxAge time.Duration) (*Service, *recordingInvoker, *requestsRepo) {
+	invoker := &recordingInvoker{}
+	repo := &requestsRepo{}
+	s := &Service{
+		BaseService:        commonservice.NewBase(&commonservice.BaseConfig{ID: ServiceID, Name: ServiceName}),
+		repo:               repo,
+		txProxy:            invoker,
+		serviceGatewayHash: "0102030405060708090a0b0c0d0e0f1011121314",
+		maxErrorLen:        defaultMaxErrorLen,
+		oracleMaxAge:       maxAge,
+	}
+	return s, invoker, repo
+}
+
+func TestCheckResultAge(t *testing.T) {
+	s := &Service{oracleMaxAge: time.Minute}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := s.checkResultAge(serviceResult{FetchedAt: now.Add(-30 * time.Second)}, now); err != nil {
+		t.Fatalf("fresh result rejected: %v", err)
+	}
+	if err := s.checkResultAge(serviceResult{}, now); err != nil {
+		t.Fatalf("result without fetch time rejected: %v", err)
+	}
+	err := s.checkResultAge(serviceResult{FetchedAt: now.Add(-2 * time.Minute)}, now)
+	if !errors.Is(err, errStaleResult) {
+		t.Fatalf("err = %v, want errStaleResult", err)
+	}
+
+	s.oracleMaxAge = -1
+	if err := s.checkResultAge(serviceResult{FetchedAt: now.Add(-time.Hour)}, now); err != nil {
+		t.Fatalf("disabled max age rejected result: %v", err)
+	}
+}
+
+func TestFulfillRequestRejectsStaleResult(t *testing.T) {
+	s, invoker, repo := newFulfillTestService(time.Minute)
+	req := &chain.ServiceRequestedEvent{RequestID: "7", AppID: "app-1"}
+	result := serviceResult{
+		ResultBytes: []byte(`{"price":"1"}`),
+		FetchedAt:   time.Now().Add(-2 * time.Minute),
+	}
+	serviceReq := &neorequestsupabase.ServiceRequest{ID: "req-1", Status: "processing"}
+
+	if err := s.fulfillRequest(context.Background(), req, "user-1", result, nil, serviceReq); err != nil {
+		t.Fatalf("fulfillRequest: %v", err)
+	}
+
+	if len(invoker.calls) != 1 {
+		t.Fatalf("calls = %d, want 1", len(invoker.calls))
+	}
+	params := invoker.calls[0].Params
+	if got := params[1].Value; got != false {
+		t.Fatalf("success param = %v, want false", got)
+	}
+	if got, ok := params[2].Value.(string); !ok || got != "" {
+		t.Fatalf("stale result bytes were submitted: %v", params[2].Value)
+	}
+	if len(repo.updated) == 0 {
+		t.Fatal("service request was not updated")
+	}
+	final := repo.updated[len(repo.updated)-1]
+	if final.Status != "failed_stale" {
+		t.Fatalf("status = %q, want failed_stale", final.Status)
+	}
+	if final.Error == "" {
+		t.Fatal("stale request has no error message")
+	}
+}
+
+func TestFulfillRequestAcceptsFreshResult(t *testing.T) {
+	s, invoker, repo := newFulfillTestService(time.Minute)
+	req := &chain.ServiceRequestedEvent{RequestID: "8", AppID: "app-1"}
+	result := serviceResult{ResultBytes: []byte("ok"), FetchedAt: time.Now()}
+	serviceReq := &neorequestsupabase.ServiceRequest{ID: "req-2", Status: "processing"}
+
+	if err := s.fulfillRequest(context.Background(), req, "user-1", result, nil, serviceReq); err != nil {
+		t.Fatalf("fulfillRequest: %v", err)
+	}
+	if got := invoker.calls[0].Params[1].Value; got != true {
+		t.Fatalf("success param = %v, want true", got)
+	}
+	if final := repo.updated[len(repo.updated)-1]; final.Status != "completed" {
+		t.Fatalf("status = %q, want completed", final.Status)
+	}
+}
+
+func TestSelfTestRequiresChainDependencies(t *testing.T) {
+	complete := func() *Service {
+		s, _, _ := newFulfillTestService(time.Minute)
+		s.eventListener = &chain.EventListener{}
+		return s
+	}
+
+	if err := complete().selfTest(context.Background()); err != nil {
+		t.Fatalf("selfTest() with all dependencies = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*Service)
+		want   string
+	}{
+		{"no event listener", func(s *Service) { s.eventListener = nil }, "NEO_RPC_URL"},
+		{"no gateway hash", func(s *Service) { s.serviceGatewayHash = "" }, "CONTRACT_SERVICE_GATEWAY_HASH"},
+		{"no txproxy", func(s *Service) { s.txProxy = nil }, "TXPROXY_URL"},
+	}
+	// Development runs only warn about missing chain dependencies.
+	t.Setenv("MARBLE_ENV", "development")
+	for _, tt := range tests {
+		s := complete()
+		tt.mutate(s)
+		if err := s.selfTest(context.Background()); err != nil {
+			t.Errorf("%s: selfTest() in development = %v, want warning only", tt.name, err)
+		}
+	}
+
+	t.Setenv("MARBLE_ENV", "production")
+	for _, tt := range tests {
+		s := complete()
+		tt.mutate(s)
+		err := s.selfTest(context.Background())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: selfTest() in production = %v, want error naming %s", tt.name, err, tt.want)
+		}
+	}
+
+	// Missing downstream service URLs only disable a request type.
+	s := complete()
+	s.vrfURL, s.oracleURL, s.computeURL = "", "", ""
+	if err := s.selfTest(context.Background()); err != nil {
+		t.Errorf("selfTest() without downstream URLs = %v, want warning only", err)
+	}
+}
@@ -29,6 +29,9 @@ const (
 	// to avoid callback failures when ServiceLayerGateway emits events.
 	defaultMaxResultBytes = 800
 	defaultMaxErrorLen    = 256
+
+	// Oracle results older than this (measured from fetch) are not delivered.
+	defaultOracleMaxAge = 5 * time.Minute
 )
 
 // Config holds NeoRequests service configuration.
@@ -54,6 +57,9 @@ type Config struct {
 	MaxErrorLen    int
 	RNGResultMode  string
 	TxWait         bool
+	// OracleMaxAge bounds the time between oracle fetch and callback.
+	// Zero uses NEOREQUESTS_ORACLE_MAX_AGE or the default; negative disables.
+	OracleMaxAge time.Duration
 
 	EnforceAppRegistry      bool
 	RequireManifestContract bool
@@ -94,6 +100,8 @@ type Service struct {
 	maxErrorLen int
 	rngMode     string
 
+	oracleMaxAge time.Duration
+
 	statsRollupInterval time.Duration
 	onchainUsage        bool
 	onchainTxUsage      bool
@@ -254,6 +262,15 @@ func New(cfg Config) (*Service, error) { //nolint:gocritic // cfg is read once a
 		}
 	}
 
+	oracleMaxAge := cfg.OracleMaxAge
+	if oracleMaxAge == 0 {
+		if parsed, ok := parseEnvDuration("NEOREQUESTS_ORACLE_MAX_AGE"); ok {
+			oracleMaxAge = parsed
+		} else {
+			oracleMaxAge = defaultOracleMaxAge
+		}
+	}
+
 	onchainUsage := cfg.OnchainUsage
 	if raw := strings.TrimSpace(os.Getenv("NEOREQUESTS_ONCHAIN_USAGE")); raw != "" {
 		onchainUsage = parseEnvBool(raw)
@@ -314,6 +331,7 @@ func New(cfg Config) (*Service, error) { //nolint:gocritic // cfg is read once a
 		maxResult:               maxResult,
 		maxErrorLen:             maxErrorLen,
 		rngMode:                 rngMode,
+		oracleMaxAge:            oracleMaxAge,
 		statsRollupInterval:     statsRollupInterval,
 		onchainUsage:            onchainUsage,
 		onchainTxUsage:          onchainTxUsage,
@@ -349,6 +367,7 @@ func New(cfg Config) (*Service, error) { //nolint:gocritic // cfg is read once a
 		s.computeURL = strings.TrimSpace(os.Getenv("NEOCOMPUTE_URL"))
 	}
 
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerHandlers()
 	s.registerStatsRollup()
@@ -356,6 +375,32 @@ func New(cfg Config) (*Service, error) { //nolint:gocritic // cfg is read once a
 	return s, nil
 }
 
+// selfTest checks what the service needs to do its core job: receive
+// ServiceRequested events and fulfill them on-chain. A missing downstream
+// service URL only disables that request type, so it is logged as a warning.
+func (s *Service) selfTest(ctx context.Context) error {
+	if err := s.CheckDependencies(ctx,
+		commonservice.Dependency{Name: "event listener (NEO_RPC_URL)", Missing: s.eventListener == nil},
+		commonservice.Dependency{Name: "ServiceLayerGateway hash (CONTRACT_SERVICE_GATEWAY_HASH)", Missing: s.serviceGatewayHash == ""},
+		commonservice.Dependency{Name: "txproxy (TXPROXY_URL)", Missing: s.txProxy == nil},
+	); err != nil {
+		return err
+	}
+
+	for _, downstream := range []struct{ name, url string }{
+		{"neovrf", s.vrfURL},
+		{"neooracle", s.oracleURL},
+		{"neocompute", s.computeURL},
+	} {
+		if downstream.url == "" {
+			s.Logger().WithContext(ctx).WithFields(map[string]interface{}{
+				"service": downstream.name,
+			}).Warn("downstream service URL not configured; requests of this type will fail")
+		}
+	}
+	return nil
+}
+
 func (s *Service) registerHandlers() {
 	if s.eventListener == nil || s.serviceGatewayHash == "" {
 		return
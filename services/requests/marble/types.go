@@ -29,6 +29,7 @@ type oracleResponse struct {
 	StatusCode int               `json:"status_code"`
 	Headers    map[string]string `json:"headers,omitempty"`
 	Body       string            `json:"body,omitempty"`
+	FetchedAt  time.Time         `json:"fetched_at"`
 }
 
 type computePayload struct {
@@ -138,6 +138,12 @@ func New(cfg Config) (*Service, error) {
 		seenRequests:   make(map[string]time.Time),
 	}
 
+	base.AddSelfTest(func(context.Context) error {
+		if s.chainClient == nil || s.signer == nil {
+			return fmt.Errorf("txproxy: chain client and signer are required")
+		}
+		return nil
+	})
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 
@@ -71,3 +71,18 @@ func TestInvokeEnforcesAllowlistAndReplay(t *testing.T) {
 		t.Fatalf("expected 503 (request_id not consumed when chain unavailable), got %d", resp.Code)
 	}
 }
+
+func TestSelfTestRequiresChainSigning(t *testing.T) {
+	m, err := marble.New(marble.Config{MarbleType: ServiceID})
+	if err != nil {
+		t.Fatalf("marble.New: %v", err)
+	}
+
+	svc, err := New(Config{Marble: m})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := svc.SelfTest(context.Background()); err == nil {
+		t.Fatal("SelfTest: expected error without chain client and signer")
+	}
+}
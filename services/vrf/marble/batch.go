@@ -0,0 +1,185 @@
+package neovrf
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/R3E-Network/service_layer/infrastructure/crypto"
+)
+
+// MaxBatchSize is the largest number of requests GenerateBatch fulfills with
+// one proof.
+const MaxBatchSize = 64
+
+// proofSize is the length of a VRF proof: a P-256 signature, r || s.
+const proofSize = 64
+
+// GenerateRandomnessRequest is one randomness request in a batch or queued
+// with Enqueue. An empty RequestID is assigned.
+type GenerateRandomnessRequest struct {
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// BatchSeed returns the message signed for a batch: SHA-256 over the
+// length-prefixed request IDs in order.
+func BatchSeed(requestIDs []string) []byte {
+	var buf bytes.Buffer
+	for _, id := range requestIDs {
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], uint32(len(id)))
+		buf.Write(n[:])
+		buf.WriteString(id)
+	}
+	return crypto.Hash256(buf.Bytes())
+}
+
+// DeriveBatchRandomness expands the batch proof into the randomness for the
+// request at index: HKDF-SHA256 keyed by sha256(proof), with the index and
+// request ID as info, so every output is bound to its position and request.
+func DeriveBatchRandomness(proof []byte, index int, requestID string) ([]byte, error) {
+	return crypto.DeriveKey(crypto.Hash256(proof), nil, fmt.Sprintf("neovrf-batch:%d:%s", index, requestID), RandomnessSize)
+}
+
+// GenerateBatch fulfills reqs with a single VRF proof. The batch seed is
+// signed once and each request's randomness is derived from the signature;
+// every returned request carries its own RequestID, is in
+// RequestStatusFulfilled, and has Proof set to the shared batch proof with
+// BatchIndex locating it. Outputs are deterministic for a given signing key
+// and request IDs.
+func (s *Service) GenerateBatch(ctx context.Context, reqs []GenerateRandomnessRequest) ([]*VRFRequest, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("neovrf: batch is empty")
+	}
+	if len(reqs) > MaxBatchSize {
+		return nil, fmt.Errorf("neovrf: batch of %d exceeds maximum %d", len(reqs), MaxBatchSize)
+	}
+	if s.privateKey == nil {
+		return nil, fmt.Errorf("neovrf: signing key not configured")
+	}
+
+	ids := make([]string, len(reqs))
+	seen := make(map[string]struct{}, len(reqs))
+	for i, req := range reqs {
+		id := strings.TrimSpace(req.RequestID)
+		if len(id) > 128 {
+			return nil, fmt.Errorf("neovrf: request_id at index %d too long", i)
+		}
+		if id == "" {
+			id = uuid.New().String()
+		}
+		if _, dup := seen[id]; dup {
+			return nil, fmt.Errorf("neovrf: duplicate request_id %q in batch", id)
+		}
+		seen[id] = struct{}{}
+		ids[i] = id
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	proof, err := crypto.Sign(s.privateKey, BatchSeed(ids))
+	if err != nil {
+		return nil, fmt.Errorf("neovrf: sign batch: %w", err)
+	}
+
+	now := time.Now()
+	out := make([]*VRFRequest, len(reqs))
+	for i := range reqs {
+		randomness, err := DeriveBatchRandomness(proof, i, ids[i])
+		if err != nil {
+			return nil, fmt.Errorf("neovrf: derive randomness %s: %w", ids[i], err)
+		}
+		out[i] = &VRFRequest{
+			RequestID:  ids[i],
+			CreatedAt:  now,
+			Status:     RequestStatusFulfilled,
+			Randomness: randomness,
+			Proof:      proof,
+			BatchIndex: i,
+			BatchSize:  len(reqs),
+		}
+	}
+	return out, nil
+}
+
+// EncodeBatchFulfillmentResult encodes a batch into one result payload: the
+// shared proof, a 2-byte big-endian count, then each request's 32-byte
+// randomness in batch order. POST /random/batch returns it as "result". A
+// consumer recomputes BatchSeed from the request IDs, verifies the proof
+// against the key published at /pubkey and checks each value with
+// DeriveBatchRandomness; VerifyBatchFulfillmentResult does all three.
+func EncodeBatchFulfillmentResult(batch []*VRFRequest) ([]byte, error) {
+	if len(batch) == 0 {
+		return nil, fmt.Errorf("batch is empty")
+	}
+	proof := batch[0].Proof
+	if len(proof) != proofSize {
+		return nil, fmt.Errorf("proof must be %d bytes, got %d", proofSize, len(proof))
+	}
+
+	result := make([]byte, 0, proofSize+2+len(batch)*RandomnessSize)
+	result = append(result, proof...)
+	result = binary.BigEndian.AppendUint16(result, uint16(len(batch)))
+	for i, req := range batch {
+		if req.Status != RequestStatusFulfilled {
+			return nil, fmt.Errorf("request %s is not fulfilled (status %q)", req.RequestID, req.Status)
+		}
+		if req.BatchIndex != i || req.BatchSize != len(batch) || !bytes.Equal(req.Proof, proof) {
+			return nil, fmt.Errorf("request %s is not at index %d of this batch", req.RequestID, i)
+		}
+		want, err := DeriveBatchRandomness(proof, i, req.RequestID)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(req.Randomness, want) {
+			return nil, fmt.Errorf("request %s: randomness does not match proof", req.RequestID)
+		}
+		result = append(result, req.Randomness...)
+	}
+	return result, nil
+}
+
+// VerifyBatchFulfillmentResult checks a result produced by
+// EncodeBatchFulfillmentResult against publicKey and the batch's request IDs,
+// and returns the randomness values in batch order.
+func VerifyBatchFulfillmentResult(publicKey []byte, requestIDs []string, result []byte) ([][]byte, error) {
+	pub, err := crypto.PublicKeyFromBytes(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(result) < proofSize+2 {
+		return nil, fmt.Errorf("result too short")
+	}
+	proof := result[:proofSize]
+	count := int(binary.BigEndian.Uint16(result[proofSize:]))
+	values := result[proofSize+2:]
+	if count != len(requestIDs) || len(values) != count*RandomnessSize {
+		return nil, fmt.Errorf("result holds %d values, want %d", count, len(requestIDs))
+	}
+	if !isLowS(proof) {
+		return nil, fmt.Errorf("batch proof is not in low-S form")
+	}
+	if !crypto.Verify(pub, BatchSeed(requestIDs), proof) {
+		return nil, fmt.Errorf("batch proof does not verify")
+	}
+
+	out := make([][]byte, count)
+	for i, id := range requestIDs {
+		want, err := DeriveBatchRandomness(proof, i, id)
+		if err != nil {
+			return nil, err
+		}
+		got := values[i*RandomnessSize : (i+1)*RandomnessSize]
+		if !bytes.Equal(got, want) {
+			return nil, fmt.Errorf("randomness for %s does not match proof", id)
+		}
+		out[i] = got
+	}
+	return out, nil
+}
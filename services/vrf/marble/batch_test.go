@@ -0,0 +1,159 @@
+package neovrf
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+func newTestVRF(t *testing.T) *Service {
+	t.Helper()
+	m, _ := marble.New(marble.Config{MarbleType: "neovrf"})
+	m.SetTestSecret("NEOVRF_SIGNING_KEY", bytes.Repeat([]byte{0x42}, 32))
+	svc, err := New(Config{Marble: m})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return svc
+}
+
+func TestGenerateBatchDistinctAndDeterministic(t *testing.T) {
+	svc := newTestVRF(t)
+	reqs := []GenerateRandomnessRequest{{RequestID: "a"}, {RequestID: "b"}, {RequestID: "c"}}
+
+	first, err := svc.GenerateBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+	second, err := svc.GenerateBatch(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for i, req := range first {
+		if req.RequestID != reqs[i].RequestID || req.Status != RequestStatusFulfilled || req.BatchIndex != i || req.BatchSize != len(reqs) {
+			t.Fatalf("request %d = %+v", i, req)
+		}
+		if len(req.Randomness) != RandomnessSize || seen[string(req.Randomness)] {
+			t.Fatalf("request %d randomness not distinct", i)
+		}
+		seen[string(req.Randomness)] = true
+		if !bytes.Equal(req.Randomness, second[i].Randomness) {
+			t.Fatalf("request %d randomness differs between runs", i)
+		}
+	}
+
+	reordered, err := svc.GenerateBatch(context.Background(), []GenerateRandomnessRequest{{RequestID: "b"}, {RequestID: "a"}, {RequestID: "c"}})
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+	if bytes.Equal(reordered[1].Randomness, first[0].Randomness) {
+		t.Fatal("randomness must depend on the batch, not only the request id")
+	}
+}
+
+func TestGenerateBatchRejectsInvalid(t *testing.T) {
+	svc := newTestVRF(t)
+	if _, err := svc.GenerateBatch(context.Background(), nil); err == nil {
+		t.Fatal("expected error for empty batch")
+	}
+	if _, err := svc.GenerateBatch(context.Background(), []GenerateRandomnessRequest{{RequestID: "x"}, {RequestID: "x"}}); err == nil {
+		t.Fatal("expected error for duplicate request id")
+	}
+	if _, err := svc.GenerateBatch(context.Background(), make([]GenerateRandomnessRequest, MaxBatchSize+1)); err == nil {
+		t.Fatal("expected error for oversized batch")
+	}
+}
+
+func TestBatchFulfillmentResultVerifies(t *testing.T) {
+	svc := newTestVRF(t)
+	batch, err := svc.GenerateBatch(context.Background(), []GenerateRandomnessRequest{{RequestID: "a"}, {RequestID: "b"}})
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+
+	result, err := EncodeBatchFulfillmentResult(batch)
+	if err != nil {
+		t.Fatalf("EncodeBatchFulfillmentResult: %v", err)
+	}
+	values, err := VerifyBatchFulfillmentResult(svc.publicKey, []string{"a", "b"}, result)
+	if err != nil {
+		t.Fatalf("VerifyBatchFulfillmentResult: %v", err)
+	}
+	if !bytes.Equal(values[1], batch[1].Randomness) {
+		t.Fatal("verified randomness does not match batch")
+	}
+	if _, err := VerifyBatchFulfillmentResult(svc.publicKey, []string{"b", "a"}, result); err == nil {
+		t.Fatal("expected verification to fail for different request ids")
+	}
+
+	malleated := VRFOutput{Proof: result[:proofSize]}
+	malleate(&malleated)
+	highS := append(append([]byte{}, malleated.Proof...), result[proofSize:]...)
+	if _, err := VerifyBatchFulfillmentResult(svc.publicKey, []string{"a", "b"}, highS); err == nil || !strings.Contains(err.Error(), "low-S") {
+		t.Fatalf("high-S batch proof: err = %v, want low-S rejection", err)
+	}
+
+	result[len(result)-1] ^= 0xff
+	if _, err := VerifyBatchFulfillmentResult(svc.publicKey, []string{"a", "b"}, result); err == nil {
+		t.Fatal("expected verification to fail for tampered randomness")
+	}
+
+	batch[0].Randomness = batch[1].Randomness
+	if _, err := EncodeBatchFulfillmentResult(batch); err == nil {
+		t.Fatal("expected encode to reject randomness not derived from the proof")
+	}
+}
+
+func TestHandleRandomBatch(t *testing.T) {
+	svc := newTestVRF(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"batch", `{"requests":[{"request_id":"a"},{"request_id":"b"}]}`, http.StatusOK},
+		{"empty", `{"requests":[]}`, http.StatusBadRequest},
+		{"too large", `{"requests":[` + strings.Repeat(`{},`, MaxBatchSize) + `{}]}`, http.StatusBadRequest},
+		{"duplicate ids", `{"requests":[{"request_id":"a"},{"request_id":"a"}]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/random/batch", strings.NewReader(tt.body))
+		req.Header.Set("X-User-ID", "user-1")
+		rr := httptest.NewRecorder()
+		svc.Router().ServeHTTP(rr, req)
+		if rr.Code != tt.wantStatus {
+			t.Fatalf("%s: status=%d want %d (%s)", tt.name, rr.Code, tt.wantStatus, rr.Body.String())
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var resp RandomBatchResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: decode: %v", tt.name, err)
+		}
+		result, err := hex.DecodeString(resp.Result)
+		if err != nil {
+			t.Fatalf("%s: result hex: %v", tt.name, err)
+		}
+		values, err := VerifyBatchFulfillmentResult(svc.publicKey, []string{"a", "b"}, result)
+		if err != nil {
+			t.Fatalf("%s: verify result: %v", tt.name, err)
+		}
+		for i, entry := range resp.Requests {
+			if entry.BatchIndex != i || entry.Randomness != hex.EncodeToString(values[i]) {
+				t.Errorf("%s: entry %d = %+v", tt.name, i, entry)
+			}
+		}
+	}
+}
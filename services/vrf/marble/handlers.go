@@ -1,12 +1,15 @@
 package neovrf
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 
 	"github.com/R3E-Network/service_layer/infrastructure/crypto"
 	"github.com/R3E-Network/service_layer/infrastructure/httputil"
@@ -14,7 +17,13 @@ import (
 
 func (s *Service) registerRoutes() {
 	s.Router().HandleFunc("/random", s.handleRandom).Methods(http.MethodPost)
+	s.Router().HandleFunc("/random/batch", s.handleRandomBatch).Methods(http.MethodPost)
+	s.Router().HandleFunc("/random/requests", s.handleEnqueueRandom).Methods(http.MethodPost)
+	s.Router().HandleFunc("/random/requests/{id}", s.handleGetQueuedRandom).Methods(http.MethodGet)
 	s.Router().HandleFunc("/pubkey", s.handlePubKey).Methods(http.MethodGet)
+	s.Router().HandleFunc("/verify", s.handleVerify).Methods(http.MethodPost)
+	// Verification only checks a proof, so it stays available in read-only mode.
+	s.AllowInReadOnly("/verify")
 }
 
 func (s *Service) handleRandom(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +76,121 @@ func (s *Service) handleRandom(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, resp)
 }
 
+// handleRandomBatch fulfills up to MaxBatchSize requests with one proof (see
+// GenerateBatch). Result is the EncodeBatchFulfillmentResult payload,
+// verifiable with VerifyBatchFulfillmentResult.
+func (s *Service) handleRandomBatch(w http.ResponseWriter, r *http.Request) {
+	if _, ok := httputil.RequireUserID(w, r); !ok {
+		return
+	}
+
+	var input RandomBatchRequest
+	if !httputil.DecodeJSON(w, r, &input) {
+		return
+	}
+	if len(input.Requests) == 0 || len(input.Requests) > MaxBatchSize {
+		httputil.BadRequest(w, fmt.Sprintf("requests must hold 1 to %d entries", MaxBatchSize))
+		return
+	}
+	if s.privateKey == nil {
+		httputil.ServiceUnavailable(w, "signing key not configured")
+		return
+	}
+
+	batch, err := s.GenerateBatch(r.Context(), input.Requests)
+	if err != nil {
+		httputil.BadRequest(w, err.Error())
+		return
+	}
+	result, err := EncodeBatchFulfillmentResult(batch)
+	if err != nil {
+		httputil.InternalError(w, err.Error())
+		return
+	}
+
+	resp := RandomBatchResponse{
+		Requests:  make([]RandomBatchEntry, len(batch)),
+		Proof:     fmt.Sprintf("%x", batch[0].Proof),
+		Result:    fmt.Sprintf("%x", result),
+		Timestamp: time.Now().Unix(),
+	}
+	for i, req := range batch {
+		resp.Requests[i] = RandomBatchEntry{
+			RequestID:  req.RequestID,
+			Randomness: fmt.Sprintf("%x", req.Randomness),
+			BatchIndex: req.BatchIndex,
+		}
+	}
+	if len(s.publicKey) > 0 {
+		resp.PublicKey = fmt.Sprintf("%x", s.publicKey)
+	}
+	if len(s.attestationHash) > 0 {
+		resp.AttestationHash = fmt.Sprintf("%x", s.attestationHash)
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
+// handleEnqueueRandom queues a request for the fulfill worker, which serves
+// higher-priority requests first; priority comes from the caller's gas bank
+// deposit. Poll /random/requests/{id} for the result.
+func (s *Service) handleEnqueueRandom(w http.ResponseWriter, r *http.Request) {
+	userID, ok := httputil.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var input GenerateRandomnessRequest
+	if !httputil.DecodeJSONOptional(w, r, &input) {
+		return
+	}
+
+	req, err := s.Enqueue(r.Context(), userID, input)
+	switch {
+	case errors.Is(err, ErrDuplicateRequest):
+		httputil.Conflict(w, err.Error())
+		return
+	case errors.Is(err, ErrQueueFull):
+		httputil.ServiceUnavailable(w, err.Error())
+		return
+	case err != nil:
+		httputil.BadRequest(w, err.Error())
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusAccepted, queuedRandomResponse(req, s.publicKey))
+}
+
+func (s *Service) handleGetQueuedRandom(w http.ResponseWriter, r *http.Request) {
+	userID, ok := httputil.RequireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	req, found := s.QueuedRequest(userID, mux.Vars(r)["id"])
+	if !found {
+		httputil.NotFound(w, "request not found")
+		return
+	}
+
+	httputil.WriteJSON(w, http.StatusOK, queuedRandomResponse(req, s.publicKey))
+}
+
+func queuedRandomResponse(req VRFRequest, publicKey []byte) QueuedRandomResponse {
+	resp := QueuedRandomResponse{
+		RequestID: req.RequestID,
+		Priority:  req.Priority,
+		Status:    req.Status,
+		CreatedAt: req.CreatedAt.Unix(),
+	}
+	if req.Status == RequestStatusFulfilled {
+		resp.Randomness = fmt.Sprintf("%x", req.Randomness)
+		resp.Signature = fmt.Sprintf("%x", req.Proof)
+		resp.PublicKey = fmt.Sprintf("%x", publicKey)
+	}
+	return resp
+}
+
 func (s *Service) handlePubKey(w http.ResponseWriter, r *http.Request) {
 	if len(s.publicKey) == 0 {
 		httputil.ServiceUnavailable(w, "public key not available")
@@ -82,3 +206,48 @@ func (s *Service) handlePubKey(w http.ResponseWriter, r *http.Request) {
 
 	httputil.WriteJSON(w, http.StatusOK, resp)
 }
+
+// handleVerify checks a randomness result so consumers need not trust the
+// response of /random. A result that fails verification is reported with
+// 200 and the failed check; malformed hex is a 400.
+func (s *Service) handleVerify(w http.ResponseWriter, r *http.Request) {
+	var input VerifyRequest
+	if !httputil.DecodeJSON(w, r, &input) {
+		return
+	}
+
+	output := VRFOutput{Input: []byte(input.RequestID)}
+	var err error
+	if output.Randomness, err = decodeHexField(input.Randomness); err != nil {
+		httputil.BadRequest(w, "invalid randomness hex")
+		return
+	}
+	if output.Proof, err = decodeHexField(input.Signature); err != nil {
+		httputil.BadRequest(w, "invalid signature hex")
+		return
+	}
+	publicKey := s.publicKey
+	if input.PublicKey != "" {
+		if publicKey, err = decodeHexField(input.PublicKey); err != nil {
+			httputil.BadRequest(w, "invalid public_key hex")
+			return
+		}
+	}
+	if len(publicKey) == 0 {
+		httputil.ServiceUnavailable(w, "public key not available")
+		return
+	}
+
+	valid, err := VerifyProof(output, publicKey)
+	resp := VerifyResponse{Valid: valid}
+	var verr *VerificationError
+	if errors.As(err, &verr) {
+		resp.FailedCheck = verr.Check
+		resp.Reason = verr.Reason
+	}
+	httputil.WriteJSON(w, http.StatusOK, resp)
+}
+
+func decodeHexField(value string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+}
@@ -0,0 +1,230 @@
+package neovrf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/R3E-Network/service_layer/infrastructure/crypto"
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+)
+
+const (
+	// DefaultPriorityAgingInterval is how long a queued request waits before
+	// its effective priority rises by one level, so low-priority requests are
+	// eventually fulfilled under sustained high-priority load.
+	DefaultPriorityAgingInterval = 30 * time.Second
+	// DefaultFulfillInterval is how often queued requests are fulfilled.
+	DefaultFulfillInterval = time.Second
+	// MaxFulfillmentsPerTick bounds how many queued requests one tick fulfills;
+	// the rest wait for the next tick in priority order.
+	MaxFulfillmentsPerTick = MaxBatchSize
+	// MaxPendingRequests bounds the fulfillment queue.
+	MaxPendingRequests = 4096
+	// fulfilledRetention is how long a fulfilled request can be fetched.
+	fulfilledRetention = 10 * time.Minute
+
+	// MaxPriority is the highest priority a queued request can hold.
+	MaxPriority = 10
+	// PriorityDepositStep is the available gas bank deposit, in GAS base
+	// units (10^-8 GAS), that buys one priority level.
+	PriorityDepositStep int64 = 100_000_000
+	// maxAgingLevels caps the aging term so EffectivePriority cannot
+	// overflow; at the default interval it is reached after ~1000 years.
+	maxAgingLevels = 1 << 30
+)
+
+var (
+	// ErrQueueFull is returned by Enqueue when MaxPendingRequests are pending.
+	ErrQueueFull = errors.New("fulfillment queue is full")
+	// ErrDuplicateRequest is returned by Enqueue for a request ID already queued.
+	ErrDuplicateRequest = errors.New("request_id already queued")
+)
+
+// PriorityForDeposit returns the queue priority bought by an available gas
+// bank deposit: one level per PriorityDepositStep, up to MaxPriority.
+func PriorityForDeposit(available int64) int {
+	if available <= 0 {
+		return 0
+	}
+	return int(min(available/PriorityDepositStep, MaxPriority))
+}
+
+// EffectivePriority returns the request priority, clamped to
+// [0, MaxPriority], adjusted for waiting time: every agingInterval since
+// CreatedAt adds one level. A request created more than MaxPriority intervals
+// before another is therefore always served first. A non-positive
+// agingInterval disables aging.
+func (r VRFRequest) EffectivePriority(now time.Time, agingInterval time.Duration) int {
+	priority := min(max(r.Priority, 0), MaxPriority)
+	if agingInterval <= 0 || r.CreatedAt.IsZero() || !now.After(r.CreatedAt) {
+		return priority
+	}
+	return priority + int(min(now.Sub(r.CreatedAt)/agingInterval, maxAgingLevels))
+}
+
+// OrderForFulfillment returns a copy of requests in fulfillment order:
+// highest effective priority first, then oldest CreatedAt, then RequestID, so
+// the order is deterministic.
+func OrderForFulfillment(requests []VRFRequest, now time.Time, agingInterval time.Duration) []VRFRequest {
+	ordered := make([]VRFRequest, len(requests))
+	copy(ordered, requests)
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi := ordered[i].EffectivePriority(now, agingInterval)
+		pj := ordered[j].EffectivePriority(now, agingInterval)
+		if pi != pj {
+			return pi > pj
+		}
+		if !ordered[i].CreatedAt.Equal(ordered[j].CreatedAt) {
+			return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+		}
+		return ordered[i].RequestID < ordered[j].RequestID
+	})
+
+	return ordered
+}
+
+type queueEntry struct {
+	userID      string
+	request     VRFRequest
+	fulfilledAt time.Time
+}
+
+// fulfillmentQueue holds queued requests until the fulfill worker signs them
+// and keeps fulfilled results for fulfilledRetention.
+type fulfillmentQueue struct {
+	mu      sync.Mutex
+	entries map[string]*queueEntry
+	pending int
+}
+
+func newFulfillmentQueue() *fulfillmentQueue {
+	return &fulfillmentQueue{entries: make(map[string]*queueEntry)}
+}
+
+// Enqueue queues a randomness request for the fulfill worker. An empty
+// RequestID is assigned. The request's priority is derived from userID's
+// available gas bank deposit (see PriorityForDeposit); callers cannot set it.
+func (s *Service) Enqueue(ctx context.Context, userID string, req GenerateRandomnessRequest) (VRFRequest, error) {
+	id := strings.TrimSpace(req.RequestID)
+	if len(id) > 128 {
+		return VRFRequest{}, fmt.Errorf("request_id too long")
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	priority := s.depositPriority(ctx, userID)
+
+	q := s.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, exists := q.entries[id]; exists {
+		return VRFRequest{}, ErrDuplicateRequest
+	}
+	if q.pending >= MaxPendingRequests {
+		return VRFRequest{}, ErrQueueFull
+	}
+
+	entry := &queueEntry{
+		userID: userID,
+		request: VRFRequest{
+			RequestID: id,
+			Priority:  priority,
+			CreatedAt: time.Now(),
+			Status:    RequestStatusPending,
+		},
+	}
+	q.entries[id] = entry
+	q.pending++
+	return entry.request, nil
+}
+
+// depositPriority returns the priority bought by userID's gas bank deposit.
+// Users without an account, or whose account cannot be read, get priority 0.
+func (s *Service) depositPriority(ctx context.Context, userID string) int {
+	if s.DB() == nil {
+		return 0
+	}
+	account, err := s.DB().GetGasBankAccount(ctx, userID)
+	if err != nil {
+		if !database.IsNotFound(err) {
+			s.Logger().WithContext(ctx).WithError(err).WithFields(map[string]interface{}{
+				"user_id": userID,
+			}).Warn("gas bank lookup failed; queueing at priority 0")
+		}
+		return 0
+	}
+	return PriorityForDeposit(account.Balance - account.Reserved)
+}
+
+// QueuedRequest returns a queued or fulfilled request owned by userID.
+func (s *Service) QueuedRequest(userID, requestID string) (VRFRequest, bool) {
+	q := s.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entry, ok := q.entries[requestID]
+	if !ok || entry.userID != userID {
+		return VRFRequest{}, false
+	}
+	return entry.request, true
+}
+
+// FulfillPending signs up to MaxFulfillmentsPerTick pending requests in
+// OrderForFulfillment order and drops fulfilled requests older than
+// fulfilledRetention. It returns the IDs fulfilled, in order.
+func (s *Service) FulfillPending(ctx context.Context) ([]string, error) {
+	if s.privateKey == nil {
+		return nil, fmt.Errorf("neovrf: signing key not configured")
+	}
+
+	q := s.queue
+	now := time.Now()
+
+	q.mu.Lock()
+	pending := make([]VRFRequest, 0, q.pending)
+	for id, entry := range q.entries {
+		if entry.request.Status == RequestStatusPending {
+			pending = append(pending, entry.request)
+		} else if now.Sub(entry.fulfilledAt) > fulfilledRetention {
+			delete(q.entries, id)
+		}
+	}
+	q.mu.Unlock()
+
+	ordered := OrderForFulfillment(pending, now, s.agingInterval)
+	if len(ordered) > MaxFulfillmentsPerTick {
+		ordered = ordered[:MaxFulfillmentsPerTick]
+	}
+
+	fulfilled := make([]string, 0, len(ordered))
+	for _, req := range ordered {
+		if err := ctx.Err(); err != nil {
+			return fulfilled, err
+		}
+		proof, err := crypto.Sign(s.privateKey, []byte(req.RequestID))
+		if err != nil {
+			return fulfilled, fmt.Errorf("neovrf: sign %s: %w", req.RequestID, err)
+		}
+
+		q.mu.Lock()
+		if entry, ok := q.entries[req.RequestID]; ok && entry.request.Status == RequestStatusPending {
+			entry.request.Status = RequestStatusFulfilled
+			entry.request.Proof = proof
+			entry.request.Randomness = crypto.Hash256(proof)
+			entry.fulfilledAt = time.Now()
+			q.pending--
+		}
+		q.mu.Unlock()
+		fulfilled = append(fulfilled, req.RequestID)
+	}
+	return fulfilled, nil
+}
@@ -0,0 +1,306 @@
+package neovrf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/database"
+	"github.com/R3E-Network/service_layer/infrastructure/marble"
+)
+
+// newQueueVRF returns a test service backed by a mock database in which each
+// user holds the given gas bank balance.
+func newQueueVRF(t *testing.T, balances map[string]int64) *Service {
+	t.Helper()
+	db := database.NewMockRepository()
+	for userID, balance := range balances {
+		if err := db.CreateGasBankAccount(context.Background(), &database.GasBankAccount{UserID: userID, Balance: balance}); err != nil {
+			t.Fatalf("CreateGasBankAccount: %v", err)
+		}
+	}
+	m, _ := marble.New(marble.Config{MarbleType: "neovrf"})
+	m.SetTestSecret("NEOVRF_SIGNING_KEY", bytes.Repeat([]byte{0x42}, 32))
+	svc, err := New(Config{Marble: m, DB: db})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return svc
+}
+
+func TestPriorityForDeposit(t *testing.T) {
+	tests := []struct {
+		available int64
+		want      int
+	}{
+		{-PriorityDepositStep, 0},
+		{0, 0},
+		{PriorityDepositStep - 1, 0},
+		{PriorityDepositStep, 1},
+		{3*PriorityDepositStep + 5, 3},
+		{math.MaxInt64, MaxPriority},
+	}
+	for _, tt := range tests {
+		if got := PriorityForDeposit(tt.available); got != tt.want {
+			t.Errorf("PriorityForDeposit(%d) = %d, want %d", tt.available, got, tt.want)
+		}
+	}
+}
+
+func TestEffectivePriorityClamps(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	if got := (VRFRequest{Priority: math.MaxInt, CreatedAt: now}).EffectivePriority(now, DefaultPriorityAgingInterval); got != MaxPriority {
+		t.Errorf("oversized priority = %d, want %d", got, MaxPriority)
+	}
+	if got := (VRFRequest{Priority: -5, CreatedAt: now}).EffectivePriority(now, DefaultPriorityAgingInterval); got != 0 {
+		t.Errorf("negative priority = %d, want 0", got)
+	}
+
+	// A decades-old request at a 1ns interval would overflow without the cap.
+	ancient := VRFRequest{Priority: math.MaxInt, CreatedAt: now.Add(-100 * 365 * 24 * time.Hour)}
+	if got := ancient.EffectivePriority(now, time.Nanosecond); got != MaxPriority+maxAgingLevels {
+		t.Errorf("aged priority = %d, want %d", got, MaxPriority+maxAgingLevels)
+	}
+}
+
+func TestOrderForFulfillmentPriority(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	requests := []VRFRequest{
+		{RequestID: "low", Priority: 1, CreatedAt: now},
+		{RequestID: "high", Priority: 5, CreatedAt: now},
+		{RequestID: "mid", Priority: 3, CreatedAt: now},
+	}
+
+	ordered := OrderForFulfillment(requests, now, DefaultPriorityAgingInterval)
+
+	want := []string{"high", "mid", "low"}
+	for i, id := range want {
+		if ordered[i].RequestID != id {
+			t.Fatalf("position %d = %s, want %s", i, ordered[i].RequestID, id)
+		}
+	}
+	if requests[0].RequestID != "low" {
+		t.Fatal("input slice should not be reordered")
+	}
+}
+
+func TestOrderForFulfillmentTieBreaksByCreatedAt(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	requests := []VRFRequest{
+		{RequestID: "b", Priority: 2, CreatedAt: now.Add(-time.Second)},
+		{RequestID: "a", Priority: 2, CreatedAt: now.Add(-2 * time.Second)},
+		{RequestID: "c", Priority: 2, CreatedAt: now.Add(-time.Second)},
+	}
+
+	ordered := OrderForFulfillment(requests, now, 0)
+
+	want := []string{"a", "b", "c"}
+	for i, id := range want {
+		if ordered[i].RequestID != id {
+			t.Fatalf("position %d = %s, want %s", i, ordered[i].RequestID, id)
+		}
+	}
+}
+
+func TestOrderForFulfillmentAgingPreventsStarvation(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	requests := []VRFRequest{
+		{RequestID: "fresh-high", Priority: 3, CreatedAt: now},
+		{RequestID: "stale-low", Priority: 0, CreatedAt: now.Add(-5 * time.Minute)},
+	}
+
+	ordered := OrderForFulfillment(requests, now, DefaultPriorityAgingInterval)
+	if ordered[0].RequestID != "stale-low" {
+		t.Fatalf("expected aged request first, got %s", ordered[0].RequestID)
+	}
+
+	ordered = OrderForFulfillment(requests, now, 0)
+	if ordered[0].RequestID != "fresh-high" {
+		t.Fatalf("expected raw priority order without aging, got %s", ordered[0].RequestID)
+	}
+}
+
+func TestFulfillPendingServesHighestPriorityFirst(t *testing.T) {
+	svc := newQueueVRF(t, map[string]int64{"rich": 5 * PriorityDepositStep})
+
+	// Together with the high-priority requests queued after them, one more
+	// request than a tick fulfills.
+	for i := 0; i < MaxFulfillmentsPerTick-1; i++ {
+		if _, err := svc.Enqueue(context.Background(), "user-1", GenerateRandomnessRequest{RequestID: fmt.Sprintf("low-%02d", i)}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	for _, id := range []string{"high-b", "high-a"} {
+		if _, err := svc.Enqueue(context.Background(), "rich", GenerateRandomnessRequest{RequestID: id}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	// Make queue order independent of enqueue timing.
+	base := time.Now().Add(-time.Second)
+	for _, entry := range svc.queue.entries {
+		entry.request.CreatedAt = base
+	}
+
+	fulfilled, err := svc.FulfillPending(context.Background())
+	if err != nil {
+		t.Fatalf("FulfillPending: %v", err)
+	}
+	if len(fulfilled) != MaxFulfillmentsPerTick {
+		t.Fatalf("fulfilled %d, want %d", len(fulfilled), MaxFulfillmentsPerTick)
+	}
+	want := []string{"high-a", "high-b", "low-00", "low-01"}
+	for i, id := range want {
+		if fulfilled[i] != id {
+			t.Fatalf("fulfillment %d = %s, want %s", i, fulfilled[i], id)
+		}
+	}
+
+	last := fmt.Sprintf("low-%02d", MaxFulfillmentsPerTick-2)
+	if req, _ := svc.QueuedRequest("user-1", last); req.Status != RequestStatusPending {
+		t.Fatalf("%s status = %s, want pending until the next tick", last, req.Status)
+	}
+	fulfilled, err = svc.FulfillPending(context.Background())
+	if err != nil {
+		t.Fatalf("FulfillPending: %v", err)
+	}
+	if len(fulfilled) != 1 || fulfilled[0] != last {
+		t.Fatalf("second tick fulfilled %v, want [%s]", fulfilled, last)
+	}
+}
+
+func TestFulfillPendingAgesStarvedRequests(t *testing.T) {
+	svc := newQueueVRF(t, map[string]int64{"rich": 3 * PriorityDepositStep})
+	if _, err := svc.Enqueue(context.Background(), "user-1", GenerateRandomnessRequest{RequestID: "stale-low"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	for i := 0; i < MaxFulfillmentsPerTick; i++ {
+		if _, err := svc.Enqueue(context.Background(), "rich", GenerateRandomnessRequest{RequestID: fmt.Sprintf("high-%02d", i)}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	svc.queue.entries["stale-low"].request.CreatedAt = time.Now().Add(-5 * time.Minute)
+
+	fulfilled, err := svc.FulfillPending(context.Background())
+	if err != nil {
+		t.Fatalf("FulfillPending: %v", err)
+	}
+	if fulfilled[0] != "stale-low" {
+		t.Fatalf("first fulfillment = %s, want the aged request", fulfilled[0])
+	}
+}
+
+func TestHugePriorityDoesNotStarveOlderRequests(t *testing.T) {
+	svc := newQueueVRF(t, map[string]int64{"whale": math.MaxInt64})
+	ctx := context.Background()
+
+	// The client-supplied priority is ignored; the deposit caps at MaxPriority.
+	req := httptest.NewRequest(http.MethodPost, "/random/requests", strings.NewReader(`{"request_id":"whale-00","priority":2147483647}`))
+	req.Header.Set("X-User-ID", "whale")
+	rr := httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	var queued QueuedRandomResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &queued); err != nil || rr.Code != http.StatusAccepted {
+		t.Fatalf("enqueue status=%d (%s)", rr.Code, rr.Body.String())
+	}
+	if queued.Priority != MaxPriority {
+		t.Fatalf("whale priority = %d, want %d", queued.Priority, MaxPriority)
+	}
+	for i := 1; i < MaxFulfillmentsPerTick; i++ {
+		if _, err := svc.Enqueue(ctx, "whale", GenerateRandomnessRequest{RequestID: fmt.Sprintf("whale-%02d", i)}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	for _, id := range []string{"old-a", "old-b"} {
+		if _, err := svc.Enqueue(ctx, "user-1", GenerateRandomnessRequest{RequestID: id}); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	// The priority-0 requests were queued just over MaxPriority aging
+	// intervals before the whale's.
+	now := time.Now()
+	for id, entry := range svc.queue.entries {
+		if strings.HasPrefix(id, "old-") {
+			entry.request.CreatedAt = now.Add(-time.Duration(MaxPriority+1) * DefaultPriorityAgingInterval)
+		} else {
+			entry.request.CreatedAt = now
+		}
+	}
+
+	fulfilled, err := svc.FulfillPending(ctx)
+	if err != nil {
+		t.Fatalf("FulfillPending: %v", err)
+	}
+	if len(fulfilled) != MaxFulfillmentsPerTick || fulfilled[0] != "old-a" || fulfilled[1] != "old-b" {
+		t.Fatalf("fulfilled %v, want old-a and old-b first", fulfilled[:min(len(fulfilled), 3)])
+	}
+}
+
+func TestEnqueueRejectsDuplicatesAndFullQueue(t *testing.T) {
+	svc := newTestVRF(t)
+	if _, err := svc.Enqueue(context.Background(), "user-1", GenerateRandomnessRequest{RequestID: "a"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if _, err := svc.Enqueue(context.Background(), "user-1", GenerateRandomnessRequest{RequestID: "a"}); err != ErrDuplicateRequest {
+		t.Fatalf("duplicate: err = %v, want ErrDuplicateRequest", err)
+	}
+
+	svc.queue.pending = MaxPendingRequests
+	if _, err := svc.Enqueue(context.Background(), "user-1", GenerateRandomnessRequest{RequestID: "b"}); err != ErrQueueFull {
+		t.Fatalf("full queue: err = %v, want ErrQueueFull", err)
+	}
+}
+
+func TestHandleQueuedRandom(t *testing.T) {
+	svc := newQueueVRF(t, map[string]int64{"user-1": 2 * PriorityDepositStep})
+
+	req := httptest.NewRequest(http.MethodPost, "/random/requests", strings.NewReader(`{"request_id":"q-1"}`))
+	req.Header.Set("X-User-ID", "user-1")
+	rr := httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("enqueue status=%d (%s)", rr.Code, rr.Body.String())
+	}
+
+	if _, err := svc.FulfillPending(context.Background()); err != nil {
+		t.Fatalf("FulfillPending: %v", err)
+	}
+
+	get := func(userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/random/requests/q-1", nil)
+		req.Header.Set("X-User-ID", userID)
+		rr := httptest.NewRecorder()
+		svc.Router().ServeHTTP(rr, req)
+		return rr
+	}
+	if rr := get("user-2"); rr.Code != http.StatusNotFound {
+		t.Fatalf("other user status=%d, want 404", rr.Code)
+	}
+
+	rr = get("user-1")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("get status=%d (%s)", rr.Code, rr.Body.String())
+	}
+	var resp QueuedRandomResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Status != RequestStatusFulfilled || resp.Priority != 2 {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	output := VRFOutput{Input: []byte("q-1")}
+	output.Randomness, _ = decodeHexField(resp.Randomness)
+	output.Proof, _ = decodeHexField(resp.Signature)
+	if valid, err := VerifyProof(output, svc.publicKey); !valid {
+		t.Fatalf("queued result does not verify: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package neovrf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrEntropyExhausted is returned when rejection sampling draws more entropy
+// than a VRF output expands to. It is practically unreachable.
+var ErrEntropyExhausted = errors.New("vrf entropy exhausted")
+
+const (
+	// MaxPermutationSize is the largest n RandomPermutation accepts.
+	MaxPermutationSize = 10000
+	// maxEntropyBlocks bounds how far one output's randomness is expanded
+	// (32 bytes per block).
+	maxEntropyBlocks = 1 << 15
+)
+
+// entropyStream expands VRF randomness into a deterministic byte stream:
+// block i is sha256("neovrf-range" || randomness || i).
+type entropyStream struct {
+	seed  []byte
+	block uint32
+	buf   []byte
+}
+
+func newEntropyStream(output VRFOutput) (*entropyStream, error) {
+	if len(output.Randomness) != RandomnessSize {
+		return nil, fmt.Errorf("randomness must be %d bytes, got %d", RandomnessSize, len(output.Randomness))
+	}
+	return &entropyStream{seed: output.Randomness}, nil
+}
+
+func (e *entropyStream) uint64() (uint64, error) {
+	if len(e.buf) < 8 {
+		if e.block >= maxEntropyBlocks {
+			return 0, ErrEntropyExhausted
+		}
+		h := sha256.New()
+		h.Write([]byte("neovrf-range"))
+		h.Write(e.seed)
+		_ = binary.Write(h, binary.BigEndian, e.block)
+		e.block++
+		e.buf = h.Sum(nil)
+	}
+	v := binary.BigEndian.Uint64(e.buf[:8])
+	e.buf = e.buf[8:]
+	return v, nil
+}
+
+// uniform returns a value in [0, n) without modulo bias: draws below
+// 2^64 mod n are rejected so every residue is equally likely. n == 0 means
+// the full 2^64 range.
+func (e *entropyStream) uniform(n uint64) (uint64, error) {
+	if n == 0 {
+		return e.uint64()
+	}
+	threshold := -n % n
+	for {
+		v, err := e.uint64()
+		if err != nil {
+			return 0, err
+		}
+		if v >= threshold {
+			return v % n, nil
+		}
+	}
+}
+
+// RandomInt returns an integer uniformly distributed in [min, max], derived
+// from output's randomness by rejection sampling. The same output always
+// yields the same value.
+func RandomInt(output VRFOutput, min, max int64) (int64, error) {
+	if min > max {
+		return 0, fmt.Errorf("invalid range: min %d > max %d", min, max)
+	}
+	stream, err := newEntropyStream(output)
+	if err != nil {
+		return 0, err
+	}
+	// max-min+1 wraps to 0 for the full int64 range, which uniform treats
+	// as 2^64.
+	v, err := stream.uniform(uint64(max) - uint64(min) + 1)
+	if err != nil {
+		return 0, err
+	}
+	return int64(uint64(min) + v), nil
+}
+
+// RandomPermutation returns a uniformly random permutation of [0, n) derived
+// from output's randomness with a Fisher-Yates shuffle.
+func RandomPermutation(output VRFOutput, n int) ([]int, error) {
+	if n < 0 || n > MaxPermutationSize {
+		return nil, fmt.Errorf("permutation size must be between 0 and %d, got %d", MaxPermutationSize, n)
+	}
+	stream, err := newEntropyStream(output)
+	if err != nil {
+		return nil, err
+	}
+
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j, err := stream.uniform(uint64(i + 1))
+		if err != nil {
+			return nil, err
+		}
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+	return perm, nil
+}
@@ -0,0 +1,118 @@
+package neovrf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sampleOutput returns a distinct VRF output per i.
+func sampleOutput(i int) VRFOutput {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(i))
+	sum := sha256.Sum256(b[:])
+	return VRFOutput{Randomness: sum[:]}
+}
+
+func TestRandomIntUniform(t *testing.T) {
+	const (
+		samples = 60000
+		min     = 1
+		max     = 6
+	)
+	counts := make(map[int64]int)
+	for i := 0; i < samples; i++ {
+		v, err := RandomInt(sampleOutput(i), min, max)
+		if err != nil {
+			t.Fatalf("RandomInt: %v", err)
+		}
+		if v < min || v > max {
+			t.Fatalf("value %d outside [%d, %d]", v, min, max)
+		}
+		counts[v]++
+	}
+
+	// Chi-square with 5 degrees of freedom; 20.52 is the 0.999 quantile.
+	expected := float64(samples) / (max - min + 1)
+	chi2 := 0.0
+	for v := int64(min); v <= max; v++ {
+		d := float64(counts[v]) - expected
+		chi2 += d * d / expected
+	}
+	if chi2 > 20.52 {
+		t.Fatalf("chi-square = %.2f, distribution not uniform: %v", chi2, counts)
+	}
+}
+
+func TestRandomIntDeterministicAndBounds(t *testing.T) {
+	out := sampleOutput(7)
+	a, _ := RandomInt(out, -1000, 1000)
+	b, _ := RandomInt(out, -1000, 1000)
+	if a != b {
+		t.Fatalf("RandomInt not deterministic: %d != %d", a, b)
+	}
+
+	if v, err := RandomInt(out, 42, 42); err != nil || v != 42 {
+		t.Fatalf("single-value range = %d, %v", v, err)
+	}
+	if _, err := RandomInt(out, math.MinInt64, math.MaxInt64); err != nil {
+		t.Fatalf("full range: %v", err)
+	}
+	if _, err := RandomInt(out, 2, 1); err == nil {
+		t.Fatal("expected error for min > max")
+	}
+	if _, err := RandomInt(VRFOutput{Randomness: []byte{1, 2}}, 0, 1); err == nil {
+		t.Fatal("expected error for short randomness")
+	}
+}
+
+func TestRandomIntRejectsBiasedDraws(t *testing.T) {
+	// For n = 2^63 + 1 about half of all 64-bit draws fall below the
+	// threshold; values must still land in range.
+	for i := 0; i < 1000; i++ {
+		v, err := RandomInt(sampleOutput(i), 0, math.MaxInt64)
+		if err != nil || v < 0 {
+			t.Fatalf("RandomInt = %d, %v", v, err)
+		}
+	}
+}
+
+func TestRandomPermutation(t *testing.T) {
+	const (
+		n       = 4
+		samples = 24000
+	)
+	// positions[v][p] counts how often value v lands at position p.
+	var positions [n][n]int
+	for i := 0; i < samples; i++ {
+		perm, err := RandomPermutation(sampleOutput(i), n)
+		if err != nil {
+			t.Fatalf("RandomPermutation: %v", err)
+		}
+		seen := make(map[int]bool)
+		for p, v := range perm {
+			if v < 0 || v >= n || seen[v] {
+				t.Fatalf("invalid permutation %v", perm)
+			}
+			seen[v] = true
+			positions[v][p]++
+		}
+	}
+
+	expected := float64(samples) / n
+	for v := 0; v < n; v++ {
+		for p := 0; p < n; p++ {
+			if dev := math.Abs(float64(positions[v][p])-expected) / expected; dev > 0.05 {
+				t.Errorf("value %d at position %d: %d times, %.1f%% from expected", v, p, positions[v][p], dev*100)
+			}
+		}
+	}
+
+	if perm, err := RandomPermutation(sampleOutput(1), 0); err != nil || len(perm) != 0 {
+		t.Fatalf("empty permutation = %v, %v", perm, err)
+	}
+	if _, err := RandomPermutation(sampleOutput(1), MaxPermutationSize+1); err == nil {
+		t.Fatal("expected error for oversized permutation")
+	}
+}
@@ -2,10 +2,12 @@
 package neovrf
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/R3E-Network/service_layer/infrastructure/crypto"
 	"github.com/R3E-Network/service_layer/infrastructure/database"
@@ -27,12 +29,21 @@ type Service struct {
 	privateKey      *ecdsa.PrivateKey
 	publicKey       []byte
 	attestationHash []byte
+
+	queue         *fulfillmentQueue
+	agingInterval time.Duration
 }
 
 // Config holds VRF service configuration.
 type Config struct {
 	Marble *marble.Marble
 	DB     database.RepositoryInterface
+
+	// PriorityAgingInterval overrides DefaultPriorityAgingInterval; a
+	// negative value disables aging.
+	PriorityAgingInterval time.Duration
+	// FulfillInterval overrides DefaultFulfillInterval.
+	FulfillInterval time.Duration
 }
 
 // New creates a new NeoVRF service.
@@ -58,7 +69,16 @@ func New(cfg Config) (*Service, error) {
 	})
 
 	s := &Service{
-		BaseService: base,
+		BaseService:   base,
+		queue:         newFulfillmentQueue(),
+		agingInterval: DefaultPriorityAgingInterval,
+	}
+	if cfg.PriorityAgingInterval != 0 {
+		s.agingInterval = cfg.PriorityAgingInterval
+	}
+	fulfillInterval := DefaultFulfillInterval
+	if cfg.FulfillInterval > 0 {
+		fulfillInterval = cfg.FulfillInterval
 	}
 	s.attestationHash = computeAttestationHash(cfg.Marble)
 
@@ -74,7 +94,13 @@ func New(cfg Config) (*Service, error) {
 		return nil, err
 	}
 
+	base.AddTickerWorker(fulfillInterval, func(ctx context.Context) error {
+		_, err := s.FulfillPending(ctx)
+		return err
+	}, commonservice.WithTickerWorkerName("fulfill-queue"))
+
 	base.WithStats(s.statistics)
+	base.AddSelfTest(s.selfTest)
 	base.RegisterStandardRoutes()
 	s.registerRoutes()
 
@@ -121,10 +147,22 @@ func deriveSigningKey(master []byte) (*ecdsa.PrivateKey, []byte, error) {
 	return priv, pub, nil
 }
 
+// selfTest requires a configured signing key: randomness signed with an
+// ephemeral key cannot be verified after a restart.
+func (s *Service) selfTest(ctx context.Context) error {
+	return s.CheckDependencies(ctx, commonservice.Dependency{
+		Name:    "signing key (NEOVRF_SIGNING_KEY)",
+		Missing: len(s.signingKey) < 32,
+	})
+}
+
 func (s *Service) statistics() map[string]any {
 	stats := map[string]any{
 		"attestation_hash": fmt.Sprintf("%x", s.attestationHash),
 	}
+	s.queue.mu.Lock()
+	stats["pending_requests"] = s.queue.pending
+	s.queue.mu.Unlock()
 	if len(s.publicKey) > 0 {
 		stats["public_key"] = fmt.Sprintf("%x", s.publicKey)
 	}
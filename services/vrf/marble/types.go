@@ -1,5 +1,33 @@
 package neovrf
 
+import "time"
+
+// VRF request states.
+const (
+	RequestStatusPending   = "pending"
+	RequestStatusFulfilled = "fulfilled"
+)
+
+// RandomnessSize is the length of the randomness value (SHA-256 of the proof).
+const RandomnessSize = 32
+
+// VRFRequest is a randomness request and, once Status is
+// RequestStatusFulfilled, its Randomness and Proof. Requests fulfilled
+// by GenerateBatch share the batch proof; BatchSize is the number of requests
+// in the batch and BatchIndex this request's position. Priority, set by
+// Enqueue from the caller's gas bank deposit, orders queued requests for
+// fulfillment (see OrderForFulfillment).
+type VRFRequest struct {
+	RequestID  string    `json:"request_id"`
+	Priority   int       `json:"priority,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	Status     string    `json:"status,omitempty"`
+	Randomness []byte    `json:"randomness,omitempty"`
+	Proof      []byte    `json:"proof,omitempty"`
+	BatchIndex int       `json:"batch_index,omitempty"`
+	BatchSize  int       `json:"batch_size,omitempty"`
+}
+
 type RandomRequest struct {
 	RequestID string `json:"request_id,omitempty"`
 }
@@ -13,6 +41,54 @@ type RandomResponse struct {
 	Timestamp       int64  `json:"timestamp"`
 }
 
+// QueuedRandomResponse is a request queued at POST /random/requests. Once
+// Status is fulfilled, Randomness and Signature verify at /verify like a
+// /random response.
+type QueuedRandomResponse struct {
+	RequestID  string `json:"request_id"`
+	Priority   int    `json:"priority"`
+	Status     string `json:"status"`
+	CreatedAt  int64  `json:"created_at"`
+	Randomness string `json:"randomness,omitempty"`
+	Signature  string `json:"signature,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+}
+
+// RandomBatchRequest is the payload of POST /random/batch.
+type RandomBatchRequest struct {
+	Requests []GenerateRandomnessRequest `json:"requests"`
+}
+
+type RandomBatchEntry struct {
+	RequestID  string `json:"request_id"`
+	Randomness string `json:"randomness"`
+	BatchIndex int    `json:"batch_index"`
+}
+
+type RandomBatchResponse struct {
+	Requests        []RandomBatchEntry `json:"requests"`
+	Proof           string             `json:"proof"`
+	Result          string             `json:"result"` // EncodeBatchFulfillmentResult
+	PublicKey       string             `json:"public_key,omitempty"`
+	AttestationHash string             `json:"attestation_hash,omitempty"`
+	Timestamp       int64              `json:"timestamp"`
+}
+
+// VerifyRequest is the payload of POST /verify. Hex fields may carry a 0x
+// prefix; PublicKey defaults to this service's key.
+type VerifyRequest struct {
+	RequestID  string `json:"request_id"`
+	Randomness string `json:"randomness"`
+	Signature  string `json:"signature"`
+	PublicKey  string `json:"public_key,omitempty"`
+}
+
+type VerifyResponse struct {
+	Valid       bool   `json:"valid"`
+	FailedCheck string `json:"failed_check,omitempty"` // public_key, proof or randomness
+	Reason      string `json:"reason,omitempty"`
+}
+
 type PublicKeyResponse struct {
 	PublicKey       string `json:"public_key"`
 	AttestationHash string `json:"attestation_hash,omitempty"`
@@ -0,0 +1,74 @@
+package neovrf
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/R3E-Network/service_layer/infrastructure/crypto"
+)
+
+// Checks reported by VerificationError, in the order VerifyProof runs them.
+const (
+	CheckPublicKey  = "public_key" // key is not a valid P-256 point
+	CheckProof      = "proof"      // proof is not the key's signature over the input
+	CheckRandomness = "randomness" // randomness is not sha256(proof)
+)
+
+// VRFOutput is a randomness result as returned by POST /random: Input is
+// the signed request ID and Proof the signature.
+type VRFOutput struct {
+	Input      []byte
+	Randomness []byte
+	Proof      []byte
+}
+
+// VerificationError reports which check a VRF output failed.
+type VerificationError struct {
+	Check  string
+	Reason string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("%s check failed: %s", e.Check, e.Reason)
+}
+
+// p256HalfOrder is N/2 for P-256; proofs with s above it are rejected.
+var p256HalfOrder = new(big.Int).Rsh(elliptic.P256().Params().N, 1)
+
+// isLowS reports whether a 64-byte r || s proof uses the low-S form that
+// crypto.Sign produces. (r, N-s) is an equally valid signature, so
+// accepting both would give every input two randomness values.
+func isLowS(proof []byte) bool {
+	s := new(big.Int).SetBytes(proof[32:])
+	return s.Cmp(p256HalfOrder) <= 0
+}
+
+// VerifyProof checks output against publicKey: the proof must be a valid
+// low-S P-256 ECDSA signature by publicKey over output.Input, and the
+// randomness must equal sha256(proof). On failure it returns false and a
+// *VerificationError naming the failed check.
+//
+// A valid proof shows the key holder produced the randomness for this input.
+// It does not show the output is the only one possible: ECDSA nonces cannot
+// be checked by a verifier, so the signer could grind k and pick a result.
+func VerifyProof(output VRFOutput, publicKey []byte) (bool, error) {
+	pub, err := crypto.PublicKeyFromBytes(publicKey)
+	if err != nil {
+		return false, &VerificationError{Check: CheckPublicKey, Reason: err.Error()}
+	}
+	if len(output.Proof) != proofSize {
+		return false, &VerificationError{Check: CheckProof, Reason: fmt.Sprintf("proof must be %d bytes, got %d", proofSize, len(output.Proof))}
+	}
+	if !isLowS(output.Proof) {
+		return false, &VerificationError{Check: CheckProof, Reason: "signature is not in low-S form"}
+	}
+	if !crypto.Verify(pub, output.Input, output.Proof) {
+		return false, &VerificationError{Check: CheckProof, Reason: "signature does not verify against public key"}
+	}
+	if !bytes.Equal(crypto.Hash256(output.Proof), output.Randomness) {
+		return false, &VerificationError{Check: CheckRandomness, Reason: "randomness is not sha256(proof)"}
+	}
+	return true, nil
+}
@@ -0,0 +1,140 @@
+package neovrf
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Known-good output: request_id "vector-1" signed with the key derived from
+// NEOVRF_SIGNING_KEY = 32 x 0x42 (see newTestVRF).
+const (
+	vectorInput      = "vector-1"
+	vectorPublicKey  = "02370dbd6d2cc28a6d9f93697f2421525c2c763a34f880bc5df1e9934396e1883f"
+	vectorProof      = "4d9ef66781267e98db8aa11f41b337bb8c790d33834a6ebafdac079d5df295bf18b62c52fc13a42a8ecd6361801ff0f2d0b05531d8d2bc96d249e7ecd1cf6d8d"
+	vectorRandomness = "2d1af640ab3d71668ef1e28cd6098fdecfd4987be288611232ade2fd671c1858"
+)
+
+func vectorOutput(t *testing.T) (VRFOutput, []byte) {
+	t.Helper()
+	proof, _ := hex.DecodeString(vectorProof)
+	randomness, _ := hex.DecodeString(vectorRandomness)
+	pub, _ := hex.DecodeString(vectorPublicKey)
+	return VRFOutput{Input: []byte(vectorInput), Randomness: randomness, Proof: proof}, pub
+}
+
+// malleate replaces the proof with its high-S twin (r, N-s), which still
+// verifies as ECDSA, and recomputes the randomness to match it.
+func malleate(o *VRFOutput) {
+	n := elliptic.P256().Params().N
+	s := new(big.Int).Sub(n, new(big.Int).SetBytes(o.Proof[32:]))
+	proof := make([]byte, proofSize)
+	copy(proof, o.Proof[:32])
+	s.FillBytes(proof[32:])
+	o.Proof = proof
+	sum := sha256.Sum256(proof)
+	o.Randomness = sum[:]
+}
+
+func TestVerifyProofVectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(*VRFOutput, *[]byte)
+		wantCheck string
+	}{
+		{"known good", func(*VRFOutput, *[]byte) {}, ""},
+		{"tampered proof", func(o *VRFOutput, _ *[]byte) { o.Proof[10] ^= 0x01 }, CheckProof},
+		{"truncated proof", func(o *VRFOutput, _ *[]byte) { o.Proof = o.Proof[:32] }, CheckProof},
+		{"different input", func(o *VRFOutput, _ *[]byte) { o.Input = []byte("vector-2") }, CheckProof},
+		{"tampered randomness", func(o *VRFOutput, _ *[]byte) { o.Randomness[0] ^= 0x01 }, CheckRandomness},
+		{"bad public key", func(_ *VRFOutput, pub *[]byte) { *pub = (*pub)[:10] }, CheckPublicKey},
+		{"high-S proof", func(o *VRFOutput, _ *[]byte) { malleate(o) }, CheckProof},
+	}
+	for _, tt := range tests {
+		output, pub := vectorOutput(t)
+		tt.mutate(&output, &pub)
+
+		valid, err := VerifyProof(output, pub)
+		if tt.wantCheck == "" {
+			if !valid || err != nil {
+				t.Errorf("%s: valid=%v err=%v, want valid", tt.name, valid, err)
+			}
+			continue
+		}
+		var verr *VerificationError
+		if valid || !errors.As(err, &verr) || verr.Check != tt.wantCheck {
+			t.Errorf("%s: valid=%v err=%v, want %s check to fail", tt.name, valid, err, tt.wantCheck)
+		}
+	}
+}
+
+func TestVerifyProofMatchesService(t *testing.T) {
+	svc := newTestVRF(t)
+	if hex.EncodeToString(svc.publicKey) != vectorPublicKey {
+		t.Fatalf("public key = %x, test vector is stale", svc.publicKey)
+	}
+}
+
+func TestHandleVerify(t *testing.T) {
+	svc := newTestVRF(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantValid  bool
+		wantCheck  string
+	}{
+		{"valid with service key", `{"request_id":"vector-1","randomness":"` + vectorRandomness + `","signature":"` + vectorProof + `"}`, http.StatusOK, true, ""},
+		{"valid with 0x key", `{"request_id":"vector-1","randomness":"0x` + vectorRandomness + `","signature":"` + vectorProof + `","public_key":"0x` + vectorPublicKey + `"}`, http.StatusOK, true, ""},
+		{"wrong request", `{"request_id":"vector-2","randomness":"` + vectorRandomness + `","signature":"` + vectorProof + `"}`, http.StatusOK, false, CheckProof},
+		{"bad hex", `{"request_id":"vector-1","randomness":"zz","signature":"` + vectorProof + `"}`, http.StatusBadRequest, false, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(tt.body))
+		rr := httptest.NewRecorder()
+		svc.Router().ServeHTTP(rr, req)
+		if rr.Code != tt.wantStatus {
+			t.Errorf("%s: status=%d want %d (%s)", tt.name, rr.Code, tt.wantStatus, rr.Body.String())
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+		var resp VerifyResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: decode: %v", tt.name, err)
+		}
+		if resp.Valid != tt.wantValid || resp.FailedCheck != tt.wantCheck {
+			t.Errorf("%s: response=%+v", tt.name, resp)
+		}
+	}
+}
+
+func TestVerifyAvailableInReadOnly(t *testing.T) {
+	svc := newTestVRF(t)
+	svc.SetReadOnly(true, "maintenance")
+
+	body := `{"request_id":"vector-1","randomness":"` + vectorRandomness + `","signature":"` + vectorProof + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/verify", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("/verify status=%d want 200 in read-only mode (%s)", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/random", strings.NewReader(`{}`))
+	req.Header.Set("X-User-ID", "user-1")
+	rr = httptest.NewRecorder()
+	svc.Router().ServeHTTP(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("/random status=%d want 503 in read-only mode", rr.Code)
+	}
+}
@@ -17,8 +17,31 @@ import (
 	"github.com/R3E-Network/service_layer/infrastructure/marble"
 	"github.com/R3E-Network/service_layer/infrastructure/testutil"
 	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+	"github.com/R3E-Network/service_layer/test/fixtures"
 )
 
+// mockFeedsConfig returns the fixture feeds config with its one source
+// replaced by a "mock" source at url. opts override the one feed, which
+// defaults to the fixture's BTC-USD feed reading from that source.
+func mockFeedsConfig(url string, opts ...fixtures.Option[neofeeds.FeedConfig]) *neofeeds.NeoFeedsConfig {
+	feedOpts := append([]fixtures.Option[neofeeds.FeedConfig]{func(f *neofeeds.FeedConfig) {
+		f.Pair = "BTCUSDT"
+		f.Sources = []string{"mock"}
+	}}, opts...)
+
+	cfg := fixtures.NewFeedsConfigFixture(func(c *neofeeds.FeedsConfig) {
+		c.Sources = []neofeeds.SourceConfig{fixtures.NewSourceConfigFixture(func(src *neofeeds.SourceConfig) {
+			src.ID = "mock"
+			src.Name = "Mock"
+			src.URL = url
+		})}
+		c.Feeds = []neofeeds.FeedConfig{fixtures.NewFeedConfigFixture(feedOpts...)}
+		c.DefaultSources = []string{"mock"}
+		c.UpdateInterval = 60 * time.Second
+	})
+	return &cfg
+}
+
 // TestNeoFeedsPriceFetching tests that neofeeds can fetch prices from Chainlink and Binance.
 func TestNeoFeedsPriceFetching(t *testing.T) {
 	if testing.Short() {
@@ -119,16 +142,7 @@ func TestNeoFeedsHTTPHandler(t *testing.T) {
 	}))
 	defer mockServer.Close()
 
-	mockConfig := &neofeeds.NeoFeedsConfig{
-		Version: "1.0",
-		Sources: []neofeeds.SourceConfig{
-			{ID: "mock", Name: "Mock", URL: mockServer.URL, JSONPath: "price", Weight: 1},
-		},
-		Feeds: []neofeeds.FeedConfig{
-			{ID: "BTC-USD", Pair: "BTCUSDT", Sources: []string{"mock"}, Enabled: true},
-		},
-		UpdateInterval: 60 * time.Second,
-	}
+	mockConfig := mockFeedsConfig(mockServer.URL)
 	svc, _ := neofeeds.New(neofeeds.Config{Marble: m, DB: database.NewMockRepository(), FeedsConfig: mockConfig})
 
 	t.Run("health endpoint", func(t *testing.T) {
@@ -190,16 +204,7 @@ func TestNeoFeedsSignatureVerification(t *testing.T) {
 	}))
 	defer mockServer.Close()
 
-	mockConfig := &neofeeds.NeoFeedsConfig{
-		Version: "1.0",
-		Sources: []neofeeds.SourceConfig{
-			{ID: "mock", Name: "Mock", URL: mockServer.URL, JSONPath: "price", Weight: 1},
-		},
-		Feeds: []neofeeds.FeedConfig{
-			{ID: "BTC-USD", Pair: "BTCUSDT", Sources: []string{"mock"}, Enabled: true},
-		},
-		UpdateInterval: 60 * time.Second,
-	}
+	mockConfig := mockFeedsConfig(mockServer.URL)
 	svc, _ := neofeeds.New(neofeeds.Config{Marble: m, DB: database.NewMockRepository(), FeedsConfig: mockConfig})
 
 	ctx := context.Background()
@@ -384,16 +389,9 @@ func BenchmarkPriceFetching(b *testing.B) {
 	m, _ := marble.New(marble.Config{MarbleType: "neofeeds"})
 	m.SetTestSecret("NEOFEEDS_SIGNING_KEY", []byte("test-signing-key-32-bytes-long!!"))
 
-	mockConfig := &neofeeds.NeoFeedsConfig{
-		Version: "1.0",
-		Sources: []neofeeds.SourceConfig{
-			{ID: "mock", URL: mockServer.URL, JSONPath: "price", Weight: 1},
-		},
-		Feeds: []neofeeds.FeedConfig{
-			{ID: "BTC/USD", Pair: "BTCUSDT", Sources: []string{"mock"}, Enabled: true},
-		},
-		UpdateInterval: 60 * time.Second,
-	}
+	mockConfig := mockFeedsConfig(mockServer.URL, func(f *neofeeds.FeedConfig) {
+		f.ID = "BTC/USD"
+	})
 	svc, _ := neofeeds.New(neofeeds.Config{Marble: m, DB: database.NewMockRepository(), FeedsConfig: mockConfig})
 
 	ctx := context.Background()
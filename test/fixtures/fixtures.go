@@ -0,0 +1,117 @@
+// Package fixtures builds valid, deterministic domain entities for tests.
+//
+// Each New*Fixture returns the same value on every call: fixed IDs, fixed
+// timestamps (FixtureTime) and no randomness. Options override fields after
+// the defaults are applied. Defaults are chosen to pass the owning service's
+// own validation, and fixtures_test.go checks that they still do, so a change
+// to a validator that invalidates the fixtures fails here first.
+//
+// This package imports the service packages, so it lives under test/ (see
+// test/layering). Tests inside a service package cannot import it without a
+// cycle; use an external _test package there.
+package fixtures
+
+import (
+	"time"
+
+	neooracle "github.com/R3E-Network/service_layer/services/conforacle/marble"
+	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+)
+
+// FixtureTime is the timestamp used by every fixture.
+var FixtureTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Option overrides fields of a fixture of type T.
+type Option[T any] func(*T)
+
+func build[T any](v T, opts []Option[T]) T {
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return v
+}
+
+// NewSourceConfigFixture returns the Binance BTC-USD price source.
+func NewSourceConfigFixture(opts ...Option[neofeeds.SourceConfig]) neofeeds.SourceConfig {
+	return build(neofeeds.SourceConfig{
+		ID:            "binance",
+		Name:          "Binance",
+		URL:           "https://api.binance.com/api/v3/ticker/price?symbol={pair}",
+		JSONPath:      "price",
+		Weight:        1,
+		Timeout:       5 * time.Second,
+		PairTemplate:  "{base}{quote}",
+		QuoteOverride: "USDT",
+	}, opts)
+}
+
+// NewFeedConfigFixture returns an enabled BTC-USD price feed with 8 decimals
+// reading from the NewSourceConfigFixture source.
+func NewFeedConfigFixture(opts ...Option[neofeeds.FeedConfig]) neofeeds.FeedConfig {
+	return build(neofeeds.FeedConfig{
+		ID:       "BTC-USD",
+		Name:     "Bitcoin / US Dollar",
+		DataType: neofeeds.DataTypePrice,
+		Base:     "BTC",
+		Quote:    "USD",
+		Decimals: 8,
+		Sources:  []string{"binance"},
+		Enabled:  true,
+	}, opts)
+}
+
+// NewFeedsConfigFixture returns a config with one source
+// (NewSourceConfigFixture) and one feed (NewFeedConfigFixture). Call
+// Validate on the result to apply the service's defaults.
+func NewFeedsConfigFixture(opts ...Option[neofeeds.FeedsConfig]) neofeeds.FeedsConfig {
+	return build(neofeeds.FeedsConfig{
+		Version:        "1.0",
+		Sources:        []neofeeds.SourceConfig{NewSourceConfigFixture()},
+		Feeds:          []neofeeds.FeedConfig{NewFeedConfigFixture()},
+		DefaultSources: []string{"binance"},
+		UpdateInterval: time.Second,
+	}, opts)
+}
+
+// NewPriceResponseFixture returns one aggregated BTC-USD round at
+// FixtureTime: 65000.00000000 from a single source.
+func NewPriceResponseFixture(opts ...Option[neofeeds.PriceResponse]) neofeeds.PriceResponse {
+	return build(neofeeds.PriceResponse{
+		FeedID:      "BTC-USD",
+		Pair:        "BTCUSDT",
+		Price:       65000_00000000,
+		Decimals:    8,
+		Timestamp:   FixtureTime,
+		Sources:     []string{"binance"},
+		SourceCount: 1,
+		Confidence:  1,
+	}, opts)
+}
+
+// NewAlertRuleFixture returns a rule that fires when BTC-USD rises above
+// 70000 (at 8 decimals), at most every 5 minutes.
+func NewAlertRuleFixture(opts ...Option[neofeeds.AlertRule]) neofeeds.AlertRule {
+	return build(neofeeds.AlertRule{
+		ID:         "alert-btc-above-70k",
+		FeedID:     "BTC-USD",
+		Comparison: neofeeds.AlertAbove,
+		Threshold:  70000_00000000,
+		Cooldown:   5 * time.Minute,
+	}, opts)
+}
+
+// NewPollFeedFixture returns an active oracle poll feed fetching a JSON
+// endpoint every minute for user-1.
+func NewPollFeedFixture(opts ...Option[neooracle.PollFeed]) neooracle.PollFeed {
+	return build(neooracle.PollFeed{
+		ID:     "poll-feed-1",
+		UserID: "user-1",
+		Query: neooracle.QueryInput{
+			URL:    "https://api.example.com/v1/data",
+			Method: "GET",
+		},
+		Interval: time.Minute,
+		Active:   true,
+		Kind:     neooracle.FeedKindRaw,
+	}, opts)
+}
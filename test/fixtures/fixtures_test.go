@@ -0,0 +1,81 @@
+package fixtures
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	neooracle "github.com/R3E-Network/service_layer/services/conforacle/marble"
+	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+)
+
+func TestFixturesPassServiceValidation(t *testing.T) {
+	cfg := NewFeedsConfigFixture()
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("FeedsConfig fixture: %v", err)
+	}
+	// The feed fixture is already in the form validation normalizes to.
+	if !reflect.DeepEqual(cfg.Feeds[0], NewFeedConfigFixture()) {
+		t.Errorf("validated feed = %+v, want %+v", cfg.Feeds[0], NewFeedConfigFixture())
+	}
+
+	rule := NewAlertRuleFixture()
+	if err := rule.Validate(); err != nil {
+		t.Errorf("AlertRule fixture: %v", err)
+	}
+
+	feed := NewPollFeedFixture()
+	if err := feed.Validate(); err != nil {
+		t.Errorf("PollFeed fixture: %v", err)
+	}
+}
+
+func TestFixturesAreDeterministic(t *testing.T) {
+	pairs := []struct {
+		name string
+		a, b any
+	}{
+		{"SourceConfig", NewSourceConfigFixture(), NewSourceConfigFixture()},
+		{"FeedConfig", NewFeedConfigFixture(), NewFeedConfigFixture()},
+		{"FeedsConfig", NewFeedsConfigFixture(), NewFeedsConfigFixture()},
+		{"PriceResponse", NewPriceResponseFixture(), NewPriceResponseFixture()},
+		{"AlertRule", NewAlertRuleFixture(), NewAlertRuleFixture()},
+		{"PollFeed", NewPollFeedFixture(), NewPollFeedFixture()},
+	}
+	for _, p := range pairs {
+		if !reflect.DeepEqual(p.a, p.b) {
+			t.Errorf("%s fixture differs between calls: %+v vs %+v", p.name, p.a, p.b)
+		}
+	}
+
+	// Fixtures do not share mutable state.
+	a := NewFeedConfigFixture()
+	a.Sources[0] = "changed"
+	if NewFeedConfigFixture().Sources[0] != "binance" {
+		t.Error("mutating a fixture changed later fixtures")
+	}
+}
+
+func TestFixtureOptionsOverrideDefaults(t *testing.T) {
+	feed := NewPollFeedFixture(func(f *neooracle.PollFeed) {
+		f.Interval = 0
+		f.Schedule = "*/5 * * * *"
+	})
+	if feed.Schedule != "*/5 * * * *" || feed.UserID != "user-1" {
+		t.Errorf("overridden PollFeed = %+v", feed)
+	}
+	if err := feed.Validate(); err != nil {
+		t.Errorf("overridden PollFeed: %v", err)
+	}
+
+	rule := NewAlertRuleFixture(func(r *neofeeds.AlertRule) { r.Comparison = "sideways" })
+	if err := rule.Validate(); err == nil {
+		t.Error("invalid override should fail validation")
+	}
+
+	price := NewPriceResponseFixture(func(p *neofeeds.PriceResponse) { p.Price = 71000_00000000 })
+	triggered, err := neofeeds.EvaluateAlerts([]neofeeds.AlertRule{NewAlertRuleFixture()}, price, FixtureTime.Add(time.Minute))
+	if err != nil || len(triggered) != 1 {
+		t.Errorf("EvaluateAlerts() = %v, %v; want the fixture rule to fire", triggered, err)
+	}
+}
@@ -0,0 +1,192 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/R3E-Network/service_layer/infrastructure/chain"
+	"github.com/R3E-Network/service_layer/infrastructure/testutil"
+	neooracle "github.com/R3E-Network/service_layer/services/conforacle/marble"
+	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
+)
+
+// TestDomainTypeJSONSchema guards the JSON shape that external clients
+// depend on. Update the golden files with UPDATE_GOLDEN=1 only for intended
+// API changes.
+func TestDomainTypeJSONSchema(t *testing.T) {
+	method := chain.ContractMethod{
+		Name: "transfer",
+		Parameters: []chain.ContractMethodArg{
+			{Name: "from", Type: "Hash160"},
+			{Name: "amount", Type: "Integer"},
+		},
+	}
+	params, err := chain.EncodeArgs(method, map[string]any{
+		"from":   "0x0102030405060708090a0b0c0d0e0f1011121314",
+		"amount": 100,
+	})
+	if err != nil {
+		t.Fatalf("EncodeArgs() error = %v", err)
+	}
+
+	golden := map[string]any{
+		"datafeed_source_config":  NewSourceConfigFixture(),
+		"datafeed_feed_config":    NewFeedConfigFixture(),
+		"datafeed_price_response": NewPriceResponseFixture(),
+		"datafeed_alert_rule":     NewAlertRuleFixture(),
+		"conforacle_poll_feed":    NewPollFeedFixture(),
+		"chain_contract_method":   method,
+		"chain_invocation_params": params,
+	}
+	for name, v := range golden {
+		t.Run(name, func(t *testing.T) {
+			testutil.AssertGoldenJSON(t, name, v)
+		})
+	}
+
+	// The defaults above leave omitempty fields out of the JSON, so the same
+	// types are also snapshotted with every field set.
+	// Interval and schedule are mutually exclusive, so each poll feed
+	// snapshot leaves out the other.
+	full := map[string]struct {
+		value  any
+		exempt []string
+	}{
+		"datafeed_source_config_full":  {value: fullSourceConfig()},
+		"datafeed_feed_config_full":    {value: fullFeedConfig()},
+		"datafeed_price_response_full": {value: fullPriceResponse()},
+		"datafeed_alert_rule_full":     {value: NewAlertRuleFixture(func(r *neofeeds.AlertRule) { r.LastTriggered = FixtureTime })},
+		"conforacle_poll_feed_full":    {value: fullPollFeed(), exempt: []string{"schedule"}},
+		"conforacle_poll_feed_cron":    {value: fullCronPollFeed(), exempt: []string{"interval"}},
+	}
+	for name, tc := range full {
+		t.Run(name, func(t *testing.T) {
+			assertEveryJSONField(t, tc.value, tc.exempt...)
+			testutil.AssertGoldenJSON(t, name, tc.value)
+		})
+	}
+}
+
+func fullSourceConfig() neofeeds.SourceConfig {
+	return NewSourceConfigFixture(func(s *neofeeds.SourceConfig) {
+		s.Headers = map[string]string{"X-MBX-APIKEY": "${BINANCE_API_KEY}"}
+		s.BaseOverride = "XBT"
+		s.Transform = &neofeeds.TransformConfig{Trim: "$", Multiply: 0.01}
+		s.Signing = &neofeeds.RequestSigningConfig{
+			Secret:          "${BINANCE_API_SECRET}",
+			Algorithm:       neofeeds.SigningHMACSHA256,
+			Encoding:        neofeeds.SignatureHex,
+			Payload:         "{timestamp}{query}",
+			SignatureHeader: "X-Signature",
+			TimestampHeader: "X-Timestamp",
+			TimestampFormat: neofeeds.TimestampUnixMs,
+		}
+		s.HistoryURL = "https://api.binance.com/api/v3/klines?symbol={pair}&startTime={timestamp_ms}&limit=1"
+		s.HistoryJSONPath = "0.4"
+	})
+}
+
+func fullFeedConfig() neofeeds.FeedConfig {
+	return NewFeedConfigFixture(func(f *neofeeds.FeedConfig) {
+		f.Pair = "BTCUSDT"
+		f.UpdateInterval = time.Minute
+		f.SignerSet = []string{"02370dbd6d2cc28a6d9f93697f2421525c2c763a34f880bc5df1e9934396e1883f"}
+		f.Threshold = 1
+	})
+}
+
+func fullPriceResponse() neofeeds.PriceResponse {
+	return NewPriceResponseFixture(func(p *neofeeds.PriceResponse) {
+		p.Signature = []byte{0x01, 0x02, 0x03}
+		p.PublicKey = []byte{0x02, 0x37}
+		p.Backfilled = true
+	})
+}
+
+func fullPollFeed() neooracle.PollFeed {
+	return NewPollFeedFixture(func(f *neooracle.PollFeed) {
+		f.Query = neooracle.QueryInput{
+			URL:         "https://api.example.com/v1/data",
+			Method:      "POST",
+			Headers:     map[string]string{"Accept": "application/json"},
+			SecretName:  "example_api_key",
+			SecretAsKey: "X-API-Key",
+			Body:        `{"symbol":"NEO"}`,
+			AuthType:    "oauth2_client_credentials",
+			OAuth2: &neooracle.OAuth2Config{
+				TokenURL:         "https://auth.example.com/oauth/token",
+				ClientID:         "client-1",
+				ClientSecretName: "example_client_secret",
+				Scopes:           []string{"read"},
+			},
+			CacheTTL: 60,
+		}
+		f.NoCache = true
+		f.Kind = neooracle.FeedKindJSON
+	})
+}
+
+func fullCronPollFeed() neooracle.PollFeed {
+	return NewPollFeedFixture(func(f *neooracle.PollFeed) {
+		f.Query = fullPollFeed().Query
+		f.Interval = 0
+		f.Schedule = "*/5 * * * *"
+		f.NoCache = true
+		f.Kind = neooracle.FeedKindWeather
+	})
+}
+
+// assertEveryJSONField fails if v's JSON omits any top-level field of its
+// type, other than exempt ones, or any field of a nested struct, so a "full"
+// golden really covers every field.
+func assertEveryJSONField(t *testing.T, v any, exempt ...string) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, name := range exempt {
+		doc[name] = nil
+	}
+	checkJSONFields(t, reflect.TypeOf(v), doc, "")
+}
+
+func checkJSONFields(t *testing.T, typ reflect.Type, doc map[string]any, prefix string) {
+	t.Helper()
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			checkJSONFields(t, field.Type, doc, prefix)
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		value, ok := doc[name]
+		if !ok {
+			t.Errorf("field %s%s is not set in the full fixture", prefix, name)
+			continue
+		}
+		ft := field.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if nested, isObject := value.(map[string]any); isObject && ft.Kind() == reflect.Struct {
+			checkJSONFields(t, ft, nested, prefix+name+".")
+		}
+	}
+}
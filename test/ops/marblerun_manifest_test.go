@@ -0,0 +1,220 @@
+package ops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type marbleRunManifest struct {
+	Packages map[string]json.RawMessage `json:"Packages"`
+	Secrets  map[string]marbleRunSecret `json:"Secrets"`
+	Marbles  map[string]marbleRunMarble `json:"Marbles"`
+	TLS      map[string]marbleRunTLSTag `json:"TLS"`
+}
+
+type marbleRunSecret struct {
+	Type string `json:"Type"`
+	Size int    `json:"Size"`
+}
+
+type marbleRunMarble struct {
+	Package    string `json:"Package"`
+	Parameters struct {
+		Files map[string]string `json:"Files"`
+		Env   map[string]string `json:"Env"`
+		Argv  []string          `json:"Argv"`
+	} `json:"Parameters"`
+	TLS []string `json:"TLS"`
+}
+
+type marbleRunTLSTag struct {
+	Outgoing []marbleRunTLSEntry `json:"Outgoing"`
+	Incoming []marbleRunTLSEntry `json:"Incoming"`
+}
+
+type marbleRunTLSEntry struct {
+	Port string `json:"Port"`
+	Cert string `json:"Cert"`
+}
+
+var manifestSecretRefPattern = regexp.MustCompile(`\.Secrets\.([A-Za-z0-9_]+)`)
+
+// validateMarbleRunManifest returns every structural error found in a MarbleRun
+// manifest: duplicate marble names, marbles referencing undefined packages,
+// templates referencing undefined secrets, and TLS tags referencing missing
+// tags or certificate secrets.
+func validateMarbleRunManifest(data []byte) []error {
+	var errs []error
+
+	for _, name := range duplicateObjectKeys(data, "Marbles") {
+		errs = append(errs, fmt.Errorf("marble %q defined more than once", name))
+	}
+
+	var m marbleRunManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return append(errs, fmt.Errorf("parse manifest: %w", err))
+	}
+
+	for _, name := range sortedKeys(m.Marbles) {
+		marble := m.Marbles[name]
+		if strings.TrimSpace(marble.Package) == "" {
+			errs = append(errs, fmt.Errorf("marble %q has no package", name))
+		} else if _, ok := m.Packages[marble.Package]; !ok {
+			errs = append(errs, fmt.Errorf("marble %q references undefined package %q", name, marble.Package))
+		}
+
+		templates := make([]string, 0, len(marble.Parameters.Env)+len(marble.Parameters.Files)+len(marble.Parameters.Argv))
+		for _, v := range marble.Parameters.Env {
+			templates = append(templates, v)
+		}
+		for _, v := range marble.Parameters.Files {
+			templates = append(templates, v)
+		}
+		templates = append(templates, marble.Parameters.Argv...)
+
+		seen := map[string]bool{}
+		for _, tmpl := range templates {
+			for _, match := range manifestSecretRefPattern.FindAllStringSubmatch(tmpl, -1) {
+				secret := match[1]
+				if seen[secret] {
+					continue
+				}
+				seen[secret] = true
+				if _, ok := m.Secrets[secret]; !ok {
+					errs = append(errs, fmt.Errorf("marble %q references undefined secret %q", name, secret))
+				}
+			}
+		}
+
+		for _, tag := range marble.TLS {
+			if _, ok := m.TLS[tag]; !ok {
+				errs = append(errs, fmt.Errorf("marble %q references undefined TLS tag %q", name, tag))
+			}
+		}
+	}
+
+	for _, tag := range sortedKeys(m.TLS) {
+		entries := append(append([]marbleRunTLSEntry{}, m.TLS[tag].Outgoing...), m.TLS[tag].Incoming...)
+		for _, entry := range entries {
+			if entry.Cert == "" {
+				continue
+			}
+			secret, ok := m.Secrets[entry.Cert]
+			if !ok {
+				errs = append(errs, fmt.Errorf("TLS tag %q references missing cert %q", tag, entry.Cert))
+				continue
+			}
+			if !strings.HasPrefix(secret.Type, "cert-") {
+				errs = append(errs, fmt.Errorf("TLS tag %q cert %q has non-certificate type %q", tag, entry.Cert, secret.Type))
+			}
+		}
+	}
+
+	return errs
+}
+
+// duplicateObjectKeys reports keys that appear more than once in the top-level
+// object field named section. encoding/json silently keeps the last duplicate,
+// so this walks the raw tokens instead.
+func duplicateObjectKeys(data []byte, section string) []string {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil
+	}
+	raw, ok := top[section]
+	if !ok {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	var dups []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return dups
+		}
+		key, _ := tok.(string)
+		if seen[key] {
+			dups = append(dups, key)
+		}
+		seen[key] = true
+
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return dups
+		}
+	}
+	return dups
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestMarbleRunManifestIsValid(t *testing.T) {
+	root := repoRoot(t)
+	path := filepath.Join(root, "manifests", "manifest.json")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	for _, err := range validateMarbleRunManifest(data) {
+		t.Errorf("%s: %v", path, err)
+	}
+}
+
+func TestValidateMarbleRunManifestReportsAllErrors(t *testing.T) {
+	manifest := []byte(`{
+		"Packages": {"svc": {}},
+		"Secrets": {
+			"KEY": {"Type": "symmetric-key", "Size": 256},
+			"NOT_A_CERT": {"Type": "symmetric-key", "Size": 256}
+		},
+		"Marbles": {
+			"a": {"Package": "svc", "Parameters": {"Env": {"K": "{{ hex .Secrets.KEY.Private }}"}}},
+			"b": {"Package": "missing", "Parameters": {"Env": {"K": "{{ hex .Secrets.UNDEFINED.Private }}"}}, "TLS": ["nope"]},
+			"a": {"Package": "svc"}
+		},
+		"TLS": {
+			"web": {"Incoming": [{"Port": "443", "Cert": "MISSING_CERT"}, {"Port": "8443", "Cert": "NOT_A_CERT"}]}
+		}
+	}`)
+
+	errs := validateMarbleRunManifest(manifest)
+
+	want := []string{
+		`marble "a" defined more than once`,
+		`marble "b" references undefined package "missing"`,
+		`marble "b" references undefined secret "UNDEFINED"`,
+		`marble "b" references undefined TLS tag "nope"`,
+		`TLS tag "web" references missing cert "MISSING_CERT"`,
+		`TLS tag "web" cert "NOT_A_CERT" has non-certificate type "symmetric-key"`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors %v, want %d", len(errs), errs, len(want))
+	}
+	for i, w := range want {
+		if errs[i].Error() != w {
+			t.Errorf("error %d = %q, want %q", i, errs[i].Error(), w)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	neofeeds "github.com/R3E-Network/service_layer/services/datafeed/marble"
 	neogasbank "github.com/R3E-Network/service_layer/services/gasbank/marble"
 	txproxy "github.com/R3E-Network/service_layer/services/txproxy/marble"
+	"github.com/R3E-Network/service_layer/test/fixtures"
 )
 
 // TestNeoAccountsSmoke performs basic smoke tests on the NeoAccounts service.
@@ -310,6 +311,20 @@ func TestNeoOracleSmoke(t *testing.T) {
 			t.Errorf("expected name 'NeoOracle Service', got '%s'", svc.Name())
 		}
 	})
+
+	t.Run("poll feed registers", func(t *testing.T) {
+		m, _ := marble.New(marble.Config{MarbleType: "neooracle"})
+		svc, _ := neooracle.New(neooracle.Config{Marble: m})
+
+		feed, err := svc.AddPollFeed(fixtures.NewPollFeedFixture())
+		if err != nil {
+			t.Fatalf("AddPollFeed: %v", err)
+		}
+		status, ok := svc.GetPollFeed(feed.ID)
+		if !ok || status.UserID != "user-1" || !status.Active {
+			t.Errorf("poll feed status = %+v, %v", status, ok)
+		}
+	})
 }
 
 // TestNeoFeedsSmoke performs basic smoke tests on the NeoFeeds/Datafeed service.